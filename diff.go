@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// Difference holds the differences between two graphs, a and b. All node
+// lists are sorted by node ID and all edge lists are sorted by the IDs of
+// the edges' from and then to nodes.
+type Difference struct {
+	// AddedNodes holds the nodes in b
+	// that are not in a and RemovedNodes
+	// holds the nodes in a that are not
+	// in b.
+	AddedNodes, RemovedNodes []Node
+
+	// AddedEdges holds the edges in b
+	// that are not in a and RemovedEdges
+	// holds the edges in a that are not
+	// in b.
+	AddedEdges, RemovedEdges []Edge
+
+	// ChangedEdges holds pairs of edges
+	// from a and b that join the same
+	// nodes but have different weights.
+	ChangedEdges [][2]Edge
+}
+
+// Empty returns whether the Difference holds no differences.
+func (d Difference) Empty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 &&
+		len(d.ChangedEdges) == 0
+}
+
+// Diff returns the differences between the graphs a and b. Nodes are identified
+// by their IDs. Edge weights, given by the Weight method of each edge, are
+// considered to differ when they differ by more than tol, with equal infinite
+// weights and NaN weights considered equal to each other. If both a and b are directed, edges are
+// compared with respect to their direction, otherwise edges are compared as
+// undirected edges and the edges in the returned Difference are oriented from
+// the node with the lower ID to the node with the higher ID.
+func Diff(a, b Graph, tol float64) Difference {
+	_, aDirected := a.(Directed)
+	_, bDirected := b.(Directed)
+	directed := aDirected && bDirected
+
+	var d Difference
+
+	aNodes := sortedNodes(a.Nodes())
+	bNodes := sortedNodes(b.Nodes())
+	for _, n := range aNodes {
+		if !b.Has(n) {
+			d.RemovedNodes = append(d.RemovedNodes, n)
+		}
+	}
+	for _, n := range bNodes {
+		if !a.Has(n) {
+			d.AddedNodes = append(d.AddedNodes, n)
+		}
+	}
+
+	aEdges := edgesOf(a, aNodes, directed)
+	bEdges := edgesOf(b, bNodes, directed)
+	for _, ea := range aEdges {
+		eb := edgeIn(b, ea.From(), ea.To(), directed)
+		switch {
+		case eb == nil:
+			d.RemovedEdges = append(d.RemovedEdges, ea)
+		case !sameWeight(ea.Weight(), eb.Weight(), tol):
+			if !directed {
+				eb = orientedEdge{Edge: eb, from: ea.From(), to: ea.To()}
+			}
+			d.ChangedEdges = append(d.ChangedEdges, [2]Edge{ea, eb})
+		}
+	}
+	for _, eb := range bEdges {
+		if edgeIn(a, eb.From(), eb.To(), directed) == nil {
+			d.AddedEdges = append(d.AddedEdges, eb)
+		}
+	}
+
+	return d
+}
+
+// Equal returns whether the graphs a and b have the same nodes and edges, and
+// that corresponding edges have the same weight. Graphs are only equal if both
+// or neither are directed.
+func Equal(a, b Graph) bool {
+	_, aDirected := a.(Directed)
+	_, bDirected := b.(Directed)
+	if aDirected != bDirected {
+		return false
+	}
+	return Diff(a, b, 0).Empty()
+}
+
+// sortedNodes sorts nodes by ID and returns them.
+func sortedNodes(nodes []Node) []Node {
+	sort.Sort(byID(nodes))
+	return nodes
+}
+
+// edgesOf returns the edges of g in the order defined by the sorted nodes. If
+// directed is false each edge is included once, oriented from the node with the
+// lower ID to the node with the higher ID.
+func edgesOf(g Graph, nodes []Node, directed bool) []Edge {
+	var edges []Edge
+	for _, u := range nodes {
+		for _, v := range sortedNodes(g.From(u)) {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			e := g.Edge(u, v)
+			if !directed {
+				e = orientedEdge{Edge: e, from: u, to: v}
+			}
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// edgeIn returns the edge in g from u to v, or between u and v if directed
+// is false. If there is no such edge, edgeIn returns nil.
+func edgeIn(g Graph, u, v Node, directed bool) Edge {
+	if !g.Has(u) || !g.Has(v) {
+		return nil
+	}
+	if directed {
+		if !g.(Directed).HasEdgeFromTo(u, v) {
+			return nil
+		}
+	} else if !g.HasEdgeBetween(u, v) {
+		return nil
+	}
+	e := g.Edge(u, v)
+	if e == nil {
+		// The edge is only reachable in the
+		// reverse direction.
+		e = g.Edge(v, u)
+	}
+	return e
+}
+
+func sameWeight(a, b, tol float64) bool {
+	// Equal infinite weights differ by NaN,
+	// so must be checked for equality first.
+	return a == b || math.Abs(a-b) <= tol || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+// orientedEdge is an edge with a specified orientation.
+type orientedEdge struct {
+	Edge
+	from, to Node
+}
+
+func (e orientedEdge) From() Node { return e.from }
+func (e orientedEdge) To() Node   { return e.to }
+
+// byID implements the sort.Interface sorting a slice of Node by ID.
+type byID []Node
+
+func (n byID) Len() int           { return len(n) }
+func (n byID) Less(i, j int) bool { return n[i].ID() < n[j].ID() }
+func (n byID) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
@@ -0,0 +1,176 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func directedFrom(nodes []int, edges []simple.Edge) graph.Directed {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, n := range nodes {
+		g.AddNode(simple.Node(n))
+	}
+	for _, e := range edges {
+		g.SetEdge(e)
+	}
+	return g
+}
+
+func undirectedFrom(nodes []int, edges []simple.Edge) graph.Undirected {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, n := range nodes {
+		g.AddNode(simple.Node(n))
+	}
+	for _, e := range edges {
+		g.SetEdge(e)
+	}
+	return g
+}
+
+// diffIDs is a representation of a graph.Difference using IDs and weights.
+type diffIDs struct {
+	addedNodes, removedNodes []int
+	addedEdges, removedEdges [][2]int
+	changedEdges             [][2]float64
+}
+
+func idsOf(d graph.Difference) diffIDs {
+	var r diffIDs
+	for _, n := range d.AddedNodes {
+		r.addedNodes = append(r.addedNodes, n.ID())
+	}
+	for _, n := range d.RemovedNodes {
+		r.removedNodes = append(r.removedNodes, n.ID())
+	}
+	for _, e := range d.AddedEdges {
+		r.addedEdges = append(r.addedEdges, [2]int{e.From().ID(), e.To().ID()})
+	}
+	for _, e := range d.RemovedEdges {
+		r.removedEdges = append(r.removedEdges, [2]int{e.From().ID(), e.To().ID()})
+	}
+	for _, e := range d.ChangedEdges {
+		r.changedEdges = append(r.changedEdges, [2]float64{e[0].Weight(), e[1].Weight()})
+	}
+	return r
+}
+
+var diffEdges = []simple.Edge{
+	{F: simple.Node(0), T: simple.Node(1), W: 1},
+	{F: simple.Node(1), T: simple.Node(2), W: 2},
+	{F: simple.Node(2), T: simple.Node(0), W: 3},
+}
+
+var diffTests = []struct {
+	name string
+	a, b graph.Graph
+	tol  float64
+
+	equal bool
+	want  diffIDs
+}{
+	{
+		name:  "identical directed",
+		a:     directedFrom(nil, diffEdges),
+		b:     directedFrom(nil, diffEdges),
+		equal: true,
+	},
+	{
+		name:  "identical undirected",
+		a:     undirectedFrom(nil, diffEdges),
+		b:     undirectedFrom([]int{2, 1, 0}, diffEdges),
+		equal: true,
+	},
+	{
+		name: "mixed directedness",
+		a:    directedFrom(nil, diffEdges),
+		b:    undirectedFrom(nil, diffEdges),
+	},
+	{
+		name: "reversed edge",
+		a:    directedFrom(nil, diffEdges),
+		b: directedFrom(nil, []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(1), W: 2},
+			{F: simple.Node(2), T: simple.Node(0), W: 3},
+		}),
+		want: diffIDs{
+			addedEdges:   [][2]int{{2, 1}},
+			removedEdges: [][2]int{{1, 2}},
+		},
+	},
+	{
+		name: "nodes and weights",
+		a:    undirectedFrom([]int{5}, diffEdges),
+		b: undirectedFrom([]int{4}, []simple.Edge{
+			{F: simple.Node(1), T: simple.Node(0), W: 1.5},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(3), W: 3},
+		}),
+		want: diffIDs{
+			addedNodes:   []int{3, 4},
+			removedNodes: []int{5},
+			addedEdges:   [][2]int{{2, 3}},
+			removedEdges: [][2]int{{0, 2}},
+			changedEdges: [][2]float64{{1, 1.5}},
+		},
+	},
+	{
+		name: "within tolerance",
+		a:    undirectedFrom(nil, diffEdges),
+		b: undirectedFrom(nil, []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1.25},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(0), W: 3},
+		}),
+		tol: 0.5,
+	},
+}
+
+func TestDiffInfiniteWeights(t *testing.T) {
+	inf := []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: math.Inf(1)},
+		{F: simple.Node(1), T: simple.Node(2), W: math.Inf(-1)},
+	}
+	if !graph.Equal(directedFrom(nil, inf), directedFrom(nil, inf)) {
+		t.Error("unexpected inequality of infinite weights")
+	}
+	for _, tol := range []float64{0, 1} {
+		d := graph.Diff(directedFrom(nil, inf), directedFrom(nil, inf), tol)
+		if !d.Empty() {
+			t.Errorf("unexpected difference of infinite weights with tolerance %v: %+v", tol, idsOf(d))
+		}
+	}
+
+	flipped := []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: math.Inf(-1)},
+		{F: simple.Node(1), T: simple.Node(2), W: math.Inf(-1)},
+	}
+	d := graph.Diff(directedFrom(nil, inf), directedFrom(nil, flipped), 1)
+	want := diffIDs{changedEdges: [][2]float64{{math.Inf(1), math.Inf(-1)}}}
+	if got := idsOf(d); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected difference of opposite infinite weights:\ngot: %+v\nwant:%+v", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, test := range diffTests {
+		if got := graph.Equal(test.a, test.b); got != test.equal {
+			t.Errorf("unexpected equality for %q: got:%t want:%t", test.name, got, test.equal)
+		}
+		d := graph.Diff(test.a, test.b, test.tol)
+		if got := idsOf(d); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected difference for %q:\ngot: %+v\nwant:%+v", test.name, got, test.want)
+		}
+		if d.Empty() != reflect.DeepEqual(test.want, diffIDs{}) {
+			t.Errorf("unexpected emptiness for %q: got:%t", test.name, d.Empty())
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dimacs implements reading and writing of graphs in the DIMACS
+// challenge formats.
+//
+// The shortest path (sp), maximum flow (max) and undirected graph (edge)
+// problem formats are supported. DIMACS node identifiers are 1-based and are
+// mapped to 0-based graph node IDs.
+//
+// The formats are described at http://dimacs.rutgers.edu/Challenges/.
+package dimacs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// DIMACS problem designators.
+const (
+	ShortestPath = "sp"
+	MaxFlow      = "max"
+	Edge         = "edge"
+)
+
+// Problem is a DIMACS problem instance.
+type Problem struct {
+	// Type is the problem designator,
+	// ShortestPath, MaxFlow or Edge.
+	Type string
+
+	// Graph is the graph of the problem.
+	// For ShortestPath and MaxFlow problems
+	// the graph is directed with edge weights
+	// holding arc lengths or capacities. For
+	// Edge problems the graph is undirected.
+	Graph graph.Graph
+
+	// Source and Sink are the source and
+	// sink nodes of a MaxFlow problem.
+	Source, Sink graph.Node
+
+	// Comments holds the text of the
+	// comment lines of the problem.
+	Comments []string
+}
+
+// Read reads a DIMACS problem from r. Nodes in the returned graph have IDs
+// one less than their DIMACS node identifiers.
+//
+// The graphs in the returned Problem are simple graphs, so parallel arcs are
+// merged: the shortest length is retained for ShortestPath problems and
+// capacities are summed for MaxFlow problems. Edges in Edge problems are given
+// unit weight. Self loops are not supported and result in an error.
+//
+// Errors in the input are reported with the line number at which they occur.
+func Read(r io.Reader) (*Problem, error) {
+	var (
+		p      Problem
+		n, m   int
+		arcs   int
+		line   int
+		parsed bool
+
+		dg *simple.DirectedGraph
+		ug *simple.UndirectedGraph
+	)
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("dimacs: line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+	node := func(field string) (simple.Node, error) {
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, errorf("invalid node identifier %q", field)
+		}
+		if id < 1 || n < id {
+			return 0, errorf("node identifier %d out of range [1,%d]", id, n)
+		}
+		return simple.Node(id - 1), nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] != "c" && fields[0] != "p" && !parsed {
+			return nil, errorf("%q line before problem line", fields[0])
+		}
+		switch fields[0] {
+		case "c":
+			p.Comments = append(p.Comments, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "c")))
+
+		case "p":
+			if parsed {
+				return nil, errorf("duplicate problem line")
+			}
+			if len(fields) != 4 {
+				return nil, errorf("invalid problem line %q", text)
+			}
+			var err error
+			n, err = strconv.Atoi(fields[2])
+			if err != nil || n < 0 {
+				return nil, errorf("invalid node count %q", fields[2])
+			}
+			m, err = strconv.Atoi(fields[3])
+			if err != nil || m < 0 {
+				return nil, errorf("invalid arc count %q", fields[3])
+			}
+			p.Type = fields[1]
+			switch p.Type {
+			case ShortestPath, MaxFlow:
+				dg = simple.NewDirectedGraph(0, math.Inf(1))
+				for i := 0; i < n; i++ {
+					dg.AddNode(simple.Node(i))
+				}
+				p.Graph = dg
+			case Edge:
+				ug = simple.NewUndirectedGraph(0, math.Inf(1))
+				for i := 0; i < n; i++ {
+					ug.AddNode(simple.Node(i))
+				}
+				p.Graph = ug
+			default:
+				return nil, errorf("unsupported problem type %q", p.Type)
+			}
+			parsed = true
+
+		case "n":
+			if p.Type != MaxFlow {
+				return nil, errorf("node descriptor in %s problem", p.Type)
+			}
+			if len(fields) != 3 {
+				return nil, errorf("invalid node descriptor %q", text)
+			}
+			u, err := node(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			switch fields[2] {
+			case "s":
+				if p.Source != nil {
+					return nil, errorf("duplicate source")
+				}
+				p.Source = u
+			case "t":
+				if p.Sink != nil {
+					return nil, errorf("duplicate sink")
+				}
+				p.Sink = u
+			default:
+				return nil, errorf("invalid node designator %q", fields[2])
+			}
+
+		case "a":
+			if dg == nil {
+				return nil, errorf("arc descriptor in %s problem", p.Type)
+			}
+			if len(fields) != 4 {
+				return nil, errorf("invalid arc descriptor %q", text)
+			}
+			u, err := node(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			v, err := node(fields[2])
+			if err != nil {
+				return nil, err
+			}
+			if u == v {
+				return nil, errorf("self loop at node %d", u+1)
+			}
+			w, err := strconv.ParseFloat(fields[3], 64)
+			if err != nil {
+				return nil, errorf("invalid arc weight %q", fields[3])
+			}
+			if e := dg.Edge(u, v); e != nil {
+				switch p.Type {
+				case ShortestPath:
+					w = math.Min(w, e.Weight())
+				case MaxFlow:
+					w += e.Weight()
+				}
+			}
+			dg.SetEdge(simple.Edge{F: u, T: v, W: w})
+			arcs++
+
+		case "e":
+			if ug == nil {
+				return nil, errorf("edge descriptor in %s problem", p.Type)
+			}
+			if len(fields) != 3 {
+				return nil, errorf("invalid edge descriptor %q", text)
+			}
+			u, err := node(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			v, err := node(fields[2])
+			if err != nil {
+				return nil, err
+			}
+			if u == v {
+				return nil, errorf("self loop at node %d", u+1)
+			}
+			ug.SetEdge(simple.Edge{F: u, T: v, W: 1})
+			arcs++
+
+		default:
+			return nil, errorf("unknown line type %q", fields[0])
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if !parsed {
+		return nil, errors.New("dimacs: no problem line")
+	}
+	if arcs != m {
+		return nil, errorf("expected %d arcs, found %d", m, arcs)
+	}
+	if p.Type == MaxFlow && (p.Source == nil || p.Sink == nil) {
+		return nil, errorf("missing source or sink")
+	}
+	return &p, nil
+}
+
+// Write writes the DIMACS problem p to w. Node identifiers are assigned in
+// order of ascending node ID, so nodes with IDs 0 to n-1 are written with
+// their ID plus one. Arc weights are taken from the weight of each edge.
+//
+// The graph of ShortestPath and MaxFlow problems must implement graph.Directed
+// and the graph of Edge problems must not. Self loops are not supported, since
+// Read does not accept them, and result in an error.
+func Write(w io.Writer, p *Problem) error {
+	g := p.Graph
+	_, directed := g.(graph.Directed)
+	switch p.Type {
+	case ShortestPath, MaxFlow:
+		if !directed {
+			return fmt.Errorf("dimacs: %s problem with undirected graph", p.Type)
+		}
+	case Edge:
+		if directed {
+			return errors.New("dimacs: edge problem with directed graph")
+		}
+	default:
+		return fmt.Errorf("dimacs: unsupported problem type %q", p.Type)
+	}
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i + 1
+	}
+
+	var edges []graph.Edge
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if v.ID() == u.ID() {
+				return fmt.Errorf("dimacs: self loop at node %d", indexOf[u.ID()])
+			}
+			if !directed && indexOf[v.ID()] < indexOf[u.ID()] {
+				continue
+			}
+			edges = append(edges, g.Edge(u, v))
+		}
+	}
+
+	b := bufio.NewWriter(w)
+	for _, c := range p.Comments {
+		if c == "" {
+			fmt.Fprintln(b, "c")
+			continue
+		}
+		fmt.Fprintf(b, "c %s\n", c)
+	}
+	fmt.Fprintf(b, "p %s %d %d\n", p.Type, len(nodes), len(edges))
+	if p.Type == MaxFlow {
+		if p.Source == nil || p.Sink == nil {
+			return errors.New("dimacs: missing source or sink")
+		}
+		for _, n := range []struct {
+			node graph.Node
+			desg string
+		}{{p.Source, "s"}, {p.Sink, "t"}} {
+			id, ok := indexOf[n.node.ID()]
+			if !ok {
+				return fmt.Errorf("dimacs: node %d not in graph", n.node.ID())
+			}
+			fmt.Fprintf(b, "n %d %s\n", id, n.desg)
+		}
+	}
+	for _, e := range edges {
+		u := indexOf[e.From().ID()]
+		v := indexOf[e.To().ID()]
+		if p.Type == Edge {
+			if u > v {
+				u, v = v, u
+			}
+			fmt.Fprintf(b, "e %d %d\n", u, v)
+			continue
+		}
+		fmt.Fprintf(b, "a %d %d %s\n", u, v, strconv.FormatFloat(e.Weight(), 'g', -1, 64))
+	}
+	return b.Flush()
+}
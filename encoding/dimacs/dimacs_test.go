@@ -0,0 +1,220 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dimacs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/flow"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+)
+
+const spProblem = `c A small shortest path problem.
+c
+p sp 4 5
+a 1 2 1
+a 1 3 4
+a 2 3 2
+a 2 4 6
+a 3 4 3
+`
+
+const maxProblem = `c Example from Cormen et al., Introduction to Algorithms.
+p max 6 9
+n 1 s
+n 6 t
+a 1 2 16
+a 1 3 13
+a 2 4 12
+a 3 2 4
+a 3 5 14
+a 4 3 9
+a 4 6 20
+a 5 4 7
+a 5 6 4
+`
+
+const edgeProblem = `c A triangle with a pendant node.
+p edge 4 4
+e 1 2
+e 1 3
+e 2 3
+e 3 4
+`
+
+func TestReadShortestPath(t *testing.T) {
+	p, err := Read(strings.NewReader(spProblem))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Type != ShortestPath {
+		t.Errorf("unexpected problem type: got:%q want:%q", p.Type, ShortestPath)
+	}
+	if len(p.Comments) != 2 || p.Comments[0] != "A small shortest path problem." || p.Comments[1] != "" {
+		t.Errorf("unexpected comments: %q", p.Comments)
+	}
+	if n := len(p.Graph.Nodes()); n != 4 {
+		t.Errorf("unexpected number of nodes: got:%d want:4", n)
+	}
+	pt := path.DijkstraFrom(simple.Node(0), p.Graph)
+	if w := pt.WeightTo(simple.Node(3)); w != 6 {
+		t.Errorf("unexpected shortest path length: got:%v want:6", w)
+	}
+}
+
+func TestReadMaxFlow(t *testing.T) {
+	p, err := Read(strings.NewReader(maxProblem))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Source.ID() != 0 || p.Sink.ID() != 5 {
+		t.Errorf("unexpected source and sink: got:%d,%d want:0,5", p.Source.ID(), p.Sink.ID())
+	}
+	if f, _ := flow.EdmondsKarp(p.Source, p.Sink, p.Graph); f != 23 {
+		t.Errorf("unexpected max flow: got:%v want:23", f)
+	}
+}
+
+func TestReadEdge(t *testing.T) {
+	p, err := Read(strings.NewReader(edgeProblem))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := p.Graph.(graph.Undirected); !ok {
+		t.Fatalf("expected undirected graph: got:%T", p.Graph)
+	}
+	if !p.Graph.HasEdgeBetween(simple.Node(3), simple.Node(2)) {
+		t.Error("missing edge between nodes 2 and 3")
+	}
+	if p.Graph.HasEdgeBetween(simple.Node(3), simple.Node(0)) {
+		t.Error("unexpected edge between nodes 0 and 3")
+	}
+}
+
+func TestReadParallelArcs(t *testing.T) {
+	for _, test := range []struct {
+		typ  string
+		want float64
+	}{
+		{typ: "sp", want: 2},
+		{typ: "max", want: 7},
+	} {
+		src := "p " + test.typ + " 2 2\na 1 2 5\na 1 2 2\n"
+		if test.typ == "max" {
+			src += "n 1 s\nn 2 t\n"
+		}
+		p, err := Read(strings.NewReader(src))
+		if err != nil {
+			t.Errorf("unexpected error for %s problem: %v", test.typ, err)
+			continue
+		}
+		if w := p.Graph.Edge(simple.Node(0), simple.Node(1)).Weight(); w != test.want {
+			t.Errorf("unexpected merged weight for %s problem: got:%v want:%v", test.typ, w, test.want)
+		}
+	}
+}
+
+var readErrorTests = []struct {
+	name string
+	src  string
+	want string
+}{
+	{name: "no problem", src: "c nothing\n", want: "dimacs: no problem line"},
+	{name: "arc before problem", src: "a 1 2 3\n", want: "dimacs: line 1: \"a\" line before problem line"},
+	{name: "unknown problem", src: "p tsp 2 1\n", want: "dimacs: line 1: unsupported problem type \"tsp\""},
+	{name: "bad count", src: "p sp x 1\n", want: "dimacs: line 1: invalid node count \"x\""},
+	{name: "out of range", src: "p sp 2 1\na 1 3 1\n", want: "dimacs: line 2: node identifier 3 out of range [1,2]"},
+	{name: "bad weight", src: "c\np sp 2 1\na 1 2 w\n", want: "dimacs: line 3: invalid arc weight \"w\""},
+	{name: "self loop", src: "p edge 2 1\ne 2 2\n", want: "dimacs: line 2: self loop at node 2"},
+	{name: "edge in sp", src: "p sp 2 1\ne 1 2\n", want: "dimacs: line 2: edge descriptor in sp problem"},
+	{name: "arc count", src: "p sp 2 2\na 1 2 1\n", want: "dimacs: line 2: expected 2 arcs, found 1"},
+	{name: "no sink", src: "p max 2 1\nn 1 s\na 1 2 1\n", want: "dimacs: line 3: missing source or sink"},
+	{name: "unknown line", src: "p sp 2 0\nx\n", want: "dimacs: line 2: unknown line type \"x\""},
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, test := range readErrorTests {
+		_, err := Read(strings.NewReader(test.src))
+		if err == nil {
+			t.Errorf("expected error for %q", test.name)
+			continue
+		}
+		if err.Error() != test.want {
+			t.Errorf("unexpected error for %q:\ngot: %v\nwant:%v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, src := range []string{spProblem, maxProblem, edgeProblem} {
+		p, err := Read(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		err = Write(&buf, p)
+		if err != nil {
+			t.Fatalf("unexpected error writing %s problem: %v", p.Type, err)
+		}
+		if buf.String() != src {
+			t.Errorf("unexpected round trip for %s problem:\ngot:\n%s\nwant:\n%s", p.Type, buf.String(), src)
+		}
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	u := simple.NewUndirectedGraph(0, 0)
+	d := simple.NewDirectedGraph(0, 0)
+	for _, p := range []*Problem{
+		{Type: ShortestPath, Graph: u},
+		{Type: Edge, Graph: d},
+		{Type: "tsp", Graph: d},
+		{Type: MaxFlow, Graph: d},
+	} {
+		if err := Write(&bytes.Buffer{}, p); err == nil {
+			t.Errorf("expected error writing %s problem with %T", p.Type, p.Graph)
+		}
+	}
+}
+
+func TestWriteSelfLoop(t *testing.T) {
+	g := simple.NewDirectedGraph(0, 0)
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	var buf bytes.Buffer
+	err := Write(&buf, &Problem{Type: ShortestPath, Graph: selfLoop{g, simple.Node(1)}})
+	const want = "dimacs: self loop at node 2"
+	if err == nil || err.Error() != want {
+		t.Errorf("unexpected error writing self loop: got:%v want:%s", err, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected output writing self loop:\n%s", buf.Bytes())
+	}
+}
+
+// selfLoop is a directed graph with an
+// added self loop at the node loop.
+type selfLoop struct {
+	*simple.DirectedGraph
+	loop graph.Node
+}
+
+func (g selfLoop) From(n graph.Node) []graph.Node {
+	from := g.DirectedGraph.From(n)
+	if n.ID() == g.loop.ID() {
+		from = append(from, n)
+	}
+	return from
+}
+
+func (g selfLoop) Edge(u, v graph.Node) graph.Edge {
+	if u.ID() == g.loop.ID() && v.ID() == g.loop.ID() {
+		return simple.Edge{F: u, T: v, W: 1}
+	}
+	return g.DirectedGraph.Edge(u, v)
+}
@@ -5,11 +5,15 @@
 package dot
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/formats/dot"
 	"github.com/gonum/graph/formats/dot/ast"
+	"github.com/gonum/graph/simple"
 	"golang.org/x/tools/container/intsets"
 )
 
@@ -40,12 +44,66 @@ func Unmarshal(data []byte, dst Builder) error {
 	if len(file.Graphs) != 1 {
 		return fmt.Errorf("invalid number of graphs; expected 1, got %d", len(file.Graphs))
 	}
-	return copyGraph(dst, file.Graphs[0])
+	_, err = copyGraph(builder{dst}, file.Graphs[0])
+	return err
+}
+
+// UnmarshalGraph parses the Graphviz DOT-encoded data and stores the result in
+// dst, returning the mapping from unquoted DOT node IDs to the IDs of the nodes
+// added to dst. UnmarshalGraph returns an error if the directedness of the DOT
+// graph and dst differ.
+//
+// DOT node IDs that are decimal integers are used as node IDs, and other nodes
+// are given IDs from dst.NewNodeID. Nodes are added as simple.Node values and
+// edges are set as simple.Edge values with the weight given by the DOT weight
+// attribute of the edge, or unit weight if it has none. Nodes and edges in
+// subgraphs are added to dst, and duplicate edges are merged. Self edges are
+// not allowed and result in an error.
+//
+// Other attributes of nodes and edges are ignored unless dst can hold them. If
+// dst is a graph.NodeValueSetter, each node with attributes is given a
+// map[string]string value holding them. If dst is a graph.EdgeAttrSetter, each
+// attribute of an edge is set with the attribute value as a string. The
+// simple.DirectedAttrGraph and simple.UndirectedAttrGraph types are both.
+// Attribute values are held as they are written in the DOT data.
+func UnmarshalGraph(data []byte, dst graph.Builder) (ids map[string]int, err error) {
+	file, err := dot.ParseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Graphs) != 1 {
+		return nil, fmt.Errorf("invalid number of graphs; expected 1, got %d", len(file.Graphs))
+	}
+	src := file.Graphs[0]
+	if _, ok := dst.(graph.Directed); ok != src.Directed {
+		return nil, errors.New("dot: mismatched graph type")
+	}
+
+	// Add nodes with integer IDs first so that
+	// they are not given to other nodes.
+	b := &graphBuilder{dst: dst, nodeAttrs: make(map[int]map[string]string)}
+	g, _ := dst.(graph.Graph)
+	for _, id := range integerIDs(src.Stmts, nil) {
+		if g == nil || !g.Has(simple.Node(id)) {
+			dst.AddNode(simple.Node(id))
+		}
+	}
+
+	nodes, err := copyGraph(b, src)
+	if err != nil {
+		return nil, err
+	}
+	ids = make(map[string]int, len(nodes))
+	for id, n := range nodes {
+		ids[unquote(id)] = n.ID()
+	}
+	return ids, nil
 }
 
 // copyGraph copies the nodes and edges from the Graphviz AST source graph to
-// the destination graph. Edge direction is maintained if present.
-func copyGraph(dst Builder, src *ast.Graph) (err error) {
+// the destination graph. Edge direction is maintained if present. The nodes
+// added to dst are returned keyed by their DOT ID.
+func copyGraph(dst destination, src *ast.Graph) (nodes map[string]graph.Node, err error) {
 	defer func() {
 		switch e := recover().(type) {
 		case nil:
@@ -62,7 +120,165 @@ func copyGraph(dst Builder, src *ast.Graph) (err error) {
 	for _, stmt := range src.Stmts {
 		gen.addStmt(dst, stmt)
 	}
-	return err
+	return gen.ids, err
+}
+
+// destination is a graph being built from a DOT AST graph.
+type destination interface {
+	// newNode adds a new node for the DOT node
+	// ID to the graph.
+	newNode(id string) graph.Node
+	// newEdge adds a new edge from the source to
+	// the destination node to the graph, or
+	// returns the existing edge if present.
+	newEdge(from, to graph.Node) graph.Edge
+	// setNodeAttr and setEdgeAttr decode a single
+	// DOT attribute of a node or edge.
+	setNodeAttr(n graph.Node, attr Attribute) error
+	setEdgeAttr(e graph.Edge, attr Attribute) error
+}
+
+// builder is a destination using a Builder to create nodes and edges, which
+// unmarshal their own attributes.
+type builder struct {
+	Builder
+}
+
+func (b builder) newNode(_ string) graph.Node {
+	return b.NewNode()
+}
+
+func (b builder) newEdge(from, to graph.Node) graph.Edge {
+	return b.NewEdge(from, to)
+}
+
+func (b builder) setNodeAttr(n graph.Node, attr Attribute) error {
+	if n, ok := n.(UnmarshalerAttr); ok {
+		return n.UnmarshalDOTAttr(attr)
+	}
+	return nil
+}
+
+func (b builder) setEdgeAttr(e graph.Edge, attr Attribute) error {
+	if e, ok := e.(UnmarshalerAttr); ok {
+		return e.UnmarshalDOTAttr(attr)
+	}
+	return nil
+}
+
+// graphBuilder is a destination adding simple.Node and simple.Edge values to
+// a graph.Builder.
+type graphBuilder struct {
+	dst graph.Builder
+
+	// nodeAttrs holds the attributes
+	// of each node with attributes.
+	nodeAttrs map[int]map[string]string
+}
+
+func (b *graphBuilder) newNode(id string) graph.Node {
+	if id, ok := integerID(id); ok {
+		// Nodes with integer IDs have
+		// already been added.
+		return simple.Node(id)
+	}
+	n := simple.Node(b.dst.NewNodeID())
+	b.dst.AddNode(n)
+	return n
+}
+
+func (b *graphBuilder) newEdge(from, to graph.Node) graph.Edge {
+	if from.ID() == to.ID() {
+		panic(fmt.Errorf("dot: self edge on node %d", from.ID()))
+	}
+	if g, ok := b.dst.(graph.Graph); ok {
+		if e := g.Edge(from, to); e != nil {
+			return e
+		}
+	}
+	e := simple.Edge{F: from, T: to, W: 1}
+	b.dst.SetEdge(e)
+	return e
+}
+
+func (b *graphBuilder) setNodeAttr(n graph.Node, attr Attribute) error {
+	s, ok := b.dst.(graph.NodeValueSetter)
+	if !ok {
+		return nil
+	}
+	attrs, ok := b.nodeAttrs[n.ID()]
+	if !ok {
+		attrs = make(map[string]string)
+		b.nodeAttrs[n.ID()] = attrs
+		s.SetNodeValue(n.ID(), attrs)
+	}
+	attrs[attr.Key] = attr.Value
+	return nil
+}
+
+func (b *graphBuilder) setEdgeAttr(e graph.Edge, attr Attribute) error {
+	if attr.Key == "weight" {
+		w, err := strconv.ParseFloat(unquote(attr.Value), 64)
+		if err != nil {
+			return err
+		}
+		b.dst.SetEdge(simple.Edge{F: e.From(), T: e.To(), W: w})
+		return nil
+	}
+	s, ok := b.dst.(graph.EdgeAttrSetter)
+	if ok {
+		s.SetEdgeAttr(e.From(), e.To(), attr.Key, attr.Value)
+	}
+	return nil
+}
+
+// integerIDs appends the DOT node IDs in stmts that are decimal integers to
+// dst and returns the result.
+func integerIDs(stmts []ast.Stmt, dst []int) []int {
+	var vertex func(v ast.Vertex)
+	vertex = func(v ast.Vertex) {
+		switch v := v.(type) {
+		case *ast.Node:
+			if id, ok := integerID(v.ID); ok {
+				dst = append(dst, id)
+			}
+		case *ast.Subgraph:
+			dst = integerIDs(v.Stmts, dst)
+		}
+	}
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.NodeStmt:
+			vertex(stmt.Node)
+		case *ast.EdgeStmt:
+			vertex(stmt.From)
+			for e := stmt.To; e != nil; e = e.To {
+				vertex(e.Vertex)
+			}
+		case *ast.Subgraph:
+			dst = integerIDs(stmt.Stmts, dst)
+		}
+	}
+	return dst
+}
+
+// integerID returns the integer value of the DOT ID and whether it is the
+// canonical decimal representation of an integer.
+func integerID(id string) (int, bool) {
+	n, err := strconv.Atoi(unquote(id))
+	if err != nil || strconv.Itoa(n) != unquote(id) {
+		return 0, false
+	}
+	return n, true
+}
+
+// unquote returns the DOT ID with surrounding double quotes and quote escapes
+// removed if it is a double-quoted string.
+func unquote(id string) string {
+	if len(id) < 2 || id[0] != '"' || id[len(id)-1] != '"' {
+		return id
+	}
+	return strings.Replace(id[1:len(id)-1], `\"`, `"`, -1)
 }
 
 // A generator keeps track of the information required for generating a gonum
@@ -82,14 +298,14 @@ type generator struct {
 
 // node returns the gonum node corresponding to the given dot AST node ID,
 // generating a new such node if none exist.
-func (gen *generator) node(dst Builder, id string) graph.Node {
-	if n, ok := gen.ids[id]; ok {
-		return n
+func (gen *generator) node(dst destination, id string) graph.Node {
+	n, ok := gen.ids[id]
+	if !ok {
+		n = dst.newNode(id)
+		gen.ids[id] = n
 	}
-	n := dst.NewNode()
-	gen.ids[id] = n
 	// Check if within the context of a subgraph, that is to be used as a vertex
-	// of an edge.
+	// of an edge. Nodes that already exist are included.
 	if gen.isInSubgraph() {
 		// Append node processed within the context of a subgraph, that is to be
 		// used as a vertex of an edge
@@ -99,19 +315,17 @@ func (gen *generator) node(dst Builder, id string) graph.Node {
 }
 
 // addStmt adds the given statement to the graph.
-func (gen *generator) addStmt(dst Builder, stmt ast.Stmt) {
+func (gen *generator) addStmt(dst destination, stmt ast.Stmt) {
 	switch stmt := stmt.(type) {
 	case *ast.NodeStmt:
 		n := gen.node(dst, stmt.Node.ID)
-		if n, ok := n.(UnmarshalerAttr); ok {
-			for _, attr := range stmt.Attrs {
-				a := Attribute{
-					Key:   attr.Key,
-					Value: attr.Val,
-				}
-				if err := n.UnmarshalDOTAttr(a); err != nil {
-					panic(fmt.Errorf("unable to unmarshal node DOT attribute (%s=%s)", a.Key, a.Value))
-				}
+		for _, attr := range stmt.Attrs {
+			a := Attribute{
+				Key:   attr.Key,
+				Value: attr.Val,
+			}
+			if err := dst.setNodeAttr(n, a); err != nil {
+				panic(fmt.Errorf("unable to unmarshal node DOT attribute (%s=%s)", a.Key, a.Value))
 			}
 		}
 	case *ast.EdgeStmt:
@@ -130,29 +344,32 @@ func (gen *generator) addStmt(dst Builder, stmt ast.Stmt) {
 }
 
 // addEdgeStmt adds the given edge statement to the graph.
-func (gen *generator) addEdgeStmt(dst Builder, e *ast.EdgeStmt) {
+func (gen *generator) addEdgeStmt(dst destination, e *ast.EdgeStmt) {
 	fs := gen.addVertex(dst, e.From)
-	ts := gen.addEdge(dst, e.To)
+	ts := gen.addEdge(dst, e.To, e.Attrs)
 	for _, f := range fs {
 		for _, t := range ts {
-			edge := dst.NewEdge(f, t)
-			if edge, ok := edge.(UnmarshalerAttr); ok {
-				for _, attr := range e.Attrs {
-					a := Attribute{
-						Key:   attr.Key,
-						Value: attr.Val,
-					}
-					if err := edge.UnmarshalDOTAttr(a); err != nil {
-						panic(fmt.Errorf("unable to unmarshal edge DOT attribute (%s=%s)", a.Key, a.Value))
-					}
-				}
-			}
+			gen.newEdge(dst, f, t, e.Attrs)
+		}
+	}
+}
+
+// newEdge adds an edge from f to t with the given attributes to the graph.
+func (gen *generator) newEdge(dst destination, f, t graph.Node, attrs []*ast.Attr) {
+	edge := dst.newEdge(f, t)
+	for _, attr := range attrs {
+		a := Attribute{
+			Key:   attr.Key,
+			Value: attr.Val,
+		}
+		if err := dst.setEdgeAttr(edge, a); err != nil {
+			panic(fmt.Errorf("unable to unmarshal edge DOT attribute (%s=%s)", a.Key, a.Value))
 		}
 	}
 }
 
 // addVertex adds the given vertex to the graph, and returns its set of nodes.
-func (gen *generator) addVertex(dst Builder, v ast.Vertex) []graph.Node {
+func (gen *generator) addVertex(dst destination, v ast.Vertex) []graph.Node {
 	switch v := v.(type) {
 	case *ast.Node:
 		n := gen.node(dst, v.ID)
@@ -168,17 +385,18 @@ func (gen *generator) addVertex(dst Builder, v ast.Vertex) []graph.Node {
 	}
 }
 
-// addEdge adds the given edge to the graph, and returns its set of nodes.
-func (gen *generator) addEdge(dst Builder, to *ast.Edge) []graph.Node {
+// addEdge adds the given edge to the graph with the given attributes, and
+// returns its set of nodes.
+func (gen *generator) addEdge(dst destination, to *ast.Edge, attrs []*ast.Attr) []graph.Node {
 	if !gen.directed && to.Directed {
 		panic(fmt.Errorf("directed edge to %v in undirected graph", to.Vertex))
 	}
 	fs := gen.addVertex(dst, to.Vertex)
 	if to.To != nil {
-		ts := gen.addEdge(dst, to.To)
+		ts := gen.addEdge(dst, to.To, attrs)
 		for _, f := range fs {
 			for _, t := range ts {
-				dst.NewEdge(f, t)
+				gen.newEdge(dst, f, t, attrs)
 			}
 		}
 	}
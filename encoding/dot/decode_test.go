@@ -6,6 +6,8 @@ package dot
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"testing"
 
 	"github.com/gonum/graph"
@@ -194,3 +196,144 @@ func (e *dotEdge) DOTAttributes() []Attribute {
 	}
 	return []Attribute{attr}
 }
+
+func TestUnmarshalGraphRoundTrip(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		var src, dst interface {
+			graph.Graph
+			graph.Builder
+		}
+		if directed {
+			src = simple.NewDirectedGraph(0, math.Inf(1))
+			dst = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			src = simple.NewUndirectedGraph(0, math.Inf(1))
+			dst = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		src.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(10), W: 2.5})
+		src.SetEdge(simple.Edge{F: simple.Node(10), T: simple.Node(0), W: -1})
+		src.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3), W: 1e6})
+		src.AddNode(simple.Node(7))
+
+		weights := EdgeAttributes(func(e graph.Edge) []Attribute {
+			return []Attribute{{Key: "weight", Value: quoteID(fmt.Sprint(e.Weight()))}}
+		})
+		want, err := Marshal(src, "", "", "\t", false, weights)
+		if err != nil {
+			t.Fatalf("unexpected error marshaling graph: %v", err)
+		}
+		ids, err := UnmarshalGraph(want, dst)
+		if err != nil {
+			t.Fatalf("unexpected error unmarshaling graph: %v", err)
+		}
+		for id, n := range ids {
+			if id != fmt.Sprint(n) {
+				t.Errorf("unexpected node ID for %q: %d", id, n)
+			}
+		}
+		got, err := Marshal(dst, "", "", "\t", false, weights)
+		if err != nil {
+			t.Fatalf("unexpected error marshaling graph: %v", err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("unexpected round trip result:\ngot: %s\nwant:%s", got, want)
+		}
+	}
+}
+
+const graphvizFile = `/* A network in the style of the Graphviz gallery. */
+digraph "service map" {
+	graph [rankdir=LR];
+	node [shape=box]; // Defaults are ignored.
+
+	"web server" [color=red, label="Web \"front\" end"];
+	db;
+	subgraph cluster_cache {
+		label = "cache";
+		a -> b [weight=3];
+	}
+	"web server" -> a -> db [weight=2.5 color=blue];
+	# A line comment.
+	db -> {b c} [style=dashed];
+	7 -> "web server";
+}`
+
+func TestUnmarshalGraph(t *testing.T) {
+	g := simple.NewDirectedAttrGraph(0, math.Inf(1))
+	ids, err := UnmarshalGraph([]byte(graphvizFile), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 6 || len(g.Nodes()) != 6 {
+		t.Errorf("unexpected number of nodes: got:%d IDs and %d nodes want:6", len(ids), len(g.Nodes()))
+	}
+	if ids["7"] != 7 {
+		t.Errorf("integer DOT ID not used as node ID: got:%d want:7", ids["7"])
+	}
+	node := func(name string) graph.Node {
+		id, ok := ids[name]
+		if !ok {
+			t.Fatalf("missing node %q", name)
+		}
+		return simple.Node(id)
+	}
+
+	for _, test := range []struct {
+		from, to string
+		weight   float64
+		attrs    map[string]string
+	}{
+		{from: "a", to: "b", weight: 3},
+		{from: "web server", to: "a", weight: 2.5, attrs: map[string]string{"color": "blue"}},
+		{from: "a", to: "db", weight: 2.5, attrs: map[string]string{"color": "blue"}},
+		{from: "db", to: "b", weight: 1, attrs: map[string]string{"style": "dashed"}},
+		{from: "db", to: "c", weight: 1, attrs: map[string]string{"style": "dashed"}},
+		{from: "7", to: "web server", weight: 1},
+	} {
+		u, v := node(test.from), node(test.to)
+		if w, ok := g.Weight(u, v); w != test.weight || !ok {
+			t.Errorf("unexpected weight for %q->%q: got:(%v, %t) want:(%v, true)", test.from, test.to, w, ok, test.weight)
+		}
+		for key, want := range test.attrs {
+			if got, _ := g.EdgeAttr(u, v, key); got != want {
+				t.Errorf("unexpected %s attribute for %q->%q: got:%v want:%v", key, test.from, test.to, got, want)
+			}
+		}
+	}
+	if g.Size() != 6 {
+		t.Errorf("unexpected number of edges: got:%d want:6", g.Size())
+	}
+
+	v, _ := g.NodeValue(ids["web server"])
+	want := map[string]string{"color": "red", "label": `"Web \"front\" end"`}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected node attributes: got:%v want:%v", v, want)
+	}
+	if v, ok := g.NodeValue(ids["db"]); ok {
+		t.Errorf("unexpected attributes for node without attributes: %v", v)
+	}
+
+	// Attributes are ignored by graphs that cannot hold them.
+	_, err = UnmarshalGraph([]byte(graphvizFile), simple.NewDirectedGraph(0, math.Inf(1)))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnmarshalGraphErrors(t *testing.T) {
+	for _, test := range []struct {
+		data string
+		dst  graph.Builder
+	}{
+		{data: "digraph { a -> b }", dst: simple.NewUndirectedGraph(0, math.Inf(1))},
+		{data: "graph { a -- b }", dst: simple.NewDirectedGraph(0, math.Inf(1))},
+		{data: "digraph { a -> b [weight=heavy] }", dst: simple.NewDirectedGraph(0, math.Inf(1))},
+		{data: "digraph { a -> ", dst: simple.NewDirectedGraph(0, math.Inf(1))},
+		{data: "digraph { a -> b; b -> b }", dst: simple.NewDirectedGraph(0, math.Inf(1))},
+		{data: "graph { 1 -- 1 }", dst: simple.NewUndirectedGraph(0, math.Inf(1))},
+	} {
+		if _, err := UnmarshalGraph([]byte(test.data), test.dst); err == nil {
+			t.Errorf("expected error for %q", test.data)
+		}
+	}
+}
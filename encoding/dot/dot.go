@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gonum/graph"
@@ -35,6 +36,9 @@ type Node interface {
 	//  - a numeral [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?).
 	//  - a double-quoted string ("...") possibly containing escaped quotes (\").
 	//  - an HTML string (<...>).
+	//
+	// Marshal quotes returned IDs that are not
+	// valid DOT IDs.
 	DOTID() string
 }
 
@@ -80,21 +84,46 @@ type Subgrapher interface {
 	Subgraph() graph.Graph
 }
 
+// Option is an encoding option for Marshal.
+type Option func(*printer)
+
+// WeightLabels returns an Option that labels each edge with its weight.
+func WeightLabels() Option {
+	return func(p *printer) { p.weightLabels = true }
+}
+
+// NodeAttributes returns an Option that adds the attributes returned by fn
+// to each node, following any attributes of the node from Attributer.
+func NodeAttributes(fn func(graph.Node) []Attribute) Option {
+	return func(p *printer) { p.nodeAttributes = fn }
+}
+
+// EdgeAttributes returns an Option that adds the attributes returned by fn
+// to each edge, following any attributes of the edge from Attributer.
+func EdgeAttributes(fn func(graph.Edge) []Attribute) Option {
+	return func(p *printer) { p.edgeAttributes = fn }
+}
+
 // Marshal returns the DOT encoding for the graph g, applying the prefix
 // and indent to the encoding. Name is used to specify the graph name. If
 // name is empty and g implements Graph, the returned string from DOTID
 // will be used. If strict is true the output bytes will be prefixed with
-// the DOT "strict" keyword.
+// the DOT "strict" keyword. Nodes and edges are written in ID order and
+// nodes without edges are included. Options may be given to add edge
+// weight labels and attributes to the encoding.
 //
 // Graph serialization will work for a graph.Graph without modification,
 // however, advanced GraphViz DOT features provided by Marshal depend on
 // implementation of the Node, Attributer, Porter, Attributers, Structurer,
 // Subgrapher and Graph interfaces.
-func Marshal(g graph.Graph, name, prefix, indent string, strict bool) ([]byte, error) {
+func Marshal(g graph.Graph, name, prefix, indent string, strict bool, opts ...Option) ([]byte, error) {
 	var p printer
 	p.indent = indent
 	p.prefix = prefix
 	p.visited = make(map[edge]bool)
+	for _, opt := range opts {
+		opt(&p)
+	}
 	if strict {
 		p.buf.WriteString("strict ")
 	}
@@ -114,6 +143,10 @@ type printer struct {
 
 	visited map[edge]bool
 
+	weightLabels   bool
+	nodeAttributes func(graph.Node) []Attribute
+	edgeAttributes func(graph.Edge) []Attribute
+
 	err error
 }
 
@@ -147,7 +180,7 @@ func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool
 	}
 	if name != "" {
 		p.buf.WriteByte(' ')
-		p.buf.WriteString(name)
+		p.buf.WriteString(quoteID(name))
 	}
 
 	p.openBlock(" {")
@@ -193,9 +226,7 @@ func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool
 		}
 		p.newline()
 		p.writeNode(n)
-		if a, ok := n.(Attributer); ok {
-			p.writeAttributeList(a)
-		}
+		p.writeAttributeList(p.attributesOfNode(n))
 		p.buf.WriteByte(';')
 	}
 
@@ -261,9 +292,7 @@ func (p *printer) print(g graph.Graph, name string, needsIndent, isSubgraph bool
 				p.writePorts(e.ToPort())
 			}
 
-			if a, ok := g.Edge(n, t).(Attributer); ok {
-				p.writeAttributeList(a)
-			}
+			p.writeAttributeList(p.attributesOfEdge(g.Edge(n, t)))
 
 			p.buf.WriteByte(';')
 		}
@@ -291,7 +320,7 @@ func (p *printer) writePorts(port, cp string) {
 func nodeID(n graph.Node) string {
 	switch n := n.(type) {
 	case Node:
-		return n.DOTID()
+		return quoteID(n.DOTID())
 	default:
 		return fmt.Sprint(n.ID())
 	}
@@ -300,14 +329,106 @@ func nodeID(n graph.Node) string {
 func graphID(g graph.Graph, n graph.Node) string {
 	switch g := g.(type) {
 	case Node:
-		return g.DOTID()
+		return quoteID(g.DOTID())
 	default:
 		return nodeID(n)
 	}
 }
 
-func (p *printer) writeAttributeList(a Attributer) {
-	attributes := a.DOTAttributes()
+// quoteID returns id as a double-quoted string if it is not a valid DOT ID.
+// Backslashes and double quotes in a quoted id are escaped.
+func quoteID(id string) string {
+	if isID(id) {
+		return id
+	}
+	return `"` + quoteEscaper.Replace(id) + `"`
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// isKeyword returns whether s is a DOT keyword. Keywords are case-independent.
+func isKeyword(s string) bool {
+	switch strings.ToLower(s) {
+	case "graph", "node", "edge", "digraph", "subgraph", "strict":
+		return true
+	}
+	return false
+}
+
+// isID returns whether s is a DOT identifier that is not a keyword, numeral,
+// double-quoted string or HTML string.
+func isID(s string) bool {
+	if s == "" {
+		return false
+	}
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return true
+	case s[0] == '<' && s[len(s)-1] == '>':
+		return true
+	}
+
+	// Identifier.
+	ident := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= 0x80 || i != 0 && '0' <= c && c <= '9' {
+			continue
+		}
+		ident = false
+		break
+	}
+	if ident {
+		return !isKeyword(s)
+	}
+
+	// Numeral.
+	if s[0] == '-' {
+		s = s[1:]
+	}
+	var digits, dots int
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits != 0 && dots <= 1
+}
+
+// attributesOfNode returns the attributes to write for n.
+func (p *printer) attributesOfNode(n graph.Node) []Attribute {
+	var attributes []Attribute
+	if a, ok := n.(Attributer); ok {
+		attributes = a.DOTAttributes()
+	}
+	if p.nodeAttributes != nil {
+		attributes = append(attributes[:len(attributes):len(attributes)], p.nodeAttributes(n)...)
+	}
+	return attributes
+}
+
+// attributesOfEdge returns the attributes to write for e.
+func (p *printer) attributesOfEdge(e graph.Edge) []Attribute {
+	var attributes []Attribute
+	if a, ok := e.(Attributer); ok {
+		attributes = a.DOTAttributes()
+	}
+	if p.weightLabels {
+		label := Attribute{Key: "label", Value: quoteID(strconv.FormatFloat(e.Weight(), 'g', -1, 64))}
+		attributes = append(attributes[:len(attributes):len(attributes)], label)
+	}
+	if p.edgeAttributes != nil {
+		attributes = append(attributes[:len(attributes):len(attributes)], p.edgeAttributes(e)...)
+	}
+	return attributes
+}
+
+func (p *printer) writeAttributeList(attributes []Attribute) {
 	switch len(attributes) {
 	case 0:
 	case 1:
@@ -5,6 +5,7 @@
 package dot
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
@@ -1421,3 +1422,109 @@ func TestEncode(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodeOptions(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: namedNode{id: 0, name: "web server"}, T: namedNode{id: 1, name: "db"}, W: 1.5})
+	g.SetEdge(simple.Edge{F: namedNode{id: 1, name: "db"}, T: attrNode{id: 2, attr: []Attribute{{"shape", "box"}}}, W: 1e6})
+	g.SetEdge(simple.Edge{F: attrNode{id: 2, attr: []Attribute{{"shape", "box"}}}, T: namedNode{id: 0, name: "web server"}, W: math.Inf(1)})
+	g.AddNode(namedNode{id: 3, name: `say "hi"`})
+
+	got, err := Marshal(g, "net work", "", "\t", false,
+		WeightLabels(),
+		NodeAttributes(func(n graph.Node) []Attribute {
+			if n.ID()%2 == 0 {
+				return []Attribute{{"color", "red"}}
+			}
+			return nil
+		}),
+		EdgeAttributes(func(e graph.Edge) []Attribute {
+			return []Attribute{{"tooltip", fmt.Sprintf("%d", e.From().ID())}}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const want = `digraph "net work" {
+	// Node definitions.
+	"web server" [color=red];
+	db;
+	2 [
+		shape=box
+		color=red
+	];
+	"say \"hi\"";
+
+	// Edge definitions.
+	"web server" -> db [
+		label=1.5
+		tooltip=0
+	];
+	db -> 2 [
+		label="1e+06"
+		tooltip=1
+	];
+	2 -> "web server" [
+		label="+Inf"
+		tooltip=2
+	];
+}`
+	if string(got) != want {
+		t.Errorf("unexpected DOT result:\ngot: %s\nwant:%s", got, want)
+	}
+}
+
+func TestEncodeQuoting(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: namedNode{id: 0, name: "node"}, T: namedNode{id: 1, name: "Edge"}, W: 1})
+	g.SetEdge(simple.Edge{F: namedNode{id: 1, name: "Edge"}, T: namedNode{id: 2, name: `C:\temp\"x"`}, W: 1})
+	g.AddNode(namedNode{id: 3, name: "digraph"})
+
+	got, err := Marshal(g, "strict", "", "\t", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const want = `digraph "strict" {
+	// Node definitions.
+	"node";
+	"Edge";
+	"C:\\temp\\\"x\"";
+	"digraph";
+
+	// Edge definitions.
+	"node" -> "Edge";
+	"Edge" -> "C:\\temp\\\"x\"";
+}`
+	if string(got) != want {
+		t.Errorf("unexpected DOT result:\ngot: %s\nwant:%s", got, want)
+	}
+}
+
+func TestQuoteID(t *testing.T) {
+	for _, test := range []struct {
+		id, want string
+	}{
+		{id: "a", want: "a"},
+		{id: "_a1", want: "_a1"},
+		{id: "1a", want: `"1a"`},
+		{id: "-1.5", want: "-1.5"},
+		{id: ".5", want: ".5"},
+		{id: "1.2.3", want: `"1.2.3"`},
+		{id: "-", want: `"-"`},
+		{id: `"quoted"`, want: `"quoted"`},
+		{id: "<<b>html</b>>", want: "<<b>html</b>>"},
+		{id: "a b", want: `"a b"`},
+		{id: "", want: `""`},
+		{id: "node", want: `"node"`},
+		{id: "Graph", want: `"Graph"`},
+		{id: "SUBGRAPH", want: `"SUBGRAPH"`},
+		{id: "strict", want: `"strict"`},
+		{id: "nodes", want: "nodes"},
+		{id: `C:\dir`, want: `"C:\\dir"`},
+		{id: `a\"b`, want: `"a\\\"b"`},
+	} {
+		if got := quoteID(test.id); got != test.want {
+			t.Errorf("unexpected quoting of %q: got:%s want:%s", test.id, got, test.want)
+		}
+	}
+}
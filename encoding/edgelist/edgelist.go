@@ -0,0 +1,177 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package edgelist implements reading and writing of graphs as delimited
+// edge lists.
+//
+// Each line of an edge list describes an edge with two or three fields, the
+// from node, the to node and optionally the weight of the edge, for example
+//
+//  # source target weight
+//  0	1	2.5
+//  1	2	1
+//
+// Nodes are identified by integer IDs or, when a simple.NodeNamer is provided,
+// by arbitrary names. Fields may be quoted as described for encoding/csv. Edge
+// lists are read and written a record at a time.
+package edgelist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/edgecsv"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// Options specifies the format of an edge list.
+type Options struct {
+	// Delimiter is the field delimiter.
+	// If Delimiter is zero, fields are
+	// separated by runs of spaces and
+	// tabs when reading and by a tab
+	// when writing.
+	Delimiter rune
+
+	// Comment is the character that
+	// starts comment lines. Comment
+	// lines and blank lines are ignored
+	// when reading. If Comment is zero,
+	// only blank lines are ignored.
+	Comment rune
+
+	// Header specifies that the first
+	// line of the list that is not a
+	// comment is a header. The header
+	// is ignored when reading.
+	Header bool
+
+	// Weighted specifies that edge
+	// weights are written as a third
+	// field. Weights are read whenever
+	// a line has three fields.
+	Weighted bool
+
+	// Namer, if not nil, is used to map
+	// between node names and nodes. When
+	// reading, the Namer must name the
+	// nodes of the destination graph. If
+	// Namer is nil, nodes are identified
+	// by integer IDs.
+	Namer *simple.NodeNamer
+}
+
+// ReadEdgeList reads an edge list in the format specified by opts from r and
+// adds its edges to dst. Edges without a weight are given unit weight. Nodes
+// are added to dst as needed, and a repeated edge replaces the earlier edge
+// as described for dst's SetEdge method. Self edges are not supported and
+// result in an error. If opts.Namer is not nil, ReadEdgeList returns an error
+// unless the Namer names the nodes of dst.
+//
+// Errors in the input are reported with the line number at which they occur.
+func ReadEdgeList(r io.Reader, dst graph.Builder, opts Options) error {
+	if opts.Namer != nil && opts.Namer.Graph() != dst {
+		return errors.New("edgelist: namer does not name the nodes of the destination graph")
+	}
+	er := edgecsv.NewReader(r, opts.Delimiter, opts.Comment, opts.Header)
+	for {
+		e, err := er.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if _, ok := err.(*edgecsv.Error); ok {
+				err = fmt.Errorf("edgelist: %v", err)
+			}
+			return err
+		}
+
+		var u, v graph.Node
+		if opts.Namer != nil {
+			u, v = opts.Namer.Node(e.From), opts.Namer.Node(e.To)
+		} else {
+			var ids [2]int
+			for i, f := range [2]string{e.From, e.To} {
+				id, err := strconv.Atoi(f)
+				if err != nil {
+					return fmt.Errorf("edgelist: line %d: invalid node ID %q", e.Line, f)
+				}
+				ids[i] = id
+			}
+			if ids[0] == ids[1] {
+				return fmt.Errorf("edgelist: line %d: self edge on %d", e.Line, ids[0])
+			}
+			u, v = simple.Node(ids[0]), simple.Node(ids[1])
+		}
+		dst.SetEdge(simple.Edge{F: u, T: v, W: e.Weight})
+	}
+}
+
+// WriteEdgeList writes the edges of g to w as an edge list in the format
+// specified by opts. Edges are written in order of their from and to node
+// IDs, and the edges of undirected graphs are written once, with the lower
+// node ID first. The edge list does not hold nodes without edges.
+//
+// If opts.Namer is not nil, nodes are written using their names and
+// WriteEdgeList returns an error for nodes without a name. Names that would
+// not be read back as a single field, such as names holding the delimiter,
+// white space or a quote, or starting with the Comment character, are quoted.
+func WriteEdgeList(w io.Writer, g graph.Graph, opts Options) error {
+	delim := "\t"
+	if opts.Delimiter != 0 {
+		delim = string(opts.Delimiter)
+	}
+	bw := bufio.NewWriter(w)
+	if opts.Header {
+		bw.WriteString("source" + delim + "target")
+		if opts.Weighted {
+			bw.WriteString(delim + "weight")
+		}
+		bw.WriteByte('\n')
+	}
+
+	name := func(n graph.Node) (string, error) {
+		if opts.Namer == nil {
+			return strconv.Itoa(n.ID()), nil
+		}
+		s, ok := opts.Namer.Name(n.ID())
+		if !ok {
+			return "", fmt.Errorf("edgelist: no name for node %d", n.ID())
+		}
+		return s, nil
+	}
+
+	_, directed := g.(graph.Directed)
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			from, err := name(u)
+			if err != nil {
+				return err
+			}
+			to, err := name(v)
+			if err != nil {
+				return err
+			}
+			bw.WriteString(edgecsv.Quote(from, opts.Delimiter, opts.Comment) + delim + edgecsv.Quote(to, opts.Delimiter, opts.Comment))
+			if opts.Weighted {
+				bw.WriteString(delim + strconv.FormatFloat(g.Edge(u, v).Weight(), 'g', -1, 64))
+			}
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
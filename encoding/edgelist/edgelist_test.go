@@ -0,0 +1,245 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edgelist
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+func TestReadEdgeList(t *testing.T) {
+	const list = `# comment
+source,target,weight
+
+0, 1, 2.5
+1,2
+# another comment
+2,0,-1
+`
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	err := ReadEdgeList(strings.NewReader(list), g, Options{Delimiter: ',', Comment: '#', Header: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2.5},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(0), W: -1},
+	} {
+		got := g.Edge(e.F, e.T)
+		if got == nil {
+			t.Errorf("missing edge %d->%d", e.F.ID(), e.T.ID())
+			continue
+		}
+		if got.Weight() != e.W {
+			t.Errorf("unexpected weight for edge %d->%d: got:%v want:%v", e.F.ID(), e.T.ID(), got.Weight(), e.W)
+		}
+	}
+	if n := len(g.Edges()); n != 3 {
+		t.Errorf("unexpected number of edges: got:%d want:3", n)
+	}
+}
+
+func TestReadEdgeListNamed(t *testing.T) {
+	const list = "london paris 344\nparis berlin 878\nberlin\tlondon 932\n\"new york\"  london\t5570\n"
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	namer := simple.NewNodeNamer(g)
+	err := ReadEdgeList(strings.NewReader(list), g, Options{Namer: namer})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paris, _ := namer.ID("paris")
+	berlin, _ := namer.ID("berlin")
+	e := g.EdgeBetween(simple.Node(berlin), simple.Node(paris))
+	if e == nil || e.Weight() != 878 {
+		t.Errorf("unexpected paris--berlin edge: got:%v", e)
+	}
+
+	ny, ok := namer.ID("new york")
+	if !ok {
+		t.Fatal("missing node for quoted name")
+	}
+	london, _ := namer.ID("london")
+	if e := g.EdgeBetween(simple.Node(ny), simple.Node(london)); e == nil || e.Weight() != 5570 {
+		t.Errorf("unexpected new york--london edge: got:%v", e)
+	}
+	g.RemoveNode(simple.Node(ny))
+
+	var buf bytes.Buffer
+	err = WriteEdgeList(&buf, g, Options{Delimiter: ' ', Weighted: true, Namer: namer})
+	if err != nil {
+		t.Fatalf("unexpected error writing edge list: %v", err)
+	}
+	want := "london paris 344\nlondon berlin 932\nparis berlin 878\n"
+	if buf.String() != want {
+		t.Errorf("unexpected edge list:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestReadEdgeListNamerGraph(t *testing.T) {
+	// A Namer of another graph would add
+	// nodes to that graph rather than dst.
+	other := simple.NewDirectedGraph(0, math.Inf(1))
+	dst := simple.NewDirectedGraph(0, math.Inf(1))
+	err := ReadEdgeList(strings.NewReader("a b\n"), dst, Options{Namer: simple.NewNodeNamer(other)})
+	if err == nil {
+		t.Error("expected error for namer of another graph")
+	}
+	if len(other.Nodes()) != 0 || len(dst.Nodes()) != 0 {
+		t.Errorf("unexpected nodes added: other:%d dst:%d", len(other.Nodes()), len(dst.Nodes()))
+	}
+}
+
+func TestReadEdgeListErrors(t *testing.T) {
+	for _, test := range []struct {
+		list string
+		want string
+	}{
+		{list: "0 1\n1\n", want: "edgelist: line 2: invalid number of fields: 1"},
+		{list: "0 1 2 3\n", want: "edgelist: line 1: invalid number of fields: 4"},
+		{list: "\n0 1 heavy\n", want: `edgelist: line 2: invalid weight "heavy"`},
+		{list: "a b\n", want: `edgelist: line 1: invalid node ID "a"`},
+		{list: "1 1\n", want: `edgelist: line 1: self edge on "1"`},
+		{list: "1 01\n", want: "edgelist: line 1: self edge on 1"},
+		{list: "0 1\n\"2 3\n", want: `edgelist: line 2: extraneous or missing " in quoted-field`},
+		{list: "\"0\" \"1\n\"\n0 1 2 3\n", want: "edgelist: line 3: invalid number of fields: 4"},
+	} {
+		err := ReadEdgeList(strings.NewReader(test.list), simple.NewDirectedGraph(0, math.Inf(1)), Options{})
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.list, err, test.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1), W: 0.5})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 1e-10})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3), W: 4})
+
+	opts := Options{Header: true, Weighted: true}
+	var buf bytes.Buffer
+	err := WriteEdgeList(&buf, g, opts)
+	if err != nil {
+		t.Fatalf("unexpected error writing edge list: %v", err)
+	}
+	want := "source\ttarget\tweight\n1\t2\t1e-10\n1\t3\t0.5\n2\t3\t4\n"
+	if buf.String() != want {
+		t.Errorf("unexpected edge list:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	dst := simple.NewUndirectedGraph(0, math.Inf(1))
+	err = ReadEdgeList(&buf, dst, opts)
+	if err != nil {
+		t.Fatalf("unexpected error reading edge list: %v", err)
+	}
+	for _, e := range g.Edges() {
+		got := dst.EdgeBetween(e.From(), e.To())
+		if got == nil || got.Weight() != e.Weight() {
+			t.Errorf("unexpected edge %d--%d: got:%v want:%v", e.From().ID(), e.To().ID(), got, e)
+		}
+	}
+	if len(dst.Edges()) != len(g.Edges()) {
+		t.Errorf("unexpected number of edges: got:%d want:%d", len(dst.Edges()), len(g.Edges()))
+	}
+}
+
+func TestRoundTripNamed(t *testing.T) {
+	names := []string{"new york", "boston", `say "hi"`, "a,b", "#hash", "tab\there"}
+	for _, opts := range []Options{
+		{Weighted: true},
+		{Delimiter: ',', Comment: '#', Header: true, Weighted: true},
+	} {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		src := simple.NewNodeNamer(g)
+		for i := 1; i < len(names); i++ {
+			src.SetEdgeByName(names[i-1], names[i], float64(i))
+		}
+
+		var buf bytes.Buffer
+		opts.Namer = src
+		err := WriteEdgeList(&buf, g, opts)
+		if err != nil {
+			t.Fatalf("unexpected error writing edge list: %v", err)
+		}
+
+		dst := simple.NewDirectedGraph(0, math.Inf(1))
+		opts.Namer = simple.NewNodeNamer(dst)
+		err = ReadEdgeList(bytes.NewReader(buf.Bytes()), dst, opts)
+		if err != nil {
+			t.Errorf("unexpected error reading edge list with delimiter %q: %v\n%s", opts.Delimiter, err, buf.Bytes())
+			continue
+		}
+		if n := len(dst.Edges()); n != len(names)-1 {
+			t.Errorf("unexpected number of edges with delimiter %q: got:%d want:%d", opts.Delimiter, n, len(names)-1)
+		}
+		for i := 1; i < len(names); i++ {
+			want := names[i-1 : i+1]
+			if opts.Delimiter == 0 {
+				// Tabs in quoted fields are read
+				// as spaces in white space mode.
+				want = []string{strings.Replace(want[0], "\t", " ", -1), strings.Replace(want[1], "\t", " ", -1)}
+			}
+			u, uok := opts.Namer.ID(want[0])
+			v, vok := opts.Namer.ID(want[1])
+			if !uok || !vok {
+				t.Errorf("missing nodes for %q with delimiter %q", want, opts.Delimiter)
+				continue
+			}
+			e := dst.Edge(simple.Node(u), simple.Node(v))
+			if e == nil || e.Weight() != float64(i) {
+				t.Errorf("unexpected edge for %q with delimiter %q: got:%v", want, opts.Delimiter, e)
+			}
+		}
+	}
+}
+
+func TestReadEdgeListLarge(t *testing.T) {
+	const (
+		lines = 100000
+		width = 1000
+	)
+
+	// The edge list is generated as it is read
+	// so the reader never holds the whole list.
+	pr, pw := io.Pipe()
+	go func() {
+		bw := bufio.NewWriter(pw)
+		fmt.Fprintln(bw, "# generated path")
+		for i := 0; i < lines; i++ {
+			// The edges form a single path with
+			// weights repeating every width edges.
+			fmt.Fprintf(bw, "%d %d %g\n", i, i+1, float64(i%width)/4)
+		}
+		bw.Flush()
+		pw.Close()
+	}()
+
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	err := ReadEdgeList(pr, g, Options{Comment: '#'})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != lines+1 {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", n, lines+1)
+	}
+	if n := len(g.Edges()); n != lines {
+		t.Errorf("unexpected number of edges: got:%d want:%d", n, lines)
+	}
+	for _, i := range []int{0, 1, 999, 1000, 54321, lines - 1} {
+		e := g.Edge(simple.Node(i), simple.Node(i+1))
+		want := float64(i%width) / 4
+		if e == nil || e.Weight() != want {
+			t.Errorf("unexpected edge %d->%d: got:%v want weight:%v", i, i+1, e, want)
+		}
+	}
+}
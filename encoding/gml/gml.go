@@ -0,0 +1,466 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gml implements reading and writing of graphs in the Graph Modelling
+// Language (GML).
+//
+// A GML document is a nested list of key-value pairs. The graph is described
+// by the graph key of the document, for example
+//
+//  graph [
+//  	directed 1
+//  	node [ id 0 label "a" ]
+//  	node [ id 1 label "b" ]
+//  	edge [ source 0 target 1 value 2.5 ]
+//  ]
+//
+// Node id values are used as graph node IDs and edge value values are used as
+// edge weights. Other keys of nodes and edges are treated as attributes.
+package gml
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// Read reads a GML document from r and adds the nodes and edges of its graph
+// to dst. Edges without a value are given unit weight.
+//
+// If dst is able to hold node values, such as a simple.DirectedAttrGraph, nodes
+// with attributes are given a map[string]interface{} value holding them, so the
+// label of a node is held under the "label" key. If dst is able to hold edge
+// attributes, edge attributes are set as the attributes of the edge. Values of
+// attributes are int, float64, string or, for nested lists,
+// map[string]interface{} values. Attributes are otherwise ignored.
+//
+// Read returns an error if the directedness of the graph does not match dst,
+// if an edge refers to a node that is not in the graph or if the graph holds
+// a self edge. Syntax errors are reported with the line at which they occur.
+func Read(r io.Reader, dst graph.Builder) error {
+	p := parser{r: bufio.NewReader(r), line: 1}
+	doc, err := p.list(true)
+	if err != nil {
+		return err
+	}
+	var (
+		g  []item
+		ok bool
+	)
+	for _, it := range doc {
+		if it.key == "graph" {
+			g, ok = it.value.([]item)
+			break
+		}
+	}
+	if !ok {
+		return errors.New("gml: no graph list")
+	}
+
+	var directed bool
+	for _, it := range g {
+		if it.key == "directed" {
+			d, ok := it.value.(int)
+			if !ok {
+				return fmt.Errorf("gml: invalid directed value: %v", it.value)
+			}
+			directed = d != 0
+		}
+	}
+	if _, ok := dst.(graph.Directed); ok != directed {
+		return errors.New("gml: mismatched graph type")
+	}
+
+	nodes := make(map[int]bool)
+	for _, it := range g {
+		if it.key != "node" {
+			continue
+		}
+		n, ok := it.value.([]item)
+		if !ok {
+			return fmt.Errorf("gml: invalid node: %v", it.value)
+		}
+		id, err := intKey(n, "node", "id")
+		if err != nil {
+			return err
+		}
+		if nodes[id] {
+			return fmt.Errorf("gml: duplicate node id %d", id)
+		}
+		nodes[id] = true
+		dst.AddNode(simple.Node(id))
+		if s, ok := dst.(graph.NodeValueSetter); ok {
+			if attrs := attrsOf(n, "id"); attrs != nil {
+				s.SetNodeValue(id, attrs)
+			}
+		}
+	}
+
+	for _, it := range g {
+		if it.key != "edge" {
+			continue
+		}
+		e, ok := it.value.([]item)
+		if !ok {
+			return fmt.Errorf("gml: invalid edge: %v", it.value)
+		}
+		source, err := intKey(e, "edge", "source")
+		if err != nil {
+			return err
+		}
+		target, err := intKey(e, "edge", "target")
+		if err != nil {
+			return err
+		}
+		for _, id := range []int{source, target} {
+			if !nodes[id] {
+				return fmt.Errorf("gml: edge refers to unknown node %d", id)
+			}
+		}
+		if source == target {
+			return fmt.Errorf("gml: self edge on node %d", source)
+		}
+		w := 1.0
+		for _, f := range e {
+			if f.key != "value" {
+				continue
+			}
+			switch v := f.value.(type) {
+			case int:
+				w = float64(v)
+			case float64:
+				w = v
+			default:
+				return fmt.Errorf("gml: invalid edge value: %v", f.value)
+			}
+		}
+		u, v := simple.Node(source), simple.Node(target)
+		dst.SetEdge(simple.Edge{F: u, T: v, W: w})
+		if s, ok := dst.(graph.EdgeAttrSetter); ok {
+			for key, value := range attrsOf(e, "source", "target", "value") {
+				s.SetEdgeAttr(u, v, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// item is a GML key-value pair. The value of an item
+// is an int, float64, string or []item.
+type item struct {
+	key   string
+	value interface{}
+}
+
+// intKey returns the int value of the named key of the list, which is
+// an element of the given kind.
+func intKey(list []item, kind, key string) (int, error) {
+	for _, it := range list {
+		if it.key != key {
+			continue
+		}
+		v, ok := it.value.(int)
+		if !ok {
+			return 0, fmt.Errorf("gml: invalid %s %s: %v", kind, key, it.value)
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("gml: %s without %s", kind, key)
+}
+
+// attrsOf returns the items of list other than those with the given keys as
+// a map, or nil if there are no other items. Nested lists are converted to
+// maps. Where a key is repeated, the last value is used.
+func attrsOf(list []item, keys ...string) map[string]interface{} {
+	var attrs map[string]interface{}
+outer:
+	for _, it := range list {
+		for _, k := range keys {
+			if it.key == k {
+				continue outer
+			}
+		}
+		if attrs == nil {
+			attrs = make(map[string]interface{})
+		}
+		if l, ok := it.value.([]item); ok {
+			m := attrsOf(l)
+			if m == nil {
+				m = make(map[string]interface{})
+			}
+			attrs[it.key] = m
+			continue
+		}
+		attrs[it.key] = it.value
+	}
+	return attrs
+}
+
+// parser is a GML parser.
+type parser struct {
+	r    *bufio.Reader
+	line int
+}
+
+// errorf returns an error annotated with the current line.
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("gml: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// list parses a list of key-value pairs. The list is terminated by the end of
+// the input if top is true and by a closing bracket otherwise.
+func (p *parser) list(top bool) ([]item, error) {
+	var l []item
+	for {
+		tok, err := p.token()
+		if err == io.EOF {
+			if top {
+				return l, nil
+			}
+			return nil, p.errorf("unexpected end of input")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if tok == "]" {
+			if top {
+				return nil, p.errorf("unexpected ]")
+			}
+			return l, nil
+		}
+		if !isKey(tok) {
+			return nil, p.errorf("invalid key %q", tok)
+		}
+
+		val, err := p.token()
+		if err == io.EOF {
+			return nil, p.errorf("unexpected end of input")
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v interface{}
+		switch {
+		case val == "[":
+			v, err = p.list(false)
+			if err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(val, `"`):
+			v = val[1 : len(val)-1]
+		default:
+			if i, err := strconv.Atoi(val); err == nil {
+				v = i
+			} else if f, err := strconv.ParseFloat(val, 64); err == nil {
+				v = f
+			} else {
+				return nil, p.errorf("invalid value %q for key %q", val, tok)
+			}
+		}
+		l = append(l, item{key: tok, value: v})
+	}
+}
+
+// token returns the next token of the input, skipping white space and
+// comments. Strings are returned with their quotes.
+func (p *parser) token() (string, error) {
+	var c rune
+	for {
+		var err error
+		c, _, err = p.r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		if c == '\n' {
+			p.line++
+			continue
+		}
+		if c == '#' {
+			_, err = p.r.ReadString('\n')
+			if err != nil {
+				return "", err
+			}
+			p.line++
+			continue
+		}
+		if !unicode.IsSpace(c) {
+			break
+		}
+	}
+
+	switch c {
+	case '[', ']':
+		return string(c), nil
+	case '"':
+		s, err := p.r.ReadString('"')
+		if err != nil {
+			return "", p.errorf("unterminated string")
+		}
+		p.line += strings.Count(s, "\n")
+		return `"` + s, nil
+	}
+	tok := []rune{c}
+	for {
+		c, _, err := p.r.ReadRune()
+		if err == io.EOF {
+			return string(tok), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if unicode.IsSpace(c) || c == '[' || c == ']' || c == '"' {
+			p.r.UnreadRune()
+			return string(tok), nil
+		}
+		tok = append(tok, c)
+	}
+}
+
+// isKey returns whether s is a valid GML key.
+func isKey(s string) bool {
+	for i, c := range s {
+		if c > unicode.MaxASCII || !(unicode.IsLetter(c) || c == '_' || (i != 0 && unicode.IsDigit(c))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Write writes g to w as a GML document. Nodes and edges are written in ID
+// order, and the edges of undirected graphs are written once. Edge weights are
+// written as the value of the edge.
+//
+// If g holds node values, node values that are maps with string keys are
+// written as attributes of the node. If g holds edge attributes, they are
+// written as attributes of the edge. Attribute values must be strings, numbers
+// or maps with string keys. Write returns an error for other node values or
+// attribute values and for attributes using the key of a node or edge field.
+func Write(w io.Writer, g graph.Graph) error {
+	_, directed := g.(graph.Directed)
+	nv, _ := g.(graph.NodeValuer)
+	ea, _ := g.(graph.EdgeAttrser)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("graph [\n")
+	if directed {
+		bw.WriteString("\tdirected 1\n")
+	} else {
+		bw.WriteString("\tdirected 0\n")
+	}
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for _, n := range nodes {
+		bw.WriteString("\tnode [\n")
+		fmt.Fprintf(bw, "\t\tid %d\n", n.ID())
+		if nv != nil {
+			if v, ok := nv.NodeValue(n.ID()); ok {
+				var attrs map[string]interface{}
+				switch v := v.(type) {
+				case map[string]interface{}:
+					attrs = v
+				case map[string]string:
+					attrs = make(map[string]interface{}, len(v))
+					for key, value := range v {
+						attrs[key] = value
+					}
+				default:
+					return fmt.Errorf("gml: unsupported node value type %T", v)
+				}
+				err := writeAttrs(bw, 2, attrs, "id")
+				if err != nil {
+					return err
+				}
+			}
+		}
+		bw.WriteString("\t]\n")
+	}
+
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			bw.WriteString("\tedge [\n")
+			fmt.Fprintf(bw, "\t\tsource %d\n\t\ttarget %d\n", u.ID(), v.ID())
+			fmt.Fprintf(bw, "\t\tvalue %s\n", formatFloat(g.Edge(u, v).Weight()))
+			if ea != nil {
+				err := writeAttrs(bw, 2, ea.EdgeAttrs(u, v), "source", "target", "value")
+				if err != nil {
+					return err
+				}
+			}
+			bw.WriteString("\t]\n")
+		}
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+// writeAttrs writes attrs in key order at the given indent depth, returning an
+// error if an attribute has one of the reserved keys.
+func writeAttrs(w *bufio.Writer, depth int, attrs map[string]interface{}, reserved ...string) error {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		for _, r := range reserved {
+			if key == r {
+				return fmt.Errorf("gml: reserved attribute key %q", key)
+			}
+		}
+		if !isKey(key) {
+			return fmt.Errorf("gml: invalid attribute key %q", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("\t", depth)
+	for _, key := range keys {
+		var s string
+		switch v := attrs[key].(type) {
+		case string:
+			if strings.Contains(v, `"`) {
+				return fmt.Errorf("gml: invalid string value for key %q", key)
+			}
+			s = `"` + v + `"`
+		case int:
+			s = strconv.Itoa(v)
+		case float64:
+			s = formatFloat(v)
+		case map[string]interface{}:
+			fmt.Fprintf(w, "%s%s [\n", indent, key)
+			err := writeAttrs(w, depth+1, v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s]\n", indent)
+			continue
+		default:
+			return fmt.Errorf("gml: unsupported attribute value type %T for key %q", v, key)
+		}
+		fmt.Fprintf(w, "%s%s %s\n", indent, key, s)
+	}
+	return nil
+}
+
+// formatFloat returns the GML representation of f. Integral values are
+// written with a decimal point so that they are read as real numbers.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEnN") {
+		s += ".0"
+	}
+	return s
+}
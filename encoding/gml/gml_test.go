@@ -0,0 +1,220 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gml
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestReadKarate(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "karate.gml"))
+	if err != nil {
+		t.Fatalf("failed to open data: %v", err)
+	}
+	defer f.Close()
+
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err = Read(f, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 34 {
+		t.Errorf("unexpected number of nodes: got:%d want:34", n)
+	}
+	if n := len(g.Edges()); n != 78 {
+		t.Errorf("unexpected number of edges: got:%d want:78", n)
+	}
+	// The club instructor and administrator are
+	// the most highly connected members.
+	for id, want := range map[int]int{1: 16, 34: 17} {
+		if d := len(g.From(simple.Node(id))); d != want {
+			t.Errorf("unexpected degree of node %d: got:%d want:%d", id, d, want)
+		}
+	}
+}
+
+const labelled = `Creator "test"
+graph [
+	comment "a directed graph" # trailing comment
+	directed 1
+	node [ id 10 label "ten" graphics [ x 1.5 y -2 ] ]
+	node [ id 20 label "twenty
+lines" ]
+	node [ id 30 ]
+	edge [ source 10 target 20 value 2.5 color "red" ]
+	edge [ source 20 target 30 ]
+]
+`
+
+func TestReadAttributes(t *testing.T) {
+	g := simple.NewDirectedAttrGraph(0, math.Inf(1))
+	err := Read(strings.NewReader(labelled), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v, _ := g.NodeValue(10)
+	want := map[string]interface{}{
+		"label":    "ten",
+		"graphics": map[string]interface{}{"x": 1.5, "y": -2},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected value for node 10: got:%v want:%v", v, want)
+	}
+	v, _ = g.NodeValue(20)
+	want = map[string]interface{}{"label": "twenty\nlines"}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected value for node 20: got:%v want:%v", v, want)
+	}
+	if _, ok := g.NodeValue(30); ok {
+		t.Error("unexpected value for node 30")
+	}
+
+	for _, test := range []struct {
+		u, v int
+		w    float64
+	}{
+		{u: 10, v: 20, w: 2.5},
+		{u: 20, v: 30, w: 1},
+	} {
+		e := g.Edge(simple.Node(test.u), simple.Node(test.v))
+		if e == nil || e.Weight() != test.w {
+			t.Errorf("unexpected edge %d->%d: got:%v want weight:%v", test.u, test.v, e, test.w)
+		}
+	}
+	if c, _ := g.EdgeAttr(simple.Node(10), simple.Node(20), "color"); c != "red" {
+		t.Errorf("unexpected edge color: got:%v want:red", c)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, test := range []struct {
+		doc  string
+		want string
+	}{
+		{doc: `node [ id 0 ]`, want: "gml: no graph list"},
+		{doc: `graph [ directed 1 ]`, want: "gml: mismatched graph type"},
+		{doc: "graph [\n node [ label \"a\" ]\n]", want: "gml: node without id"},
+		{doc: "graph [\n node [ id 0.5 ]\n]", want: "gml: invalid node id: 0.5"},
+		{doc: "graph [\n node [ id 0 ]\n node [ id 0 ]\n]", want: "gml: duplicate node id 0"},
+		{doc: "graph [\n node [ id 0 ]\n edge [ source 0 target 1 ]\n]", want: "gml: edge refers to unknown node 1"},
+		{doc: "graph [\n node [ id 0 ]\n edge [ source 0 target 0 ]\n]", want: "gml: self edge on node 0"},
+		{doc: "graph [\n node [ id 0 ]\n", want: "gml: line 3: unexpected end of input"},
+		{doc: "graph [\n node [ id zero ]\n]", want: `gml: line 2: invalid value "zero" for key "id"`},
+		{doc: "graph [\n 0 1 ]", want: `gml: line 2: invalid key "0"`},
+		{doc: "graph [ ] ]", want: "gml: line 1: unexpected ]"},
+		{doc: "graph [ label \"a ]", want: "gml: line 1: unterminated string"},
+	} {
+		err := Read(strings.NewReader(test.doc), simple.NewUndirectedGraph(0, math.Inf(1)))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.doc, err, test.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, g := range []interface {
+		graph.Graph
+		graph.Builder
+		graph.NodeValueSetter
+		graph.EdgeAttrSetter
+	}{
+		simple.NewDirectedAttrGraph(0, math.Inf(1)),
+		simple.NewUndirectedAttrGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 2})
+		g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 0.25})
+		g.AddNode(simple.Node(5))
+		g.SetNodeValue(0, map[string]string{"label": "zero"})
+		g.SetNodeValue(1, map[string]interface{}{"size": 3, "pos": map[string]interface{}{"x": 1.5}})
+		g.SetEdgeAttr(simple.Node(1), simple.Node(2), "color", "blue")
+
+		var buf bytes.Buffer
+		err := Write(&buf, g)
+		if err != nil {
+			t.Fatalf("unexpected error writing %T: %v", g, err)
+		}
+		first := buf.String()
+
+		var dst interface {
+			graph.Graph
+			graph.Builder
+		}
+		if _, ok := g.(graph.Directed); ok {
+			dst = simple.NewDirectedAttrGraph(0, math.Inf(1))
+		} else {
+			dst = simple.NewUndirectedAttrGraph(0, math.Inf(1))
+		}
+		err = Read(&buf, dst)
+		if err != nil {
+			t.Fatalf("unexpected error reading %T: %v\n%s", g, err, first)
+		}
+		buf.Reset()
+		err = Write(&buf, dst)
+		if err != nil {
+			t.Fatalf("unexpected error rewriting %T: %v", g, err)
+		}
+		if buf.String() != first {
+			t.Errorf("unexpected round trip for %T:\ngot:\n%s\nwant:\n%s", g, buf.String(), first)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := simple.NewUndirectedAttrGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0), W: 1})
+	g.SetNodeValue(1, map[string]string{"label": "one"})
+
+	var buf bytes.Buffer
+	err := Write(&buf, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `graph [
+	directed 0
+	node [
+		id 0
+	]
+	node [
+		id 1
+		label "one"
+	]
+	edge [
+		source 0
+		target 1
+		value 1.0
+	]
+]
+`
+	if buf.String() != want {
+		t.Errorf("unexpected document:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	for _, test := range []struct {
+		value interface{}
+		want  string
+	}{
+		{value: "one", want: "gml: unsupported node value type string"},
+		{value: map[string]string{"id": "one"}, want: `gml: reserved attribute key "id"`},
+		{value: map[string]string{"two words": "one"}, want: `gml: invalid attribute key "two words"`},
+		{value: map[string]string{"label": `"one"`}, want: `gml: invalid string value for key "label"`},
+		{value: map[string]interface{}{"label": true}, want: `gml: unsupported attribute value type bool for key "label"`},
+	} {
+		g.SetNodeValue(1, test.value)
+		err = Write(&bytes.Buffer{}, g)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for node value %v: got:%v want:%s", test.value, err, test.want)
+		}
+	}
+}
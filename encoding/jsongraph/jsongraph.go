@@ -0,0 +1,369 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jsongraph implements reading and writing of graphs in the node-link
+// JSON format used by D3 and NetworkX.
+//
+// A graph is encoded as a JSON object of the form
+//
+//  {
+//  	"directed": true,
+//  	"nodes": [{"id": 0}, {"id": 1, "color": "red"}],
+//  	"links": [{"source": 0, "target": 1, "weight": 2.5}]
+//  }
+//
+// where fields of nodes and links other than the ID, source, target and
+// weight fields hold attributes. Node IDs are integers.
+package jsongraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// Marshal returns the node-link JSON encoding of g. Nodes and links are written
+// in ID order, and the links of undirected graphs are written once.
+//
+// If g is a graph.NodeValuer, node values that are maps with string keys are
+// written as attributes of the node. If g is a graph.EdgeAttrser, edge
+// attributes are written as attributes of the link. Marshal returns an error if g holds another type of
+// node value, an attribute uses the key of a node or link field, or an
+// attribute or weight cannot be encoded as JSON.
+func Marshal(g graph.Graph) ([]byte, error) {
+	var buf bytes.Buffer
+	err := write(&buf, g)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses the node-link JSON encoded data and stores the result in
+// dst, as described for Decoder.Decode.
+func Unmarshal(data []byte, dst graph.Builder) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(dst)
+}
+
+// An Encoder writes node-link JSON encoded graphs to an output stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the node-link JSON encoding of g, as described for Marshal,
+// to the stream followed by a newline. The encoding is written as each node
+// and link is encoded rather than being held in memory.
+func (enc *Encoder) Encode(g graph.Graph) error {
+	bw := bufio.NewWriter(enc.w)
+	err := write(bw, g)
+	if err != nil {
+		return err
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// writer is the destination of an encoding.
+type writer interface {
+	io.Writer
+	WriteString(string) (int, error)
+}
+
+// write writes the node-link JSON encoding of g to w.
+func write(w writer, g graph.Graph) error {
+	_, directed := g.(graph.Directed)
+	nv, _ := g.(graph.NodeValuer)
+	ea, _ := g.(graph.EdgeAttrser)
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+
+	fmt.Fprintf(w, `{"directed":%t,"nodes":[`, directed)
+	for i, n := range nodes {
+		obj := map[string]interface{}{"id": n.ID()}
+		if nv != nil {
+			v, ok := nv.NodeValue(n.ID())
+			if ok {
+				err := addNodeValue(obj, v)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		err := writeObject(w, i, obj)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.WriteString(`],"links":[`)
+	var i int
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			obj := map[string]interface{}{
+				"source": u.ID(),
+				"target": v.ID(),
+				"weight": g.Edge(u, v).Weight(),
+			}
+			if ea != nil {
+				err := addAttrs(obj, ea.EdgeAttrs(u, v))
+				if err != nil {
+					return err
+				}
+			}
+			err := writeObject(w, i, obj)
+			if err != nil {
+				return err
+			}
+			i++
+		}
+	}
+	_, err := w.WriteString("]}")
+	return err
+}
+
+// addNodeValue adds the fields of the node value v to the node object obj.
+func addNodeValue(obj map[string]interface{}, v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return addAttrs(obj, v)
+	case map[string]string:
+		attrs := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			attrs[key] = value
+		}
+		return addAttrs(obj, attrs)
+	default:
+		return fmt.Errorf("jsongraph: unsupported node value type %T", v)
+	}
+}
+
+// addAttrs adds the attributes to obj, returning an error if an attribute
+// key is already held by obj.
+func addAttrs(obj, attrs map[string]interface{}) error {
+	for key, value := range attrs {
+		if _, ok := obj[key]; ok {
+			return fmt.Errorf("jsongraph: reserved attribute key %q", key)
+		}
+		obj[key] = value
+	}
+	return nil
+}
+
+// writeObject writes obj as the ith element of a JSON array.
+func writeObject(w writer, i int, obj map[string]interface{}) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if i != 0 {
+		w.WriteString(",")
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// A Decoder reads node-link JSON encoded graphs from an input stream.
+type Decoder struct {
+	dec *json.Decoder
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next node-link JSON encoded graph from the stream and
+// stores it in dst. Nodes and links are decoded one at a time rather than
+// the whole encoding being held in memory.
+//
+// Nodes are added to dst as simple.Node values unless dst is a graph.Graph
+// already holding a node with the same ID, and links are set as simple.Edge
+// values. Links without a weight field are given unit weight. If dst is a
+// graph.NodeValueSetter, nodes with attributes are given a map[string]interface{}
+// value holding them, and if dst is a graph.EdgeAttrSetter, link attributes
+// are set as edge attributes. Attributes are otherwise ignored, as are fields of
+// the graph object other than the directed, nodes and links fields.
+//
+// Decode returns an error if the directed field of the encoding does not
+// match the directedness of dst, or if a link has the same source and target.
+func (d *Decoder) Decode(dst graph.Builder) error {
+	err := d.expect(json.Delim('{'))
+	if err != nil {
+		return err
+	}
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case "directed":
+			var directed bool
+			err = d.dec.Decode(&directed)
+			if err != nil {
+				return err
+			}
+			if _, ok := dst.(graph.Directed); ok != directed {
+				return errors.New("jsongraph: mismatched graph type")
+			}
+		case "nodes":
+			err = d.array(func(obj map[string]json.RawMessage) error {
+				return addNode(dst, obj)
+			})
+		case "links":
+			err = d.array(func(obj map[string]json.RawMessage) error {
+				return addLink(dst, obj)
+			})
+		default:
+			var skip json.RawMessage
+			err = d.dec.Decode(&skip)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return d.expect(json.Delim('}'))
+}
+
+// expect reads the next token and returns an error if it is not want.
+func (d *Decoder) expect(want json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("jsongraph: unexpected token %v", tok)
+	}
+	return nil
+}
+
+// array reads a JSON array of objects, calling fn with each object.
+func (d *Decoder) array(fn func(map[string]json.RawMessage) error) error {
+	err := d.expect(json.Delim('['))
+	if err != nil {
+		return err
+	}
+	for d.dec.More() {
+		var obj map[string]json.RawMessage
+		err = d.dec.Decode(&obj)
+		if err != nil {
+			return err
+		}
+		err = fn(obj)
+		if err != nil {
+			return err
+		}
+	}
+	return d.expect(json.Delim(']'))
+}
+
+// addNode adds the node described by obj to dst.
+func addNode(dst graph.Builder, obj map[string]json.RawMessage) error {
+	id, err := intField(obj, "id")
+	if err != nil {
+		return err
+	}
+	if g, ok := dst.(graph.Graph); !ok || !g.Has(simple.Node(id)) {
+		dst.AddNode(simple.Node(id))
+	}
+	attrs, err := attrsOf(obj, "id")
+	if err != nil {
+		return err
+	}
+	if s, ok := dst.(graph.NodeValueSetter); ok && attrs != nil {
+		s.SetNodeValue(id, attrs)
+	}
+	return nil
+}
+
+// addLink sets the edge described by obj in dst.
+func addLink(dst graph.Builder, obj map[string]json.RawMessage) error {
+	source, err := intField(obj, "source")
+	if err != nil {
+		return err
+	}
+	target, err := intField(obj, "target")
+	if err != nil {
+		return err
+	}
+	if source == target {
+		return fmt.Errorf("jsongraph: self link on node %d", source)
+	}
+	weight := 1.0
+	if raw, ok := obj["weight"]; ok {
+		err = json.Unmarshal(raw, &weight)
+		if err != nil {
+			return fmt.Errorf("jsongraph: invalid link weight: %v", err)
+		}
+	}
+	u, v := simple.Node(source), simple.Node(target)
+	dst.SetEdge(simple.Edge{F: u, T: v, W: weight})
+	attrs, err := attrsOf(obj, "source", "target", "weight")
+	if err != nil {
+		return err
+	}
+	if s, ok := dst.(graph.EdgeAttrSetter); ok {
+		for key, value := range attrs {
+			s.SetEdgeAttr(u, v, key, value)
+		}
+	}
+	return nil
+}
+
+// intField returns the integer value of the named field of obj.
+func intField(obj map[string]json.RawMessage, name string) (int, error) {
+	raw, ok := obj[name]
+	if !ok {
+		return 0, fmt.Errorf("jsongraph: missing %s field", name)
+	}
+	var n int
+	err := json.Unmarshal(raw, &n)
+	if err != nil {
+		return 0, fmt.Errorf("jsongraph: invalid %s field: %s", name, raw)
+	}
+	return n, nil
+}
+
+// attrsOf returns the fields of obj other than the named fields, or nil if
+// there are none.
+func attrsOf(obj map[string]json.RawMessage, fields ...string) (map[string]interface{}, error) {
+	var attrs map[string]interface{}
+outer:
+	for key, raw := range obj {
+		for _, f := range fields {
+			if key == f {
+				continue outer
+			}
+		}
+		var v interface{}
+		err := json.Unmarshal(raw, &v)
+		if err != nil {
+			return nil, err
+		}
+		if attrs == nil {
+			attrs = make(map[string]interface{})
+		}
+		attrs[key] = v
+	}
+	return attrs, nil
+}
@@ -0,0 +1,236 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsongraph
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		src  graph.Builder
+		dst  func() graph.Builder
+		want string
+	}{
+		{
+			name: "directed",
+			src:  simple.NewDirectedGraph(0, math.Inf(1)),
+			dst:  func() graph.Builder { return simple.NewDirectedGraph(0, math.Inf(1)) },
+			want: `{"directed":true,"nodes":[{"id":0},{"id":1},{"id":2},{"id":4}],"links":[{"source":0,"target":1,"weight":1},{"source":1,"target":0,"weight":2},{"source":1,"target":2,"weight":0.5}]}`,
+		},
+		{
+			name: "undirected",
+			src:  simple.NewUndirectedGraph(0, math.Inf(1)),
+			dst:  func() graph.Builder { return simple.NewUndirectedGraph(0, math.Inf(1)) },
+			want: `{"directed":false,"nodes":[{"id":0},{"id":1},{"id":2},{"id":4}],"links":[{"source":0,"target":1,"weight":2},{"source":1,"target":2,"weight":0.5}]}`,
+		},
+	} {
+		test.src.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+		test.src.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0), W: 2})
+		test.src.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 0.5})
+		test.src.AddNode(simple.Node(4))
+
+		b, err := Marshal(test.src.(graph.Graph))
+		if err != nil {
+			t.Errorf("unexpected error marshaling %s graph: %v", test.name, err)
+			continue
+		}
+		if string(b) != test.want {
+			t.Errorf("unexpected encoding of %s graph:\ngot: %s\nwant:%s", test.name, b, test.want)
+		}
+
+		dst := test.dst()
+		err = Unmarshal(b, dst)
+		if err != nil {
+			t.Errorf("unexpected error unmarshaling %s graph: %v", test.name, err)
+			continue
+		}
+		got, err := Marshal(dst.(graph.Graph))
+		if err != nil {
+			t.Errorf("unexpected error remarshaling %s graph: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(got, b) {
+			t.Errorf("unexpected round trip of %s graph:\ngot: %s\nwant:%s", test.name, got, b)
+		}
+	}
+}
+
+func TestAttrRoundTrip(t *testing.T) {
+	g := simple.NewDirectedAttrGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 3})
+	g.SetNodeValue(0, map[string]string{"label": "start"})
+	g.SetNodeValue(1, map[string]interface{}{"size": 2.0})
+	g.SetEdgeAttr(simple.Node(0), simple.Node(1), "color", "red")
+
+	b, err := Marshal(g)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling graph: %v", err)
+	}
+	want := `{"directed":true,"nodes":[{"id":0,"label":"start"},{"id":1,"size":2}],"links":[{"color":"red","source":0,"target":1,"weight":3}]}`
+	if string(b) != want {
+		t.Errorf("unexpected encoding:\ngot: %s\nwant:%s", b, want)
+	}
+
+	dst := simple.NewDirectedAttrGraph(0, math.Inf(1))
+	err = Unmarshal(b, dst)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling graph: %v", err)
+	}
+	v, _ := dst.NodeValue(0)
+	if !reflect.DeepEqual(v, map[string]interface{}{"label": "start"}) {
+		t.Errorf("unexpected node value: got:%v", v)
+	}
+	v, _ = dst.NodeValue(1)
+	if !reflect.DeepEqual(v, map[string]interface{}{"size": 2.0}) {
+		t.Errorf("unexpected node value: got:%v", v)
+	}
+	if v, _ := dst.EdgeAttr(simple.Node(0), simple.Node(1), "color"); v != "red" {
+		t.Errorf("unexpected edge attribute: got:%v want:red", v)
+	}
+	if w := dst.Edge(simple.Node(0), simple.Node(1)).Weight(); w != 3 {
+		t.Errorf("unexpected edge weight: got:%v want:3", w)
+	}
+}
+
+// d3 is a node-link graph in the form used by D3 and written by NetworkX.
+const d3 = `{
+	"directed": false,
+	"multigraph": false,
+	"graph": {"name": "example"},
+	"nodes": [{"id": 0, "group": 1}, {"id": 1, "group": 1}, {"id": 2, "group": 2}],
+	"links": [{"source": 0, "target": 1, "value": 5}, {"source": 1, "target": 2}]
+}`
+
+func TestUnmarshalD3(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err := Unmarshal([]byte(d3), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 3 {
+		t.Errorf("unexpected number of nodes: got:%d want:3", n)
+	}
+	for _, e := range [][2]int{{0, 1}, {1, 2}} {
+		edge := g.EdgeBetween(simple.Node(e[0]), simple.Node(e[1]))
+		if edge == nil {
+			t.Errorf("missing edge %d--%d", e[0], e[1])
+			continue
+		}
+		if w := edge.Weight(); w != 1 {
+			t.Errorf("unexpected default weight for edge %d--%d: got:%v want:1", e[0], e[1], w)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "mismatched graph type",
+			data: `{"directed":true,"nodes":[],"links":[]}`,
+			want: "jsongraph: mismatched graph type",
+		},
+		{
+			name: "missing id",
+			data: `{"nodes":[{"name":"a"}]}`,
+			want: "jsongraph: missing id field",
+		},
+		{
+			name: "string id",
+			data: `{"nodes":[{"id":"a"}]}`,
+			want: `jsongraph: invalid id field: "a"`,
+		},
+		{
+			name: "missing target",
+			data: `{"links":[{"source":0}]}`,
+			want: "jsongraph: missing target field",
+		},
+		{
+			name: "invalid weight",
+			data: `{"links":[{"source":0,"target":1,"weight":"heavy"}]}`,
+			want: "jsongraph: invalid link weight",
+		},
+		{
+			name: "self link",
+			data: `{"nodes":[{"id":0},{"id":1}],"links":[{"source":0,"target":1},{"source":1,"target":1}]}`,
+			want: "jsongraph: self link on node 1",
+		},
+		{
+			name: "not an object",
+			data: `[]`,
+			want: "jsongraph: unexpected token [",
+		},
+	} {
+		err := Unmarshal([]byte(test.data), simple.NewUndirectedGraph(0, math.Inf(1)))
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("unexpected error for %s: got:%v want:%s", test.name, err, test.want)
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	g := simple.NewUndirectedAttrGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(0))
+	g.SetNodeValue(0, "zero")
+	_, err := Marshal(g)
+	if err == nil || err.Error() != "jsongraph: unsupported node value type string" {
+		t.Errorf("unexpected error for unsupported node value: %v", err)
+	}
+
+	g.SetNodeValue(0, map[string]string{"id": "zero"})
+	_, err = Marshal(g)
+	if err == nil || err.Error() != `jsongraph: reserved attribute key "id"` {
+		t.Errorf("unexpected error for reserved attribute key: %v", err)
+	}
+}
+
+func TestEncoderDecoder(t *testing.T) {
+	var graphs []*simple.DirectedGraph
+	for i := 0; i < 3; i++ {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for j := 0; j < i; j++ {
+			g.SetEdge(simple.Edge{F: simple.Node(j), T: simple.Node(j + 1), W: float64(j)})
+		}
+		graphs = append(graphs, g)
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, g := range graphs {
+		err := enc.Encode(g)
+		if err != nil {
+			t.Fatalf("unexpected error encoding graph: %v", err)
+		}
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(graphs) {
+		t.Errorf("unexpected number of lines: got:%d want:%d", n, len(graphs))
+	}
+
+	dec := NewDecoder(&buf)
+	for i, want := range graphs {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		err := dec.Decode(g)
+		if err != nil {
+			t.Fatalf("unexpected error decoding graph %d: %v", i, err)
+		}
+		got, _ := Marshal(g)
+		wantb, _ := Marshal(want)
+		if !bytes.Equal(got, wantb) {
+			t.Errorf("unexpected decoded graph %d:\ngot: %s\nwant:%s", i, got, wantb)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mtx implements reading and writing of graphs as Matrix Market
+// coordinate format adjacency matrices.
+//
+// A Matrix Market file begins with a banner line describing the matrix,
+// followed by comment lines, a size line and the matrix entries, for example
+//
+//  %%MatrixMarket matrix coordinate real general
+//  % a comment
+//  3 3 2
+//  1 2 2.5
+//  3 1 1
+//
+// where the entry in row i and column j of the matrix is the weight of the
+// edge from node i-1 to node j-1. Matrices with symmetric storage hold
+// undirected graphs.
+//
+// The format is described at http://math.nist.gov/MatrixMarket/formats.html.
+package mtx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// ReadMatrixMarket reads a square Matrix Market coordinate format matrix from
+// r and adds the graph it describes to dst. The matrix row and column indices
+// are 1-based and are mapped to 0-based node IDs, so an n×n matrix is read as
+// a graph with nodes 0 to n-1. Real and integer entries give the weight of
+// their edge, and the entries of pattern matrices give edges with unit weight.
+//
+// Matrices with general storage are read into directed graphs and matrices with
+// symmetric storage are read into undirected graphs, and ReadMatrixMarket
+// returns an error if dst does not match the matrix storage. Diagonal entries
+// are ignored since they describe self edges. Array format, complex, hermitian
+// and skew-symmetric matrices are not supported.
+//
+// Errors in the input are reported with the line number at which they occur.
+func ReadMatrixMarket(r io.Reader, dst graph.Builder) error {
+	var line int
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("mtx: line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return err
+		}
+		return errors.New("mtx: empty input")
+	}
+	line++
+	banner := strings.Fields(strings.ToLower(sc.Text()))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" {
+		return errorf("invalid banner %q", sc.Text())
+	}
+	if banner[1] != "matrix" {
+		return errorf("unsupported object %q", banner[1])
+	}
+	if banner[2] != "coordinate" {
+		return errorf("unsupported format %q", banner[2])
+	}
+	field := banner[3]
+	switch field {
+	case "real", "integer", "pattern":
+	default:
+		return errorf("unsupported field %q", field)
+	}
+	switch banner[4] {
+	case "general", "symmetric":
+	default:
+		return errorf("unsupported symmetry %q", banner[4])
+	}
+	if _, directed := dst.(graph.Directed); directed != (banner[4] == "general") {
+		return errorf("%s matrix in mismatched graph type", banner[4])
+	}
+
+	var (
+		n, nnz  int
+		entries int
+		sized   bool
+	)
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		fields := strings.Fields(text)
+
+		if !sized {
+			if len(fields) != 3 {
+				return errorf("invalid size line %q", text)
+			}
+			var size [3]int
+			for i, f := range fields {
+				v, err := strconv.Atoi(f)
+				if err != nil || v < 0 {
+					return errorf("invalid size %q", f)
+				}
+				size[i] = v
+			}
+			if size[0] != size[1] {
+				return errorf("matrix is not square: %d×%d", size[0], size[1])
+			}
+			n, nnz = size[0], size[2]
+			for id := 0; id < n; id++ {
+				dst.AddNode(simple.Node(id))
+			}
+			sized = true
+			continue
+		}
+
+		want := 3
+		if field == "pattern" {
+			want = 2
+		}
+		if len(fields) != want {
+			return errorf("invalid entry %q", text)
+		}
+		var idx [2]int
+		for k, f := range fields[:2] {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return errorf("invalid index %q", f)
+			}
+			if v < 1 || n < v {
+				return errorf("index %d out of range [1,%d]", v, n)
+			}
+			idx[k] = v - 1
+		}
+		w := 1.0
+		if field != "pattern" {
+			var err error
+			w, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return errorf("invalid value %q", fields[2])
+			}
+		}
+		entries++
+		if idx[0] == idx[1] {
+			continue
+		}
+		dst.SetEdge(simple.Edge{F: simple.Node(idx[0]), T: simple.Node(idx[1]), W: w})
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	if !sized {
+		return errorf("missing size line")
+	}
+	if entries != nnz {
+		return errorf("expected %d entries, found %d", nnz, entries)
+	}
+	return nil
+}
+
+// WriteMatrixMarket writes the adjacency matrix of g to w in Matrix Market
+// coordinate format with real entries holding the edge weights. Node IDs are
+// mapped to matrix indices in ascending ID order, so a graph with node IDs 0
+// to n-1 is written with indices one greater than the node IDs. Directed
+// graphs are written with general storage and undirected graphs are written
+// with symmetric storage as the lower triangle of the matrix.
+func WriteMatrixMarket(w io.Writer, g graph.Graph) error {
+	_, directed := g.(graph.Directed)
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i + 1
+	}
+
+	var edges []graph.Edge
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() > u.ID() {
+				continue
+			}
+			edges = append(edges, g.Edge(u, v))
+		}
+	}
+
+	b := bufio.NewWriter(w)
+	if directed {
+		b.WriteString("%%MatrixMarket matrix coordinate real general\n")
+	} else {
+		b.WriteString("%%MatrixMarket matrix coordinate real symmetric\n")
+	}
+	fmt.Fprintf(b, "%d %d %d\n", len(nodes), len(nodes), len(edges))
+	for _, e := range edges {
+		i := indexOf[e.From().ID()]
+		j := indexOf[e.To().ID()]
+		if !directed && i < j {
+			i, j = j, i
+		}
+		fmt.Fprintf(b, "%d %d %s\n", i, j, strconv.FormatFloat(e.Weight(), 'g', -1, 64))
+	}
+	return b.Flush()
+}
@@ -0,0 +1,167 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mtx
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestReadKarate(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "karate.mtx"))
+	if err != nil {
+		t.Fatalf("failed to open data: %v", err)
+	}
+	defer f.Close()
+
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err = ReadMatrixMarket(f, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 34 {
+		t.Errorf("unexpected number of nodes: got:%d want:34", n)
+	}
+	// The documented number of nonzeros of the
+	// symmetric matrix counts each edge twice.
+	const nnz = 156
+	if n := 2 * len(g.Edges()); n != nnz {
+		t.Errorf("unexpected number of nonzeros: got:%d want:%d", n, nnz)
+	}
+	for _, e := range g.Edges() {
+		if e.Weight() != 1 {
+			t.Errorf("unexpected weight for pattern entry: got:%v want:1", e.Weight())
+			break
+		}
+	}
+}
+
+const general = `%%MatrixMarket matrix coordinate real general
+% a comment
+
+%  another comment
+4 4 5
+1 2 2.5
+2 1 -1
+3 3 7
+4 1 1e3
+2 4 0
+`
+
+func TestReadGeneral(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	err := ReadMatrixMarket(strings.NewReader(general), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 4 {
+		t.Errorf("unexpected number of nodes: got:%d want:4", n)
+	}
+	if n := len(g.Edges()); n != 4 {
+		t.Errorf("unexpected number of edges: got:%d want:4", n)
+	}
+	for _, test := range []struct {
+		u, v int
+		w    float64
+	}{
+		{u: 0, v: 1, w: 2.5},
+		{u: 1, v: 0, w: -1},
+		{u: 3, v: 0, w: 1000},
+		{u: 1, v: 3, w: 0},
+	} {
+		e := g.Edge(simple.Node(test.u), simple.Node(test.v))
+		if e == nil || e.Weight() != test.w {
+			t.Errorf("unexpected edge %d->%d: got:%v want weight:%v", test.u, test.v, e, test.w)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		directed bool
+		want     string
+	}{
+		{data: "", want: "mtx: empty input"},
+		{data: "%%MatrixMarket matrix coordinate real\n", want: `mtx: line 1: invalid banner "%%MatrixMarket matrix coordinate real"`},
+		{data: "%%MatrixMarket vector coordinate real general\n", want: `mtx: line 1: unsupported object "vector"`},
+		{data: "%%MatrixMarket matrix array real general\n", want: `mtx: line 1: unsupported format "array"`},
+		{data: "%%MatrixMarket matrix coordinate complex general\n", want: `mtx: line 1: unsupported field "complex"`},
+		{data: "%%MatrixMarket matrix coordinate real skew-symmetric\n", want: `mtx: line 1: unsupported symmetry "skew-symmetric"`},
+		{data: "%%MatrixMarket matrix coordinate real general\n", want: "mtx: line 1: general matrix in mismatched graph type"},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n% no size\n", want: "mtx: line 2: missing size line"},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 3 1\n", want: "mtx: line 2: matrix is not square: 2×3"},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2\n", want: `mtx: line 2: invalid size line "2 2"`},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 -1\n", want: `mtx: line 2: invalid size "-1"`},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n2 1\n", want: `mtx: line 3: invalid entry "2 1"`},
+		{data: "%%MatrixMarket matrix coordinate pattern symmetric\n2 2 1\n2 1 1\n", want: `mtx: line 3: invalid entry "2 1 1"`},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n3 1 1\n", want: "mtx: line 3: index 3 out of range [1,2]"},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\nb 1 1\n", want: `mtx: line 3: invalid index "b"`},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n2 1 x\n", want: `mtx: line 3: invalid value "x"`},
+		{data: "%%MatrixMarket matrix coordinate real symmetric\n2 2 2\n2 1 1\n", want: "mtx: line 3: expected 2 entries, found 1"},
+		{data: "%%MatrixMarket matrix coordinate real general\n2 2 1\n", directed: true, want: "mtx: line 2: expected 1 entries, found 0"},
+	} {
+		var dst graph.Builder
+		if test.directed {
+			dst = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			dst = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		err := ReadMatrixMarket(strings.NewReader(test.data), dst)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.data, err, test.want)
+		}
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	for _, test := range []struct {
+		g    graph.Builder
+		dst  func() graph.Builder
+		want string
+	}{
+		{
+			g:    simple.NewDirectedGraph(0, math.Inf(1)),
+			dst:  func() graph.Builder { return simple.NewDirectedGraph(0, math.Inf(1)) },
+			want: "%%MatrixMarket matrix coordinate real general\n3 3 3\n1 2 0.5\n2 1 2\n2 3 4\n",
+		},
+		{
+			g:    simple.NewUndirectedGraph(0, math.Inf(1)),
+			dst:  func() graph.Builder { return simple.NewUndirectedGraph(0, math.Inf(1)) },
+			want: "%%MatrixMarket matrix coordinate real symmetric\n3 3 2\n2 1 2\n3 2 4\n",
+		},
+	} {
+		test.g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 0.5})
+		test.g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0), W: 2})
+		test.g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 4})
+
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, test.g.(graph.Graph))
+		if err != nil {
+			t.Fatalf("unexpected error writing %T: %v", test.g, err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("unexpected matrix for %T:\ngot:\n%s\nwant:\n%s", test.g, buf.String(), test.want)
+		}
+
+		dst := test.dst()
+		err = ReadMatrixMarket(&buf, dst)
+		if err != nil {
+			t.Fatalf("unexpected error reading %T: %v", test.g, err)
+		}
+		var got bytes.Buffer
+		WriteMatrixMarket(&got, dst.(graph.Graph))
+		if got.String() != test.want {
+			t.Errorf("unexpected round trip for %T:\ngot:\n%s\nwant:\n%s", test.g, got.String(), test.want)
+		}
+	}
+}
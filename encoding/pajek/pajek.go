@@ -0,0 +1,260 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pajek implements reading and writing of graphs in the Pajek .net
+// format.
+//
+// A Pajek network lists its vertices in a *Vertices section followed by
+// sections of undirected edges and directed arcs, for example
+//
+//  *Vertices 3
+//  1 "a"
+//  2 "b"
+//  3 "c"
+//  *Arcs
+//  1 2 2.5
+//  *Edges
+//  2 3
+//
+// Pajek vertex numbers are 1-based and are mapped to 0-based graph node IDs.
+package pajek
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// Read reads a Pajek network from r and adds its vertices and lines to dst.
+// Vertex n of the network is added as the node with ID n-1. The *Edges,
+// *Arcs, *Edgeslist and *Arcslist line sections are supported. Lines without
+// a weight are given unit weight, and the lines of list sections all have unit
+// weight.
+//
+// Edges are added to directed graphs as a pair of arcs in opposite directions.
+// Arcs may not be added to undirected graphs. If dst is able to hold node
+// values, such as a simple.DirectedAttrGraph, vertices with a label are given a
+// map[string]interface{} value holding the label under the "label" key. Vertex
+// coordinates and shapes are ignored.
+//
+// Errors in the input are reported with the line number at which they occur.
+// Self edges are not supported and result in an error.
+func Read(r io.Reader, dst graph.Builder) error {
+	_, directed := dst.(graph.Directed)
+	nvs, _ := dst.(graph.NodeValueSetter)
+
+	var (
+		section string
+		n       int
+	)
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("pajek: line %d: %s", line, fmt.Sprintf(format, args...))
+		}
+
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		fields, err := split(text)
+		if err != nil {
+			return errorf("%v", err)
+		}
+
+		if strings.HasPrefix(text, "*") {
+			section = strings.ToLower(fields[0])
+			switch section {
+			case "*network":
+			case "*vertices":
+				if len(fields) < 2 {
+					return errorf("missing number of vertices")
+				}
+				n, err = strconv.Atoi(fields[1])
+				if err != nil || n < 0 {
+					return errorf("invalid number of vertices %q", fields[1])
+				}
+				for id := 0; id < n; id++ {
+					dst.AddNode(simple.Node(id))
+				}
+			case "*arcs", "*arcslist":
+				if !directed {
+					return errorf("arcs in undirected graph")
+				}
+			case "*edges", "*edgeslist":
+			default:
+				return errorf("unsupported section %s", fields[0])
+			}
+			continue
+		}
+
+		// vertex returns the node for the vertex number s.
+		vertex := func(s string) (graph.Node, error) {
+			v, err := strconv.Atoi(s)
+			if err != nil || v < 1 || n < v {
+				return nil, errorf("invalid vertex %q", s)
+			}
+			return simple.Node(v - 1), nil
+		}
+		// setLine sets the line from u to v in dst.
+		setLine := func(u, v graph.Node, w float64) error {
+			if u.ID() == v.ID() {
+				return errorf("self edge on vertex %d", u.ID()+1)
+			}
+			dst.SetEdge(simple.Edge{F: u, T: v, W: w})
+			if directed && (section == "*edges" || section == "*edgeslist") {
+				dst.SetEdge(simple.Edge{F: v, T: u, W: w})
+			}
+			return nil
+		}
+
+		switch section {
+		case "*vertices":
+			u, err := vertex(fields[0])
+			if err != nil {
+				return err
+			}
+			if len(fields) > 1 && nvs != nil {
+				nvs.SetNodeValue(u.ID(), map[string]interface{}{"label": fields[1]})
+			}
+		case "*arcs", "*edges":
+			if len(fields) < 2 {
+				return errorf("invalid number of fields: %d", len(fields))
+			}
+			u, err := vertex(fields[0])
+			if err != nil {
+				return err
+			}
+			v, err := vertex(fields[1])
+			if err != nil {
+				return err
+			}
+			w := 1.0
+			if len(fields) > 2 {
+				w, err = strconv.ParseFloat(fields[2], 64)
+				if err != nil {
+					return errorf("invalid weight %q", fields[2])
+				}
+			}
+			err = setLine(u, v, w)
+			if err != nil {
+				return err
+			}
+		case "*arcslist", "*edgeslist":
+			u, err := vertex(fields[0])
+			if err != nil {
+				return err
+			}
+			for _, f := range fields[1:] {
+				v, err := vertex(f)
+				if err != nil {
+					return err
+				}
+				err = setLine(u, v, 1)
+				if err != nil {
+					return err
+				}
+			}
+		default:
+			return errorf("line outside section")
+		}
+	}
+	return sc.Err()
+}
+
+// split returns the white space separated fields of text. Quoted fields
+// may hold white space and are returned without their quotes.
+func split(text string) ([]string, error) {
+	var fields []string
+	for {
+		text = strings.TrimLeft(text, " \t")
+		if text == "" {
+			return fields, nil
+		}
+		if text[0] == '"' {
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			fields = append(fields, text[1:end+1])
+			text = text[end+2:]
+			continue
+		}
+		end := strings.IndexAny(text, " \t")
+		if end < 0 {
+			end = len(text)
+		}
+		fields = append(fields, text[:end])
+		text = text[end:]
+	}
+}
+
+// Write writes g to w as a Pajek network. The nodes of g are written as
+// vertices numbered from 1 in order of node ID, so a graph with node IDs
+// 0 to n-1 is written with vertex numbers one greater than the node IDs.
+// The edges of directed graphs are written as arcs and the edges of
+// undirected graphs are written once as edges, with edge weights as line
+// values.
+//
+// If g holds node values, vertices are labelled with node values that are
+// strings or with the "label" value of node values that are maps with string
+// keys. Write returns an error if a label holds a quote or a newline.
+func Write(w io.Writer, g graph.Graph) error {
+	_, directed := g.(graph.Directed)
+	nv, _ := g.(graph.NodeValuer)
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	vertex := make(map[int]int, len(nodes))
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "*Vertices %d\n", len(nodes))
+	for i, n := range nodes {
+		vertex[n.ID()] = i + 1
+		var label string
+		if nv != nil {
+			v, _ := nv.NodeValue(n.ID())
+			switch v := v.(type) {
+			case string:
+				label = v
+			case map[string]string:
+				label = v["label"]
+			case map[string]interface{}:
+				label, _ = v["label"].(string)
+			}
+		}
+		if label == "" {
+			fmt.Fprintf(bw, "%d\n", i+1)
+			continue
+		}
+		if strings.ContainsAny(label, "\"\n") {
+			return fmt.Errorf("pajek: invalid label for node %d", n.ID())
+		}
+		fmt.Fprintf(bw, "%d \"%s\"\n", i+1, label)
+	}
+
+	if directed {
+		bw.WriteString("*Arcs\n")
+	} else {
+		bw.WriteString("*Edges\n")
+	}
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			fmt.Fprintf(bw, "%d %d %s\n", vertex[u.ID()], vertex[v.ID()], strconv.FormatFloat(g.Edge(u, v).Weight(), 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,179 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pajek
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+func TestReadKarate(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "karate.net"))
+	if err != nil {
+		t.Fatalf("failed to open data: %v", err)
+	}
+	defer f.Close()
+
+	g := simple.NewUndirectedAttrGraph(0, math.Inf(1))
+	err = Read(f, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 34 {
+		t.Errorf("unexpected number of nodes: got:%d want:34", n)
+	}
+	if n := len(g.Edges()); n != 78 {
+		t.Errorf("unexpected number of edges: got:%d want:78", n)
+	}
+	// The club instructor and administrator, vertices
+	// 1 and 34, are the most highly connected members.
+	for id, want := range map[int]int{0: 16, 33: 17} {
+		if d := len(g.From(simple.Node(id))); d != want {
+			t.Errorf("unexpected degree of node %d: got:%d want:%d", id, d, want)
+		}
+	}
+	v, _ := g.NodeValue(33)
+	if want := map[string]interface{}{"label": "34"}; !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected value for node 33: got:%v want:%v", v, want)
+	}
+}
+
+const network = `% a small network
+*Network example
+*Vertices 4
+1 "first vertex" 0.1 0.2 0.5
+2 second
+*Arcs
+1 2 2.5
+*Edges
+2 3
+*Arcslist
+4 1 3
+`
+
+func TestReadDirected(t *testing.T) {
+	g := simple.NewDirectedAttrGraph(0, math.Inf(1))
+	err := Read(strings.NewReader(network), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Nodes()); n != 4 {
+		t.Errorf("unexpected number of nodes: got:%d want:4", n)
+	}
+	var got [][]int
+	for _, e := range g.Edges() {
+		got = append(got, []int{e.From().ID(), e.To().ID()})
+	}
+	sort.Sort(ordered.BySliceValues(got))
+	want := [][]int{{0, 1}, {1, 2}, {2, 1}, {3, 0}, {3, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected edges: got:%v want:%v", got, want)
+	}
+	if w := g.Edge(simple.Node(0), simple.Node(1)).Weight(); w != 2.5 {
+		t.Errorf("unexpected arc weight: got:%v want:2.5", w)
+	}
+	for id, want := range map[int]string{0: "first vertex", 1: "second"} {
+		v, _ := g.NodeValue(id)
+		if label := v.(map[string]interface{})["label"]; label != want {
+			t.Errorf("unexpected label for node %d: got:%v want:%s", id, label, want)
+		}
+	}
+	if _, ok := g.NodeValue(2); ok {
+		t.Error("unexpected value for unlabelled node")
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, test := range []struct {
+		net  string
+		want string
+	}{
+		{net: "*Vertices 2\n*Arcs\n1 2\n", want: "pajek: line 2: arcs in undirected graph"},
+		{net: "*Vertices\n", want: "pajek: line 1: missing number of vertices"},
+		{net: "*Vertices two\n", want: `pajek: line 1: invalid number of vertices "two"`},
+		{net: "*Vertices 2\n*Edges\n1 3\n", want: `pajek: line 3: invalid vertex "3"`},
+		{net: "*Vertices 2\n*Edges\n0 1\n", want: `pajek: line 3: invalid vertex "0"`},
+		{net: "*Vertices 2\n*Edges\n1 2 heavy\n", want: `pajek: line 3: invalid weight "heavy"`},
+		{net: "*Vertices 2\n*Edges\n1\n", want: "pajek: line 3: invalid number of fields: 1"},
+		{net: "*Vertices 2\n*Edges\n2 2\n", want: "pajek: line 3: self edge on vertex 2"},
+		{net: "*Vertices 2\n1 \"one\n", want: "pajek: line 2: unterminated string"},
+		{net: "*Matrix\n", want: "pajek: line 1: unsupported section *Matrix"},
+		{net: "1 2\n", want: "pajek: line 1: line outside section"},
+	} {
+		err := Read(strings.NewReader(test.net), simple.NewUndirectedGraph(0, math.Inf(1)))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.net, err, test.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := simple.NewUndirectedAttrGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 0.5})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1), W: 1})
+	g.SetNodeValue(0, "zero")
+	g.SetNodeValue(2, map[string]string{"label": "two words"})
+
+	var buf bytes.Buffer
+	err := Write(&buf, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `*Vertices 3
+1 "zero"
+2
+3 "two words"
+*Edges
+1 3 0.5
+2 3 1
+`
+	if buf.String() != want {
+		t.Errorf("unexpected network:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	dst := simple.NewUndirectedAttrGraph(0, math.Inf(1))
+	err = Read(&buf, dst)
+	if err != nil {
+		t.Fatalf("unexpected error reading network: %v", err)
+	}
+	if e := dst.EdgeBetween(simple.Node(2), simple.Node(0)); e == nil || e.Weight() != 0.5 {
+		t.Errorf("unexpected edge after round trip: got:%v", e)
+	}
+	v, _ := dst.NodeValue(2)
+	if want := map[string]interface{}{"label": "two words"}; !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected value after round trip: got:%v want:%v", v, want)
+	}
+
+	g.SetNodeValue(1, `"quoted"`)
+	err = Write(&bytes.Buffer{}, g)
+	if err == nil || err.Error() != "pajek: invalid label for node 1" {
+		t.Errorf("unexpected error for quoted label: %v", err)
+	}
+}
+
+func TestWriteDirected(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(10), T: simple.Node(5), W: 3})
+	g.SetEdge(simple.Edge{F: simple.Node(5), T: simple.Node(10), W: 4})
+
+	var buf bytes.Buffer
+	err := Write(&buf, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "*Vertices 2\n1\n2\n*Arcs\n1 2 4\n2 1 3\n"
+	if buf.String() != want {
+		t.Errorf("unexpected network:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/linear"
+)
+
+// Dinic returns the maximum flow from s to t in the graph g and the flow
+// through each edge achieving it, as described for EdmondsKarpCapacity. The
+// flow is found by repeatedly saturating a blocking flow in the level graph
+// of the residual network.
+//
+// If capacity is nil and g implements graph.Weighter, the weight of each edge
+// is used as its capacity, otherwise edges have unit capacity. Dinic will
+// panic if g has a negative edge capacity. If s and t are the same node, or
+// either is not in g, the returned flow is zero and the flow map is nil.
+//
+// The time complexity of Dinic is O(|V|^2.|E|).
+func Dinic(s, t graph.Node, g graph.Graph, capacity func(graph.Edge) float64) (maxFlow float64, flow map[int]map[int]float64) {
+	if s.ID() == t.ID() || !g.Has(s) || !g.Has(t) {
+		return 0, nil
+	}
+	r := newResidual(g, capacityFunc(g, capacity))
+	for {
+		level := r.levels(s, t)
+		if level == nil {
+			break
+		}
+		next := make(map[int]int)
+		for {
+			f := r.augment(s, t, math.Inf(1), level, next)
+			if f == 0 {
+				break
+			}
+			maxFlow += f
+		}
+	}
+	return maxFlow, r.positive()
+}
+
+// levels returns the breadth first search depth from s of each node in the
+// residual network that is no deeper than t, or nil if t is not reachable.
+func (r *residual) levels(s, t graph.Node) map[int]int {
+	level := map[int]int{s.ID(): 0}
+	var queue linear.NodeQueue
+	queue.Enqueue(s)
+	for queue.Len() != 0 {
+		u := queue.Dequeue()
+		d := level[u.ID()] + 1
+		if td, ok := level[t.ID()]; ok && d > td {
+			break
+		}
+		for _, v := range r.adjacent[u.ID()] {
+			if _, seen := level[v.ID()]; seen || r.residual(u, v) <= 0 {
+				continue
+			}
+			level[v.ID()] = d
+			queue.Enqueue(v)
+		}
+	}
+	if _, ok := level[t.ID()]; !ok {
+		return nil
+	}
+	return level
+}
+
+// augment pushes a flow of at most limit from u to t along a path in the
+// level graph and returns the flow pushed. The index of the next adjacent
+// node to try from each node is held in next so that dead ends are not
+// revisited within a phase.
+func (r *residual) augment(u, t graph.Node, limit float64, level, next map[int]int) float64 {
+	uid := u.ID()
+	if uid == t.ID() {
+		return limit
+	}
+	adj := r.adjacent[uid]
+	for ; next[uid] < len(adj); next[uid]++ {
+		v := adj[next[uid]]
+		if d, ok := level[v.ID()]; !ok || d != level[uid]+1 {
+			continue
+		}
+		c := r.residual(u, v)
+		if c <= 0 {
+			continue
+		}
+		if f := r.augment(v, t, math.Min(limit, c), level, next); f > 0 {
+			r.push(u, v, f)
+			return f
+		}
+	}
+	return 0
+}
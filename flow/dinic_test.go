@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestDinic(t *testing.T) {
+	for _, test := range maxFlowTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		got, flow := Dinic(simple.Node(test.s), simple.Node(test.t), g, nil)
+		if got != test.want {
+			t.Errorf("unexpected max flow for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		checkFlow(t, test.name, simple.Node(test.s), simple.Node(test.t), g, got, flow)
+	}
+}
+
+func TestDinicEdmondsKarp(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		g := randomNetwork(rnd, 2+rnd.Intn(30), rnd.Float64())
+		s, tn := simple.Node(0), simple.Node(len(g.Nodes())-1)
+
+		want, _ := EdmondsKarp(s, tn, g)
+		got, flow := Dinic(s, tn, g, nil)
+		if got != want {
+			t.Errorf("unexpected max flow for trial %d: got:%v want:%v", i, got, want)
+		}
+		checkFlow(t, "random", s, tn, g, got, flow)
+	}
+}
+
+// randomNetwork returns a directed graph with n nodes and an edge with a
+// random integer capacity between each ordered pair of nodes with
+// probability p. Integer capacities allow flows to be compared exactly.
+func randomNetwork(rnd *rand.Rand, n int, p float64) *simple.DirectedGraph {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for u := 0; u < n; u++ {
+		g.AddNode(simple.Node(u))
+	}
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u == v || rnd.Float64() >= p {
+				continue
+			}
+			w := float64(1 + rnd.Intn(20))
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+	}
+	return g
+}
+
+var (
+	network_100_tenth  = randomNetwork(rand.New(rand.NewSource(1)), 100, 0.1)
+	network_1000_tenth = randomNetwork(rand.New(rand.NewSource(1)), 1000, 0.1)
+)
+
+func benchmarkMaxFlow(b *testing.B, g graph.Graph, fn func(s, t graph.Node, g graph.Graph) (float64, map[int]map[int]float64)) {
+	s, t := simple.Node(0), simple.Node(len(g.Nodes())-1)
+	var f float64
+	for i := 0; i < b.N; i++ {
+		f, _ = fn(s, t, g)
+	}
+	if f == 0 {
+		b.Fatal("unexpected zero max flow")
+	}
+}
+
+func dinic(s, t graph.Node, g graph.Graph) (float64, map[int]map[int]float64) {
+	return Dinic(s, t, g, nil)
+}
+
+func BenchmarkEdmondsKarp_100_tenth(b *testing.B) {
+	benchmarkMaxFlow(b, network_100_tenth, EdmondsKarp)
+}
+func BenchmarkEdmondsKarp_1000_tenth(b *testing.B) {
+	benchmarkMaxFlow(b, network_1000_tenth, EdmondsKarp)
+}
+func BenchmarkDinic_100_tenth(b *testing.B) {
+	benchmarkMaxFlow(b, network_100_tenth, dinic)
+}
+func BenchmarkDinic_1000_tenth(b *testing.B) {
+	benchmarkMaxFlow(b, network_1000_tenth, dinic)
+}
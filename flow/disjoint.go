@@ -0,0 +1,143 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// EdgeDisjointPaths returns a maximum set of edge-disjoint paths from s to t in
+// the directed graph g. The number of paths is equal to the maximum flow from
+// s to t when every edge of g has unit capacity. The paths are sorted lexically
+// by the IDs of their nodes. If s and t are the same node, or either is not in
+// g, EdgeDisjointPaths returns nil.
+func EdgeDisjointPaths(s, t graph.Node, g graph.Directed) [][]graph.Node {
+	_, flow := edmondsKarp(s, t, g, unit)
+	if flow == nil {
+		return nil
+	}
+	nodes := make(map[int]graph.Node)
+	for _, n := range g.Nodes() {
+		nodes[n.ID()] = n
+	}
+	ids := decompose(s.ID(), t.ID(), flow)
+	paths := make([][]graph.Node, len(ids))
+	for i, p := range ids {
+		paths[i] = make([]graph.Node, len(p))
+		for j, id := range p {
+			paths[i][j] = nodes[id]
+		}
+	}
+	sort.Sort(ordered.BySliceIDs(paths))
+	return paths
+}
+
+// NodeDisjointPaths returns a maximum set of paths from s to t in the directed
+// graph g that share no nodes other than s and t. The paths are found as edge
+// disjoint paths in a graph where each node of g is split into an in and out
+// node joined by a unit capacity edge. The paths are sorted lexically by the IDs
+// of their nodes. If s and t are the same node, or either is not in g,
+// NodeDisjointPaths returns nil.
+func NodeDisjointPaths(s, t graph.Node, g graph.Directed) [][]graph.Node {
+	if s.ID() == t.ID() || !g.Has(s) || !g.Has(t) {
+		return nil
+	}
+
+	nodes := g.Nodes()
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Node i of g is represented by the nodes
+	// 2i and 2i+1 of split, which are the in and
+	// out ends of the node respectively.
+	split := simple.NewDirectedGraph(0, math.Inf(1))
+	for i := range nodes {
+		split.SetEdge(simple.Edge{F: simple.Node(2 * i), T: simple.Node(2*i + 1)})
+	}
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if i == j {
+				continue
+			}
+			split.SetEdge(simple.Edge{F: simple.Node(2*i + 1), T: simple.Node(2 * j)})
+		}
+	}
+
+	sid := 2*indexOf[s.ID()] + 1
+	tid := 2 * indexOf[t.ID()]
+	_, flow := edmondsKarp(simple.Node(sid), simple.Node(tid), split, unit)
+	ids := decompose(sid, tid, flow)
+	paths := make([][]graph.Node, len(ids))
+	for i, p := range ids {
+		// Each node of g other than s appears
+		// on the path first as its in node.
+		paths[i] = []graph.Node{s}
+		for _, id := range p {
+			if id%2 == 0 {
+				paths[i] = append(paths[i], nodes[id/2])
+			}
+		}
+	}
+	sort.Sort(ordered.BySliceIDs(paths))
+	return paths
+}
+
+// decompose returns the paths from s to t of the integral flow, which is
+// consumed. Cycles in the flow are discarded.
+func decompose(s, t int, flow map[int]map[int]float64) [][]int {
+	var paths [][]int
+	for {
+		path := []int{s}
+		onPath := map[int]int{s: 0}
+		for u := s; u != t; {
+			v, ok := next(flow, u)
+			if !ok {
+				// There is no more flow from s.
+				return paths
+			}
+			if i, ok := onPath[v]; ok {
+				// Remove a cycle in the flow.
+				for _, n := range path[i+1:] {
+					delete(onPath, n)
+				}
+				path = path[:i+1]
+			} else {
+				onPath[v] = len(path)
+				path = append(path, v)
+			}
+			u = v
+		}
+		paths = append(paths, path)
+	}
+}
+
+// next removes a unit of flow leaving u and returns the node it was sent to.
+// Flow is taken to the node with the lowest ID first so that the decomposition
+// does not depend on map iteration order.
+func next(flow map[int]map[int]float64, u int) (int, bool) {
+	v, ok := 0, false
+	for id, f := range flow[u] {
+		if f >= 1 && (!ok || id < v) {
+			v, ok = id, true
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	if flow[u][v] == 1 {
+		delete(flow[u], v)
+	} else {
+		flow[u][v]--
+	}
+	return v, true
+}
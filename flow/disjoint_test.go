@@ -0,0 +1,136 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+var disjointPathTests = []struct {
+	name  string
+	edges [][2]int
+	s, t  int
+
+	edge, node int
+}{
+	{
+		name:  "single edge",
+		edges: [][2]int{{0, 1}},
+		s:     0, t: 1,
+		edge: 1, node: 1,
+	},
+	{
+		name:  "unreachable",
+		edges: [][2]int{{0, 1}, {2, 1}},
+		s:     0, t: 2,
+		edge: 0, node: 0,
+	},
+	{
+		// Two edge-disjoint paths that must share node 2.
+		name:  "bowtie",
+		edges: [][2]int{{0, 1}, {1, 2}, {0, 2}, {2, 3}, {2, 4}, {3, 4}},
+		s:     0, t: 4,
+		edge: 2, node: 1,
+	},
+	{
+		name: "three routes",
+		edges: [][2]int{
+			{0, 1}, {1, 5},
+			{0, 2}, {2, 5},
+			{0, 3}, {3, 4}, {4, 5},
+			{1, 2}, {3, 2},
+		},
+		s: 0, t: 5,
+		edge: 3, node: 3,
+	},
+	{
+		// A cycle through the middle node that must
+		// not appear in the decomposed paths.
+		name:  "direct and cycle",
+		edges: [][2]int{{0, 3}, {0, 1}, {1, 2}, {2, 1}, {1, 3}},
+		s:     0, t: 3,
+		edge: 2, node: 2,
+	},
+}
+
+func TestDisjointPaths(t *testing.T) {
+	for _, test := range disjointPathTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for _, e := range test.edges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+		s := simple.Node(test.s)
+		tn := simple.Node(test.t)
+
+		edgePaths := EdgeDisjointPaths(s, tn, g)
+		if len(edgePaths) != test.edge {
+			t.Errorf("unexpected number of edge-disjoint paths for %q: got:%d want:%d", test.name, len(edgePaths), test.edge)
+		}
+		checkPaths(t, test.name, s, tn, g, edgePaths)
+		used := make(map[[2]int]bool)
+		for _, p := range edgePaths {
+			for i := 1; i < len(p); i++ {
+				e := [2]int{p[i-1].ID(), p[i].ID()}
+				if used[e] {
+					t.Errorf("edge %v used more than once for %q: %v", e, test.name, edgePaths)
+				}
+				used[e] = true
+			}
+		}
+
+		nodePaths := NodeDisjointPaths(s, tn, g)
+		if len(nodePaths) != test.node {
+			t.Errorf("unexpected number of node-disjoint paths for %q: got:%d want:%d", test.name, len(nodePaths), test.node)
+		}
+		checkPaths(t, test.name, s, tn, g, nodePaths)
+		if !sort.IsSorted(ordered.BySliceIDs(edgePaths)) || !sort.IsSorted(ordered.BySliceIDs(nodePaths)) {
+			t.Errorf("paths not sorted for %q: edge:%v node:%v", test.name, edgePaths, nodePaths)
+		}
+		for i := 0; i < 5; i++ {
+			if got := EdgeDisjointPaths(s, tn, g); !reflect.DeepEqual(got, edgePaths) {
+				t.Errorf("edge-disjoint paths differ between calls for %q: got:%v want:%v", test.name, got, edgePaths)
+			}
+			if got := NodeDisjointPaths(s, tn, g); !reflect.DeepEqual(got, nodePaths) {
+				t.Errorf("node-disjoint paths differ between calls for %q: got:%v want:%v", test.name, got, nodePaths)
+			}
+		}
+		seen := make(map[int]bool)
+		for _, p := range nodePaths {
+			for _, n := range p[1 : len(p)-1] {
+				if seen[n.ID()] {
+					t.Errorf("node %d used more than once for %q: %v", n.ID(), test.name, nodePaths)
+				}
+				seen[n.ID()] = true
+			}
+		}
+	}
+}
+
+// checkPaths checks that each path is a simple path from s to t in g.
+func checkPaths(t *testing.T, name string, s, tn graph.Node, g graph.Directed, paths [][]graph.Node) {
+	for _, p := range paths {
+		if len(p) < 2 || p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+			t.Errorf("invalid path end points for %q: %v", name, p)
+			continue
+		}
+		seen := make(map[int]bool)
+		for i, n := range p {
+			if seen[n.ID()] {
+				t.Errorf("path is not simple for %q: %v", name, p)
+			}
+			seen[n.ID()] = true
+			if i != 0 && !g.HasEdgeFromTo(p[i-1], n) {
+				t.Errorf("path uses non-existent edge for %q: %v", name, p)
+			}
+		}
+	}
+}
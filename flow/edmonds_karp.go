@@ -0,0 +1,187 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/linear"
+)
+
+// EdmondsKarp returns the maximum flow from s to t in the graph g and the flow
+// through each edge achieving it. The flow is keyed by the IDs of the nodes
+// at either end of each edge carrying flow, in the direction of the flow, and
+// only edges carrying a positive flow are included.
+//
+// If g implements graph.Weighter, the weight of each edge is used as its
+// capacity, otherwise edges have unit capacity. Undirected edges may carry
+// flow in either direction. EdmondsKarp will panic if g has a negative edge
+// capacity. If s and t are the same node, or either is not in g, the returned
+// flow is zero and the flow map is nil.
+//
+// The time complexity of EdmondsKarp is O(|V|.|E|^2).
+func EdmondsKarp(s, t graph.Node, g graph.Graph) (maxFlow float64, flow map[int]map[int]float64) {
+	return edmondsKarp(s, t, g, capacityFunc(g, nil))
+}
+
+// EdmondsKarpCapacity returns the maximum flow from s to t in the graph g and
+// the flow through each edge achieving it as described for EdmondsKarp, using
+// capacity to determine the capacity of each edge of g. This allows the edge
+// weights of g to hold a quantity other than capacity, such as a cost. If
+// capacity is nil, capacities are determined as they are by EdmondsKarp.
+// EdmondsKarpCapacity will panic if capacity returns a negative value.
+func EdmondsKarpCapacity(s, t graph.Node, g graph.Graph, capacity func(graph.Edge) float64) (maxFlow float64, flow map[int]map[int]float64) {
+	return edmondsKarp(s, t, g, capacityFunc(g, capacity))
+}
+
+// capacityFunc returns a function returning the capacity of the edge from u
+// to v in g. If capacity is nil the returned function uses the weight of the
+// edge if g is a graph.Weighter and unit capacity otherwise.
+func capacityFunc(g graph.Graph, capacity func(graph.Edge) float64) func(u, v graph.Node) float64 {
+	if capacity != nil {
+		return func(u, v graph.Node) float64 {
+			return capacity(g.Edge(u, v))
+		}
+	}
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return unit
+}
+
+// unit is a capacity function returning unit capacity for all edges.
+func unit(_, _ graph.Node) float64 { return 1 }
+
+// edmondsKarp is the Edmonds-Karp implementation, using capacity to determine
+// the capacity of each edge of g.
+func edmondsKarp(s, t graph.Node, g graph.Graph, capacity func(u, v graph.Node) float64) (maxFlow float64, flow map[int]map[int]float64) {
+	if s.ID() == t.ID() || !g.Has(s) || !g.Has(t) {
+		return 0, nil
+	}
+	r := newResidual(g, capacity)
+	sid := s.ID()
+	tid := t.ID()
+	for {
+		prev := r.augmentingPath(s, t)
+		if prev == nil {
+			break
+		}
+
+		// Find the bottleneck of the path.
+		df := r.residual(prev[tid], t)
+		for v := prev[tid]; v.ID() != sid; v = prev[v.ID()] {
+			if c := r.residual(prev[v.ID()], v); c < df {
+				df = c
+			}
+		}
+
+		// Push the bottleneck flow along the path.
+		for v := t; v.ID() != sid; v = prev[v.ID()] {
+			r.push(prev[v.ID()], v, df)
+		}
+		maxFlow += df
+	}
+
+	return maxFlow, r.positive()
+}
+
+// residual is a residual network for a flow in a graph.
+type residual struct {
+	// adjacent holds the nodes adjacent
+	// to each node in either direction.
+	adjacent map[int][]graph.Node
+
+	capacity map[int]map[int]float64
+	flow     map[int]map[int]float64
+}
+
+// newResidual returns a residual network for g with no flow, using capacity to
+// determine the capacity of each edge.
+func newResidual(g graph.Graph, capacity func(u, v graph.Node) float64) *residual {
+	nodes := g.Nodes()
+	r := &residual{
+		adjacent: make(map[int][]graph.Node, len(nodes)),
+		capacity: make(map[int]map[int]float64, len(nodes)),
+		flow:     make(map[int]map[int]float64, len(nodes)),
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		r.capacity[uid] = make(map[int]float64)
+		r.flow[uid] = make(map[int]float64)
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, v := range g.From(u) {
+			vid := v.ID()
+			if vid == uid {
+				continue
+			}
+			c := capacity(u, v)
+			if c < 0 {
+				panic("flow: negative edge capacity")
+			}
+			if _, ok := r.capacity[vid][uid]; !ok {
+				r.adjacent[uid] = append(r.adjacent[uid], v)
+				r.adjacent[vid] = append(r.adjacent[vid], u)
+				r.capacity[vid][uid] = 0
+			}
+			r.capacity[uid][vid] = c
+		}
+	}
+	return r
+}
+
+// residual returns the residual capacity from u to v.
+func (r *residual) residual(u, v graph.Node) float64 {
+	return r.capacity[u.ID()][v.ID()] - r.flow[u.ID()][v.ID()]
+}
+
+// push adds a flow of f from u to v, maintaining skew symmetry.
+func (r *residual) push(u, v graph.Node, f float64) {
+	r.flow[u.ID()][v.ID()] += f
+	r.flow[v.ID()][u.ID()] -= f
+}
+
+// augmentingPath returns the breadth first search tree of the residual network
+// from s as a mapping from each node to its parent if t is reachable. If t is
+// not reachable in the residual network, augmentingPath returns nil.
+func (r *residual) augmentingPath(s, t graph.Node) map[int]graph.Node {
+	prev := map[int]graph.Node{s.ID(): nil}
+	var queue linear.NodeQueue
+	queue.Enqueue(s)
+	for queue.Len() != 0 {
+		u := queue.Dequeue()
+		for _, v := range r.adjacent[u.ID()] {
+			if _, seen := prev[v.ID()]; seen || r.residual(u, v) <= 0 {
+				continue
+			}
+			prev[v.ID()] = u
+			if v.ID() == t.ID() {
+				return prev
+			}
+			queue.Enqueue(v)
+		}
+	}
+	return nil
+}
+
+// positive returns the positive flows in the residual network.
+func (r *residual) positive() map[int]map[int]float64 {
+	flow := make(map[int]map[int]float64)
+	for uid, to := range r.flow {
+		for vid, f := range to {
+			if f <= 0 {
+				continue
+			}
+			if flow[uid] == nil {
+				flow[uid] = make(map[int]float64)
+			}
+			flow[uid][vid] = f
+		}
+	}
+	return flow
+}
@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var maxFlowTests = []struct {
+	name     string
+	directed bool
+	edges    []simple.Edge
+	s, t     int
+	want     float64
+}{
+	{
+		// Example from Cormen et al., Introduction to Algorithms, figure 26.1.
+		name:     "clrs",
+		directed: true,
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 16},
+			{F: simple.Node(0), T: simple.Node(2), W: 13},
+			{F: simple.Node(2), T: simple.Node(1), W: 4},
+			{F: simple.Node(1), T: simple.Node(3), W: 12},
+			{F: simple.Node(3), T: simple.Node(2), W: 9},
+			{F: simple.Node(2), T: simple.Node(4), W: 14},
+			{F: simple.Node(4), T: simple.Node(3), W: 7},
+			{F: simple.Node(3), T: simple.Node(5), W: 20},
+			{F: simple.Node(4), T: simple.Node(5), W: 4},
+		},
+		s: 0, t: 5,
+		want: 23,
+	},
+	{
+		name:     "antiparallel",
+		directed: true,
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(1), T: simple.Node(0), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 5},
+		},
+		s: 0, t: 2,
+		want: 3,
+	},
+	{
+		name:     "unreachable",
+		directed: true,
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(2), T: simple.Node(1), W: 2},
+		},
+		s: 0, t: 2,
+		want: 0,
+	},
+	{
+		name: "undirected",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 3},
+		},
+		s: 0, t: 3,
+		want: 4,
+	},
+}
+
+func TestEdmondsKarp(t *testing.T) {
+	for _, test := range maxFlowTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		got, flow := EdmondsKarp(simple.Node(test.s), simple.Node(test.t), g)
+		if got != test.want {
+			t.Errorf("unexpected max flow for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		checkFlow(t, test.name, simple.Node(test.s), simple.Node(test.t), g, got, flow)
+	}
+}
+
+func TestEdmondsKarpUnitCapacity(t *testing.T) {
+	// A graph that does not implement graph.Weighter.
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(0), T: simple.Node(2)},
+		{F: simple.Node(0), T: simple.Node(3)},
+		{F: simple.Node(1), T: simple.Node(4)},
+		{F: simple.Node(2), T: simple.Node(4)},
+		{F: simple.Node(3), T: simple.Node(2)},
+	} {
+		g.SetEdge(e)
+	}
+	got, _ := EdmondsKarp(simple.Node(0), simple.Node(4), struct{ graph.Directed }{g})
+	if got != 2 {
+		t.Errorf("unexpected unit capacity max flow: got:%v want:2", got)
+	}
+}
+
+// capacityEdge is an edge with a capacity independent of its weight.
+type capacityEdge struct {
+	simple.Edge
+	capacity float64
+}
+
+func TestEdmondsKarpCapacity(t *testing.T) {
+	for _, test := range maxFlowTests {
+		// caps holds capacities as weights for checking
+		// flows and costs holds unrelated weights.
+		var caps, costs interface {
+			graph.Graph
+			graph.Builder
+		}
+		if test.directed {
+			caps = simple.NewDirectedGraph(0, math.Inf(1))
+			costs = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			caps = simple.NewUndirectedGraph(0, math.Inf(1))
+			costs = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range test.edges {
+			caps.SetEdge(e)
+			costs.SetEdge(capacityEdge{
+				Edge:     simple.Edge{F: e.F, T: e.T, W: 1 / (e.W + 1)},
+				capacity: e.W,
+			})
+		}
+
+		got, flow := EdmondsKarpCapacity(simple.Node(test.s), simple.Node(test.t), costs, func(e graph.Edge) float64 {
+			return e.(capacityEdge).capacity
+		})
+		if got != test.want {
+			t.Errorf("unexpected max flow for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		checkFlow(t, test.name, simple.Node(test.s), simple.Node(test.t), caps, got, flow)
+
+		// A nil capacity function uses edge weights.
+		got, _ = EdmondsKarpCapacity(simple.Node(test.s), simple.Node(test.t), caps, nil)
+		if got != test.want {
+			t.Errorf("unexpected max flow for %q with nil capacity: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+// checkFlow checks that flow is a valid flow in g from s to t with value want.
+func checkFlow(t *testing.T, name string, s, tn graph.Node, g graph.Graph, want float64, flow map[int]map[int]float64) {
+	wg := g.(graph.Weighter)
+	net := make(map[int]float64)
+	for uid, to := range flow {
+		for vid, f := range to {
+			if f <= 0 {
+				t.Errorf("unexpected non-positive flow for %q from %d to %d: %v", name, uid, vid, f)
+			}
+			w, ok := wg.Weight(simple.Node(uid), simple.Node(vid))
+			if !ok || f > w {
+				t.Errorf("flow exceeds capacity for %q from %d to %d: flow:%v capacity:%v", name, uid, vid, f, w)
+			}
+			net[uid] -= f
+			net[vid] += f
+		}
+	}
+	for _, n := range g.Nodes() {
+		id := n.ID()
+		switch id {
+		case s.ID():
+			if net[id] != -want {
+				t.Errorf("unexpected source outflow for %q: got:%v want:%v", name, -net[id], want)
+			}
+		case tn.ID():
+			if net[id] != want {
+				t.Errorf("unexpected sink inflow for %q: got:%v want:%v", name, net[id], want)
+			}
+		default:
+			if net[id] != 0 {
+				t.Errorf("flow not conserved for %q at %d: %v", name, id, net[id])
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/linear"
+)
+
+// MinCostMaxFlow returns a maximum flow from s to t in the graph g with the
+// minimum total cost of all maximum flows, the value of the flow and its total
+// cost. The flow is keyed as described for EdmondsKarp.
+//
+// The capacity of each edge is given by capacity and the cost of a unit of
+// flow through each edge is given by cost. If capacity is nil, capacities are
+// determined as they are by EdmondsKarp. If cost is nil, the weight of each
+// edge is used as its cost. Undirected edges may carry flow in either
+// direction at the same cost.
+//
+// The flow is found by successive augmentation along shortest paths in the
+// residual network with respect to cost, found with the Bellman-Ford-Moore
+// algorithm, so negative costs are allowed. MinCostMaxFlow will panic if g has
+// a negative edge capacity or a cycle of negative cost with positive capacity,
+// including an undirected edge with a negative cost. If s and t are the same
+// node, or either is not in g, the returned flow is zero and the flow map is
+// nil.
+func MinCostMaxFlow(s, t graph.Node, g graph.Graph, capacity, cost func(graph.Edge) float64) (flowValue, totalCost float64, flow map[int]map[int]float64) {
+	if s.ID() == t.ID() || !g.Has(s) || !g.Has(t) {
+		return 0, 0, nil
+	}
+	if cost == nil {
+		cost = func(e graph.Edge) float64 { return e.Weight() }
+	}
+
+	nodes := g.Nodes()
+	r := &costResidual{
+		residual: newResidual(g, capacityFunc(g, capacity)),
+		cost:     make(map[int]map[int]float64, len(nodes)),
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		r.cost[uid] = make(map[int]float64)
+		for _, v := range g.From(u) {
+			if v.ID() != uid {
+				r.cost[uid][v.ID()] = cost(g.Edge(u, v))
+			}
+		}
+	}
+
+	sid := s.ID()
+	for {
+		prev := r.cheapestPath(s, t, len(nodes))
+		if prev == nil {
+			break
+		}
+
+		// Find the bottleneck of the path.
+		df := math.Inf(1)
+		for v := t; v.ID() != sid; v = prev[v.ID()] {
+			if c := r.segment(prev[v.ID()], v); c < df {
+				df = c
+			}
+		}
+
+		// Push the bottleneck flow along the path.
+		for v := t; v.ID() != sid; v = prev[v.ID()] {
+			r.push(prev[v.ID()], v, df)
+		}
+		flowValue += df
+	}
+
+	flow = r.positive()
+	for uid, to := range flow {
+		for vid, f := range to {
+			totalCost += f * r.cost[uid][vid]
+		}
+	}
+	return flowValue, totalCost, flow
+}
+
+// costResidual is a residual network with a cost for each edge.
+//
+// Flows between a pair of nodes are held as a single net flow, so the
+// residual edge from u to v first cancels any flow from v to u, at the
+// negated cost of the edge from v to u, before adding flow from u to v
+// at the cost of the edge from u to v.
+type costResidual struct {
+	*residual
+	cost map[int]map[int]float64
+}
+
+// segment returns the residual capacity from u to v available at the
+// cost returned by segmentCost.
+func (r *costResidual) segment(u, v graph.Node) float64 {
+	f := r.flow[u.ID()][v.ID()]
+	if f < 0 {
+		return -f
+	}
+	return r.capacity[u.ID()][v.ID()] - f
+}
+
+// segmentCost returns the cost of a unit of additional flow from u to v.
+func (r *costResidual) segmentCost(u, v graph.Node) float64 {
+	if r.flow[u.ID()][v.ID()] < 0 {
+		return -r.cost[v.ID()][u.ID()]
+	}
+	return r.cost[u.ID()][v.ID()]
+}
+
+// cheapestPath returns the shortest path tree of the residual network from s
+// with respect to cost as a mapping from each node to its parent if t is
+// reachable. If t is not reachable in the residual network, cheapestPath
+// returns nil. The network has n nodes. cheapestPath will panic if a negative
+// cost cycle is reachable from s.
+func (r *costResidual) cheapestPath(s, t graph.Node, n int) map[int]graph.Node {
+	dist := map[int]float64{s.ID(): 0}
+	prev := map[int]graph.Node{s.ID(): nil}
+	queued := map[int]bool{s.ID(): true}
+	relaxed := make(map[int]int)
+	var queue linear.NodeQueue
+	queue.Enqueue(s)
+	for queue.Len() != 0 {
+		u := queue.Dequeue()
+		uid := u.ID()
+		queued[uid] = false
+		for _, v := range r.adjacent[uid] {
+			if r.segment(u, v) <= 0 {
+				continue
+			}
+			vid := v.ID()
+			d := dist[uid] + r.segmentCost(u, v)
+			if dv, ok := dist[vid]; ok && d >= dv {
+				continue
+			}
+			dist[vid] = d
+			prev[vid] = u
+			if queued[vid] {
+				continue
+			}
+			relaxed[vid]++
+			if relaxed[vid] > n {
+				panic("flow: negative cost cycle")
+			}
+			queued[vid] = true
+			queue.Enqueue(v)
+		}
+	}
+	if _, ok := prev[t.ID()]; !ok {
+		return nil
+	}
+	return prev
+}
@@ -0,0 +1,220 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var minCostFlowTests = []struct {
+	name     string
+	directed bool
+	edges    []capacityEdge
+	s, t     int
+
+	wantFlow float64
+	wantCost float64
+}{
+	{
+		// Two routes of capacity 2 and cost 2 and 5
+		// per unit, and a shortcut of capacity 1
+		// between them.
+		name:     "routes",
+		directed: true,
+		edges: []capacityEdge{
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(3), W: 1}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 2}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3), W: 3}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(1), W: 0}, capacity: 1},
+		},
+		s: 0, t: 3,
+		wantFlow: 4,
+		wantCost: 14,
+	},
+	{
+		// The cheapest first path must be undone
+		// to achieve the maximum flow.
+		name:     "cancellation",
+		directed: true,
+		edges: []capacityEdge{
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 1}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3), W: 1}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 5}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(3), W: 5}, capacity: 1},
+		},
+		s: 0, t: 3,
+		wantFlow: 2,
+		wantCost: 12,
+	},
+	{
+		name:     "negative costs",
+		directed: true,
+		edges: []capacityEdge{
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1), W: -2}, capacity: 3},
+			{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 1}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 4}, capacity: 1},
+		},
+		s: 0, t: 2,
+		wantFlow: 3,
+		wantCost: 2,
+	},
+	{
+		name: "undirected",
+		edges: []capacityEdge{
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1}, capacity: 2},
+			{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 1}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(0), W: 4}, capacity: 2},
+		},
+		s: 0, t: 2,
+		wantFlow: 3,
+		wantCost: 10,
+	},
+	{
+		name:     "unreachable",
+		directed: true,
+		edges: []capacityEdge{
+			{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1}, capacity: 1},
+			{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(1), W: 1}, capacity: 1},
+		},
+		s: 0, t: 2,
+	},
+}
+
+func capacityOf(e graph.Edge) float64 { return e.(capacityEdge).capacity }
+
+func TestMinCostMaxFlow(t *testing.T) {
+	for _, test := range minCostFlowTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		f, c, flow := MinCostMaxFlow(simple.Node(test.s), simple.Node(test.t), g, capacityOf, nil)
+		if f != test.wantFlow {
+			t.Errorf("unexpected flow value for %q: got:%v want:%v", test.name, f, test.wantFlow)
+		}
+		if c != test.wantCost {
+			t.Errorf("unexpected total cost for %q: got:%v want:%v", test.name, c, test.wantCost)
+		}
+		var sum float64
+		for uid, to := range flow {
+			for vid, f := range to {
+				e := g.Edge(simple.Node(uid), simple.Node(vid))
+				if f > capacityOf(e) {
+					t.Errorf("flow exceeds capacity for %q from %d to %d: flow:%v capacity:%v", test.name, uid, vid, f, capacityOf(e))
+				}
+				sum += f * e.Weight()
+			}
+		}
+		if sum != c {
+			t.Errorf("total cost does not match flow for %q: got:%v want:%v", test.name, c, sum)
+		}
+	}
+}
+
+func TestMinCostMaxFlowAssignment(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + rnd.Intn(5)
+		costs := make([][]float64, n)
+		for w := range costs {
+			costs[w] = make([]float64, n)
+			for j := range costs[w] {
+				costs[w][j] = float64(rnd.Intn(20) - 5)
+			}
+		}
+
+		// Workers are nodes 1 to n and jobs are nodes
+		// n+1 to 2n, between source 0 and sink 2n+1.
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		s, tn := simple.Node(0), simple.Node(2*n+1)
+		for w := 0; w < n; w++ {
+			g.SetEdge(capacityEdge{Edge: simple.Edge{F: s, T: simple.Node(1 + w)}, capacity: 1})
+			g.SetEdge(capacityEdge{Edge: simple.Edge{F: simple.Node(1 + n + w), T: tn}, capacity: 1})
+			for j := 0; j < n; j++ {
+				g.SetEdge(capacityEdge{Edge: simple.Edge{F: simple.Node(1 + w), T: simple.Node(1 + n + j), W: costs[w][j]}, capacity: 1})
+			}
+		}
+
+		f, c, flow := MinCostMaxFlow(s, tn, g, capacityOf, nil)
+		if f != float64(n) {
+			t.Errorf("unexpected flow value for trial %d: got:%v want:%d", i, f, n)
+		}
+		if want := minAssignment(costs); c != want {
+			t.Errorf("unexpected assignment cost for trial %d: got:%v want:%v", i, c, want)
+		}
+		for w := 0; w < n; w++ {
+			if len(flow[1+w]) != 1 {
+				t.Errorf("worker %d not assigned one job for trial %d: %v", w, i, flow[1+w])
+			}
+		}
+
+		// The flow value matches the maximum flow.
+		want, _ := EdmondsKarpCapacity(s, tn, g, capacityOf)
+		if f != want {
+			t.Errorf("flow value does not match maximum flow for trial %d: got:%v want:%v", i, f, want)
+		}
+	}
+}
+
+// minAssignment returns the minimum total cost of assigning each worker to a
+// distinct job by exhaustive search, where costs[w][j] is the cost of worker
+// w doing job j.
+func minAssignment(costs [][]float64) float64 {
+	used := make([]bool, len(costs))
+	var assign func(w int) float64
+	assign = func(w int) float64 {
+		if w == len(costs) {
+			return 0
+		}
+		best := math.Inf(1)
+		for j, u := range used {
+			if u {
+				continue
+			}
+			used[j] = true
+			if c := costs[w][j] + assign(w+1); c < best {
+				best = c
+			}
+			used[j] = false
+		}
+		return best
+	}
+	return assign(0)
+}
+
+func TestMinCostMaxFlowNegativeCycle(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: -3})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3), W: 1})
+
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		MinCostMaxFlow(simple.Node(0), simple.Node(3), g, func(graph.Edge) float64 { return 1 }, nil)
+		return
+	}()
+	if !panicked {
+		t.Error("expected panic for negative cost cycle")
+	}
+}
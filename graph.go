@@ -74,6 +74,74 @@ type Weighter interface {
 	Weight(x, y Node) (w float64, ok bool)
 }
 
+// DirectedDegreer defines directed graphs that can report the
+// number of edges leading to and from a node.
+type DirectedDegreer interface {
+	// InDegree returns the number of edges
+	// leading to the node.
+	InDegree(Node) int
+
+	// OutDegree returns the number of edges
+	// leading from the node.
+	OutDegree(Node) int
+}
+
+// FromVisitor defines graphs that can visit the nodes
+// reachable from a node without allocating.
+type FromVisitor interface {
+	// VisitFrom calls fn with each node that can be
+	// reached directly from n and the weight of the
+	// edge from n to the node, as would be returned
+	// by the graph's Weight method. Iteration stops
+	// when fn returns false. VisitFrom is a no-op if
+	// n is not in the graph.
+	VisitFrom(n Node, fn func(neighbor Node, weight float64) bool)
+}
+
+// ToVisitor defines directed graphs that can visit the
+// nodes that can reach a node without allocating.
+type ToVisitor interface {
+	// VisitTo calls fn with each node that can reach
+	// n directly and the weight of the edge from the
+	// node to n, as would be returned by the graph's
+	// Weight method. Iteration stops when fn returns
+	// false. VisitTo is a no-op if n is not in the
+	// graph.
+	VisitTo(n Node, fn func(neighbor Node, weight float64) bool)
+}
+
+// NodeValuer defines graphs that hold a value for each node.
+type NodeValuer interface {
+	// NodeValue returns the value held for the
+	// node with the given ID and whether a value
+	// is held.
+	NodeValue(id int) (v interface{}, ok bool)
+}
+
+// NodeValueSetter defines graphs that can hold a value for each node.
+type NodeValueSetter interface {
+	// SetNodeValue sets the value held for the
+	// node with the given ID.
+	SetNodeValue(id int, v interface{})
+}
+
+// EdgeAttrser defines graphs that hold a set of keyed attributes
+// for each edge.
+type EdgeAttrser interface {
+	// EdgeAttrs returns the attributes of the
+	// edge from u to v, or nil if the edge has
+	// no attributes.
+	EdgeAttrs(u, v Node) map[string]interface{}
+}
+
+// EdgeAttrSetter defines graphs that can hold a set of keyed
+// attributes for each edge.
+type EdgeAttrSetter interface {
+	// SetEdgeAttr sets the value of the attribute
+	// key for the edge from u to v.
+	SetEdgeAttr(u, v Node, key string, value interface{})
+}
+
 // NodeAdder is an interface for adding arbitrary nodes to a graph.
 type NodeAdder interface {
 	// NewNodeID returns a new unique arbitrary ID.
@@ -111,6 +179,34 @@ type EdgeRemover interface {
 	RemoveEdge(Edge)
 }
 
+// Observer is notified of mutations of a graph. Notifications are made
+// after each mutation that changes the graph.
+type Observer interface {
+	// NodeAdded is called after n is
+	// added to the graph.
+	NodeAdded(n Node)
+
+	// NodeRemoved is called after n is
+	// removed from the graph. The edges
+	// attached to n are reported as
+	// removed before n.
+	NodeRemoved(n Node)
+
+	// EdgeAdded is called after e is
+	// added to the graph.
+	EdgeAdded(e Edge)
+
+	// EdgeRemoved is called after e is
+	// removed from the graph.
+	EdgeRemoved(e Edge)
+
+	// EdgeWeightChanged is called after
+	// the weight of an edge in the graph
+	// is changed from old. The edge as
+	// held by the graph is passed as e.
+	EdgeWeightChanged(e Edge, old float64)
+}
+
 // Builder is a graph that can have nodes and edges added.
 type Builder interface {
 	NodeAdder
@@ -151,3 +247,33 @@ func Copy(dst Builder, src Graph) {
 		}
 	}
 }
+
+// Subgraph copies the subgraph of src induced by the given nodes into dst
+// without first clearing the destination. Nodes in the given set that do not
+// exist in src are ignored, and nodes appearing more than once are copied once.
+// Subgraph will panic if a node ID in the induced subgraph matches a node ID
+// in the destination.
+//
+// Edges, including self edges, are copied as they are returned by src, so the
+// handling of edge direction follows that of Copy; directedness of the result is
+// determined by the destination. If dst does not allow self edges, src must not
+// have self edges among the given nodes.
+func Subgraph(dst Builder, src Graph, nodes []Node) {
+	in := make(map[int]struct{}, len(nodes))
+	var members []Node
+	for _, n := range nodes {
+		if _, ok := in[n.ID()]; ok || !src.Has(n) {
+			continue
+		}
+		in[n.ID()] = struct{}{}
+		members = append(members, n)
+		dst.AddNode(n)
+	}
+	for _, u := range members {
+		for _, v := range src.From(u) {
+			if _, ok := in[v.ID()]; ok {
+				dst.SetEdge(src.Edge(u, v))
+			}
+		}
+	}
+}
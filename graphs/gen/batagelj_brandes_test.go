@@ -6,6 +6,7 @@ package gen
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/gonum/graph"
@@ -173,3 +174,36 @@ func TestSmallWorldsBBDirected(t *testing.T) {
 		}
 	}
 }
+
+func TestGnpSize(t *testing.T) {
+	const (
+		n = 1000
+		p = 0.01
+	)
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err := Gnp(g, n, p, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The number of edges is binomially distributed.
+	pairs := float64(n * (n - 1) / 2)
+	mean := pairs * p
+	sd := math.Sqrt(pairs * p * (1 - p))
+	if got := float64(len(g.Edges())); math.Abs(got-mean) > 5*sd {
+		t.Errorf("unexpected number of edges: got:%v want:%v±%v", got, mean, 5*sd)
+	}
+}
+
+func TestGnmSize(t *testing.T) {
+	for _, m := range []int{0, 1, 100, 1000} {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		err := Gnm(g, 100, m, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatalf("unexpected error: m=%d: %v", m, err)
+		}
+		if got := len(g.Edges()); got != m {
+			t.Errorf("unexpected number of edges: got:%d want:%d", got, m)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// ConfigurationModel constructs a random graph in the destination, dst, with
+// the degree sequence given by seq, using stub matching. Nodes are given IDs
+// 0 to len(seq)-1 and node i has degree seq[i].
+//
+// If simpleGraph is true, seq must be graphical as determined by the
+// Erdős-Gallai theorem and the constructed graph has no self-loops or multiple
+// edges; these are removed from the initial stub matching by degree preserving
+// edge swaps. An error is returned if they cannot be removed after a bounded
+// number of swap attempts. If simpleGraph is false, the self-loops and multiple
+// edges of the stub matching are passed to dst, which must be able to represent
+// them for the degree sequence to be preserved.
+//
+// If src is not nil it is used as the random source, otherwise rand.Intn is used.
+//
+// The configuration model is described in doi:10.1137/S003614450342480.
+func ConfigurationModel(dst graph.UndirectedBuilder, seq []int, simpleGraph bool, src *rand.Rand) error {
+	var sum int
+	for i, d := range seq {
+		if d < 0 {
+			return fmt.Errorf("gen: bad degree: seq[%d]=%d", i, d)
+		}
+		sum += d
+	}
+	if sum%2 != 0 {
+		return fmt.Errorf("gen: odd degree sum: %d", sum)
+	}
+	if simpleGraph && !isGraphical(seq) {
+		return errors.New("gen: degree sequence is not graphical")
+	}
+
+	var rndN func(int) int
+	if src == nil {
+		rndN = rand.Intn
+	} else {
+		rndN = src.Intn
+	}
+
+	stubs := make([]int, 0, sum)
+	for u, d := range seq {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, u)
+		}
+	}
+	for i := len(stubs) - 1; i > 0; i-- {
+		j := rndN(i + 1)
+		stubs[i], stubs[j] = stubs[j], stubs[i]
+	}
+	edges := make([][2]int, len(stubs)/2)
+	for i := range edges {
+		edges[i] = [2]int{stubs[2*i], stubs[2*i+1]}
+	}
+
+	if simpleGraph {
+		err := simplify(edges, rndN)
+		if err != nil {
+			return err
+		}
+	}
+
+	for u := range seq {
+		if !dst.Has(simple.Node(u)) {
+			dst.AddNode(simple.Node(u))
+		}
+	}
+	for _, e := range edges {
+		dst.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+
+	return nil
+}
+
+// isGraphical returns whether the degree sequence seq can be realised by a
+// simple graph according to the Erdős-Gallai theorem.
+func isGraphical(seq []int) bool {
+	d := append([]int(nil), seq...)
+	sort.Sort(sort.Reverse(sort.IntSlice(d)))
+	n := len(d)
+	var sum int
+	for _, v := range d {
+		if v >= n {
+			return false
+		}
+		sum += v
+	}
+	if sum%2 != 0 {
+		return false
+	}
+	var left int
+	for k := 1; k <= n; k++ {
+		left += d[k-1]
+		right := k * (k - 1)
+		for _, v := range d[k:] {
+			if v < k {
+				right += v
+			} else {
+				right += k
+			}
+		}
+		if left > right {
+			return false
+		}
+	}
+	return true
+}
+
+// simplify removes self-loops and multiple edges from edges in place by degree
+// preserving edge swaps using rndN as the random source.
+func simplify(edges [][2]int, rndN func(int) int) error {
+	key := func(e [2]int) [2]int {
+		if e[0] > e[1] {
+			e[0], e[1] = e[1], e[0]
+		}
+		return e
+	}
+	count := make(map[[2]int]int, len(edges))
+	for _, e := range edges {
+		count[key(e)]++
+	}
+	isBad := func(e [2]int) bool {
+		return e[0] == e[1] || count[key(e)] > 1
+	}
+
+	limit := 100*len(edges) + 100
+	for attempt := 0; attempt < limit; attempt++ {
+		var bad []int
+		for i, e := range edges {
+			if isBad(e) {
+				bad = append(bad, i)
+			}
+		}
+		if len(bad) == 0 {
+			return nil
+		}
+		if len(edges) < 2 {
+			break
+		}
+
+		i := bad[rndN(len(bad))]
+		j := rndN(len(edges) - 1)
+		if j >= i {
+			j++
+		}
+		a, b := edges[i][0], edges[i][1]
+		c, d := edges[j][0], edges[j][1]
+		if rndN(2) == 0 {
+			c, d = d, c
+		}
+		e1, e2 := [2]int{a, c}, [2]int{b, d}
+		if a == c || b == d || key(e1) == key(e2) || count[key(e1)] != 0 || count[key(e2)] != 0 {
+			continue
+		}
+		count[key(edges[i])]--
+		count[key(edges[j])]--
+		count[key(e1)]++
+		count[key(e2)]++
+		edges[i], edges[j] = e1, e2
+	}
+	return errors.New("gen: failed to construct simple graph")
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var configurationModelTests = []struct {
+	name string
+	seq  []int
+}{
+	{name: "empty", seq: nil},
+	{name: "isolated", seq: []int{0, 0, 0}},
+	{name: "regular", seq: []int{3, 3, 3, 3, 3, 3, 3, 3}},
+	{name: "star", seq: []int{5, 1, 1, 1, 1, 1}},
+	{name: "complete", seq: []int{4, 4, 4, 4, 4}},
+	{name: "mixed", seq: []int{6, 5, 4, 4, 3, 3, 2, 2, 2, 1, 1, 1}},
+	{
+		name: "heavy tail",
+		seq: func() []int {
+			// Degrees from a preferential attachment graph.
+			g := simple.NewUndirectedGraph(0, math.Inf(1))
+			err := PreferentialAttachment(g, 200, 2, rand.New(rand.NewSource(1)))
+			if err != nil {
+				panic(err)
+			}
+			seq := make([]int, 200)
+			for _, u := range g.Nodes() {
+				seq[u.ID()] = len(g.From(u))
+			}
+			return seq
+		}(),
+	},
+}
+
+func TestConfigurationModel(t *testing.T) {
+	for _, test := range configurationModelTests {
+		g := &gnUndirected{UndirectedBuilder: simple.NewUndirectedGraph(0, math.Inf(1))}
+		err := ConfigurationModel(g, test.seq, true, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if g.addSelfLoop {
+			t.Errorf("unexpected self edge for %s", test.name)
+		}
+		if g.addMultipleEdge {
+			t.Errorf("unexpected multiple edge for %s", test.name)
+		}
+		if n := len(g.Nodes()); n != len(test.seq) {
+			t.Errorf("unexpected number of nodes for %s: got:%d want:%d", test.name, n, len(test.seq))
+		}
+		for _, u := range g.Nodes() {
+			if got, want := len(g.From(u)), test.seq[u.ID()]; got != want {
+				t.Errorf("unexpected degree for node %d of %s: got:%d want:%d", u.ID(), test.name, got, want)
+			}
+		}
+	}
+}
+
+// stubCounter is a graph.UndirectedBuilder that records node degrees
+// including self-loops and multiple edges.
+type stubCounter struct {
+	graph.UndirectedBuilder
+	degree map[int]int
+}
+
+func (g *stubCounter) SetEdge(e graph.Edge) {
+	g.degree[e.From().ID()]++
+	g.degree[e.To().ID()]++
+}
+
+func TestConfigurationModelMulti(t *testing.T) {
+	seq := []int{6, 5, 4, 4, 3, 3, 2, 2, 2, 1}
+	g := &stubCounter{UndirectedBuilder: simple.NewUndirectedGraph(0, math.Inf(1)), degree: make(map[int]int)}
+	err := ConfigurationModel(g, seq, false, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for u, want := range seq {
+		if got := g.degree[u]; got != want {
+			t.Errorf("unexpected stub count for node %d: got:%d want:%d", u, got, want)
+		}
+	}
+
+	// A self-loop only sequence is valid for multigraphs.
+	g = &stubCounter{UndirectedBuilder: simple.NewUndirectedGraph(0, math.Inf(1)), degree: make(map[int]int)}
+	err = ConfigurationModel(g, []int{2}, false, nil)
+	if err != nil {
+		t.Errorf("unexpected error for self-loop sequence: %v", err)
+	}
+}
+
+func TestConfigurationModelReproducible(t *testing.T) {
+	seq := []int{6, 5, 4, 4, 3, 3, 2, 2, 2, 1, 1, 1}
+	a := simple.NewUndirectedGraph(0, math.Inf(1))
+	b := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, g := range []*simple.UndirectedGraph{a, b} {
+		err := ConfigurationModel(g, seq, true, rand.New(rand.NewSource(7)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for u := range seq {
+		for v := u + 1; v < len(seq); v++ {
+			if a.HasEdgeBetween(simple.Node(u), simple.Node(v)) != b.HasEdgeBetween(simple.Node(u), simple.Node(v)) {
+				t.Fatalf("graphs differ at edge between %d and %d", u, v)
+			}
+		}
+	}
+}
+
+func TestConfigurationModelInvalid(t *testing.T) {
+	for _, seq := range [][]int{
+		{1, 1, 1},    // Odd sum.
+		{-1, 1},      // Negative degree.
+		{3, 3, 1, 1}, // Fails Erdős-Gallai.
+		{4, 2, 1, 1}, // Degree exceeds order.
+		{2},          // Self-loop only.
+	} {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		if err := ConfigurationModel(g, seq, true, nil); err == nil {
+			t.Errorf("expected error for sequence %v", seq)
+		}
+	}
+}
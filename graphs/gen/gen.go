@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package gen provides random graph generation functions.
+// Package gen provides random and structured graph generation functions.
 package gen
 
 import "github.com/gonum/graph"
@@ -6,6 +6,7 @@ package gen
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/gonum/graph/simple"
@@ -54,3 +55,32 @@ func TestPreferentialAttachment(t *testing.T) {
 		}
 	}
 }
+
+func TestPreferentialAttachmentDegrees(t *testing.T) {
+	const n, m = 2000, 2
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err := PreferentialAttachment(g, n, m, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := len(g.Edges()), m*(n-m); got != want {
+		t.Errorf("unexpected number of edges: got:%d want:%d", got, want)
+	}
+
+	// Every added node has degree at least m and the
+	// degree distribution is heavy-tailed, so the hubs
+	// have degrees far above the mean.
+	var max int
+	for _, u := range g.Nodes() {
+		d := len(g.From(u))
+		if u.ID() >= m && d < m {
+			t.Errorf("unexpected degree for node %d: got:%d want>=%d", u.ID(), d, m)
+		}
+		if d > max {
+			max = d
+		}
+	}
+	if mean := 2 * float64(m*(n-m)) / n; float64(max) < 10*mean {
+		t.Errorf("degree distribution not heavy-tailed: max:%d mean:%v", max, mean)
+	}
+}
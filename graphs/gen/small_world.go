@@ -202,3 +202,82 @@ func idFromDelta(base, delta, dims []int, translate int) int {
 	}
 	return id
 }
+
+// WattsStrogatz constructs a Watts-Strogatz small world graph of order n in the
+// destination, dst. The graph is constructed from a ring lattice where each node
+// is joined to its k nearest neighbours, k/2 on either side, by rewiring the
+// far end of each lattice edge to a uniformly chosen node with probability beta.
+// Rewiring does not create self-loops or multiple edges. The value of k must be
+// even and satisfy 0 < k < n. If src is not nil it is used as the random source,
+// otherwise rand.Float64 and rand.Intn are used. The number of edges in the
+// constructed graph is n*k/2.
+//
+// The algorithm used is described in doi:10.1038/30918.
+func WattsStrogatz(dst graph.UndirectedBuilder, n, k int, beta float64, src *rand.Rand) error {
+	if k <= 0 || k >= n || k%2 != 0 {
+		return fmt.Errorf("gen: bad degree: k=%d", k)
+	}
+	if beta < 0 || beta > 1 {
+		return fmt.Errorf("gen: bad probability: beta=%v", beta)
+	}
+	var (
+		rnd  func() float64
+		rndN func(int) int
+	)
+	if src == nil {
+		rnd = rand.Float64
+		rndN = rand.Intn
+	} else {
+		rnd = src.Float64
+		rndN = src.Intn
+	}
+
+	// Construct the ring lattice.
+	adj := make([]map[int]struct{}, n)
+	for u := range adj {
+		adj[u] = make(map[int]struct{})
+	}
+	for u := 0; u < n; u++ {
+		for j := 1; j <= k/2; j++ {
+			v := (u + j) % n
+			adj[u][v] = struct{}{}
+			adj[v][u] = struct{}{}
+		}
+	}
+
+	// Rewire lattice edges in order of increasing
+	// lattice distance.
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			v := (u + j) % n
+			if rnd() >= beta || len(adj[u]) == n-1 {
+				continue
+			}
+			w := rndN(n)
+			for _, exists := adj[u][w]; w == u || exists; _, exists = adj[u][w] {
+				w = rndN(n)
+			}
+			delete(adj[u], v)
+			delete(adj[v], u)
+			adj[u][w] = struct{}{}
+			adj[w][u] = struct{}{}
+		}
+	}
+
+	for u := 0; u < n; u++ {
+		if !dst.Has(simple.Node(u)) {
+			dst.AddNode(simple.Node(u))
+		}
+	}
+	for u := 0; u < n; u++ {
+		// Iterate in order so that construction
+		// is reproducible for a given source.
+		for v := u + 1; v < n; v++ {
+			if _, ok := adj[u][v]; ok {
+				dst.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+			}
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ package gen
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/gonum/graph/simple"
@@ -71,3 +72,75 @@ func TestNavigableSmallWorldDirected(t *testing.T) {
 		}
 	}
 }
+
+func TestWattsStrogatz(t *testing.T) {
+	for n := 3; n <= 20; n++ {
+		for k := 2; k < n; k += 2 {
+			for beta := 0.; beta <= 1; beta += 0.25 {
+				g := &gnUndirected{UndirectedBuilder: simple.NewUndirectedGraph(0, math.Inf(1))}
+				err := WattsStrogatz(g, n, k, beta, rand.New(rand.NewSource(int64(n*k))))
+				if err != nil {
+					t.Fatalf("unexpected error: n=%d, k=%d, beta=%v: %v", n, k, beta, err)
+				}
+				if g.addBackwards {
+					t.Errorf("edge added with From.ID > To.ID: n=%d, k=%d, beta=%v", n, k, beta)
+				}
+				if g.addSelfLoop {
+					t.Errorf("unexpected self edge: n=%d, k=%d, beta=%v", n, k, beta)
+				}
+				if g.addMultipleEdge {
+					t.Errorf("unexpected multiple edge: n=%d, k=%d, beta=%v", n, k, beta)
+				}
+				if got, want := len(g.UndirectedBuilder.(*simple.UndirectedGraph).Edges()), n*k/2; got != want {
+					t.Errorf("unexpected number of edges: n=%d, k=%d, beta=%v: got:%d want:%d", n, k, beta, got, want)
+				}
+			}
+		}
+	}
+
+	for _, k := range []int{0, 3, 10} {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		if err := WattsStrogatz(g, 10, k, 0.5, nil); err == nil {
+			t.Errorf("expected error for k=%d", k)
+		}
+	}
+}
+
+func TestWattsStrogatzLattice(t *testing.T) {
+	const n, k = 20, 4
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	err := WattsStrogatz(g, n, k, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			d := v - u
+			if n-d < d {
+				d = n - d
+			}
+			if want := d <= k/2; g.HasEdgeBetween(simple.Node(u), simple.Node(v)) != want {
+				t.Errorf("unexpected lattice edge state between %d and %d: got:%t want:%t", u, v, !want, want)
+			}
+		}
+	}
+}
+
+func TestWattsStrogatzReproducible(t *testing.T) {
+	const n, k, beta = 50, 6, 0.3
+	a := simple.NewUndirectedGraph(0, math.Inf(1))
+	b := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, g := range []*simple.UndirectedGraph{a, b} {
+		err := WattsStrogatz(g, n, k, beta, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			if a.HasEdgeBetween(simple.Node(u), simple.Node(v)) != b.HasEdgeBetween(simple.Node(u), simple.Node(v)) {
+				t.Fatalf("graphs differ at edge between %d and %d", u, v)
+			}
+		}
+	}
+}
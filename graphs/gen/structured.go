@@ -0,0 +1,126 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"fmt"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// The functions in this file construct graphs with fixed structure. When
+// the destination graph is directed, edges are directed from the node with
+// the lower ID to the node with the higher ID unless otherwise noted.
+
+// Path constructs a path graph of order n in the destination, dst. Nodes
+// are given IDs 0 to n-1 in path order.
+func Path(dst GraphBuilder, n int) error {
+	if n < 0 {
+		return fmt.Errorf("gen: bad order: n=%d", n)
+	}
+	addNodes(dst, n)
+	for i := 1; i < n; i++ {
+		dst.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i), W: 1})
+	}
+	return nil
+}
+
+// Cycle constructs a cycle graph of order n in the destination, dst. Nodes
+// are given IDs 0 to n-1 in cycle order. When dst is directed the cycle is
+// directed from node i to node i+1 mod n. The value of n must be at least 3.
+func Cycle(dst GraphBuilder, n int) error {
+	if n < 3 {
+		return fmt.Errorf("gen: bad order: n=%d", n)
+	}
+	addNodes(dst, n)
+	for i := 0; i < n; i++ {
+		dst.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % n), W: 1})
+	}
+	return nil
+}
+
+// Star constructs a star graph of order n in the destination, dst. The centre
+// of the star is given ID 0 and the leaves IDs 1 to n-1.
+func Star(dst GraphBuilder, n int) error {
+	if n < 0 {
+		return fmt.Errorf("gen: bad order: n=%d", n)
+	}
+	addNodes(dst, n)
+	for i := 1; i < n; i++ {
+		dst.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(i), W: 1})
+	}
+	return nil
+}
+
+// Complete constructs a complete graph of order n in the destination, dst.
+// Nodes are given IDs 0 to n-1. When dst is directed edges are added in both
+// directions between each pair of nodes.
+func Complete(dst GraphBuilder, n int) error {
+	if n < 0 {
+		return fmt.Errorf("gen: bad order: n=%d", n)
+	}
+	addNodes(dst, n)
+	_, isDirected := dst.(graph.Directed)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			dst.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j), W: 1})
+			if isDirected {
+				dst.SetEdge(simple.Edge{F: simple.Node(j), T: simple.Node(i), W: 1})
+			}
+		}
+	}
+	return nil
+}
+
+// Grid constructs a rows×cols grid graph in the destination, dst. The node at
+// row r and column c is given the ID r*cols+c, and is joined to the nodes
+// immediately before and after it in its row and column.
+func Grid(dst GraphBuilder, rows, cols int) error {
+	if rows < 0 || cols < 0 {
+		return fmt.Errorf("gen: bad dimensions: rows=%d cols=%d", rows, cols)
+	}
+	addNodes(dst, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			u := simple.Node(r*cols + c)
+			if c+1 < cols {
+				dst.SetEdge(simple.Edge{F: u, T: u + 1, W: 1})
+			}
+			if r+1 < rows {
+				dst.SetEdge(simple.Edge{F: u, T: u + simple.Node(cols), W: 1})
+			}
+		}
+	}
+	return nil
+}
+
+// Hypercube constructs a d-dimensional hypercube graph of order 2^d in the
+// destination, dst. Nodes are given IDs 0 to 2^d-1 and two nodes are joined
+// when the binary representations of their IDs differ in exactly one bit.
+func Hypercube(dst GraphBuilder, d int) error {
+	if d < 0 || d >= 30 {
+		return fmt.Errorf("gen: bad dimension: d=%d", d)
+	}
+	n := 1 << uint(d)
+	addNodes(dst, n)
+	for u := 0; u < n; u++ {
+		for b := uint(0); b < uint(d); b++ {
+			if v := u | 1<<b; v != u {
+				dst.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+			}
+		}
+	}
+	return nil
+}
+
+// addNodes adds nodes with IDs 0 to n-1 to dst if they are not already present.
+func addNodes(dst GraphBuilder, n int) {
+	for i := 0; i < n; i++ {
+		if !dst.Has(simple.Node(i)) {
+			dst.AddNode(simple.Node(i))
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+var structuredTests = []struct {
+	name  string
+	build func(GraphBuilder) error
+
+	nodes, edges int
+	// degree returns the expected
+	// degree of node id.
+	degree func(id int) int
+}{
+	{
+		name: "path", build: func(dst GraphBuilder) error { return Path(dst, 5) },
+		nodes: 5, edges: 4,
+		degree: func(id int) int {
+			if id == 0 || id == 4 {
+				return 1
+			}
+			return 2
+		},
+	},
+	{
+		name: "cycle", build: func(dst GraphBuilder) error { return Cycle(dst, 6) },
+		nodes: 6, edges: 6,
+		degree: func(int) int { return 2 },
+	},
+	{
+		name: "star", build: func(dst GraphBuilder) error { return Star(dst, 6) },
+		nodes: 6, edges: 5,
+		degree: func(id int) int {
+			if id == 0 {
+				return 5
+			}
+			return 1
+		},
+	},
+	{
+		name: "complete", build: func(dst GraphBuilder) error { return Complete(dst, 6) },
+		nodes: 6, edges: 15,
+		degree: func(int) int { return 5 },
+	},
+	{
+		name: "grid", build: func(dst GraphBuilder) error { return Grid(dst, 3, 4) },
+		nodes: 12, edges: 17,
+		degree: func(id int) int {
+			r, c := id/4, id%4
+			d := 4
+			if r == 0 || r == 2 {
+				d--
+			}
+			if c == 0 || c == 3 {
+				d--
+			}
+			return d
+		},
+	},
+	{
+		name: "hypercube", build: func(dst GraphBuilder) error { return Hypercube(dst, 4) },
+		nodes: 16, edges: 32,
+		degree: func(int) int { return 4 },
+	},
+}
+
+func TestStructured(t *testing.T) {
+	for _, test := range structuredTests {
+		g := &gnUndirected{UndirectedBuilder: simple.NewUndirectedGraph(0, math.Inf(1))}
+		err := test.build(g)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.name, err)
+		}
+		if g.addSelfLoop {
+			t.Errorf("unexpected self edge for %s", test.name)
+		}
+		if g.addMultipleEdge {
+			t.Errorf("unexpected multiple edge for %s", test.name)
+		}
+		u := g.UndirectedBuilder.(*simple.UndirectedGraph)
+		if n := len(u.Nodes()); n != test.nodes {
+			t.Errorf("unexpected number of nodes for %s: got:%d want:%d", test.name, n, test.nodes)
+		}
+		if m := len(u.Edges()); m != test.edges {
+			t.Errorf("unexpected number of edges for %s: got:%d want:%d", test.name, m, test.edges)
+		}
+		for _, n := range u.Nodes() {
+			if got, want := len(u.From(n)), test.degree(n.ID()); got != want {
+				t.Errorf("unexpected degree for node %d of %s: got:%d want:%d", n.ID(), test.name, got, want)
+			}
+		}
+
+		d := &gnDirected{DirectedBuilder: simple.NewDirectedGraph(0, math.Inf(1))}
+		err = test.build(d)
+		if err != nil {
+			t.Fatalf("unexpected error for directed %s: %v", test.name, err)
+		}
+		if d.addSelfLoop {
+			t.Errorf("unexpected self edge for directed %s", test.name)
+		}
+		if d.addMultipleEdge {
+			t.Errorf("unexpected multiple edge for directed %s", test.name)
+		}
+	}
+}
+
+func TestStructuredLayout(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	if err := Grid(g, 3, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Node at row 1, column 2 is joined to
+	// its row and column neighbours.
+	for _, v := range []int{5, 7, 2, 10} {
+		if !g.HasEdgeBetween(simple.Node(1*4+2), simple.Node(v)) {
+			t.Errorf("missing grid edge between 6 and %d", v)
+		}
+	}
+
+	h := simple.NewUndirectedGraph(0, math.Inf(1))
+	if err := Hypercube(h, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !h.HasEdgeBetween(simple.Node(5), simple.Node(7)) || h.HasEdgeBetween(simple.Node(5), simple.Node(6)) {
+		t.Error("unexpected hypercube adjacency for node 5")
+	}
+
+	c := simple.NewDirectedGraph(0, math.Inf(1))
+	if err := Cycle(c, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.HasEdgeFromTo(simple.Node(3), simple.Node(0)) || c.HasEdgeFromTo(simple.Node(0), simple.Node(3)) {
+		t.Error("unexpected direction for closing edge of directed cycle")
+	}
+
+	k := simple.NewDirectedGraph(0, math.Inf(1))
+	if err := Complete(k, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(k.Edges()) != 12 {
+		t.Errorf("unexpected number of edges for directed complete graph: got:%d want:12", len(k.Edges()))
+	}
+
+	for _, err := range []error{
+		Path(g, -1),
+		Cycle(g, 2),
+		Star(g, -1),
+		Complete(g, -1),
+		Grid(g, -1, 2),
+		Hypercube(g, -1),
+	} {
+		if err == nil {
+			t.Error("expected error for bad parameter")
+		}
+	}
+}
@@ -0,0 +1,593 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+const (
+	Closed = '*' // Closed is the closed grid node representation.
+	Open   = '.' // Open is the open grid node repesentation.
+)
+
+// Grid is a 2D grid planar undirected graph.
+//
+// Each open node of the grid has a movement cost, which is 1 unless
+// set otherwise by SetCost. The weight of an edge is the cost of its
+// destination node multiplied by the length of the step between the
+// nodes, so edge weights depend on the direction of travel.
+type Grid struct {
+	// AllowDiagonal specifies whether
+	// diagonally adjacent nodes can
+	// be connected by an edge.
+	AllowDiagonal bool
+	// UnitEdgeWeight specifies whether
+	// finite edge weights are returned as
+	// the unit length. Otherwise edge
+	// weights are the Euclidean distance
+	// between connected nodes.
+	UnitEdgeWeight bool
+	// DiagonalLength specifies the length
+	// of a diagonal step. If DiagonalLength
+	// is zero, the length is determined by
+	// UnitEdgeWeight.
+	DiagonalLength float64
+	// AllowCornerCutting specifies whether
+	// a diagonal edge may pass between
+	// orthogonally adjacent nodes that are
+	// not open. By default a diagonal edge
+	// requires both of the orthogonally
+	// adjacent nodes that it passes between
+	// to be open.
+	AllowCornerCutting bool
+
+	// WrapHorizontal and WrapVertical
+	// specify whether the first and last
+	// columns, and the first and last rows,
+	// respectively are adjacent, making the
+	// grid a cylinder or torus.
+	WrapHorizontal bool
+	WrapVertical   bool
+
+	// AllVisible specifies whether
+	// non-open nodes are visible
+	// in calls to Nodes and HasNode.
+	AllVisible bool
+
+	open []bool
+	r, c int
+
+	// cost holds the movement cost of each
+	// node. A nil cost indicates that all
+	// nodes have unit cost.
+	cost []float64
+
+	// mu protects the cached minimum
+	// cost and region labels below, which
+	// are computed lazily by methods that
+	// may be called concurrently.
+	mu sync.Mutex
+
+	// minCost is the minimum cost of the
+	// open nodes of the grid, valid when
+	// minValid is true.
+	minCost  float64
+	minValid bool
+
+	// regions holds the region label of each
+	// node, or -1 for closed nodes. It is valid
+	// when non-nil and regionsFor matches the
+	// current connectivity of the grid.
+	regions    []int
+	regionsFor connectivity
+}
+
+// NewGrid returns an r by c grid with all positions
+// set to the specified open state.
+func NewGrid(r, c int, open bool) *Grid {
+	states := make([]bool, r*c)
+	if open {
+		for i := range states {
+			states[i] = true
+		}
+	}
+	return &Grid{
+		open: states,
+		r:    r,
+		c:    c,
+	}
+}
+
+// NewGridFrom returns a grid specified by the rows strings. All rows must
+// be the same length and must only contain the Open or Closed characters
+// or the digits '1' to '9', NewGridFrom will panic otherwise. A digit
+// specifies an open node with the movement cost of the digit's value.
+func NewGridFrom(rows ...string) *Grid {
+	if len(rows) == 0 {
+		return nil
+	}
+	for i, r := range rows[:len(rows)-1] {
+		if len(r) != len(rows[i+1]) {
+			panic("grid: unequal row lengths")
+		}
+	}
+	states := make([]bool, 0, len(rows)*len(rows[0]))
+	var cost []float64
+	for _, r := range rows {
+		for _, b := range r {
+			switch {
+			case b == Closed:
+				states = append(states, false)
+			case b == Open:
+				states = append(states, true)
+			case '1' <= b && b <= '9':
+				if cost == nil {
+					cost = make([]float64, cap(states))
+					for i := range cost {
+						cost[i] = 1
+					}
+				}
+				cost[len(states)] = float64(b - '0')
+				states = append(states, true)
+			default:
+				panic(fmt.Sprintf("grid: invalid state: %q", r))
+			}
+		}
+	}
+	return &Grid{
+		open: states,
+		r:    len(rows),
+		c:    len(rows[0]),
+		cost: cost,
+	}
+}
+
+// Nodes returns all the open nodes in the grid if AllVisible is
+// false, otherwise all nodes are returned.
+func (g *Grid) Nodes() []graph.Node {
+	var nodes []graph.Node
+	for id, ok := range g.open {
+		if ok || g.AllVisible {
+			nodes = append(nodes, simple.Node(id))
+		}
+	}
+	return nodes
+}
+
+// Has returns whether n is a node in the grid. The state of
+// the AllVisible field determines whether a non-open node is
+// present.
+func (g *Grid) Has(n graph.Node) bool {
+	return g.has(n.ID())
+}
+
+func (g *Grid) has(id int) bool {
+	return id >= 0 && id < len(g.open) && (g.AllVisible || g.open[id])
+}
+
+// HasOpen returns whether n is an open node in the grid.
+func (g *Grid) HasOpen(n graph.Node) bool {
+	id := n.ID()
+	return id >= 0 && id < len(g.open) && g.open[id]
+}
+
+// Set sets the node at position (r, c) to the specified open state.
+func (g *Grid) Set(r, c int, open bool) {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	g.open[r*g.c+c] = open
+	g.mu.Lock()
+	g.minValid = false
+	g.regions = nil
+	g.mu.Unlock()
+}
+
+// SetCost sets the movement cost of the node at position (r, c). SetCost
+// will panic if cost is negative, infinite or NaN.
+func (g *Grid) SetCost(r, c int, cost float64) {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	if !(cost >= 0) || math.IsInf(cost, 1) {
+		panic("grid: illegal cost")
+	}
+	if g.cost == nil {
+		if cost == 1 {
+			return
+		}
+		g.cost = make([]float64, len(g.open))
+		for i := range g.cost {
+			g.cost[i] = 1
+		}
+	}
+	g.cost[r*g.c+c] = cost
+	g.mu.Lock()
+	g.minValid = false
+	g.mu.Unlock()
+}
+
+// Cost returns the movement cost of the node at position (r, c). Cost
+// will panic if the position is outside the grid.
+func (g *Grid) Cost(r, c int) float64 {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	return g.costOf(r*g.c + c)
+}
+
+func (g *Grid) costOf(id int) float64 {
+	if g.cost == nil {
+		return 1
+	}
+	return g.cost[id]
+}
+
+// MinCost returns the minimum movement cost of the open nodes in the
+// grid. If the grid has no open nodes, MinCost returns zero.
+func (g *Grid) MinCost() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.minValid {
+		return g.minCost
+	}
+	min := math.Inf(1)
+	for id, ok := range g.open {
+		if ok {
+			min = math.Min(min, g.costOf(id))
+		}
+	}
+	if math.IsInf(min, 1) {
+		min = 0
+	}
+	g.minCost = min
+	g.minValid = true
+	return min
+}
+
+// Heuristic returns an admissible, consistent estimate of the
+// weight of the shortest path from x to y. The estimate is the
+// Manhattan distance between the nodes when diagonal moves are
+// not allowed, and the Octile distance when they are, scaled by
+// the minimum movement cost of the grid. Heuristic will panic if
+// either node ID is outside the range of the grid.
+func (g *Grid) Heuristic(x, y graph.Node) float64 {
+	if !g.AllowDiagonal {
+		dr, dc := g.separation(x, y)
+		return float64(dr+dc) * g.MinCost()
+	}
+	return g.Octile(x, y) * g.MinCost()
+}
+
+// Octile returns the length of the shortest path from x to y in an
+// unobstructed 8-connected grid with the diagonal step length and
+// wrapping of g. If the diagonal step is shorter than an orthogonal
+// step, Octile returns a lower bound on that length, the length of a
+// path of diagonal steps between the nodes. Octile does not take into
+// account movement costs or the value of AllowDiagonal. Octile will
+// panic if either node ID is outside the range of the grid.
+func (g *Grid) Octile(x, y graph.Node) float64 {
+	r, c := g.separation(x, y)
+	dr := float64(r)
+	dc := float64(c)
+
+	d := g.diagonalLength()
+	if d < 1 {
+		// Every step, orthogonal or diagonal,
+		// costs at least d and reduces each
+		// separation by at most one.
+		return d * math.Max(dr, dc)
+	}
+	// A diagonal step longer than two orthogonal
+	// steps is never part of a shortest path.
+	d = math.Min(d, 2)
+	return math.Max(dr, dc) + (d-1)*math.Min(dr, dc)
+}
+
+// separation returns the number of rows and columns between x and y,
+// taking the shorter way around wrapped dimensions.
+func (g *Grid) separation(x, y graph.Node) (dr, dc int) {
+	xr, xc := g.RowCol(x.ID())
+	yr, yc := g.RowCol(y.ID())
+	dr = abs(xr - yr)
+	dc = abs(xc - yc)
+	if g.WrapVertical && g.r-dr < dr {
+		dr = g.r - dr
+	}
+	if g.WrapHorizontal && g.c-dc < dc {
+		dc = g.c - dc
+	}
+	return dr, dc
+}
+
+// diagonalLength returns the length of a diagonal step.
+func (g *Grid) diagonalLength() float64 {
+	switch {
+	case g.DiagonalLength != 0:
+		return g.DiagonalLength
+	case g.UnitEdgeWeight:
+		return 1
+	default:
+		return math.Sqrt2
+	}
+}
+
+// Dims returns the dimensions of the grid.
+func (g *Grid) Dims() (r, c int) {
+	return g.r, g.c
+}
+
+// RowCol returns the row and column of the id. RowCol will panic if the
+// node id is outside the range of the grid.
+func (g *Grid) RowCol(id int) (r, c int) {
+	if id < 0 || id >= len(g.open) {
+		panic("grid: illegal node id")
+	}
+	return id / g.c, id % g.c
+}
+
+// XY returns the cartesian coordinates of n. If n is not a node
+// in the grid, (NaN, NaN) is returned.
+func (g *Grid) XY(n graph.Node) (x, y float64) {
+	if !g.Has(n) {
+		return math.NaN(), math.NaN()
+	}
+	r, c := g.RowCol(n.ID())
+	return float64(c), float64(r)
+}
+
+// NodeAt returns the node at (r, c). The returned node may be open or closed.
+func (g *Grid) NodeAt(r, c int) graph.Node {
+	if r < 0 || r >= g.r || c < 0 || c >= g.c {
+		return nil
+	}
+	return simple.Node(r*g.c + c)
+}
+
+// wrappedAt returns the node at (r, c) after wrapping r and c according
+// to WrapVertical and WrapHorizontal, or nil if the wrapped position is
+// outside the grid.
+func (g *Grid) wrappedAt(r, c int) graph.Node {
+	if g.WrapVertical {
+		r = (r%g.r + g.r) % g.r
+	}
+	if g.WrapHorizontal {
+		c = (c%g.c + g.c) % g.c
+	}
+	return g.NodeAt(r, c)
+}
+
+// From returns all the nodes reachable from u. Reachabilty requires that both
+// ends of an edge must be open.
+func (g *Grid) From(u graph.Node) []graph.Node {
+	var to []graph.Node
+	g.VisitFrom(u, func(v graph.Node, _ float64) bool {
+		to = append(to, v)
+		return true
+	})
+	return to
+}
+
+// VisitFrom calls fn with each node reachable from u and the weight of the
+// joining edge until fn returns false.
+func (g *Grid) VisitFrom(u graph.Node, fn func(v graph.Node, w float64) bool) {
+	if !g.HasOpen(u) {
+		return
+	}
+	nr, nc := g.RowCol(u.ID())
+
+	// seen holds the nodes already visited, since
+	// a wrapped grid with fewer than three rows or
+	// columns reaches some neighbors more than once.
+	var seen [8]int
+	var n int
+outer:
+	for r := nr - 1; r <= nr+1; r++ {
+		for c := nc - 1; c <= nc+1; c++ {
+			v := g.wrappedAt(r, c)
+			if v == nil || v.ID() == u.ID() {
+				continue
+			}
+			id := v.ID()
+			for _, s := range seen[:n] {
+				if s == id {
+					continue outer
+				}
+			}
+			seen[n] = id
+			n++
+			vr, vc := g.RowCol(id)
+			diagonal := r != nr && c != nc
+			if !g.open[id] || (diagonal && !g.canMoveDiagonally(nr, nc, vr, vc)) {
+				continue
+			}
+			if !fn(v, g.weight(id, diagonal)) {
+				return
+			}
+		}
+	}
+}
+
+// EdgesAround returns the directed edges whose weight depends on the
+// state or cost of the node at (r, c), whether or not the edges are
+// currently in the grid. Absent edges are returned with an infinite
+// weight. The returned edges can be used to notify a dynamic path
+// planner of a change to the node. EdgesAround will panic if (r, c) is
+// outside the grid.
+func (g *Grid) EdgesAround(r, c int) []graph.Edge {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	var edges []graph.Edge
+	seen := make(map[[2]int]bool)
+	pair := func(u, v graph.Node) {
+		if u == nil || v == nil || u.ID() == v.ID() || seen[[2]int{u.ID(), v.ID()}] {
+			return
+		}
+		seen[[2]int{u.ID(), v.ID()}] = true
+		seen[[2]int{v.ID(), u.ID()}] = true
+		for _, e := range [2][2]graph.Node{{u, v}, {v, u}} {
+			w, _ := g.Weight(e[0], e[1])
+			edges = append(edges, simple.Edge{F: e[0], T: e[1], W: w})
+		}
+	}
+	n := g.NodeAt(r, c)
+	for nr := r - 1; nr <= r+1; nr++ {
+		for nc := c - 1; nc <= c+1; nc++ {
+			if (nr == r && nc == c) || (nr != r && nc != c && !g.AllowDiagonal) {
+				continue
+			}
+			pair(n, g.wrappedAt(nr, nc))
+		}
+	}
+	if g.AllowDiagonal && !g.AllowCornerCutting {
+		// Diagonal moves between the orthogonal
+		// neighbors of n pass by n.
+		for _, dr := range []int{-1, 1} {
+			for _, dc := range []int{-1, 1} {
+				pair(g.wrappedAt(r+dr, c), g.wrappedAt(r, c+dc))
+			}
+		}
+	}
+	return edges
+}
+
+// HasEdgeBetween returns whether there is an edge between u and v.
+func (g *Grid) HasEdgeBetween(u, v graph.Node) bool {
+	if !g.HasOpen(u) || !g.HasOpen(v) || u.ID() == v.ID() {
+		return false
+	}
+	dr, dc := g.separation(u, v)
+	if dr > 1 || dc > 1 {
+		return false
+	}
+	ur, uc := g.RowCol(u.ID())
+	vr, vc := g.RowCol(v.ID())
+	return ur == vr || uc == vc || g.canMoveDiagonally(ur, uc, vr, vc)
+}
+
+// canMoveDiagonally returns whether a diagonal step between the
+// adjacent positions (ur, uc) and (vr, vc) is allowed.
+func (g *Grid) canMoveDiagonally(ur, uc, vr, vc int) bool {
+	if !g.AllowDiagonal {
+		return false
+	}
+	return g.AllowCornerCutting || (g.open[ur*g.c+vc] && g.open[vr*g.c+uc])
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Edge returns the edge between u and v.
+func (g *Grid) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between u and v. The weight of the
+// returned edge is the weight of travel from u to v.
+func (g *Grid) EdgeBetween(u, v graph.Node) graph.Edge {
+	if w, ok := g.Weight(u, v); ok && u.ID() != v.ID() {
+		return simple.Edge{F: u, T: v, W: w}
+	}
+	return nil
+}
+
+// Weight returns the weight of travel from x to y.
+func (g *Grid) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	if !g.HasEdgeBetween(x, y) {
+		return math.Inf(1), false
+	}
+	xr, xc := g.RowCol(x.ID())
+	yr, yc := g.RowCol(y.ID())
+	return g.weight(y.ID(), xr != yr && xc != yc), true
+}
+
+// weight returns the weight of a step to the node with the given
+// id, taking into account whether the step is diagonal.
+func (g *Grid) weight(id int, diagonal bool) float64 {
+	w := g.costOf(id)
+	if diagonal {
+		w *= g.diagonalLength()
+	}
+	return w
+}
+
+// String returns a string representation of the grid.
+func (g *Grid) String() string {
+	b, _ := g.Render(nil)
+	return string(b)
+}
+
+// Render returns a text representation of the graph
+// with the given path included. If the path is not a path
+// in the grid Render returns a non-nil error and the
+// path up to that point.
+func (g *Grid) Render(path []graph.Node) ([]byte, error) {
+	b := make([]byte, g.r*(g.c+1)-1)
+	for r := 0; r < g.r; r++ {
+		for c := 0; c < g.c; c++ {
+			if g.open[r*g.c+c] {
+				b[r*(g.c+1)+c] = Open
+				if cost := g.costOf(r*g.c + c); 1 < cost && cost <= 9 && cost == math.Trunc(cost) {
+					b[r*(g.c+1)+c] = '0' + byte(cost)
+				}
+			} else {
+				b[r*(g.c+1)+c] = Closed
+			}
+		}
+		if r < g.r-1 {
+			b[r*(g.c+1)+g.c] = '\n'
+		}
+	}
+
+	// We don't use topo.IsPathIn at the outset because we
+	// want to draw as much as possible before failing.
+	for i, n := range path {
+		if !g.Has(n) || (i != 0 && !g.HasEdgeBetween(path[i-1], n)) {
+			id := n.ID()
+			if id >= 0 && id < len(g.open) {
+				r, c := g.RowCol(n.ID())
+				b[r*(g.c+1)+c] = '!'
+			}
+			return b, errors.New("grid: not a path in graph")
+		}
+		r, c := g.RowCol(n.ID())
+		switch i {
+		case len(path) - 1:
+			b[r*(g.c+1)+c] = 'G'
+		case 0:
+			b[r*(g.c+1)+c] = 'S'
+		default:
+			b[r*(g.c+1)+c] = 'o'
+		}
+	}
+	return b, nil
+}
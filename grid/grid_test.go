@@ -0,0 +1,679 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var (
+	_ graph.Graph       = (*Grid)(nil)
+	_ graph.FromVisitor = (*Grid)(nil)
+)
+
+func join(g ...string) string { return strings.Join(g, "\n") }
+
+type node int
+
+func (n node) ID() int { return int(n) }
+
+func TestGrid(t *testing.T) {
+	g := NewGrid(4, 4, false)
+
+	got := g.String()
+	want := join(
+		"****",
+		"****",
+		"****",
+		"****",
+	)
+	if got != want {
+		t.Fatalf("unexpected grid rendering:\ngot: %q\nwant:%q", got, want)
+	}
+
+	var ops = []struct {
+		r, c  int
+		state bool
+		want  string
+	}{
+		{
+			r: 0, c: 1,
+			state: true,
+			want: join(
+				"*.**",
+				"****",
+				"****",
+				"****",
+			),
+		},
+		{
+			r: 0, c: 1,
+			state: false,
+			want: join(
+				"****",
+				"****",
+				"****",
+				"****",
+			),
+		},
+		{
+			r: 0, c: 1,
+			state: true,
+			want: join(
+				"*.**",
+				"****",
+				"****",
+				"****",
+			),
+		},
+		{
+			r: 0, c: 2,
+			state: true,
+			want: join(
+				"*..*",
+				"****",
+				"****",
+				"****",
+			),
+		},
+		{
+			r: 1, c: 2,
+			state: true,
+			want: join(
+				"*..*",
+				"**.*",
+				"****",
+				"****",
+			),
+		},
+		{
+			r: 2, c: 2,
+			state: true,
+			want: join(
+				"*..*",
+				"**.*",
+				"**.*",
+				"****",
+			),
+		},
+		{
+			r: 3, c: 2,
+			state: true,
+			want: join(
+				"*..*",
+				"**.*",
+				"**.*",
+				"**.*",
+			),
+		},
+	}
+	for _, test := range ops {
+		g.Set(test.r, test.c, test.state)
+		got := g.String()
+		if got != test.want {
+			t.Fatalf("unexpected grid rendering after set (%d, %d) open state to %t:\ngot: %q\nwant:%q",
+				test.r, test.c, test.state, got, test.want)
+		}
+	}
+
+	// Match the last state from the loop against the
+	// explicit description of the grid.
+	got = NewGridFrom(
+		"*..*",
+		"**.*",
+		"**.*",
+		"**.*",
+	).String()
+	want = g.String()
+	if got != want {
+		t.Fatalf("unexpected grid rendering from NewGridFrom:\ngot: %q\nwant:%q", got, want)
+	}
+
+	var paths = []struct {
+		path     []graph.Node
+		diagonal bool
+		want     string
+	}{
+		{
+			path:     nil,
+			diagonal: false,
+			want: join(
+				"*..*",
+				"**.*",
+				"**.*",
+				"**.*",
+			),
+		},
+		{
+			path:     []graph.Node{node(1), node(2), node(6), node(10), node(14)},
+			diagonal: false,
+			want: join(
+				"*So*",
+				"**o*",
+				"**o*",
+				"**G*",
+			),
+		},
+		{
+			path:     []graph.Node{node(1), node(6), node(10), node(14)},
+			diagonal: false,
+			want: join(
+				"*S.*",
+				"**!*",
+				"**.*",
+				"**.*",
+			),
+		},
+		{
+			path:     []graph.Node{node(1), node(6), node(10), node(14)},
+			diagonal: true,
+			want: join(
+				"*S.*",
+				"**o*",
+				"**o*",
+				"**G*",
+			),
+		},
+		{
+			path:     []graph.Node{node(1), node(5), node(9)},
+			diagonal: false,
+			want: join(
+				"*S.*",
+				"*!.*",
+				"**.*",
+				"**.*",
+			),
+		},
+	}
+	// The diagonal path passes between
+	// closed nodes, cutting a corner.
+	g.AllowCornerCutting = true
+	for _, test := range paths {
+		g.AllowDiagonal = test.diagonal
+		got, err := g.Render(test.path)
+		errored := err != nil
+		if bytes.Contains(got, []byte{'!'}) != errored {
+			t.Fatalf("unexpected error return: got:%v want:%v", err, errors.New("grid: not a path in graph"))
+		}
+		if string(got) != test.want {
+			t.Fatalf("unexpected grid path rendering for %v:\ngot: %q\nwant:%q", test.path, got, want)
+		}
+	}
+
+	var coords = []struct {
+		r, c int
+		id   int
+	}{
+		{r: 0, c: 0, id: 0},
+		{r: 0, c: 3, id: 3},
+		{r: 3, c: 0, id: 12},
+		{r: 3, c: 3, id: 15},
+	}
+	for _, test := range coords {
+		if id := g.NodeAt(test.r, test.c).ID(); id != test.id {
+			t.Fatalf("unexpected ID for node at (%d, %d):\ngot: %d\nwant:%d", test.r, test.c, id, test.id)
+		}
+		if r, c := g.RowCol(test.id); r != test.r || c != test.c {
+			t.Fatalf("unexpected row/col for node %d:\ngot: (%d, %d)\nwant:(%d, %d)", test.id, r, c, test.r, test.c)
+		}
+	}
+
+	var reach = []struct {
+		from     graph.Node
+		diagonal bool
+		to       []graph.Node
+	}{
+		{
+			from:     node(0),
+			diagonal: false,
+			to:       nil,
+		},
+		{
+			from:     node(2),
+			diagonal: false,
+			to:       []graph.Node{simple.Node(1), simple.Node(6)},
+		},
+		{
+			from:     node(1),
+			diagonal: false,
+			to:       []graph.Node{simple.Node(2)},
+		},
+		{
+			from:     node(1),
+			diagonal: true,
+			to:       []graph.Node{simple.Node(2), simple.Node(6)},
+		},
+	}
+	for _, test := range reach {
+		g.AllowDiagonal = test.diagonal
+		got := g.From(test.from)
+		if !reflect.DeepEqual(got, test.to) {
+			t.Fatalf("unexpected nodes from %d with allow diagonal=%t:\ngot: %v\nwant:%v",
+				test.from, test.diagonal, got, test.to)
+		}
+	}
+}
+
+func TestGridVisitFrom(t *testing.T) {
+	for _, diagonal := range []bool{false, true} {
+		for _, unit := range []bool{false, true} {
+			g := NewGridFrom(
+				"*.3*",
+				"**.*",
+				"..2.",
+				".*9.",
+			)
+			g.AllowDiagonal = diagonal
+			g.UnitEdgeWeight = unit
+			for id := 0; id < 16; id++ {
+				u := simple.Node(id)
+				var got []graph.Node
+				g.VisitFrom(u, func(v graph.Node, w float64) bool {
+					got = append(got, v)
+					if want, _ := g.Weight(u, v); w != want {
+						t.Errorf("unexpected weight from %d to %d with diagonal=%t unit=%t: got:%v want:%v",
+							id, v.ID(), diagonal, unit, w, want)
+					}
+					return true
+				})
+				if want := g.From(u); !reflect.DeepEqual(got, want) {
+					t.Errorf("unexpected nodes visited from %d with diagonal=%t unit=%t:\ngot: %v\nwant:%v",
+						id, diagonal, unit, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGridCost(t *testing.T) {
+	g := NewGridFrom(
+		"1.3*",
+		"**.*",
+		"..2.",
+		".*9.",
+	)
+	want := join(
+		"..3*",
+		"**.*",
+		"..2.",
+		".*9.",
+	)
+	if got := g.String(); got != want {
+		t.Errorf("unexpected grid rendering with costs:\ngot: %q\nwant:%q", got, want)
+	}
+	for _, test := range []struct {
+		r, c int
+		want float64
+	}{
+		{r: 0, c: 0, want: 1},
+		{r: 0, c: 2, want: 3},
+		{r: 2, c: 2, want: 2},
+		{r: 3, c: 2, want: 9},
+	} {
+		if got := g.Cost(test.r, test.c); got != test.want {
+			t.Errorf("unexpected cost at (%d, %d): got:%v want:%v", test.r, test.c, got, test.want)
+		}
+	}
+
+	// Edge weights are the cost of the destination
+	// scaled by the length of the step.
+	sqrt2 := math.Sqrt2
+	for _, test := range []struct {
+		u, v     int
+		diagonal bool
+		want     float64
+	}{
+		{u: 1, v: 2, want: 3},
+		{u: 2, v: 1, want: 1},
+		{u: 6, v: 10, want: 2},
+		{u: 10, v: 14, want: 9},
+		{u: 14, v: 10, want: 2},
+		{u: 14, v: 11, diagonal: true, want: sqrt2},
+		{u: 11, v: 14, diagonal: true, want: 9 * sqrt2},
+	} {
+		g.AllowDiagonal = test.diagonal
+		u, v := simple.Node(test.u), simple.Node(test.v)
+		if got, ok := g.Weight(u, v); got != test.want || !ok {
+			t.Errorf("unexpected weight from %d to %d: got:(%v, %t) want:(%v, true)", test.u, test.v, got, ok, test.want)
+		}
+		if got := g.Edge(u, v).Weight(); got != test.want {
+			t.Errorf("unexpected edge weight from %d to %d: got:%v want:%v", test.u, test.v, got, test.want)
+		}
+	}
+	g.AllowDiagonal = false
+
+	if got := g.MinCost(); got != 1 {
+		t.Errorf("unexpected minimum cost: got:%v want:1", got)
+	}
+	g.SetCost(0, 0, 0.5)
+	if got := g.MinCost(); got != 0.5 {
+		t.Errorf("unexpected minimum cost after SetCost: got:%v want:0.5", got)
+	}
+	g.Set(0, 0, false)
+	if got := g.MinCost(); got != 1 {
+		t.Errorf("unexpected minimum cost after closing node: got:%v want:1", got)
+	}
+
+	// The heuristic is the Manhattan distance
+	// scaled by the minimum cost.
+	g.SetCost(0, 1, 0.25)
+	if got, want := g.Heuristic(simple.Node(1), simple.Node(15)), 5*0.25; got != want {
+		t.Errorf("unexpected heuristic: got:%v want:%v", got, want)
+	}
+
+	for _, cost := range []float64{-1, math.Inf(1), math.NaN()} {
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			g.SetCost(0, 1, cost)
+			return
+		}()
+		if !panicked {
+			t.Errorf("expected panic for cost %v", cost)
+		}
+	}
+}
+
+func TestGridCornerCutting(t *testing.T) {
+	g := NewGridFrom(
+		"..*",
+		".*.",
+		"...",
+	)
+	g.AllowDiagonal = true
+	for _, test := range []struct {
+		cut  bool
+		from graph.Node
+		to   []graph.Node
+	}{
+		{
+			from: simple.Node(0),
+			to:   []graph.Node{simple.Node(1), simple.Node(3)},
+		},
+		{
+			cut:  true,
+			from: simple.Node(3),
+			to:   []graph.Node{simple.Node(0), simple.Node(1), simple.Node(6), simple.Node(7)},
+		},
+		{
+			from: simple.Node(3),
+			to:   []graph.Node{simple.Node(0), simple.Node(6)},
+		},
+		{
+			cut:  true,
+			from: simple.Node(5),
+			to:   []graph.Node{simple.Node(1), simple.Node(7), simple.Node(8)},
+		},
+		{
+			from: simple.Node(5),
+			to:   []graph.Node{simple.Node(8)},
+		},
+	} {
+		g.AllowCornerCutting = test.cut
+		got := g.From(test.from)
+		if !reflect.DeepEqual(got, test.to) {
+			t.Errorf("unexpected nodes from %d with corner cutting=%t:\ngot: %v\nwant:%v",
+				test.from.ID(), test.cut, got, test.to)
+		}
+		var visited []graph.Node
+		g.VisitFrom(test.from, func(v graph.Node, _ float64) bool {
+			visited = append(visited, v)
+			return true
+		})
+		if !reflect.DeepEqual(visited, test.to) {
+			t.Errorf("unexpected nodes visited from %d with corner cutting=%t:\ngot: %v\nwant:%v",
+				test.from.ID(), test.cut, visited, test.to)
+		}
+	}
+}
+
+func TestGridOctile(t *testing.T) {
+	g := NewGrid(5, 5, true)
+	u, v := simple.Node(0), simple.Node(2*5+4)
+	for _, test := range []struct {
+		unit   bool
+		length float64
+		want   float64
+	}{
+		{want: 2 + 2*math.Sqrt2},
+		{unit: true, want: 4},
+		{length: 1.5, want: 2 + 2*1.5},
+		{length: 3, want: 6},
+		{length: 0.5, want: 2},
+	} {
+		g.UnitEdgeWeight = test.unit
+		g.DiagonalLength = test.length
+		if got := g.Octile(u, v); got != test.want {
+			t.Errorf("unexpected octile distance with unit=%t length=%v: got:%v want:%v",
+				test.unit, test.length, got, test.want)
+		}
+	}
+}
+
+func TestGridEdgesAround(t *testing.T) {
+	g := NewGridFrom(
+		"...",
+		".*.",
+		"...",
+	)
+	for _, test := range []struct {
+		r, c     int
+		diagonal bool
+		cut      bool
+		want     [][2]int
+	}{
+		{
+			r: 0, c: 0,
+			want: [][2]int{{0, 1}, {1, 0}, {0, 3}, {3, 0}},
+		},
+		{
+			r: 0, c: 0, diagonal: true, cut: true,
+			want: [][2]int{{0, 1}, {1, 0}, {0, 3}, {3, 0}, {0, 4}, {4, 0}},
+		},
+		{
+			r: 0, c: 1, diagonal: true,
+			want: [][2]int{
+				{1, 0}, {0, 1}, {1, 2}, {2, 1}, {1, 3}, {3, 1}, {1, 4}, {4, 1}, {1, 5}, {5, 1},
+				{4, 0}, {0, 4}, {4, 2}, {2, 4},
+			},
+		},
+	} {
+		g.AllowDiagonal = test.diagonal
+		g.AllowCornerCutting = test.cut
+		var got [][2]int
+		for _, e := range g.EdgesAround(test.r, test.c) {
+			got = append(got, [2]int{e.From().ID(), e.To().ID()})
+			w, _ := g.Weight(e.From(), e.To())
+			if e.Weight() != w {
+				t.Errorf("unexpected weight for edge %d->%d around (%d, %d): got:%v want:%v",
+					e.From().ID(), e.To().ID(), test.r, test.c, e.Weight(), w)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected edges around (%d, %d) with diagonal=%t corner cutting=%t:\ngot: %v\nwant:%v",
+				test.r, test.c, test.diagonal, test.cut, got, test.want)
+		}
+	}
+}
+
+func TestGridWrap(t *testing.T) {
+	for _, test := range []struct {
+		rows       []string
+		horizontal bool
+		vertical   bool
+		diagonal   bool
+		from       int
+		want       []int
+	}{
+		{
+			rows:       []string{"....", "....", "...."},
+			horizontal: true,
+			from:       4,
+			want:       []int{0, 7, 5, 8},
+		},
+		{
+			rows:     []string{"....", "....", "...."},
+			vertical: true,
+			from:     1,
+			want:     []int{9, 0, 2, 5},
+		},
+		{
+			rows:       []string{"....", "....", "...."},
+			horizontal: true,
+			vertical:   true,
+			diagonal:   true,
+			from:       0,
+			want:       []int{11, 8, 9, 3, 1, 7, 4, 5},
+		},
+		{
+			rows:       []string{"..*.", "....", ".*.."},
+			horizontal: true,
+			vertical:   true,
+			from:       3,
+			want:       []int{11, 0, 7},
+		},
+		{
+			// Wrapping two columns or rows does not
+			// duplicate neighbors.
+			rows:       []string{"..", ".."},
+			horizontal: true,
+			vertical:   true,
+			diagonal:   true,
+			from:       0,
+			want:       []int{3, 2, 1},
+		},
+	} {
+		g := NewGridFrom(test.rows...)
+		g.WrapHorizontal = test.horizontal
+		g.WrapVertical = test.vertical
+		g.AllowDiagonal = test.diagonal
+		var got []int
+		for _, n := range g.From(simple.Node(test.from)) {
+			got = append(got, n.ID())
+			if !g.HasEdgeBetween(simple.Node(test.from), n) {
+				t.Errorf("missing edge between %d and %d with wrap=(%t, %t)", test.from, n.ID(), test.horizontal, test.vertical)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected nodes from %d with wrap=(%t, %t) diagonal=%t:\ngot: %v\nwant:%v",
+				test.from, test.horizontal, test.vertical, test.diagonal, got, test.want)
+		}
+	}
+
+	g := NewGrid(10, 10, true)
+	g.WrapHorizontal = true
+	g.WrapVertical = true
+	u, v := simple.Node(0), simple.Node(99)
+	if got := g.Heuristic(u, v); got != 2 {
+		t.Errorf("unexpected wrapped heuristic: got:%v want:2", got)
+	}
+	if got := g.Octile(u, v); got != math.Sqrt2 {
+		t.Errorf("unexpected wrapped octile distance: got:%v want:%v", got, math.Sqrt2)
+	}
+	if g.HasEdgeBetween(simple.Node(0), simple.Node(5)) {
+		t.Error("unexpected edge between non-adjacent nodes in wrapped grid")
+	}
+}
+
+func TestGridRegions(t *testing.T) {
+	// The wall fixture of the no path A* test.
+	g := NewGrid(5, 5, true)
+	for c := 0; c < 5; c++ {
+		g.Set(2, c, false)
+	}
+
+	regions := g.Regions()
+	if len(regions) != 20 {
+		t.Errorf("unexpected number of labeled nodes: got:%d want:20", len(regions))
+	}
+	for id, l := range regions {
+		r, _ := g.RowCol(id)
+		want := 0
+		if r > 2 {
+			want = 1
+		}
+		if l != want {
+			t.Errorf("unexpected region for node %d: got:%d want:%d", id, l, want)
+		}
+	}
+	if g.SameRegion(node(2), node(22)) {
+		t.Error("unexpected same region across wall")
+	}
+	if !g.SameRegion(node(0), node(9)) {
+		t.Error("expected same region on same side of wall")
+	}
+	if g.SameRegion(node(10), node(10)) {
+		t.Error("unexpected same region for closed node")
+	}
+
+	// Opening a gap in the wall joins the regions.
+	g.Set(2, 2, true)
+	if !g.SameRegion(node(2), node(22)) {
+		t.Error("expected same region through gap in wall")
+	}
+	for id, l := range g.Regions() {
+		if l != 0 {
+			t.Errorf("unexpected region for node %d after opening gap: got:%d want:0", id, l)
+		}
+	}
+
+	// Changes to adjacency are reflected in the regions.
+	g = NewGridFrom(
+		".*",
+		"*.",
+	)
+	if g.SameRegion(node(0), node(3)) {
+		t.Error("unexpected same region without diagonal moves")
+	}
+	g.AllowDiagonal = true
+	if g.SameRegion(node(0), node(3)) {
+		t.Error("unexpected same region without corner cutting")
+	}
+	g.AllowCornerCutting = true
+	if !g.SameRegion(node(0), node(3)) {
+		t.Error("expected same region with corner cutting")
+	}
+
+	g = NewGridFrom(".*.")
+	if g.SameRegion(node(0), node(2)) {
+		t.Error("unexpected same region without wrapping")
+	}
+	g.WrapHorizontal = true
+	if !g.SameRegion(node(0), node(2)) {
+		t.Error("expected same region with horizontal wrapping")
+	}
+}
+
+func TestGridConcurrentCaches(t *testing.T) {
+	// The lazily computed caches must be safe
+	// for concurrent use by readers of the grid.
+	g := NewGrid(10, 10, true)
+	g.SetCost(3, 3, 0.5)
+	g.Set(5, 5, false)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := g.MinCost(); got != 0.5 {
+				t.Errorf("unexpected minimum cost: got:%v want:0.5", got)
+			}
+			if !g.SameRegion(simple.Node(0), simple.Node(99)) {
+				t.Error("unexpected disconnected nodes")
+			}
+		}()
+	}
+	wg.Wait()
+}
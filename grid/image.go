@@ -0,0 +1,87 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/gonum/graph"
+)
+
+// Colors used by RenderImage.
+var (
+	ClosedColor = color.RGBA{A: 0xff}                            // ClosedColor is the color of closed nodes.
+	OpenColor   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff} // OpenColor is the color of open nodes.
+	StartColor  = color.RGBA{G: 0xc0, A: 0xff}                   // StartColor is the color of the first node of a path.
+	GoalColor   = color.RGBA{R: 0xe0, A: 0xff}                   // GoalColor is the color of the last node of a path.
+	PathColor   = color.RGBA{B: 0xff, A: 0xff}                   // PathColor is the color of the other nodes of a path.
+	BadColor    = color.RGBA{R: 0xff, B: 0xff, A: 0xff}          // BadColor is the color of a node that breaks a path.
+)
+
+// NewGridFromImage returns a grid with a node for each pixel of img. The
+// node at (r, c) corresponds to the pixel at (c, r) relative to the minimum
+// point of the image's bounds, and is open if passable returns true for the
+// pixel's color. If passable is nil, dark pixels are closed and all other
+// pixels are open.
+func NewGridFromImage(img image.Image, passable func(color.Color) bool) *Grid {
+	if passable == nil {
+		passable = isLight
+	}
+	b := img.Bounds()
+	g := NewGrid(b.Dy(), b.Dx(), false)
+	for r := 0; r < g.r; r++ {
+		for c := 0; c < g.c; c++ {
+			g.open[r*g.c+c] = passable(img.At(b.Min.X+c, b.Min.Y+r))
+		}
+	}
+	return g
+}
+
+// isLight returns whether the gray level of col is at least half intensity.
+func isLight(col color.Color) bool {
+	return color.GrayModel.Convert(col).(color.Gray).Y >= 0x80
+}
+
+// RenderImage returns an image of the grid with the given path included,
+// with one pixel for each node. Nodes are colored using the colors defined
+// in this package. If the path is not a path in the grid RenderImage returns
+// a non-nil error and the path up to that point.
+func (g *Grid) RenderImage(path []graph.Node) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, g.c, g.r))
+	for r := 0; r < g.r; r++ {
+		for c := 0; c < g.c; c++ {
+			if g.open[r*g.c+c] {
+				img.SetRGBA(c, r, OpenColor)
+			} else {
+				img.SetRGBA(c, r, ClosedColor)
+			}
+		}
+	}
+
+	// As in Render, we draw as much of
+	// the path as possible before failing.
+	for i, n := range path {
+		if !g.Has(n) || (i != 0 && !g.HasEdgeBetween(path[i-1], n)) {
+			id := n.ID()
+			if id >= 0 && id < len(g.open) {
+				r, c := g.RowCol(id)
+				img.SetRGBA(c, r, BadColor)
+			}
+			return img, errors.New("grid: not a path in graph")
+		}
+		r, c := g.RowCol(n.ID())
+		switch i {
+		case len(path) - 1:
+			img.SetRGBA(c, r, GoalColor)
+		case 0:
+			img.SetRGBA(c, r, StartColor)
+		default:
+			img.SetRGBA(c, r, PathColor)
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestNewGridFromImage(t *testing.T) {
+	rows := []string{
+		"*..*",
+		"**.*",
+		"....",
+	}
+
+	// Draw the grid offset from the origin, with
+	// walls of various dark colors.
+	img := image.NewRGBA(image.Rect(3, 5, 7, 8))
+	dark := []color.Color{color.Black, color.RGBA{R: 0x40, A: 0xff}, color.Gray{Y: 0x10}}
+	for r, row := range rows {
+		for c, b := range row {
+			col := color.Color(color.White)
+			if b == Closed {
+				col = dark[(r+c)%len(dark)]
+			}
+			img.Set(3+c, 5+r, col)
+		}
+	}
+
+	g := NewGridFromImage(img, nil)
+	if got, want := g.String(), join(rows...); got != want {
+		t.Errorf("unexpected grid from image:\ngot: %q\nwant:%q", got, want)
+	}
+
+	// A custom passable function can select a different
+	// set of open pixels, here only the pure black walls.
+	isBlack := func(c color.Color) bool {
+		r, g, b, _ := c.RGBA()
+		return r == 0 && g == 0 && b == 0
+	}
+	g = NewGridFromImage(img, isBlack)
+	want := join(
+		".**.",
+		"****",
+		"****",
+	)
+	if got := g.String(); got != want {
+		t.Errorf("unexpected grid from image with custom passable:\ngot: %q\nwant:%q", got, want)
+	}
+}
+
+func TestRenderImage(t *testing.T) {
+	g := NewGridFrom(
+		"*..*",
+		"**.*",
+		"**.*",
+		"**..",
+	)
+	path := []graph.Node{simple.Node(1), simple.Node(2), simple.Node(6), simple.Node(10), simple.Node(14), simple.Node(15)}
+	img, err := g.RenderImage(path)
+	if err != nil {
+		t.Fatalf("unexpected error rendering valid path: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 4 || b.Dy() != 4 {
+		t.Fatalf("unexpected image bounds: %v", b)
+	}
+	for _, test := range []struct {
+		r, c int
+		want color.RGBA
+	}{
+		{r: 0, c: 0, want: ClosedColor},
+		{r: 0, c: 1, want: StartColor},
+		{r: 0, c: 2, want: PathColor},
+		{r: 2, c: 2, want: PathColor},
+		{r: 3, c: 3, want: GoalColor},
+		{r: 3, c: 0, want: ClosedColor},
+	} {
+		if got := img.RGBAAt(test.c, test.r); got != test.want {
+			t.Errorf("unexpected color at (%d, %d): got:%v want:%v", test.r, test.c, got, test.want)
+		}
+	}
+
+	// Round trip the image through PNG encoding. Path
+	// colors may be dark, so passability is determined
+	// by comparison with the closed color.
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		t.Fatalf("unexpected error encoding PNG: %v", err)
+	}
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding PNG: %v", err)
+	}
+	if got, want := NewGridFromImage(decoded, notClosed).String(), g.String(); got != want {
+		t.Errorf("unexpected grid from decoded image:\ngot: %q\nwant:%q", got, want)
+	}
+
+	// Without a path the default passability
+	// recovers the grid.
+	img, _ = g.RenderImage(nil)
+	if got, want := NewGridFromImage(img, nil).String(), g.String(); got != want {
+		t.Errorf("unexpected grid from rendered image:\ngot: %q\nwant:%q", got, want)
+	}
+
+	// An invalid path is drawn up to the failure.
+	img, err = g.RenderImage([]graph.Node{simple.Node(1), simple.Node(2), simple.Node(3)})
+	if err == nil {
+		t.Error("expected error rendering invalid path")
+	}
+	if got := img.RGBAAt(3, 0); got != BadColor {
+		t.Errorf("unexpected color at failing node: got:%v want:%v", got, BadColor)
+	}
+}
+
+// notClosed returns whether c is not ClosedColor.
+func notClosed(c color.Color) bool {
+	return color.RGBAModel.Convert(c).(color.RGBA) != ClosedColor
+}
@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// connectivity holds the fields of a Grid that
+// determine which open nodes are adjacent.
+type connectivity struct {
+	allowDiagonal      bool
+	allowCornerCutting bool
+	wrapHorizontal     bool
+	wrapVertical       bool
+}
+
+func (g *Grid) connectivity() connectivity {
+	return connectivity{
+		allowDiagonal:      g.AllowDiagonal,
+		allowCornerCutting: g.AllowCornerCutting,
+		wrapHorizontal:     g.WrapHorizontal,
+		wrapVertical:       g.WrapVertical,
+	}
+}
+
+// Regions returns a map from the ID of each open node in the grid to a
+// label identifying the connected region of open nodes that contains it.
+// Regions are labeled from zero in order of their lowest node ID.
+//
+// Region labels are computed lazily and are retained until the grid is
+// changed by a call to Set or a change to a field that alters adjacency.
+func (g *Grid) Regions() map[int]int {
+	labels := g.regionLabels()
+	regions := make(map[int]int)
+	for id, l := range labels {
+		if l >= 0 {
+			regions[id] = l
+		}
+	}
+	return regions
+}
+
+// SameRegion returns whether a and b are open nodes in the same connected
+// region of the grid, and so whether there is a path between them. After
+// the region labels have been computed SameRegion is O(1).
+func (g *Grid) SameRegion(a, b graph.Node) bool {
+	if !g.HasOpen(a) || !g.HasOpen(b) {
+		return false
+	}
+	labels := g.regionLabels()
+	return labels[a.ID()] == labels[b.ID()]
+}
+
+// regionLabels returns the region label of each node, computing
+// the labels if they are not valid for the current grid.
+func (g *Grid) regionLabels() []int {
+	conn := g.connectivity()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.regions != nil && g.regionsFor == conn {
+		return g.regions
+	}
+
+	labels := make([]int, len(g.open))
+	for i := range labels {
+		labels[i] = -1
+	}
+	var (
+		region int
+		stack  []graph.Node
+	)
+	for id, ok := range g.open {
+		if !ok || labels[id] >= 0 {
+			continue
+		}
+		labels[id] = region
+		stack = append(stack[:0], simple.Node(id))
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			g.VisitFrom(u, func(v graph.Node, _ float64) bool {
+				if labels[v.ID()] < 0 {
+					labels[v.ID()] = region
+					stack = append(stack, v)
+				}
+				return true
+			})
+		}
+		region++
+	}
+
+	g.regions = labels
+	g.regionsFor = conn
+	return labels
+}
@@ -0,0 +1,179 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package edgecsv provides the delimited edge list parser shared by the
+// edge list readers of the simple and encoding/edgelist packages.
+package edgecsv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Edge is an edge record of an edge list.
+type Edge struct {
+	// From and To are the fields
+	// naming the nodes of the edge.
+	From, To string
+
+	// Weight is the weight of the
+	// edge, or 1 if the record has
+	// no weight field.
+	Weight float64
+
+	// Line is the line of the input
+	// on which the record starts.
+	Line int
+}
+
+// Error is an error in an edge list.
+type Error struct {
+	Line int
+	Err  string
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("line %d: %s", e.Line, e.Err) }
+
+// Reader reads the edge records of an edge list. Records are read using
+// encoding/csv, so fields may be quoted, and hold two or three fields, the
+// from and to nodes and optionally the edge weight. White space around
+// fields is ignored, as are blank lines.
+type Reader struct {
+	br      *bufio.Reader
+	delim   rune
+	comment rune
+	spaced  bool
+	header  bool
+
+	// line is the number of
+	// lines read from br.
+	line int
+}
+
+// NewReader returns a Reader reading from r. Fields are separated by delim,
+// or by runs of spaces and tabs if delim is zero, in which case tabs within
+// quoted fields are read as spaces. If comment is not zero, lines starting
+// with comment are ignored. If header is true, the first record is ignored.
+func NewReader(r io.Reader, delim, comment rune, header bool) *Reader {
+	spaced := delim == 0
+	if spaced {
+		delim = ' '
+	}
+	return &Reader{br: bufio.NewReader(r), delim: delim, comment: comment, spaced: spaced, header: header}
+}
+
+// Read returns the next edge record. At the end of the input Read returns
+// io.EOF. Errors in the input are returned as an *Error. Read returns an
+// error for records naming the same node twice.
+func (r *Reader) Read() (Edge, error) {
+	for {
+		text, line, err := r.record()
+		if err != nil {
+			return Edge{}, err
+		}
+		if r.spaced {
+			text = strings.Replace(text, "\t", " ", -1)
+		}
+		cr := csv.NewReader(strings.NewReader(text))
+		cr.Comma = r.delim
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		rec, err := cr.Read()
+		if err != nil {
+			if pe, ok := err.(*csv.ParseError); ok {
+				return Edge{}, &Error{Line: line + pe.Line - 1, Err: pe.Err.Error()}
+			}
+			if err == io.EOF {
+				// The record holds only
+				// white space.
+				continue
+			}
+			return Edge{}, err
+		}
+
+		fields := rec[:0]
+		for _, f := range rec {
+			f = strings.TrimSpace(f)
+			if f == "" && r.spaced {
+				// Runs of white space
+				// separate single fields.
+				continue
+			}
+			fields = append(fields, f)
+		}
+		if len(fields) == 0 || (len(fields) == 1 && fields[0] == "") {
+			continue
+		}
+		if r.header {
+			r.header = false
+			continue
+		}
+
+		if len(fields) != 2 && len(fields) != 3 {
+			return Edge{}, &Error{Line: line, Err: fmt.Sprintf("invalid number of fields: %d", len(fields))}
+		}
+		if fields[0] == fields[1] {
+			return Edge{}, &Error{Line: line, Err: fmt.Sprintf("self edge on %q", fields[0])}
+		}
+		e := Edge{From: fields[0], To: fields[1], Weight: 1, Line: line}
+		if len(fields) == 3 {
+			e.Weight, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return Edge{}, &Error{Line: line, Err: fmt.Sprintf("invalid weight %q", fields[2])}
+			}
+		}
+		return e, nil
+	}
+}
+
+// record returns the text of the next record that is not a comment and the
+// line on which it starts. A record continues onto following lines while it
+// holds an unterminated quoted field. At the end of the input record returns
+// io.EOF.
+func (r *Reader) record() (text string, line int, err error) {
+	for {
+		text, err = r.br.ReadString('\n')
+		if text == "" {
+			if err == nil {
+				err = io.EOF
+			}
+			return "", 0, err
+		}
+		r.line++
+		line = r.line
+		if r.comment != 0 && strings.HasPrefix(text, string(r.comment)) {
+			continue
+		}
+		for err == nil && strings.Count(text, `"`)%2 != 0 {
+			var more string
+			more, err = r.br.ReadString('\n')
+			if more != "" {
+				r.line++
+				text += more
+			}
+		}
+		if err != nil && err != io.EOF {
+			return "", 0, err
+		}
+		return text, line, nil
+	}
+}
+
+// Quote returns field quoted as described for encoding/csv if it would not
+// otherwise be read back as a single field by a Reader with the given delim
+// and comment, or field unchanged if it would.
+func Quote(field string, delim, comment rune) string {
+	needed := field == "" || strings.ContainsAny(field, "\" \t\r\n") || strings.ContainsRune(field, delim)
+	if !needed && comment != 0 {
+		needed = strings.HasPrefix(field, string(comment))
+	}
+	if !needed {
+		return field
+	}
+	return `"` + strings.Replace(field, `"`, `""`, -1) + `"`
+}
@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import "errors"
+
+// LineGraph constructs the line graph of g in dst and returns a mapping from
+// the IDs of the nodes of dst to the edges of g they represent. Each edge of g
+// is represented by a node in dst and two nodes in dst are joined by a unit
+// weight edge when the edges they represent share an end point in g.
+//
+// A self edge in g shares its single end point with every other edge incident
+// to that node, so it is joined to each of them in dst, but it is not joined
+// to itself.
+//
+// Node IDs are obtained from dst.NewNodeID in order of the edges of g sorted by
+// the IDs of their end points, so the mapping is deterministic when the ID
+// allocation of dst is. LineGraph returns an error if dst is not empty.
+func LineGraph(dst UndirectedBuilder, g Undirected) (map[int]Edge, error) {
+	if len(dst.Nodes()) != 0 {
+		return nil, errors.New("graph: line graph destination is not empty")
+	}
+
+	edges := edgesOf(g, sortedNodes(g.Nodes()), false)
+	mapping := make(map[int]Edge, len(edges))
+	incident := make(map[int][]Node)
+	for _, e := range edges {
+		n := lineNode(dst.NewNodeID())
+		dst.AddNode(n)
+		mapping[n.ID()] = e.(orientedEdge).Edge
+
+		u, v := e.From(), e.To()
+		incident[u.ID()] = append(incident[u.ID()], n)
+		if v.ID() != u.ID() {
+			incident[v.ID()] = append(incident[v.ID()], n)
+		}
+	}
+	for _, shared := range incident {
+		for i, x := range shared {
+			for _, y := range shared[i+1:] {
+				dst.SetEdge(lineEdge{f: x, t: y})
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+// lineNode is a node in a line graph.
+type lineNode int
+
+func (n lineNode) ID() int { return int(n) }
+
+// lineEdge is a unit weight edge in a line graph.
+type lineEdge struct {
+	f, t Node
+}
+
+func (e lineEdge) From() Node      { return e.f }
+func (e lineEdge) To() Node        { return e.t }
+func (e lineEdge) Weight() float64 { return 1 }
@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestLineGraphStar(t *testing.T) {
+	for n := 1; n <= 6; n++ {
+		star := simple.NewUndirectedGraph(0, math.Inf(1))
+		for i := 1; i <= n; i++ {
+			star.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(i), W: 1})
+		}
+
+		dst := simple.NewUndirectedGraph(0, math.Inf(1))
+		mapping, err := graph.LineGraph(dst, star)
+		if err != nil {
+			t.Fatalf("unexpected error for K1,%d: %v", n, err)
+		}
+		if len(mapping) != n {
+			t.Errorf("unexpected mapping size for K1,%d: got:%d want:%d", n, len(mapping), n)
+		}
+
+		// The line graph of the star K1,n is the complete graph Kn.
+		nodes := dst.Nodes()
+		if len(nodes) != n {
+			t.Errorf("unexpected number of nodes for K1,%d: got:%d want:%d", n, len(nodes), n)
+		}
+		for _, u := range nodes {
+			if len(dst.From(u)) != n-1 {
+				t.Errorf("unexpected degree of %d for K1,%d: got:%d want:%d", u.ID(), n, len(dst.From(u)), n-1)
+			}
+		}
+	}
+}
+
+func TestLineGraph(t *testing.T) {
+	// A path 0-1-2-3 with a pendant 1-4.
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(1), T: simple.Node(0)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(3), T: simple.Node(2)},
+		{F: simple.Node(1), T: simple.Node(4)},
+	} {
+		g.SetEdge(e)
+	}
+
+	dst := simple.NewUndirectedGraph(0, math.Inf(1))
+	mapping, err := graph.LineGraph(dst, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[int][2]int)
+	for id, e := range mapping {
+		u, v := e.From().ID(), e.To().ID()
+		if u > v {
+			u, v = v, u
+		}
+		got[id] = [2]int{u, v}
+	}
+	want := map[int][2]int{0: {0, 1}, 1: {1, 2}, 2: {1, 4}, 3: {2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected mapping: got:%v want:%v", got, want)
+	}
+	for _, pair := range [][2]int{{0, 1}, {0, 2}, {1, 2}, {1, 3}} {
+		if !dst.HasEdgeBetween(simple.Node(pair[0]), simple.Node(pair[1])) {
+			t.Errorf("expected edge between %d and %d", pair[0], pair[1])
+		}
+	}
+	if n := len(dst.Edges()); n != 4 {
+		t.Errorf("unexpected number of edges: got:%d want:4", n)
+	}
+
+	if _, err := graph.LineGraph(dst, g); err == nil {
+		t.Error("expected error for non-empty destination")
+	}
+}
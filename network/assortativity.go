@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import "github.com/gonum/graph"
+
+// DegreeAssortativity returns the degree assortativity coefficient of the
+// undirected graph g. The coefficient is the Pearson correlation of the
+// degrees of the nodes at either end of each edge,
+//
+//  r = (\sum_{uv} d_u d_v / 2m - (\sum_{uv} d_u / 2m)^2) / (\sum_{uv} d_u^2 / 2m - (\sum_{uv} d_u / 2m)^2)
+//
+// where the sums are over both orientations of each of the m edges in g.
+// Self-loops are ignored both as edges and in the calculation of degree.
+//
+// If g has no edges or the degree variance over edge ends is zero, as is
+// the case for regular graphs, the correlation is undefined and
+// DegreeAssortativity returns zero.
+//
+// The algorithm used is described in doi:10.1103/PhysRevLett.89.208701.
+func DegreeAssortativity(g graph.Undirected) float64 {
+	deg := degreesWithoutSelf(g)
+
+	var n, sumJK, sumJ, sumJ2 float64
+	for _, u := range g.Nodes() {
+		uid := u.ID()
+		du := float64(deg[uid])
+		for _, v := range g.From(u) {
+			vid := v.ID()
+			if vid == uid {
+				continue
+			}
+			dv := float64(deg[vid])
+			n++
+			sumJK += du * dv
+			sumJ += du
+			sumJ2 += du * du
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	mean := sumJ / n
+	variance := sumJ2/n - mean*mean
+	if variance == 0 {
+		return 0
+	}
+	return (sumJK/n - mean*mean) / variance
+}
+
+// RichClubCoefficient returns the rich-club coefficient of the undirected
+// graph g for the degree k. The coefficient is the density of the subgraph
+// induced by the nodes with degree greater than k,
+//
+//  φ(k) = 2 E_{>k} / (N_{>k} (N_{>k} - 1))
+//
+// where N_{>k} is the number of nodes with degree greater than k and E_{>k}
+// is the number of edges between them. Self-loops are ignored both as edges
+// and in the calculation of degree. If fewer than two nodes have degree
+// greater than k, RichClubCoefficient returns zero.
+//
+// The rich-club coefficient is described in doi:10.1103/PhysRevLett.87.278701.
+func RichClubCoefficient(g graph.Undirected, k int) float64 {
+	deg := degreesWithoutSelf(g)
+
+	var nodes, ends int
+	for _, u := range g.Nodes() {
+		uid := u.ID()
+		if deg[uid] <= k {
+			continue
+		}
+		nodes++
+		for _, v := range g.From(u) {
+			vid := v.ID()
+			if vid != uid && deg[vid] > k {
+				ends++
+			}
+		}
+	}
+	if nodes < 2 {
+		return 0
+	}
+	// Each edge between rich nodes has been counted from both
+	// ends, so ends is already 2E_{>k}.
+	return float64(ends) / float64(nodes*(nodes-1))
+}
+
+// degreesWithoutSelf returns the degree of each node in g, keyed by node ID,
+// ignoring self-loops.
+func degreesWithoutSelf(g graph.Graph) map[int]int {
+	nodes := g.Nodes()
+	deg := make(map[int]int, len(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, v := range g.From(u) {
+			if v.ID() != uid {
+				deg[uid]++
+			}
+		}
+	}
+	return deg
+}
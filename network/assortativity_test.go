@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+var (
+	// star is the star graph K_{1,5} with its centre at A.
+	star = []set{
+		A: linksTo(B, C, D, E, F),
+		B: nil,
+		C: nil,
+		D: nil,
+		E: nil,
+		F: nil,
+	}
+
+	// complete is the complete graph K_5.
+	complete = []set{
+		A: linksTo(B, C, D, E),
+		B: linksTo(C, D, E),
+		C: linksTo(D, E),
+		D: linksTo(E),
+		E: nil,
+	}
+
+	// path4 is the path graph P_4.
+	path4 = []set{
+		A: linksTo(B),
+		B: linksTo(C),
+		C: linksTo(D),
+		D: nil,
+	}
+)
+
+var degreeAssortativityTests = []struct {
+	name string
+	g    []set
+	want float64
+}{
+	{name: "empty", g: []set{A: nil, B: nil}, want: 0},
+	{name: "star", g: star, want: -1},
+	{name: "complete", g: complete, want: 0},
+	{name: "path", g: path4, want: -0.5},
+}
+
+func TestDegreeAssortativity(t *testing.T) {
+	for _, test := range degreeAssortativityTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := DegreeAssortativity(g)
+		if math.IsNaN(got) || math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected degree assortativity for %q: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+var richClubTests = []struct {
+	name string
+	g    []set
+	k    int
+	want float64
+}{
+	{name: "star k=0", g: star, k: 0, want: 2.0 / 6},
+	{name: "star k=1", g: star, k: 1, want: 0},
+	{name: "complete k=0", g: complete, k: 0, want: 1},
+	{name: "complete k=3", g: complete, k: 3, want: 1},
+	{name: "complete k=4", g: complete, k: 4, want: 0},
+	{name: "path k=1", g: path4, k: 1, want: 1},
+}
+
+func TestRichClubCoefficient(t *testing.T) {
+	for _, test := range richClubTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := RichClubCoefficient(g, test.k)
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected rich-club coefficient for %q: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
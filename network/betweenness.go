@@ -5,6 +5,7 @@
 package network
 
 import (
+	"container/heap"
 	"math"
 
 	"github.com/gonum/graph"
@@ -91,54 +92,172 @@ func EdgeBetweenness(g graph.Graph) map[[2]int]float64 {
 func brandes(g graph.Graph, accumulate func(s graph.Node, stack linear.NodeStack, p map[int][]graph.Node, delta, sigma map[int]float64)) {
 	var (
 		nodes = g.Nodes()
-		stack linear.NodeStack
-		p     = make(map[int][]graph.Node, len(nodes))
-		sigma = make(map[int]float64, len(nodes))
-		d     = make(map[int]int, len(nodes))
+		c     = newShortestPathCounter(nodes)
 		delta = make(map[int]float64, len(nodes))
-		queue linear.NodeQueue
 	)
 	for _, s := range nodes {
-		stack = stack[:0]
+		c.countFrom(s, g, nil)
 
-		for _, w := range nodes {
-			p[w.ID()] = p[w.ID()][:0]
+		for _, v := range nodes {
+			delta[v.ID()] = 0
 		}
 
-		for _, t := range nodes {
-			sigma[t.ID()] = 0
-			d[t.ID()] = -1
-		}
-		sigma[s.ID()] = 1
-		d[s.ID()] = 0
+		// S returns vertices in order of non-increasing distance from s
+		accumulate(s, c.stack, c.p, delta, c.sigma)
+	}
+}
+
+// CountShortestPaths returns the distances from s to each node reachable
+// from s in g and the number of distinct shortest paths from s to each of
+// those nodes. Nodes that are not reachable from s are not included in the
+// returned maps. If g implements graph.Weighter, path weights are used to
+// define distance, otherwise the number of edges in a path is used.
+// CountShortestPaths will panic if g has an s-reachable negative edge weight.
+//
+// Path counts are held as float64 values, so counts greater than 2^53 are
+// subject to rounding.
+func CountShortestPaths(s graph.Node, g graph.Graph) (dist, count map[int]float64) {
+	if !g.Has(s) {
+		return nil, nil
+	}
+	var weight path.Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	}
+	c := newShortestPathCounter(g.Nodes())
+	c.countFrom(s, g, weight)
 
-		queue.Enqueue(s)
-		for queue.Len() != 0 {
-			v := queue.Dequeue()
-			stack.Push(v)
+	dist = make(map[int]float64, len(c.stack))
+	count = make(map[int]float64, len(c.stack))
+	for _, n := range c.stack {
+		dist[n.ID()] = c.d[n.ID()]
+		count[n.ID()] = c.sigma[n.ID()]
+	}
+	return dist, count
+}
+
+// shortestPathCounter holds the state for the single source shortest path
+// phase of Brandes' algorithm. The state is retained between sources to
+// reduce allocation.
+type shortestPathCounter struct {
+	nodes []graph.Node
+
+	// stack holds the nodes reached from the
+	// source in order of non-decreasing
+	// distance from the source.
+	stack linear.NodeStack
+	// p holds the shortest path predecessors
+	// of each node.
+	p map[int][]graph.Node
+	// sigma holds the number of shortest
+	// paths from the source to each node.
+	sigma map[int]float64
+	// d holds the distance from the source
+	// to each node.
+	d map[int]float64
+
+	queue linear.NodeQueue
+}
+
+func newShortestPathCounter(nodes []graph.Node) *shortestPathCounter {
+	return &shortestPathCounter{
+		nodes: nodes,
+		p:     make(map[int][]graph.Node, len(nodes)),
+		sigma: make(map[int]float64, len(nodes)),
+		d:     make(map[int]float64, len(nodes)),
+	}
+}
+
+// countFrom finds the shortest paths from s in g, counting the number of
+// shortest paths to each node and recording the shortest path predecessors.
+// If weight is nil, distances are measured in edge hops by breadth-first
+// search, otherwise Dijkstra's algorithm is used with the provided weights.
+func (c *shortestPathCounter) countFrom(s graph.Node, g graph.Graph, weight path.Weighting) {
+	c.stack = c.stack[:0]
+	for _, w := range c.nodes {
+		c.p[w.ID()] = c.p[w.ID()][:0]
+		c.sigma[w.ID()] = 0
+		c.d[w.ID()] = math.Inf(1)
+	}
+	c.sigma[s.ID()] = 1
+	c.d[s.ID()] = 0
+
+	if weight == nil {
+		c.queue.Enqueue(s)
+		for c.queue.Len() != 0 {
+			v := c.queue.Dequeue()
+			c.stack.Push(v)
 			for _, w := range g.From(v) {
 				// w found for the first time?
-				if d[w.ID()] < 0 {
-					queue.Enqueue(w)
-					d[w.ID()] = d[v.ID()] + 1
+				if math.IsInf(c.d[w.ID()], 1) {
+					c.queue.Enqueue(w)
+					c.d[w.ID()] = c.d[v.ID()] + 1
 				}
 				// shortest path to w via v?
-				if d[w.ID()] == d[v.ID()]+1 {
-					sigma[w.ID()] += sigma[v.ID()]
-					p[w.ID()] = append(p[w.ID()], v)
+				if c.d[w.ID()] == c.d[v.ID()]+1 {
+					c.sigma[w.ID()] += c.sigma[v.ID()]
+					c.p[w.ID()] = append(c.p[w.ID()], v)
 				}
 			}
 		}
+		return
+	}
 
-		for _, v := range nodes {
-			delta[v.ID()] = 0
+	// This is the weighted variant of the first phase of
+	// Brandes' algorithm as described in section 3.2 of
+	// http://www.inf.uni-konstanz.de/algo/publications/b-fabc-01.pdf
+	settled := make(map[int]bool, len(c.nodes))
+	q := distanceQueue{{node: s, dist: 0}}
+	for q.Len() != 0 {
+		mid := heap.Pop(&q).(distanceNode)
+		v := mid.node
+		if settled[v.ID()] || mid.dist > c.d[v.ID()] {
+			continue
+		}
+		settled[v.ID()] = true
+		c.stack.Push(v)
+		for _, w := range g.From(v) {
+			wt, ok := weight(v, w)
+			if !ok {
+				panic("network: unexpected invalid weight")
+			}
+			if wt < 0 {
+				panic("network: negative edge weight")
+			}
+			joint := c.d[v.ID()] + wt
+			switch {
+			case joint < c.d[w.ID()]:
+				c.d[w.ID()] = joint
+				c.sigma[w.ID()] = c.sigma[v.ID()]
+				c.p[w.ID()] = append(c.p[w.ID()][:0], v)
+				heap.Push(&q, distanceNode{node: w, dist: joint})
+			case joint == c.d[w.ID()] && !settled[w.ID()]:
+				c.sigma[w.ID()] += c.sigma[v.ID()]
+				c.p[w.ID()] = append(c.p[w.ID()], v)
+			}
 		}
-
-		// S returns vertices in order of non-increasing distance from s
-		accumulate(s, stack, p, delta, sigma)
 	}
 }
 
+type distanceNode struct {
+	node graph.Node
+	dist float64
+}
+
+// distanceQueue implements a no-dec priority queue.
+type distanceQueue []distanceNode
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(n interface{}) { *q = append(*q, n.(distanceNode)) }
+func (q *distanceQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
+
 // WeightedGraph is a graph with edge weights.
 type WeightedGraph interface {
 	graph.Graph
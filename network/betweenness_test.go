@@ -7,10 +7,12 @@ package network
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"sort"
 	"testing"
 
 	"github.com/gonum/floats"
+	"github.com/gonum/graph"
 	"github.com/gonum/graph/path"
 	"github.com/gonum/graph/simple"
 )
@@ -338,3 +340,87 @@ func (o orderedPairFloatsMap) Less(i, j int) bool {
 	return o[i].key[0] < o[j].key[0] || (o[i].key[0] == o[j].key[0] && o[i].key[1] < o[j].key[1])
 }
 func (o orderedPairFloatsMap) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+var countShortestPathsTests = []struct {
+	name  string
+	g     func() graph.Graph
+	from  int
+	dist  map[int]float64
+	count map[int]float64
+}{
+	{
+		name: "2x2 grid",
+		g: func() graph.Graph {
+			g := simple.NewUndirectedGraph(0, math.Inf(1))
+			for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}} {
+				g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+			}
+			return g
+		},
+		from:  0,
+		dist:  map[int]float64{0: 0, 1: 1, 2: 1, 3: 2},
+		count: map[int]float64{0: 1, 1: 1, 2: 1, 3: 2},
+	},
+	{
+		name: "3x3 grid",
+		g: func() graph.Graph {
+			g := simple.NewUndirectedGraph(0, math.Inf(1))
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 3; c++ {
+					if c < 2 {
+						g.SetEdge(simple.Edge{F: simple.Node(r*3 + c), T: simple.Node(r*3 + c + 1), W: 1})
+					}
+					if r < 2 {
+						g.SetEdge(simple.Edge{F: simple.Node(r*3 + c), T: simple.Node((r+1)*3 + c), W: 1})
+					}
+				}
+			}
+			return g
+		},
+		from: 0,
+		dist: map[int]float64{
+			0: 0, 1: 1, 2: 2,
+			3: 1, 4: 2, 5: 3,
+			6: 2, 7: 3, 8: 4,
+		},
+		count: map[int]float64{
+			0: 1, 1: 1, 2: 1,
+			3: 1, 4: 2, 5: 3,
+			6: 1, 7: 3, 8: 6,
+		},
+	},
+	{
+		name: "weighted directed",
+		g: func() graph.Graph {
+			g := simple.NewDirectedGraph(0, math.Inf(1))
+			for _, e := range []simple.Edge{
+				{F: simple.Node(0), T: simple.Node(1), W: 1},
+				{F: simple.Node(0), T: simple.Node(2), W: 1},
+				{F: simple.Node(1), T: simple.Node(3), W: 1},
+				{F: simple.Node(2), T: simple.Node(3), W: 1},
+				{F: simple.Node(0), T: simple.Node(3), W: 2},
+				{F: simple.Node(0), T: simple.Node(4), W: 5},
+				{F: simple.Node(3), T: simple.Node(4), W: 2},
+				{F: simple.Node(5), T: simple.Node(0), W: 1},
+			} {
+				g.SetEdge(e)
+			}
+			return g
+		},
+		from:  0,
+		dist:  map[int]float64{0: 0, 1: 1, 2: 1, 3: 2, 4: 4},
+		count: map[int]float64{0: 1, 1: 1, 2: 1, 3: 3, 4: 3},
+	},
+}
+
+func TestCountShortestPaths(t *testing.T) {
+	for _, test := range countShortestPathsTests {
+		dist, count := CountShortestPaths(simple.Node(test.from), test.g())
+		if !reflect.DeepEqual(dist, test.dist) {
+			t.Errorf("unexpected distances for %q:\ngot: %v\nwant:%v", test.name, dist, test.dist)
+		}
+		if !reflect.DeepEqual(count, test.count) {
+			t.Errorf("unexpected path counts for %q:\ngot: %v\nwant:%v", test.name, count, test.count)
+		}
+	}
+}
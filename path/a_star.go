@@ -6,6 +6,7 @@ package path
 
 import (
 	"container/heap"
+	"sort"
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/set"
@@ -24,15 +25,123 @@ import (
 // falling back to NullHeuristic otherwise. If the graph does not implement graph.Weighter,
 // UniformCost is used. AStar will panic if g has an A*-reachable negative edge weight.
 func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded int) {
+	return aStar(s, t, g, h, nil, nil)
+}
+
+// AStarWeighted performs an A* search in the same way as AStar, but uses the
+// weight function to obtain all edge weights used by the search, allowing edge
+// weights to be modified without altering g. If weight is nil, AStarWeighted
+// behaves as AStar. The heuristic h must be admissible with respect to weight
+// for the returned path to be the shortest path. AStarWeighted will panic if
+// weight returns an A*-reachable negative edge weight, or returns false for an
+// edge that exists in g.
+func AStarWeighted(s, t graph.Node, g graph.Graph, h Heuristic, weight Weighting) (path Shortest, expanded int) {
+	return aStar(s, t, g, h, weight, nil)
+}
+
+// AStarInflated performs an A* search in the same way as AStar, but with the
+// heuristic multiplied by epsilon, a search commonly known as weighted A*. An
+// inflated heuristic directs the search more greedily toward t, so usually
+// fewer nodes are expanded at the cost of optimality. If h is admissible, the
+// cost of the returned path to t is at most epsilon times the cost of the
+// shortest path. When epsilon is 1, AStarInflated behaves as AStar. The paths
+// and costs to other explored nodes carry no such bound. AStarInflated will
+// panic if epsilon is less than 1 or NaN.
+func AStarInflated(s, t graph.Node, g graph.Graph, h Heuristic, epsilon float64) (path Shortest, expanded int) {
+	if !(epsilon >= 1) {
+		panic("A*: inflation factor less than 1")
+	}
+	if h == nil {
+		if g, ok := g.(HeuristicCoster); ok {
+			h = g.HeuristicCost
+		} else {
+			h = NullHeuristic
+		}
+	}
+	if epsilon != 1 {
+		heuristic := h
+		h = func(x, y graph.Node) float64 {
+			return epsilon * heuristic(x, y)
+		}
+	}
+	return aStar(s, t, g, h, nil, nil)
+}
+
+// AStarExpansion is a record of a node expansion made during an A* search.
+type AStarExpansion struct {
+	// Node is the expanded node and Parent
+	// is its predecessor on the best path
+	// to Node known at the time of the
+	// expansion. Parent is nil for the
+	// start node.
+	Node, Parent graph.Node
+
+	// G is the cost of the best known path
+	// from the start node to Node and F is
+	// G plus the heuristic estimate of the
+	// cost from Node to the target.
+	G, F float64
+}
+
+// AStarTrace is a record of the progress of an A* search.
+type AStarTrace struct {
+	// Expansions holds the node expansions
+	// in the order they were made. If the
+	// target was reached it is the last
+	// expansion.
+	Expansions []AStarExpansion
+
+	// Open and Closed hold the members of
+	// the open and closed sets at the time
+	// the search terminated. Closed is in
+	// the order the nodes were expanded and
+	// Open is in order of ascending f-score,
+	// the order in which the nodes would be
+	// expanded if the search continued.
+	Open, Closed []graph.Node
+}
+
+// GScores returns the g-score of each expanded node in the trace, keyed by
+// node ID. The g-score of a node is the cost of the best known path from the
+// start node to the node when it was expanded.
+func (t AStarTrace) GScores() map[int]float64 {
+	g := make(map[int]float64, len(t.Expansions))
+	for _, e := range t.Expansions {
+		g[e.Node.ID()] = e.G
+	}
+	return g
+}
+
+// Parents returns the parent of each expanded node in the trace, keyed by
+// node ID. Following the parents from an expanded node leads back to the
+// start node, which has a nil parent. The parents describe the search tree
+// explored by the A* search.
+func (t AStarTrace) Parents() map[int]graph.Node {
+	p := make(map[int]graph.Node, len(t.Expansions))
+	for _, e := range t.Expansions {
+		p[e.Node.ID()] = e.Parent
+	}
+	return p
+}
+
+// AStarWithTrace performs an A* search in the same way as AStar, additionally
+// returning a trace of the node expansions made by the search and the state
+// of the open and closed sets at its termination. The number of expanded
+// nodes is len(trace.Expansions).
+func AStarWithTrace(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, trace AStarTrace) {
+	path, _ = aStar(s, t, g, h, nil, &trace)
+	return path, trace
+}
+
+// aStar is the A* implementation shared by AStar, AStarWeighted,
+// AStarInflated and AStarWithTrace. If weight is nil, edge weights
+// are obtained from g. If trace is not nil, expansions and the final
+// state of the search are recorded in it.
+func aStar(s, t graph.Node, g graph.Graph, h Heuristic, weight Weighting, trace *AStarTrace) (path Shortest, expanded int) {
 	if !g.Has(s) || !g.Has(t) {
 		return Shortest{from: s}, 0
 	}
-	var weight Weighting
-	if wg, ok := g.(graph.Weighter); ok {
-		weight = wg.Weight
-	} else {
-		weight = UniformCost(g)
-	}
+	visit := visitorFor(g, weight, "A*")
 	if h == nil {
 		if g, ok := g.(HeuristicCoster); ok {
 			h = g.HeuristicCost
@@ -48,39 +157,65 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 	open := &aStarQueue{indexOf: make(map[int]int)}
 	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: h(s, t)})
 
+	var (
+		u aStarNode
+		i int
+	)
+	relax := func(v graph.Node, w float64) bool {
+		vid := v.ID()
+		if visited.Has(vid) {
+			return true
+		}
+		j := path.indexOf[vid]
+
+		if w < 0 {
+			panic("A*: negative edge weight")
+		}
+		g := u.gscore + w
+		if n, ok := open.node(vid); !ok {
+			path.set(j, g, i)
+			heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
+		} else if g < n.gscore {
+			path.set(j, g, i)
+			open.update(vid, g, g+h(v, t))
+		}
+		return true
+	}
 	for open.Len() != 0 {
-		u := heap.Pop(open).(aStarNode)
+		u = heap.Pop(open).(aStarNode)
 		uid := u.node.ID()
-		i := path.indexOf[uid]
+		i = path.indexOf[uid]
 		expanded++
+		if trace != nil {
+			var parent graph.Node
+			if p := path.next[i]; p >= 0 {
+				parent = path.nodes[p]
+			}
+			trace.Expansions = append(trace.Expansions, AStarExpansion{
+				Node:   u.node,
+				Parent: parent,
+				G:      u.gscore,
+				F:      u.fscore,
+			})
+		}
 
 		if uid == tid {
 			break
 		}
 
 		visited.Add(uid)
-		for _, v := range g.From(u.node) {
-			vid := v.ID()
-			if visited.Has(vid) {
-				continue
-			}
-			j := path.indexOf[vid]
+		if trace != nil {
+			trace.Closed = append(trace.Closed, u.node)
+		}
+		visit(u.node, relax)
+	}
 
-			w, ok := weight(u.node, v)
-			if !ok {
-				panic("A*: unexpected invalid weight")
-			}
-			if w < 0 {
-				panic("A*: negative edge weight")
-			}
-			g := u.gscore + w
-			if n, ok := open.node(vid); !ok {
-				path.set(j, g, i)
-				heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
-			} else if g < n.gscore {
-				path.set(j, g, i)
-				open.update(vid, g, g+h(v, t))
-			}
+	if trace != nil {
+		// The queue is held in heap order,
+		// and is not needed after the search.
+		sort.Sort(open)
+		for _, n := range open.nodes {
+			trace.Open = append(trace.Open, n.node)
 		}
 	}
 
@@ -10,7 +10,7 @@ import (
 	"testing"
 
 	"github.com/gonum/graph"
-	"github.com/gonum/graph/path/internal"
+	"github.com/gonum/graph/grid"
 	"github.com/gonum/graph/path/internal/testgraphs"
 	"github.com/gonum/graph/simple"
 	"github.com/gonum/graph/topo"
@@ -27,7 +27,7 @@ var aStarTests = []struct {
 	{
 		name: "simple path",
 		g: func() graph.Graph {
-			return internal.NewGridFrom(
+			return grid.NewGridFrom(
 				"*..*",
 				"**.*",
 				"**.*",
@@ -40,20 +40,20 @@ var aStarTests = []struct {
 	},
 	{
 		name: "small open graph",
-		g:    internal.NewGrid(3, 3, true),
+		g:    grid.NewGrid(3, 3, true),
 
 		s: 0, t: 8,
 	},
 	{
 		name: "large open graph",
-		g:    internal.NewGrid(1000, 1000, true),
+		g:    grid.NewGrid(1000, 1000, true),
 
 		s: 0, t: 999*1000 + 999,
 	},
 	{
 		name: "no path",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(5, 5, true)
+			tg := grid.NewGrid(5, 5, true)
 
 			// Create a complete "wall" across the middle row.
 			tg.Set(2, 0, false)
@@ -70,7 +70,7 @@ var aStarTests = []struct {
 	{
 		name: "partially obstructed",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(10, 10, true)
+			tg := grid.NewGrid(10, 10, true)
 
 			// Create a partial "wall" accross the middle
 			// row with a gap at the left-hand end.
@@ -92,7 +92,7 @@ var aStarTests = []struct {
 	{
 		name: "partially obstructed with heuristic",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(10, 10, true)
+			tg := grid.NewGrid(10, 10, true)
 
 			// Create a partial "wall" accross the middle
 			// row with a gap at the left-hand end.
@@ -153,6 +153,490 @@ func TestAStar(t *testing.T) {
 	}
 }
 
+// terrainGrid returns a partially obstructed grid in the style of the
+// aStarTests grids, with a band of mud (cost 3) before the gap in the
+// wall and a road (cost 1) beside it, over grass of cost 2.
+func terrainGrid() *grid.Grid {
+	return grid.NewGridFrom(
+		"2222222222",
+		"2222222222",
+		"3332222222",
+		"3331111111",
+		"3*********",
+		"3333111112",
+		"2222222212",
+		"2222222212",
+		"2222222212",
+		"2222222212",
+	)
+}
+
+func TestAStarTerrain(t *testing.T) {
+	for _, diagonal := range []bool{false, true} {
+		for _, unit := range []bool{false, true} {
+			g := terrainGrid()
+			g.AllowDiagonal = diagonal
+			g.UnitEdgeWeight = unit
+
+			s := simple.Node(5)
+			target := simple.Node(9*10 + 9)
+			pt, _ := AStar(s, target, g, g.Heuristic)
+			p, cost := pt.To(target)
+			if !topo.IsPathIn(g, p) {
+				t.Errorf("got path that is not path in input graph for diagonal=%t unit=%t", diagonal, unit)
+			}
+
+			bfp, ok := BellmanFordFrom(s, g)
+			if !ok {
+				t.Fatalf("unexpected negative cycle for diagonal=%t unit=%t", diagonal, unit)
+			}
+			if want := bfp.WeightTo(target); math.Abs(cost-want) > 1e-10 {
+				t.Errorf("unexpected cost for diagonal=%t unit=%t: got:%v want:%v", diagonal, unit, cost, want)
+			}
+
+			var sum float64
+			for i, n := range p[1:] {
+				w, _ := g.Weight(p[i], n)
+				sum += w
+			}
+			if math.Abs(sum-cost) > 1e-10 {
+				t.Errorf("path weight does not match cost for diagonal=%t unit=%t: got:%v want:%v", diagonal, unit, sum, cost)
+			}
+
+			paths := DijkstraAllPaths(g)
+			for _, u := range g.Nodes() {
+				for _, v := range g.Nodes() {
+					if h, w := g.Heuristic(u, v), paths.Weight(u, v); h > w+1e-10 {
+						t.Errorf("inadmissible heuristic from %d to %d for diagonal=%t unit=%t: h=%v > %v",
+							u.ID(), v.ID(), diagonal, unit, h, w)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAStarTerrainAvoidsMud(t *testing.T) {
+	g := grid.NewGridFrom(
+		"1111",
+		"1991",
+		"2222",
+	)
+	s := simple.Node(4)
+	target := simple.Node(7)
+
+	for _, test := range []struct {
+		setCost  func()
+		wantPath []int
+		wantCost float64
+	}{
+		{
+			// The direct path through the mud costs 19
+			// so the road around the top is taken.
+			wantPath: []int{4, 0, 1, 2, 3, 7},
+			wantCost: 5,
+		},
+		{
+			// Flooding the top road makes the grass
+			// around the bottom the cheapest route.
+			setCost:  func() { g.SetCost(0, 1, 9) },
+			wantPath: []int{4, 8, 9, 10, 11, 7},
+			wantCost: 9,
+		},
+	} {
+		if test.setCost != nil {
+			test.setCost()
+		}
+		pt, _ := AStar(s, target, g, g.Heuristic)
+		p, cost := pt.To(target)
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path: got:%v want:%v", got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost: got:%v want:%v", cost, test.wantCost)
+		}
+	}
+}
+
+var aStarDiagonalTests = []struct {
+	name   string
+	rows   []string
+	s, t   int
+	length float64
+	cut    bool
+
+	wantCost float64
+}{
+	{
+		name: "corner cutting",
+		rows: []string{
+			"...",
+			".*.",
+			"...",
+		},
+		s: 0, t: 8,
+		cut:      true,
+		wantCost: 2 + math.Sqrt2,
+	},
+	{
+		name: "no corner cutting",
+		rows: []string{
+			"...",
+			".*.",
+			"...",
+		},
+		s: 0, t: 8,
+		wantCost: 4,
+	},
+	{
+		name: "short diagonal",
+		rows: []string{
+			"...",
+			".*.",
+			"...",
+		},
+		s: 0, t: 8,
+		cut:      true,
+		length:   1.5,
+		wantCost: 3.5,
+	},
+	{
+		name: "long diagonal",
+		rows: []string{
+			"...",
+			".*.",
+			"...",
+		},
+		s: 0, t: 8,
+		length:   3,
+		wantCost: 4,
+	},
+	{
+		name: "diagonal shorter than orthogonal",
+		rows: []string{
+			".....",
+			".....",
+			".....",
+			".....",
+			".....",
+		},
+		s: 10, t: 14,
+		length:   0.5,
+		wantCost: 2,
+	},
+	{
+		name: "open",
+		rows: []string{
+			"....",
+			"....",
+			"....",
+		},
+		s: 0, t: 11,
+		wantCost: 1 + 2*math.Sqrt2,
+	},
+	{
+		name: "diagonal gap with corner cutting",
+		rows: []string{
+			".*..",
+			"*...",
+			"....",
+		},
+		s: 0, t: 3,
+		cut:      true,
+		wantCost: 1 + 2*math.Sqrt2,
+	},
+	{
+		name: "diagonal gap without corner cutting",
+		rows: []string{
+			".*..",
+			"*...",
+			"....",
+		},
+		s: 0, t: 3,
+		wantCost: math.Inf(1),
+	},
+}
+
+func TestAStarDiagonal(t *testing.T) {
+	for _, test := range aStarDiagonalTests {
+		g := grid.NewGridFrom(test.rows...)
+		g.AllowDiagonal = true
+		g.DiagonalLength = test.length
+		g.AllowCornerCutting = test.cut
+
+		s, target := simple.Node(test.s), simple.Node(test.t)
+		pt, _ := AStar(s, target, g, g.Octile)
+		p, cost := pt.To(target)
+		if math.Abs(cost-test.wantCost) > 1e-10 && cost != test.wantCost {
+			t.Errorf("unexpected cost for %q: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+		if p != nil && !topo.IsPathIn(g, p) {
+			t.Errorf("got path that is not path in input graph for %q", test.name)
+		}
+
+		bfp, _ := BellmanFordFrom(s, g)
+		if want := bfp.WeightTo(target); math.Abs(cost-want) > 1e-10 && cost != want {
+			t.Errorf("unexpected cost for %q compared to Bellman-Ford: got:%v want:%v", test.name, cost, want)
+		}
+	}
+}
+
+func TestAStarWrapped(t *testing.T) {
+	open := grid.NewGrid(10, 10, true)
+	open.WrapHorizontal = true
+	open.WrapVertical = true
+	pt, _ := AStar(simple.Node(0), simple.Node(99), open, open.Heuristic)
+	p, cost := pt.To(simple.Node(99))
+	if cost != 2 {
+		t.Errorf("unexpected corner to corner cost on torus: got:%v want:2", cost)
+	}
+	if !topo.IsPathIn(open, p) {
+		t.Error("got path that is not path in torus")
+	}
+
+	for _, diagonal := range []bool{false, true} {
+		for _, wrap := range [][2]bool{{true, false}, {false, true}, {true, true}} {
+			g := terrainGrid()
+			g.AllowDiagonal = diagonal
+			g.WrapHorizontal = wrap[0]
+			g.WrapVertical = wrap[1]
+
+			paths := DijkstraAllPaths(g)
+			for _, u := range g.Nodes() {
+				for _, v := range g.Nodes() {
+					if h, w := g.Heuristic(u, v), paths.Weight(u, v); h > w+1e-10 {
+						t.Errorf("inadmissible heuristic from %d to %d for diagonal=%t wrap=%v: h=%v > %v",
+							u.ID(), v.ID(), diagonal, wrap, h, w)
+					}
+				}
+			}
+
+			s, target := simple.Node(5), simple.Node(9*10+9)
+			pt, _ := AStar(s, target, g, g.Heuristic)
+			_, cost := pt.To(target)
+			if want := paths.Weight(s, target); math.Abs(cost-want) > 1e-10 {
+				t.Errorf("unexpected cost for diagonal=%t wrap=%v: got:%v want:%v", diagonal, wrap, cost, want)
+			}
+		}
+	}
+}
+
+func TestAStarWeighted(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+	} {
+		g.SetEdge(e)
+	}
+
+	// penalised adds a penalty to the edge from 1 to 3,
+	// for example a time of day dependent delay.
+	penalised := func(x, y graph.Node) (float64, bool) {
+		w, ok := g.Weight(x, y)
+		if x.ID() == 1 && y.ID() == 3 {
+			w += 10
+		}
+		return w, ok
+	}
+
+	for _, test := range []struct {
+		name     string
+		weight   Weighting
+		wantPath []int
+		wantCost float64
+	}{
+		{name: "default", wantPath: []int{0, 1, 3}, wantCost: 2},
+		{name: "penalised", weight: penalised, wantPath: []int{0, 2, 3}, wantCost: 4},
+	} {
+		pt, _ := AStarWeighted(simple.Node(0), simple.Node(3), g, nil, test.weight)
+		p, cost := pt.To(simple.Node(3))
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if cost != test.wantCost {
+			t.Errorf("unexpected cost for %q: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+		if w := pt.WeightTo(simple.Node(1)); w != 1 {
+			t.Errorf("unexpected weight to 1 for %q: got:%v want:1", test.name, w)
+		}
+	}
+}
+
+func TestAStarInflated(t *testing.T) {
+	for _, test := range aStarTests {
+		s, u := simple.Node(test.s), simple.Node(test.t)
+		pt, wantExpanded := AStar(s, u, test.g, test.heuristic)
+		wantPath, wantCost := pt.To(u)
+
+		pt, expanded := AStarInflated(s, u, test.g, test.heuristic, 1)
+		p, cost := pt.To(u)
+		if cost != wantCost || expanded != wantExpanded || !reflect.DeepEqual(p, wantPath) {
+			t.Errorf("unexpected result for %q with unit inflation: got:%v cost %v expanded %d want:%v cost %v expanded %d",
+				test.name, p, cost, expanded, wantPath, wantCost, wantExpanded)
+		}
+	}
+
+	g := grid.NewGridFrom(
+		"........................",
+		"........................",
+		".........*****..........",
+		".............*..........",
+		".............*..........",
+		".............*..........",
+		"........................",
+		"........................",
+	)
+	s, u := g.NodeAt(4, 2), g.NodeAt(4, 21)
+	manhattan := func(x, y graph.Node) float64 {
+		xr, xc := g.RowCol(x.ID())
+		yr, yc := g.RowCol(y.ID())
+		return float64(abs(xr-yr) + abs(xc-yc))
+	}
+	pt, optimalExpanded := AStar(s, u, g, manhattan)
+	_, optimal := pt.To(u)
+	for _, epsilon := range []float64{1.5, 2, 5} {
+		pt, expanded := AStarInflated(s, u, g, manhattan, epsilon)
+		p, cost := pt.To(u)
+		if !topo.IsPathIn(g, p) {
+			t.Errorf("got path that is not path in input graph with epsilon=%v", epsilon)
+		}
+		if cost > epsilon*optimal {
+			t.Errorf("cost exceeds suboptimality bound with epsilon=%v: got:%v bound:%v", epsilon, cost, epsilon*optimal)
+		}
+		if expanded >= optimalExpanded {
+			t.Errorf("unexpected number of expansions with epsilon=%v: got:%d want less than:%d", epsilon, expanded, optimalExpanded)
+		}
+	}
+
+	for _, epsilon := range []float64{0.5, math.NaN()} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic for epsilon=%v", epsilon)
+				}
+			}()
+			AStarInflated(s, u, g, manhattan, epsilon)
+		}()
+	}
+}
+
+func TestAStarWithTrace(t *testing.T) {
+	for _, test := range aStarTests {
+		s := simple.Node(test.s)
+		pt, trace := AStarWithTrace(s, simple.Node(test.t), test.g, test.heuristic)
+		_, expanded := AStar(s, simple.Node(test.t), test.g, test.heuristic)
+
+		if len(trace.Expansions) != expanded {
+			t.Errorf("unexpected number of expansions for %q: got:%d want:%d",
+				test.name, len(trace.Expansions), expanded)
+		}
+		if len(trace.Expansions) == 0 {
+			t.Errorf("no expansions recorded for %q", test.name)
+			continue
+		}
+		first := trace.Expansions[0]
+		if first.Node.ID() != test.s || first.Parent != nil || first.G != 0 {
+			t.Errorf("unexpected first expansion for %q: got:%+v", test.name, first)
+		}
+
+		p, _ := pt.To(simple.Node(test.t))
+		if p == nil {
+			if len(trace.Open) != 0 {
+				t.Errorf("unexpected non-empty open set for failed search %q", test.name)
+			}
+			continue
+		}
+		last := trace.Expansions[len(trace.Expansions)-1]
+		if last.Node.ID() != test.t {
+			t.Errorf("unexpected last expansion for %q: got:%d want:%d", test.name, last.Node.ID(), test.t)
+		}
+
+		gScores := trace.GScores()
+		if len(gScores) != len(trace.Expansions) {
+			t.Errorf("unexpected number of g-scores for %q: got:%d want:%d",
+				test.name, len(gScores), len(trace.Expansions))
+		}
+		if got, want := gScores[test.t], pt.WeightTo(simple.Node(test.t)); got != want {
+			t.Errorf("unexpected target g-score for %q: got:%v want:%v", test.name, got, want)
+		}
+
+		// Reconstruct the path from the recorded parents.
+		parent := trace.Parents()
+		var got []int
+		for n := graph.Node(simple.Node(test.t)); n != nil; n = parent[n.ID()] {
+			got = append(got, n.ID())
+		}
+		for i, j := 0, len(got)-1; i < j; i, j = i+1, j-1 {
+			got[i], got[j] = got[j], got[i]
+		}
+		var want []int
+		for _, n := range p {
+			want = append(want, n.ID())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("path not reconstructible from trace for %q:\ngot: %v\nwant:%v", test.name, got, want)
+		}
+
+		// All expansions other than that of the
+		// target are closed, in expansion order.
+		if len(trace.Closed) != len(trace.Expansions)-1 {
+			t.Errorf("unexpected number of closed nodes for %q: got:%d want:%d",
+				test.name, len(trace.Closed), len(trace.Expansions)-1)
+		} else {
+			for i, n := range trace.Closed {
+				if n.ID() != trace.Expansions[i].Node.ID() {
+					t.Errorf("closed set not in expansion order for %q at %d: got:%d want:%d",
+						test.name, i, n.ID(), trace.Expansions[i].Node.ID())
+					break
+				}
+			}
+		}
+
+		closed := make(map[int]bool)
+		for _, n := range trace.Closed {
+			closed[n.ID()] = true
+		}
+		for _, n := range trace.Open {
+			if closed[n.ID()] {
+				t.Errorf("node %d in both open and closed sets for %q", n.ID(), test.name)
+			}
+		}
+	}
+}
+
+func TestAStarTraceOpenOrder(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 4},
+		{F: simple.Node(0), T: simple.Node(3), W: 2},
+		{F: simple.Node(0), T: simple.Node(4), W: 3},
+		{F: simple.Node(0), T: simple.Node(5), W: 5},
+	} {
+		g.SetEdge(e)
+	}
+	_, trace := AStarWithTrace(simple.Node(0), simple.Node(1), g, nil)
+	var got []int
+	for _, n := range trace.Open {
+		got = append(got, n.ID())
+	}
+	want := []int{3, 4, 2, 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected open set order: got:%v want:%v", got, want)
+	}
+}
+
 func TestExhaustiveAStar(t *testing.T) {
 	g := simple.NewUndirectedGraph(0, math.Inf(1))
 	nodes := []locatedNode{
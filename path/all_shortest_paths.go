@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// AllShortestPaths returns all shortest paths from u to v in the graph g and the
+// weight of the paths. If the graph does not implement graph.Weighter, UniformCost
+// is used and the paths returned are all the minimum hop paths from u to v. Paths
+// containing zero-weight cycles are not returned. If limit is positive, at most limit
+// paths are returned; the number of shortest paths may be exponential in the size of
+// the graph. If there is no path from u to v, AllShortestPaths returns nil paths
+// and +Inf weight. AllShortestPaths will panic if g has a u-reachable negative
+// edge weight.
+//
+// The time complexity of AllShortestPaths is O(|E|.log|V|) plus the cost of
+// enumerating the returned paths.
+func AllShortestPaths(u, v graph.Node, g graph.Graph, limit int) (paths [][]graph.Node, weight float64) {
+	if !g.Has(u) || !g.Has(v) {
+		return nil, math.Inf(1)
+	}
+	var weightOf Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weightOf = wg.Weight
+	} else {
+		weightOf = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	dist := make([]float64, len(nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	// prev holds all the predecessors of each
+	// node on shortest paths from u.
+	prev := make([][]int, len(nodes))
+
+	from := indexOf[u.ID()]
+	to := indexOf[v.ID()]
+	dist[from] = 0
+	Q := priorityQueue{{node: u, dist: 0}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		if mid.dist > dist[to] {
+			// All paths to v have been found.
+			break
+		}
+		k := indexOf[mid.node.ID()]
+		if mid.dist > dist[k] {
+			continue
+		}
+		for _, n := range g.From(mid.node) {
+			j := indexOf[n.ID()]
+			w, ok := weightOf(mid.node, n)
+			if !ok {
+				panic("all shortest paths: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("all shortest paths: negative edge weight")
+			}
+			joint := dist[k] + w
+			switch {
+			case joint < dist[j]:
+				heap.Push(&Q, distanceNode{node: n, dist: joint})
+				dist[j] = joint
+				prev[j] = append(prev[j][:0], k)
+			case joint == dist[j] && j != k:
+				var seen bool
+				for _, p := range prev[j] {
+					if p == k {
+						seen = true
+						break
+					}
+				}
+				if !seen {
+					prev[j] = append(prev[j], k)
+				}
+			}
+		}
+	}
+	if math.IsInf(dist[to], 1) {
+		return nil, math.Inf(1)
+	}
+
+	// Walk the predecessor graph back from v,
+	// avoiding zero-weight cycles.
+	var walk func(k int, seen []bool, path []graph.Node)
+	walk = func(k int, seen []bool, path []graph.Node) {
+		if limit > 0 && len(paths) >= limit {
+			return
+		}
+		path = append(path, nodes[k])
+		if k == from {
+			p := make([]graph.Node, len(path))
+			for i, n := range path {
+				p[len(p)-1-i] = n
+			}
+			paths = append(paths, p)
+			return
+		}
+		seen[k] = true
+		for _, j := range prev[k] {
+			if !seen[j] {
+				walk(j, seen, path)
+			}
+		}
+		seen[k] = false
+	}
+	walk(to, make([]bool, len(nodes)), nil)
+
+	return paths, dist[to]
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/path/internal/testgraphs"
+	"github.com/gonum/graph/simple"
+)
+
+func TestAllShortestPaths(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetEdge(e)
+		}
+
+		paths, weight := AllShortestPaths(test.Query.From(), test.Query.To(), g.(graph.Graph), 0)
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight: got:%f want:%f", test.Name, weight, test.Weight)
+		}
+		got := pathIDs(paths)
+		if !reflect.DeepEqual(got, test.WantPaths) {
+			t.Errorf("%q: unexpected shortest paths:\ngot: %v\nwant:%v", test.Name, got, test.WantPaths)
+		}
+
+		nps, weight := AllShortestPaths(test.NoPathFor.From(), test.NoPathFor.To(), g.(graph.Graph), 0)
+		if nps != nil || !math.IsInf(weight, 1) {
+			t.Errorf("%q: unexpected path:\ngot: paths=%v weight=%f\nwant:path=<nil> weight=+Inf",
+				test.Name, nps, weight)
+		}
+	}
+}
+
+func TestAllShortestPathsUnweighted(t *testing.T) {
+	// A 3×3 grid has six minimum hop paths
+	// between opposite corners.
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			n := simple.Node(r*3 + c)
+			if c < 2 {
+				g.SetEdge(simple.Edge{F: n, T: n + 1})
+			}
+			if r < 2 {
+				g.SetEdge(simple.Edge{F: n, T: n + 3})
+			}
+		}
+	}
+	// Hide the zero edge weights of the grid.
+	type unweighted struct{ graph.Graph }
+
+	want := [][]int{
+		{0, 1, 2, 5, 8},
+		{0, 1, 4, 5, 8},
+		{0, 1, 4, 7, 8},
+		{0, 3, 4, 5, 8},
+		{0, 3, 4, 7, 8},
+		{0, 3, 6, 7, 8},
+	}
+	paths, weight := AllShortestPaths(simple.Node(0), simple.Node(8), unweighted{g}, 0)
+	if weight != 4 {
+		t.Errorf("unexpected weight: got:%f want:4", weight)
+	}
+	if got := pathIDs(paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected shortest paths:\ngot: %v\nwant:%v", got, want)
+	}
+
+	for _, limit := range []int{1, 4, 6, 10} {
+		paths, _ := AllShortestPaths(simple.Node(0), simple.Node(8), unweighted{g}, limit)
+		wantLen := limit
+		if wantLen > len(want) {
+			wantLen = len(want)
+		}
+		if len(paths) != wantLen {
+			t.Errorf("unexpected number of paths for limit %d: got:%d want:%d", limit, len(paths), wantLen)
+		}
+	}
+}
+
+func pathIDs(paths [][]graph.Node) [][]int {
+	var ids [][]int
+	if len(paths) != 0 {
+		ids = make([][]int, len(paths))
+	}
+	for i, p := range paths {
+		for _, v := range p {
+			ids[i] = append(ids[i], v.ID())
+		}
+	}
+	sort.Sort(ordered.BySliceValues(ids))
+	return ids
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/graphs/gen"
+	"github.com/gonum/graph/grid"
 	"github.com/gonum/graph/simple"
 )
 
@@ -140,3 +141,26 @@ func BenchmarkAStarUndirectedmallWorld_100_5_20_2_Heur(b *testing.B) {
 	}
 	benchmarkAStarHeuristic(b, nswUndirected_100_5_20_2, h)
 }
+
+// fromOnly hides any graph.FromVisitor implementation
+// of the wrapped graph.
+type fromOnly struct {
+	graph.Graph
+	graph.Weighter
+}
+
+func benchmarkDijkstraFrom(b *testing.B, g graph.Graph) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DijkstraFrom(simple.Node(0), g)
+	}
+}
+
+func BenchmarkDijkstraGrid_1000_Visit(b *testing.B) {
+	benchmarkDijkstraFrom(b, grid.NewGrid(1000, 1000, true))
+}
+func BenchmarkDijkstraGrid_1000_From(b *testing.B) {
+	g := grid.NewGrid(1000, 1000, true)
+	benchmarkDijkstraFrom(b, fromOnly{g, g})
+}
@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/traverse"
+)
+
+// BreadthFirstSearchFunc performs a breadth-first search of g from s for the
+// nearest node, by number of edges, for which isGoal returns true. The path from
+// s to the goal node and the goal node are returned, along with the number of
+// nodes expanded during the search. If s satisfies isGoal, the path holds only
+// s. If no reachable node satisfies isGoal, the returned path and goal are nil.
+func BreadthFirstSearchFunc(s graph.Node, g graph.Graph, isGoal func(graph.Node) bool) (path []graph.Node, goal graph.Node, expanded int) {
+	if !g.Has(s) {
+		return nil, nil, 0
+	}
+	parent := make(map[int]graph.Node)
+	bf := traverse.BreadthFirst{
+		Visit: func(u, v graph.Node) { parent[v.ID()] = u },
+	}
+	goal = bf.Walk(g, s, func(n graph.Node, _ int) bool {
+		expanded++
+		return isGoal(n)
+	})
+	if goal == nil {
+		return nil, nil, expanded
+	}
+	for n := goal; n != nil; n = parent[n.ID()] {
+		path = append(path, n)
+	}
+	reverse(path)
+	return path, goal, expanded
+}
+
+// HopDistance returns the number of edges in a shortest path from s to t in g,
+// ignoring edge weights, and whether t is reachable from s. If t is not
+// reachable from s, HopDistance returns -1 and false.
+func HopDistance(s, t graph.Node, g graph.Graph) (hops int, ok bool) {
+	if !g.Has(s) || !g.Has(t) {
+		return -1, false
+	}
+	tid := t.ID()
+	hops = -1
+	var bf traverse.BreadthFirst
+	bf.Walk(g, s, func(n graph.Node, depth int) bool {
+		if n.ID() == tid {
+			hops = depth
+			return true
+		}
+		return false
+	})
+	return hops, hops >= 0
+}
@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestBreadthFirstSearchFunc(t *testing.T) {
+	// 0 - 1 - 2 - 3 - 4 - 5
+	//      \
+	//       6 - 7
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {1, 6}, {6, 7}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(8))
+
+	for _, test := range []struct {
+		name   string
+		s      int
+		isGoal func(graph.Node) bool
+
+		wantPath []int
+	}{
+		{
+			name:     "start",
+			s:        0,
+			isGoal:   func(n graph.Node) bool { return n.ID() == 0 },
+			wantPath: []int{0},
+		},
+		{
+			name:     "leaf",
+			s:        0,
+			isGoal:   func(n graph.Node) bool { return g.Degree(n) == 1 && n.ID() != 0 },
+			wantPath: []int{0, 1, 6, 7},
+		},
+		{
+			name:     "nearest of several",
+			s:        2,
+			isGoal:   func(n graph.Node) bool { return n.ID() >= 5 },
+			wantPath: []int{2, 1, 6},
+		},
+		{
+			name:   "unreachable",
+			s:      0,
+			isGoal: func(n graph.Node) bool { return n.ID() == 8 },
+		},
+		{
+			name:   "absent start",
+			s:      9,
+			isGoal: func(graph.Node) bool { return true },
+		},
+	} {
+		path, goal, expanded := BreadthFirstSearchFunc(simple.Node(test.s), g, test.isGoal)
+		var got []int
+		for _, n := range path {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if test.wantPath == nil {
+			if goal != nil {
+				t.Errorf("unexpected goal for %q: %v", test.name, goal)
+			}
+			continue
+		}
+		if goal == nil || goal.ID() != test.wantPath[len(test.wantPath)-1] {
+			t.Errorf("unexpected goal for %q: got:%v want:%d", test.name, goal, test.wantPath[len(test.wantPath)-1])
+		}
+		if expanded < len(test.wantPath) {
+			t.Errorf("too few nodes expanded for %q: got:%d want at least:%d", test.name, expanded, len(test.wantPath))
+		}
+	}
+}
+
+func TestHopDistance(t *testing.T) {
+	// 0 - 1 - 2 - 3 - 4 - 5
+	//      \
+	//       6 - 7
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {1, 6}, {6, 7}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(8))
+
+	// 0 -> 1 -> 2
+	d := simple.NewDirectedGraph(0, math.Inf(1))
+	d.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	d.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+		s, t int
+
+		want   int
+		wantOK bool
+	}{
+		{name: "self", g: g, s: 0, t: 0, want: 0, wantOK: true},
+		{name: "neighbor", g: g, s: 0, t: 1, want: 1, wantOK: true},
+		{name: "far", g: g, s: 5, t: 7, want: 6, wantOK: true},
+		{name: "unreachable", g: g, s: 0, t: 8, want: -1},
+		{name: "absent start", g: g, s: 9, t: 0, want: -1},
+		{name: "absent target", g: g, s: 0, t: 9, want: -1},
+		{name: "directed", g: d, s: 0, t: 2, want: 2, wantOK: true},
+		{name: "directed reverse", g: d, s: 2, t: 0, want: -1},
+	} {
+		got, ok := HopDistance(simple.Node(test.s), simple.Node(test.t), test.g)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("unexpected hop distance for %q: got:(%d, %t) want:(%d, %t)",
+				test.name, got, ok, test.want, test.wantOK)
+		}
+	}
+}
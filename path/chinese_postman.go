@@ -0,0 +1,172 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/topo"
+)
+
+// ChinesePostman returns a minimum weight closed walk in the undirected graph g
+// that traverses every edge of g at least once, and the total weight of the walk.
+// If the graph does not implement graph.Weighter, UniformCost is used.
+//
+// The walk is returned as a sequence of edges oriented in the direction of travel,
+// each holding the weight of the traversed edge, such that the To node of each edge
+// is the From node of the next and the To node of the last edge is the From node
+// of the first. Edges of g that are incident to odd degree nodes may be traversed
+// more than once. If g has no edges ChinesePostman returns a nil walk and zero
+// weight, and if the edges of g are not all within a single connected component,
+// no closed walk exists and ChinesePostman returns a nil walk and +Inf weight.
+//
+// ChinesePostman will panic if g has a negative edge weight.
+//
+// The odd degree nodes of g are paired by a minimum weight perfect matching over
+// their shortest path distances, so the time complexity of ChinesePostman is
+// O(|O|(|E|+|V|log|V|) + |O|^3) where |O| is the number of odd degree nodes.
+func ChinesePostman(g graph.Undirected) (walk []graph.Edge, weight float64) {
+	var weightOf Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weightOf = wg.Weight
+	} else {
+		weightOf = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+
+	m := postmanMultigraph{adjacent: make(map[int][]int)}
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if v.ID() < u.ID() {
+				continue
+			}
+			w, ok := weightOf(u, v)
+			if !ok {
+				panic("chinese postman: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("chinese postman: negative edge weight")
+			}
+			m.add(u, v, w)
+		}
+	}
+	if len(m.edges) == 0 {
+		return nil, 0
+	}
+
+	var withEdges int
+	for _, c := range topo.ConnectedComponents(g) {
+		if len(m.adjacent[c[0].ID()]) != 0 {
+			withEdges++
+		}
+	}
+	if withEdges != 1 {
+		return nil, math.Inf(1)
+	}
+
+	var odd []graph.Node
+	for _, n := range nodes {
+		if len(m.adjacent[n.ID()])%2 != 0 {
+			odd = append(odd, n)
+		}
+	}
+	if len(odd) != 0 {
+		// Duplicate the edges on the shortest paths between
+		// the odd degree nodes paired by a minimum weight
+		// perfect matching, making all node degrees even.
+		paths := make([]Shortest, len(odd))
+		var dist []weightedPair
+		for i, u := range odd {
+			paths[i] = DijkstraFrom(u, g)
+			for j, v := range odd[i+1:] {
+				dist = append(dist, weightedPair{i: i, j: i + 1 + j, w: paths[i].WeightTo(v)})
+			}
+		}
+		mate, _ := minWeightPerfectMatching(len(odd), dist)
+		for i, j := range mate {
+			if j < i {
+				continue
+			}
+			path, _ := paths[i].To(odd[j])
+			for k, u := range path[:len(path)-1] {
+				v := path[k+1]
+				w, _ := weightOf(u, v)
+				m.add(u, v, w)
+			}
+		}
+	}
+
+	walk = m.eulerianCircuit(m.edges[0].F)
+	for _, e := range walk {
+		weight += e.Weight()
+	}
+	return walk, weight
+}
+
+// postmanMultigraph is an undirected multigraph used to
+// construct an Eulerian circuit.
+type postmanMultigraph struct {
+	edges    []simple.Edge
+	adjacent map[int][]int
+}
+
+// add adds an edge between u and v with weight w to the multigraph.
+// Self edges are added to the adjacency of their node twice.
+func (m *postmanMultigraph) add(u, v graph.Node, w float64) {
+	i := len(m.edges)
+	m.edges = append(m.edges, simple.Edge{F: u, T: v, W: w})
+	m.adjacent[u.ID()] = append(m.adjacent[u.ID()], i)
+	m.adjacent[v.ID()] = append(m.adjacent[v.ID()], i)
+}
+
+// eulerianCircuit returns an Eulerian circuit of the multigraph starting
+// from the node start using Hierholzer's algorithm. All nodes in the multigraph
+// must have even degree and all edges must be reachable from start.
+func (m *postmanMultigraph) eulerianCircuit(start graph.Node) []graph.Edge {
+	used := make([]bool, len(m.edges))
+	next := make(map[int]int)
+
+	var circuit, steps []graph.Edge
+	stack := []graph.Node{start}
+	for len(stack) != 0 {
+		u := stack[len(stack)-1]
+		uid := u.ID()
+		adj := m.adjacent[uid]
+		for next[uid] < len(adj) && used[adj[next[uid]]] {
+			next[uid]++
+		}
+		if next[uid] < len(adj) {
+			i := adj[next[uid]]
+			used[i] = true
+			e := m.edges[i]
+			v := e.T
+			if v.ID() == uid {
+				v = e.F
+			}
+			stack = append(stack, v)
+			steps = append(steps, simple.Edge{F: u, T: v, W: e.W})
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		if len(steps) != 0 {
+			circuit = append(circuit, steps[len(steps)-1])
+			steps = steps[:len(steps)-1]
+		}
+	}
+
+	// The circuit is constructed in reverse.
+	for i, j := 0, len(circuit)-1; i < j; i, j = i+1, j-1 {
+		circuit[i], circuit[j] = circuit[j], circuit[i]
+	}
+	return circuit
+}
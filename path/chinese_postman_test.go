@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var chinesePostmanTests = []struct {
+	name  string
+	nodes []int
+	edges []simple.Edge
+
+	wantLen    int
+	wantWeight float64
+}{
+	{
+		name:       "empty",
+		wantWeight: 0,
+	},
+	{
+		name:  "no edges",
+		nodes: []int{0, 1},
+
+		wantWeight: 0,
+	},
+	{
+		name: "eulerian",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(2), T: simple.Node(0), W: 3},
+		},
+		wantLen:    3,
+		wantWeight: 6,
+	},
+	{
+		name: "path",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+		},
+		wantLen:    4,
+		wantWeight: 6,
+	},
+	{
+		name: "square with diagonal",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(0), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 3},
+		},
+		// The diagonal is more expensive than
+		// going around the square.
+		wantLen:    7,
+		wantWeight: 9,
+	},
+	{
+		name: "K4",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		wantLen:    8,
+		wantWeight: 8,
+	},
+	{
+		name: "pendants",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(0), W: 1},
+			{F: simple.Node(0), T: simple.Node(4), W: 1},
+			{F: simple.Node(2), T: simple.Node(5), W: 2},
+		},
+		// Odd nodes are 0, 2, 4 and 5. The best pairing
+		// is {0, 4} and {2, 5}, duplicating the pendants.
+		wantLen:    8,
+		wantWeight: 7 + 3,
+	},
+	{
+		name: "disconnected",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		wantWeight: math.Inf(1),
+	},
+	{
+		// A star has an odd degree node at each leaf,
+		// more than can be paired by exhaustive search.
+		name: "star",
+		edges: func() []simple.Edge {
+			var edges []simple.Edge
+			for i := 1; i <= 100; i++ {
+				edges = append(edges, simple.Edge{F: simple.Node(0), T: simple.Node(i), W: 1})
+			}
+			return edges
+		}(),
+		wantLen:    200,
+		wantWeight: 200,
+	},
+}
+
+func TestChinesePostman(t *testing.T) {
+	for _, test := range chinesePostmanTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for _, n := range test.nodes {
+			g.AddNode(simple.Node(n))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		walk, weight := ChinesePostman(g)
+		if weight != test.wantWeight {
+			t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+		if len(walk) != test.wantLen {
+			t.Errorf("unexpected walk length for %q: got:%d want:%d", test.name, len(walk), test.wantLen)
+		}
+		if len(walk) == 0 {
+			continue
+		}
+
+		var sum float64
+		seen := make(map[[2]int]bool)
+		for i, e := range walk {
+			u, v := e.From(), e.To()
+			if !g.HasEdgeBetween(u, v) {
+				t.Errorf("walk for %q has step %d-%d not in graph", test.name, u.ID(), v.ID())
+			}
+			if w, _ := g.Weight(u, v); e.Weight() != w {
+				t.Errorf("unexpected step weight for %q %d-%d: got:%v want:%v", test.name, u.ID(), v.ID(), e.Weight(), w)
+			}
+			if next := walk[(i+1)%len(walk)].From(); v.ID() != next.ID() {
+				t.Errorf("walk for %q is not closed at step %d: %d != %d", test.name, i, v.ID(), next.ID())
+			}
+			seen[edgeKey(u, v)] = true
+			sum += e.Weight()
+		}
+		if sum != weight {
+			t.Errorf("unexpected sum of walk weights for %q: got:%v want:%v", test.name, sum, weight)
+		}
+		for _, e := range test.edges {
+			if !seen[edgeKey(e.F, e.T)] {
+				t.Errorf("walk for %q does not traverse edge %d-%d", test.name, e.F.ID(), e.T.ID())
+			}
+		}
+	}
+}
+
+func edgeKey(u, v graph.Node) [2]int {
+	if u.ID() > v.ID() {
+		u, v = v, u
+	}
+	return [2]int{u.ID(), v.ID()}
+}
@@ -6,6 +6,7 @@ package path
 
 import (
 	"container/heap"
+	"math"
 
 	"github.com/gonum/graph"
 )
@@ -19,13 +20,99 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 	if !g.Has(u) {
 		return Shortest{from: u}
 	}
-	var weight Weighting
-	if wg, ok := g.(graph.Weighter); ok {
-		weight = wg.Weight
-	} else {
-		weight = UniformCost(g)
+	return dijkstraFrom(u, g, visitorFor(g, nil, "dijkstra"))
+}
+
+// DijkstraAvoiding returns a shortest path from s to t in the graph g and its
+// weight, treating the nodes with IDs in blockedNodes and the edges for which
+// blockedEdges returns true as absent from g. The graph is not modified. Either
+// blockedNodes or blockedEdges may be nil, in which case no nodes or edges are
+// blocked respectively. If there is no path from s to t avoiding the blocked
+// nodes and edges, DijkstraAvoiding returns a nil path and +Inf weight.
+//
+// If the graph does not implement graph.Weighter, UniformCost is used.
+// DijkstraAvoiding will panic if g has an s-reachable negative edge weight
+// that is not blocked.
+func DijkstraAvoiding(s, t graph.Node, g graph.Graph, blockedNodes map[int]bool, blockedEdges func(graph.Edge) bool) (path []graph.Node, weight float64) {
+	if !g.Has(s) || blockedNodes[s.ID()] {
+		return nil, math.Inf(1)
 	}
+	visit := visitorFor(g, nil, "dijkstra")
+	avoiding := func(u graph.Node, fn func(v graph.Node, w float64) bool) {
+		visit(u, func(v graph.Node, w float64) bool {
+			if blockedNodes[v.ID()] {
+				return true
+			}
+			if blockedEdges != nil && blockedEdges(g.Edge(u, v)) {
+				return true
+			}
+			return fn(v, w)
+		})
+	}
+	return dijkstraFrom(s, g, avoiding).To(t)
+}
+
+// PairwiseDistances returns the shortest path distances in the graph g between
+// each pair of the given nodes, indexed by the IDs of the start and end nodes.
+// Distances from each node are found by a Dijkstra search that terminates once
+// the distances to all the given nodes are known, so PairwiseDistances is more
+// efficient than DijkstraAllPaths when the number of given nodes is small
+// relative to the size of g. Nodes that are not reachable from a start node,
+// including nodes that are not in g, have a distance of +Inf.
+//
+// If the graph does not implement graph.Weighter, UniformCost is used.
+// PairwiseDistances will panic if g has a negative edge weight reachable
+// from one of the given nodes.
+func PairwiseDistances(nodes []graph.Node, g graph.Graph) map[int]map[int]float64 {
+	visit := visitorFor(g, nil, "dijkstra")
 
+	dist := make(map[int]map[int]float64, len(nodes))
+	for _, u := range nodes {
+		to := make(map[int]float64, len(nodes))
+		for _, v := range nodes {
+			to[v.ID()] = math.Inf(1)
+		}
+		dist[u.ID()] = to
+		if !g.Has(u) {
+			continue
+		}
+
+		// remain is the number of given nodes that
+		// have not yet been removed from the queue.
+		remain := len(to)
+		best := map[int]float64{u.ID(): 0}
+		Q := priorityQueue{{node: u, dist: 0}}
+		var d float64
+		relax := func(v graph.Node, w float64) bool {
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			if b, ok := best[v.ID()]; !ok || d+w < b {
+				best[v.ID()] = d + w
+				heap.Push(&Q, distanceNode{node: v, dist: d + w})
+			}
+			return true
+		}
+		for Q.Len() != 0 && remain != 0 {
+			mid := heap.Pop(&Q).(distanceNode)
+			if mid.dist > best[mid.node.ID()] {
+				continue
+			}
+			d = mid.dist
+			if _, ok := to[mid.node.ID()]; ok {
+				to[mid.node.ID()] = d
+				remain--
+			}
+			visit(mid.node, relax)
+		}
+	}
+	return dist
+}
+
+// dijkstraFrom is the single source implementation of Dijkstra. It is shared
+// between DijkstraFrom and DijkstraAvoiding, with visit determining the edges
+// and weights seen by the search.
+func dijkstraFrom(u graph.Node, g graph.Graph, visit func(u graph.Node, fn func(v graph.Node, w float64) bool)) Shortest {
 	nodes := g.Nodes()
 	path := newShortestFrom(u, nodes)
 
@@ -40,27 +127,26 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 	//
 	// http://www.cs.utexas.edu/ftp/techreports/tr07-54.pdf
 	Q := priorityQueue{{node: u, dist: 0}}
+	var k int
+	relax := func(v graph.Node, w float64) bool {
+		if w < 0 {
+			panic("dijkstra: negative edge weight")
+		}
+		j := path.indexOf[v.ID()]
+		joint := path.dist[k] + w
+		if joint < path.dist[j] {
+			heap.Push(&Q, distanceNode{node: v, dist: joint})
+			path.set(j, joint, k)
+		}
+		return true
+	}
 	for Q.Len() != 0 {
 		mid := heap.Pop(&Q).(distanceNode)
-		k := path.indexOf[mid.node.ID()]
+		k = path.indexOf[mid.node.ID()]
 		if mid.dist > path.dist[k] {
 			continue
 		}
-		for _, v := range g.From(mid.node) {
-			j := path.indexOf[v.ID()]
-			w, ok := weight(mid.node, v)
-			if !ok {
-				panic("dijkstra: unexpected invalid weight")
-			}
-			if w < 0 {
-				panic("dijkstra: negative edge weight")
-			}
-			joint := path.dist[k] + w
-			if joint < path.dist[j] {
-				heap.Push(&Q, distanceNode{node: v, dist: joint})
-				path.set(j, joint, k)
-			}
-		}
+		visit(mid.node, relax)
 	}
 
 	return path
@@ -82,15 +168,28 @@ func DijkstraAllPaths(g graph.Graph) (paths AllShortest) {
 // of the nodes slice and the indexOf map. It returns nothing, but stores the
 // result of the work in the paths parameter which is a reference type.
 func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
-	var weight Weighting
-	if wg, ok := g.(graph.Weighter); ok {
-		weight = wg.Weight
-	} else {
-		weight = UniformCost(g)
-	}
+	visit := visitorFor(g, nil, "dijkstra")
 
-	var Q priorityQueue
-	for i, u := range paths.nodes {
+	var (
+		Q    priorityQueue
+		i, k int
+	)
+	relax := func(v graph.Node, w float64) bool {
+		if w < 0 {
+			panic("dijkstra: negative edge weight")
+		}
+		j := paths.indexOf[v.ID()]
+		joint := paths.dist.At(i, k) + w
+		if joint < paths.dist.At(i, j) {
+			heap.Push(&Q, distanceNode{node: v, dist: joint})
+			paths.set(i, j, joint, k)
+		} else if joint == paths.dist.At(i, j) {
+			paths.add(i, j, k)
+		}
+		return true
+	}
+	var u graph.Node
+	for i, u = range paths.nodes {
 		// Dijkstra's algorithm here is implemented essentially as
 		// described in Function B.2 in figure 6 of UTCS Technical
 		// Report TR-07-54 with the addition of handling multiple
@@ -102,27 +201,11 @@ func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 		heap.Push(&Q, distanceNode{node: u, dist: 0})
 		for Q.Len() != 0 {
 			mid := heap.Pop(&Q).(distanceNode)
-			k := paths.indexOf[mid.node.ID()]
+			k = paths.indexOf[mid.node.ID()]
 			if mid.dist < paths.dist.At(i, k) {
 				paths.dist.Set(i, k, mid.dist)
 			}
-			for _, v := range g.From(mid.node) {
-				j := paths.indexOf[v.ID()]
-				w, ok := weight(mid.node, v)
-				if !ok {
-					panic("dijkstra: unexpected invalid weight")
-				}
-				if w < 0 {
-					panic("dijkstra: negative edge weight")
-				}
-				joint := paths.dist.At(i, k) + w
-				if joint < paths.dist.At(i, j) {
-					heap.Push(&Q, distanceNode{node: v, dist: joint})
-					paths.set(i, j, joint, k)
-				} else if joint == paths.dist.At(i, j) {
-					paths.add(i, j, k)
-				}
-			}
+			visit(mid.node, relax)
 		}
 	}
 }
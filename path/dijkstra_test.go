@@ -13,6 +13,7 @@ import (
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/graph/path/internal/testgraphs"
+	"github.com/gonum/graph/simple"
 )
 
 func TestDijkstraFrom(t *testing.T) {
@@ -81,6 +82,67 @@ func TestDijkstraFrom(t *testing.T) {
 	}
 }
 
+func TestDijkstraDense(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetEdge(e)
+		}
+
+		// The dense graphs hold nodes with IDs
+		// from zero up to the largest used ID.
+		var n int
+		for _, e := range append([]simple.Edge{test.Query, test.NoPathFor}, test.Edges...) {
+			for _, id := range []int{e.From().ID(), e.To().ID()} {
+				if id >= n {
+					n = id + 1
+				}
+			}
+		}
+
+		var dense interface {
+			graph.Graph
+			graph.EdgeSetter
+		}
+		if _, ok := g.(graph.Directed); ok {
+			dense = simple.NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+		} else {
+			dense = simple.NewUndirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+		}
+		for _, e := range test.Edges {
+			dense.SetEdge(e)
+		}
+
+		pt := DijkstraFrom(test.Query.From(), dense)
+		if weight := pt.WeightTo(test.Query.To()); weight != test.Weight {
+			t.Errorf("%q: unexpected weight from dense DijkstraFrom: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+		want := DijkstraFrom(test.Query.From(), g.(graph.Graph))
+		for _, v := range g.(graph.Graph).Nodes() {
+			if got, want := pt.WeightTo(v), want.WeightTo(v); got != want {
+				t.Errorf("%q: unexpected weight to %d from dense DijkstraFrom: got:%f want:%f",
+					test.Name, v.ID(), got, want)
+			}
+		}
+
+		apt, _ := AStar(test.Query.From(), test.Query.To(), dense, nil)
+		if weight := apt.WeightTo(test.Query.To()); weight != test.Weight {
+			t.Errorf("%q: unexpected weight from dense AStar: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+
+		paths := DijkstraAllPaths(dense)
+		if weight := paths.Weight(test.Query.From(), test.Query.To()); weight != test.Weight {
+			t.Errorf("%q: unexpected weight from dense DijkstraAllPaths: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+	}
+}
+
 func TestDijkstraAllPaths(t *testing.T) {
 	for _, test := range testgraphs.ShortestPathTests {
 		g := test.Graph()
@@ -176,3 +238,128 @@ func TestDijkstraAllPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestDijkstraAvoiding(t *testing.T) {
+	// The graph is a ring 0-1-2-3-4-0 with a chord
+	// 0-2 so there are several routes from 0 to 3.
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 5},
+		{F: simple.Node(4), T: simple.Node(0), W: 5},
+		{F: simple.Node(0), T: simple.Node(2), W: 1.5},
+	} {
+		g.SetEdge(e)
+	}
+	isEdge := func(u, v int) func(graph.Edge) bool {
+		return func(e graph.Edge) bool {
+			x, y := e.From().ID(), e.To().ID()
+			return (x == u && y == v) || (x == v && y == u)
+		}
+	}
+
+	for _, test := range []struct {
+		name         string
+		blockedNodes map[int]bool
+		blockedEdges func(graph.Edge) bool
+
+		want       []int
+		wantWeight float64
+	}{
+		{
+			name:       "nothing blocked",
+			want:       []int{0, 2, 3},
+			wantWeight: 2.5,
+		},
+		{
+			name:         "chord blocked",
+			blockedEdges: isEdge(2, 0),
+			want:         []int{0, 1, 2, 3},
+			wantWeight:   3,
+		},
+		{
+			name:         "node 2 blocked",
+			blockedNodes: map[int]bool{2: true},
+			want:         []int{0, 4, 3},
+			wantWeight:   10,
+		},
+		{
+			name:         "all routes blocked",
+			blockedNodes: map[int]bool{2: true},
+			blockedEdges: isEdge(3, 4),
+			wantWeight:   math.Inf(1),
+		},
+		{
+			name:         "start blocked",
+			blockedNodes: map[int]bool{0: true},
+			wantWeight:   math.Inf(1),
+		},
+		{
+			name:         "goal blocked",
+			blockedNodes: map[int]bool{3: true},
+			wantWeight:   math.Inf(1),
+		},
+	} {
+		p, weight := DijkstraAvoiding(simple.Node(0), simple.Node(3), g, test.blockedNodes, test.blockedEdges)
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		if weight != test.wantWeight {
+			t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+	}
+	if n := len(g.Edges()); n != 6 {
+		t.Errorf("graph modified by search: got %d edges, want 6", n)
+	}
+}
+
+func TestPairwiseDistances(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetEdge(e)
+		}
+		all := DijkstraAllPaths(g.(graph.Graph))
+
+		// Use every other node of the graph and a
+		// node that is not in the graph.
+		nodes := g.(graph.Graph).Nodes()
+		sort.Sort(ordered.ByID(nodes))
+		var subset []graph.Node
+		for i := 0; i < len(nodes); i += 2 {
+			subset = append(subset, nodes[i])
+		}
+		absent := simple.Node(-1)
+		subset = append(subset, absent)
+
+		got := PairwiseDistances(subset, g.(graph.Graph))
+		if len(got) != len(subset) {
+			t.Errorf("%q: unexpected number of start nodes: got:%d want:%d", test.Name, len(got), len(subset))
+		}
+		for _, u := range subset {
+			if len(got[u.ID()]) != len(subset) {
+				t.Errorf("%q: unexpected number of end nodes from %d: got:%d want:%d",
+					test.Name, u.ID(), len(got[u.ID()]), len(subset))
+			}
+			for _, v := range subset {
+				want := math.Inf(1)
+				if u.ID() != absent.ID() && v.ID() != absent.ID() {
+					want = all.Weight(u, v)
+				}
+				if d := got[u.ID()][v.ID()]; d != want {
+					t.Errorf("%q: unexpected distance from %d to %d: got:%v want:%v",
+						test.Name, u.ID(), v.ID(), d, want)
+				}
+			}
+		}
+	}
+}
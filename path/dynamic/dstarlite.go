@@ -28,6 +28,10 @@ type DStarLite struct {
 
 	weight    path.Weighting
 	heuristic path.Heuristic
+
+	// expanded is the number of node
+	// expansions performed by the planner.
+	expanded int
 }
 
 // WorldModel is a mutable weighted directed graph that returns nodes identified
@@ -191,6 +195,7 @@ func (d *DStarLite) findShortestPath() {
 		case u.key.less(kNew):
 			d.queue.update(u, kNew)
 		case u.g > u.rhs:
+			d.expanded++
 			u.g = u.rhs
 			d.queue.remove(u)
 			for _, _s := range d.model.To(u) {
@@ -201,6 +206,7 @@ func (d *DStarLite) findShortestPath() {
 				d.update(s)
 			}
 		default:
+			d.expanded++
 			gOld := u.g
 			u.g = math.Inf(1)
 			for _, _s := range append(d.model.To(u), u) {
@@ -331,6 +337,12 @@ func (d *DStarLite) worldNodeFor(n graph.Node) *dStarLiteNode {
 	}
 }
 
+// Expanded returns the number of node expansions performed by the planner
+// since it was created, including those performed by NewDStarLite.
+func (d *DStarLite) Expanded() int {
+	return d.expanded
+}
+
 // Here returns the current location.
 func (d *DStarLite) Here() graph.Node {
 	return d.s.Node
@@ -9,11 +9,13 @@ import (
 	"flag"
 	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
 	"github.com/gonum/graph/path"
 	"github.com/gonum/graph/path/internal"
 	"github.com/gonum/graph/path/internal/testgraphs"
@@ -88,7 +90,7 @@ func TestDStarLiteNullHeuristic(t *testing.T) {
 }
 
 var dynamicDStarLiteTests = []struct {
-	g          *internal.Grid
+	g          *grid.Grid
 	radius     float64
 	all        bool
 	diag, unit bool
@@ -105,7 +107,7 @@ var dynamicDStarLiteTests = []struct {
 }{
 	{
 		// This is the example shown in figures 6 and 7 of doi:10.1109/tro.2004.838026.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"...",
 			".*.",
 			".*.",
@@ -139,7 +141,7 @@ var dynamicDStarLiteTests = []struct {
 		// may be taken incorrectly at 90° or correctly at 45° because the
 		// calculated rhs values of 12 and 17 are tied when moving from node
 		// 16, and the grid is small enough to examine by a dump.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			".....",
 			"...*.",
 			"**.*.",
@@ -176,7 +178,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count and only allowing information to be known
 		// from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -239,7 +241,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count, not closing the exit and only allowing
 		// information to be known from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -299,7 +301,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count, the exit is closed at a distance and
 		// information is allowed to be known from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -363,7 +365,7 @@ var dynamicDStarLiteTests = []struct {
 		// This is the example shown in figure 2 of doi:10.1109/tro.2004.838026
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -456,7 +458,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: 21.242640687119287,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -484,7 +486,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: 4,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -511,7 +513,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: math.Sqrt2 + 2,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"...",
 			".*.",
 			".*.",
@@ -564,6 +566,9 @@ func TestDStarLiteDynamic(t *testing.T) {
 			l.Grid.AllVisible = test.all
 
 			l.Grid.AllowDiagonal = test.diag
+			// LimitedVisionGrid allows diagonal moves
+			// between closed nodes.
+			l.Grid.AllowCornerCutting = true
 			l.Grid.UnitEdgeWeight = test.unit
 
 			if test.modify != nil {
@@ -683,3 +688,85 @@ func simpleEdgesOf(g weightedGraph, edges []graph.Edge) []simple.Edge {
 	}
 	return w
 }
+
+func TestDStarLiteGridRepair(t *testing.T) {
+	const n = 20
+	rnd := rand.New(rand.NewSource(1))
+	g := grid.NewGrid(n, n, true)
+	g.AllVisible = true
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			g.SetCost(r, c, float64(1+rnd.Intn(3)))
+		}
+	}
+
+	// Costs are never set below one, so the unscaled
+	// Manhattan distance remains admissible as the grid
+	// changes. Grid.Heuristic is not used since its value
+	// depends on the current minimum cost of the grid.
+	heuristic := func(a, b graph.Node) float64 {
+		ar, ac := g.RowCol(a.ID())
+		br, bc := g.RowCol(b.ID())
+		return math.Abs(float64(ar-br)) + math.Abs(float64(ac-bc))
+	}
+
+	s, goal := g.NodeAt(0, 0), g.NodeAt(n-1, n-1)
+	d := NewDStarLite(s, goal, g, heuristic, simple.NewDirectedGraph(0, math.Inf(1)))
+
+	var (
+		closed             []graph.Node
+		repaired, replayed int
+		steps              int
+	)
+	for d.Step() {
+		steps++
+		if steps%2 != 0 {
+			continue
+		}
+
+		// Make a localized change ahead of the agent: block
+		// the path or make it more costly, and sometimes
+		// reopen a previously blocked node.
+		ahead, _ := d.Path()
+		if len(ahead) < 4 {
+			continue
+		}
+		r, c := g.RowCol(ahead[3].ID())
+		var changes []graph.Edge
+		if steps%4 == 0 {
+			g.Set(r, c, false)
+			closed = append(closed, ahead[3])
+		} else {
+			g.SetCost(r, c, g.Cost(r, c)+5)
+		}
+		changes = append(changes, g.EdgesAround(r, c)...)
+		if len(closed) > 2 && steps%6 == 0 {
+			r, c := g.RowCol(closed[0].ID())
+			closed = closed[1:]
+			g.Set(r, c, true)
+			changes = append(changes, g.EdgesAround(r, c)...)
+		}
+
+		before := d.Expanded()
+		d.UpdateWorld(changes)
+		repaired += d.Expanded() - before
+
+		got, weight := d.Path()
+		pt, expanded := path.AStar(d.Here(), goal, g, heuristic)
+		replayed += expanded
+		want, wantWeight := pt.To(goal)
+		if weight != wantWeight {
+			t.Errorf("unexpected path weight after step %d: got:%v want:%v\ngot: %v\nwant:%v",
+				steps, weight, wantWeight, got, want)
+		}
+		if w := weightOf(got, g); w != weight {
+			t.Errorf("path weight does not match grid weight after step %d: got:%v want:%v", steps, weight, w)
+		}
+	}
+	if d.Here().ID() != goal.ID() {
+		t.Errorf("failed to reach goal: stopped at %d", d.Here().ID())
+	}
+	if repaired >= replayed/2 {
+		t.Errorf("unexpectedly many expansions for path repair: got:%d replanning from scratch:%d", repaired, replayed)
+	}
+}
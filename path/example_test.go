@@ -0,0 +1,78 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gonum/graph/simple"
+)
+
+func ExampleDijkstraFrom_named() {
+	// Edges between named places, one per line.
+	const data = `paris,lyon
+lyon,marseille
+paris,brussels
+brussels,amsterdam
+marseille,nice
+`
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := simple.NewUndirectedGraph(0, 0)
+	names := simple.NewNodeNamer(g)
+	for _, r := range records {
+		names.SetEdgeByName(r[0], r[1], 1)
+	}
+
+	paris, _ := names.ID("paris")
+	nice, _ := names.ID("nice")
+	pt := DijkstraFrom(simple.Node(paris), g)
+	path, weight := pt.To(simple.Node(nice))
+	for _, n := range path {
+		name, _ := names.Name(n.ID())
+		fmt.Println(name)
+	}
+	fmt.Println("hops:", weight)
+
+	// Output:
+	// paris
+	// lyon
+	// marseille
+	// nice
+	// hops: 3
+}
+
+func ExampleDijkstraFrom_keyed() {
+	// Links between hosts with their latency in milliseconds.
+	links := []struct {
+		from, to string
+		latency  float64
+	}{
+		{"web1", "lb", 2},
+		{"lb", "app1", 1},
+		{"lb", "app2", 1},
+		{"app1", "db", 5},
+		{"app2", "db", 2},
+	}
+
+	g := simple.NewDirectedGraph(0, 0)
+	hosts := simple.NewNodeNamer(g)
+	for _, l := range links {
+		hosts.SetEdgeByKey(l.from, l.to, l.latency)
+	}
+
+	pt := DijkstraFrom(hosts.NodeFor("web1"), g)
+	path, latency := pt.To(hosts.NodeFor("db"))
+	fmt.Println(hosts.Keys(path), latency)
+
+	// Output:
+	// [web1 lb app2 db] 5
+}
@@ -0,0 +1,290 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// HamiltonianPath returns a path in g that visits every node of g exactly once
+// and whether such a path exists. If g is a graph.Directed, edges are followed
+// in their forward direction. A graph with no nodes has no Hamiltonian path.
+//
+// HamiltonianPath performs a backtracking search, so its worst case time
+// complexity is exponential in the number of nodes and it is intended for use
+// with small graphs. The search is pruned by rejecting partial paths that leave
+// unvisited nodes unreachable or leave more than one unvisited node that could
+// only be the last node of the path.
+func HamiltonianPath(g graph.Graph) (path []graph.Node, ok bool) {
+	h := newHamiltonian(g, false)
+	for _, s := range h.starts() {
+		if h.searchFrom(s) {
+			return h.result(), true
+		}
+	}
+	return nil, false
+}
+
+// HamiltonianCycle returns a cycle in g that visits every node of g exactly
+// once and whether such a cycle exists. The cycle is returned as a path that
+// begins at the node of g with the lowest ID and does not repeat that node at
+// its end, the last node of the path having an edge to the first. If g is a
+// graph.Directed, edges are followed in their forward direction. Graphs with
+// fewer than three nodes have no undirected Hamiltonian cycle.
+//
+// HamiltonianCycle performs a backtracking search with the same pruning and
+// complexity as HamiltonianPath and is intended for use with small graphs.
+func HamiltonianCycle(g graph.Graph) (cycle []graph.Node, ok bool) {
+	h := newHamiltonian(g, true)
+	if len(h.nodes) < 2 || (!h.directed && len(h.nodes) < 3) {
+		return nil, false
+	}
+	if h.searchFrom(0) {
+		return h.result(), true
+	}
+	return nil, false
+}
+
+// hamiltonian is a backtracking Hamiltonian path and cycle search.
+type hamiltonian struct {
+	nodes    []graph.Node
+	directed bool
+	cycle    bool
+
+	// from and to hold the indices of
+	// the out and in neighbours of each
+	// node. For undirected graphs, from
+	// and to are the same.
+	from, to [][]int
+
+	// adjacent holds whether there is
+	// an edge from each node to each
+	// other node.
+	adjacent [][]bool
+
+	visited []bool
+	path    []int
+
+	// queue and seen are work space
+	// for reachability checks.
+	queue []int
+	seen  []bool
+}
+
+func newHamiltonian(g graph.Graph, cycle bool) *hamiltonian {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	dg, directed := g.(graph.Directed)
+	h := &hamiltonian{
+		nodes:    nodes,
+		directed: directed,
+		cycle:    cycle,
+		from:     make([][]int, len(nodes)),
+		to:       make([][]int, len(nodes)),
+		adjacent: make([][]bool, len(nodes)),
+		visited:  make([]bool, len(nodes)),
+		seen:     make([]bool, len(nodes)),
+	}
+	for i, u := range nodes {
+		h.adjacent[i] = make([]bool, len(nodes))
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if j == i {
+				continue
+			}
+			h.from[i] = append(h.from[i], j)
+			h.adjacent[i][j] = true
+		}
+		sort.Ints(h.from[i])
+		if !directed {
+			h.to[i] = h.from[i]
+			continue
+		}
+		for _, v := range dg.To(u) {
+			if j := indexOf[v.ID()]; j != i {
+				h.to[i] = append(h.to[i], j)
+			}
+		}
+	}
+	return h
+}
+
+// starts returns the indices of the nodes that may begin a Hamiltonian path.
+func (h *hamiltonian) starts() []int {
+	if len(h.nodes) == 1 {
+		return []int{0}
+	}
+	var forced []int
+	for i := range h.nodes {
+		if h.directed {
+			// A node with no in-neighbours must
+			// be the first node of the path.
+			if len(h.to[i]) == 0 {
+				forced = append(forced, i)
+			}
+		} else if len(h.from[i]) == 1 {
+			// A node with one neighbour must be
+			// an end of the path, and an
+			// undirected path may be reversed.
+			forced = append(forced, i)
+		}
+	}
+	switch {
+	case len(forced) == 0:
+		all := make([]int, len(h.nodes))
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	case h.directed && len(forced) > 1, len(forced) > 2:
+		return nil
+	default:
+		return forced[:1]
+	}
+}
+
+// searchFrom returns whether a Hamiltonian path or cycle begins at the node
+// with index s, leaving the path in h.path if it does.
+func (h *hamiltonian) searchFrom(s int) bool {
+	for i := range h.visited {
+		h.visited[i] = false
+	}
+	h.visited[s] = true
+	h.path = append(h.path[:0], s)
+	return h.extend(s)
+}
+
+// extend returns whether the current path, ending at u, can be extended to a
+// Hamiltonian path or cycle.
+func (h *hamiltonian) extend(u int) bool {
+	if len(h.path) == len(h.nodes) {
+		return !h.cycle || h.adjacent[u][h.path[0]]
+	}
+	if !h.feasible(u) {
+		return false
+	}
+
+	// Try the neighbours with the fewest onward
+	// choices first, so that nodes that are hard
+	// to reach are visited before they are cut off.
+	var next []int
+	for _, v := range h.from[u] {
+		if !h.visited[v] {
+			next = append(next, v)
+		}
+	}
+	sort.Stable(byChoices{nodes: next, choices: h.choices})
+	for _, v := range next {
+		h.visited[v] = true
+		h.path = append(h.path, v)
+		if h.extend(v) {
+			return true
+		}
+		h.path = h.path[:len(h.path)-1]
+		h.visited[v] = false
+	}
+	return false
+}
+
+// choices returns the number of unvisited out-neighbours of v.
+func (h *hamiltonian) choices(v int) int {
+	var n int
+	for _, w := range h.from[v] {
+		if !h.visited[w] {
+			n++
+		}
+	}
+	return n
+}
+
+// feasible returns whether the unvisited nodes may still complete the current
+// path ending at u. All unvisited nodes must be reachable from u through
+// unvisited nodes, and at most one unvisited node may be a dead end that can
+// only be the last node of the path. In a cycle search, the last node must
+// also have an edge back to the start of the path.
+func (h *hamiltonian) feasible(u int) bool {
+	remaining := len(h.nodes) - len(h.path)
+
+	for i := range h.seen {
+		h.seen[i] = false
+	}
+	h.queue = append(h.queue[:0], u)
+	h.seen[u] = true
+	reached := 0
+	for len(h.queue) != 0 {
+		x := h.queue[0]
+		h.queue = h.queue[1:]
+		for _, y := range h.from[x] {
+			if h.visited[y] || h.seen[y] {
+				continue
+			}
+			h.seen[y] = true
+			reached++
+			h.queue = append(h.queue, y)
+		}
+	}
+	if reached != remaining {
+		return false
+	}
+
+	var ends int
+	for x, visited := range h.visited {
+		if visited {
+			continue
+		}
+		exits := h.choices(x)
+		var deadEnd bool
+		if h.directed {
+			deadEnd = exits == 0
+		} else {
+			// An undirected node must be entered and
+			// left through different neighbours, one
+			// of which may be u.
+			entries := exits
+			if h.adjacent[u][x] {
+				entries++
+			}
+			deadEnd = entries <= 1
+		}
+		if !deadEnd {
+			continue
+		}
+		if h.cycle && !h.adjacent[x][h.path[0]] {
+			return false
+		}
+		ends++
+		if ends > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// result returns the nodes of the current path.
+func (h *hamiltonian) result() []graph.Node {
+	path := make([]graph.Node, len(h.path))
+	for i, j := range h.path {
+		path[i] = h.nodes[j]
+	}
+	return path
+}
+
+// byChoices sorts node indices by their number of onward choices.
+type byChoices struct {
+	nodes   []int
+	choices func(int) int
+}
+
+func (c byChoices) Len() int           { return len(c.nodes) }
+func (c byChoices) Less(i, j int) bool { return c.choices(c.nodes[i]) < c.choices(c.nodes[j]) }
+func (c byChoices) Swap(i, j int)      { c.nodes[i], c.nodes[j] = c.nodes[j], c.nodes[i] }
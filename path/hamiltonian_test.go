@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// petersen is the Petersen graph, which has a
+// Hamiltonian path but no Hamiltonian cycle.
+var petersen = [][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+	{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+	{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+}
+
+var hamiltonianTests = []struct {
+	name     string
+	directed bool
+	nodes    int
+	edges    [][2]int
+
+	wantPath, wantCycle bool
+}{
+	{name: "empty"},
+	{name: "single node", nodes: 1, wantPath: true},
+	{name: "single edge", edges: [][2]int{{0, 1}}, wantPath: true},
+	{name: "triangle", edges: [][2]int{{0, 1}, {1, 2}, {2, 0}}, wantPath: true, wantCycle: true},
+	{name: "star", edges: [][2]int{{0, 1}, {0, 2}, {0, 3}}},
+	{name: "disconnected", edges: [][2]int{{0, 1}, {2, 3}}},
+	{name: "petersen", edges: petersen, wantPath: true},
+	{
+		name:      "directed two cycle",
+		directed:  true,
+		edges:     [][2]int{{0, 1}, {1, 0}},
+		wantPath:  true,
+		wantCycle: true,
+	},
+	{
+		name:     "directed path",
+		directed: true,
+		edges:    [][2]int{{2, 1}, {1, 0}, {0, 3}},
+		wantPath: true,
+	},
+	{
+		name:     "directed two sources",
+		directed: true,
+		edges:    [][2]int{{0, 2}, {1, 2}, {2, 3}},
+	},
+	{
+		name:      "directed square",
+		directed:  true,
+		edges:     [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}},
+		wantPath:  true,
+		wantCycle: true,
+	},
+}
+
+func TestHamiltonian(t *testing.T) {
+	for _, test := range hamiltonianTests {
+		g := hamiltonianGraph(test.directed, test.nodes, test.edges)
+
+		path, ok := HamiltonianPath(g)
+		if ok != test.wantPath {
+			t.Errorf("unexpected Hamiltonian path existence for %q: got:%t want:%t", test.name, ok, test.wantPath)
+		}
+		if ok {
+			checkHamiltonian(t, test.name, g, path, false)
+		}
+
+		cycle, ok := HamiltonianCycle(g)
+		if ok != test.wantCycle {
+			t.Errorf("unexpected Hamiltonian cycle existence for %q: got:%t want:%t", test.name, ok, test.wantCycle)
+		}
+		if ok {
+			checkHamiltonian(t, test.name, g, cycle, true)
+		}
+	}
+}
+
+func TestHamiltonianRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := 1 + rnd.Intn(7)
+		p := rnd.Float64()
+		directed := i%2 == 0
+		var edges [][2]int
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u == v || (!directed && v < u) {
+					continue
+				}
+				if rnd.Float64() < p {
+					edges = append(edges, [2]int{u, v})
+				}
+			}
+		}
+		g := hamiltonianGraph(directed, n, edges)
+		wantPath, wantCycle := bruteForceHamiltonian(g)
+		name := "random"
+
+		path, ok := HamiltonianPath(g)
+		if ok != wantPath {
+			t.Errorf("unexpected Hamiltonian path existence for trial %d: got:%t want:%t", i, ok, wantPath)
+		}
+		if ok {
+			checkHamiltonian(t, name, g, path, false)
+		}
+		cycle, ok := HamiltonianCycle(g)
+		if ok != wantCycle {
+			t.Errorf("unexpected Hamiltonian cycle existence for trial %d: got:%t want:%t", i, ok, wantCycle)
+		}
+		if ok {
+			checkHamiltonian(t, name, g, cycle, true)
+		}
+	}
+}
+
+func hamiltonianGraph(directed bool, nodes int, edges [][2]int) graph.Graph {
+	var g graph.Builder
+	if directed {
+		g = simple.NewDirectedGraph(0, math.Inf(1))
+	} else {
+		g = simple.NewUndirectedGraph(0, math.Inf(1))
+	}
+	for i := 0; i < nodes; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for _, e := range edges {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+	return g.(graph.Graph)
+}
+
+func checkHamiltonian(t *testing.T, name string, g graph.Graph, path []graph.Node, cycle bool) {
+	if len(path) != len(g.Nodes()) {
+		t.Errorf("unexpected length for %q: got:%d want:%d", name, len(path), len(g.Nodes()))
+		return
+	}
+	seen := make(map[int]bool)
+	for i, n := range path {
+		if seen[n.ID()] {
+			t.Errorf("node %d visited more than once for %q", n.ID(), name)
+		}
+		seen[n.ID()] = true
+		if i != 0 && g.Edge(path[i-1], n) == nil {
+			t.Errorf("missing edge %d->%d for %q", path[i-1].ID(), n.ID(), name)
+		}
+	}
+	if cycle && g.Edge(path[len(path)-1], path[0]) == nil {
+		t.Errorf("missing closing edge %d->%d for %q", path[len(path)-1].ID(), path[0].ID(), name)
+	}
+}
+
+// bruteForceHamiltonian returns whether g has a Hamiltonian path and cycle by
+// testing every permutation of the nodes of g.
+func bruteForceHamiltonian(g graph.Graph) (path, cycle bool) {
+	nodes := g.Nodes()
+	_, directed := g.(graph.Directed)
+	if len(nodes) == 0 {
+		return false, false
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(nodes) {
+			for i := 1; i < len(nodes); i++ {
+				if g.Edge(nodes[i-1], nodes[i]) == nil {
+					return
+				}
+			}
+			path = true
+			if len(nodes) > 2 || (directed && len(nodes) == 2) {
+				if g.Edge(nodes[len(nodes)-1], nodes[0]) != nil {
+					cycle = true
+				}
+			}
+			return
+		}
+		for i := k; i < len(nodes); i++ {
+			nodes[k], nodes[i] = nodes[i], nodes[k]
+			permute(k + 1)
+			nodes[k], nodes[i] = nodes[i], nodes[k]
+		}
+	}
+	permute(0)
+	return path, cycle
+}
@@ -9,15 +9,19 @@ import (
 	"math"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
 	"github.com/gonum/graph/simple"
 )
 
+// Unknown is the unknown grid node repesentation.
+const Unknown = '?'
+
 // LimitedVisionGrid is a 2D grid planar undirected graph where the capacity
 // to determine the presence of edges is dependent on the current and past
 // positions on the grid. In the absence of information, the grid is
 // optimistic.
 type LimitedVisionGrid struct {
-	Grid *Grid
+	Grid *grid.Grid
 
 	// Location is the current
 	// location on the grid.
@@ -144,8 +148,8 @@ func (l *LimitedVisionGrid) XY(n graph.Node) (x, y float64) {
 
 // Nodes returns all the nodes in the grid.
 func (l *LimitedVisionGrid) Nodes() []graph.Node {
-	nodes := make([]graph.Node, 0, len(l.Grid.open))
-	for id := range l.Grid.open {
+	nodes := make([]graph.Node, 0, l.size())
+	for id := 0; id < l.size(); id++ {
 		nodes = append(nodes, simple.Node(id))
 	}
 	return nodes
@@ -161,8 +165,14 @@ func (l *LimitedVisionGrid) Has(n graph.Node) bool {
 	return l.has(n.ID())
 }
 
+// size returns the number of nodes in the grid.
+func (l *LimitedVisionGrid) size() int {
+	r, c := l.Grid.Dims()
+	return r * c
+}
+
 func (l *LimitedVisionGrid) has(id int) bool {
-	return id >= 0 && id < len(l.Grid.open)
+	return id >= 0 && id < l.size()
 }
 
 // From returns nodes that are optimistically reachable from u.
@@ -270,10 +280,10 @@ func (l *LimitedVisionGrid) Render(path []graph.Node) ([]byte, error) {
 		for c := 0; c < cols; c++ {
 			if !l.Known[r*cols+c] {
 				b[r*(cols+1)+c] = Unknown
-			} else if l.Grid.open[r*cols+c] {
-				b[r*(cols+1)+c] = Open
+			} else if l.Grid.HasOpen(simple.Node(r*cols + c)) {
+				b[r*(cols+1)+c] = grid.Open
 			} else {
-				b[r*(cols+1)+c] = Closed
+				b[r*(cols+1)+c] = grid.Closed
 			}
 		}
 		if r < rows-1 {
@@ -286,7 +296,7 @@ func (l *LimitedVisionGrid) Render(path []graph.Node) ([]byte, error) {
 	for i, n := range path {
 		if !l.Has(n) || (i != 0 && !l.HasEdgeBetween(path[i-1], n)) {
 			id := n.ID()
-			if id >= 0 && id < len(l.Grid.open) {
+			if id >= 0 && id < l.size() {
 				r, c := l.RowCol(n.ID())
 				b[r*(cols+1)+c] = '!'
 			}
@@ -304,3 +314,10 @@ func (l *LimitedVisionGrid) Render(path []graph.Node) ([]byte, error) {
 	}
 	return b, nil
 }
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
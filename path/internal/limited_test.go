@@ -10,9 +10,14 @@ import (
 	"testing"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
 	"github.com/gonum/graph/simple"
 )
 
+type node int
+
+func (n node) ID() int { return int(n) }
+
 type changes struct {
 	n graph.Node
 
@@ -20,7 +25,7 @@ type changes struct {
 }
 
 var limitedVisionTests = []struct {
-	g        *Grid
+	g        *grid.Grid
 	radius   float64
 	diag     bool
 	remember bool
@@ -30,7 +35,7 @@ var limitedVisionTests = []struct {
 	want []changes
 }{
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -109,7 +114,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -244,7 +249,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -344,7 +349,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -493,7 +498,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -600,7 +605,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -791,7 +796,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -931,7 +936,7 @@ var limitedVisionTests = []struct {
 		},
 	},
 	{
-		g: NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -1164,6 +1169,9 @@ func TestLimitedVisionGrid(t *testing.T) {
 			l.Known = make(map[int]bool)
 		}
 		l.Grid.AllowDiagonal = test.diag
+		// LimitedVisionGrid allows diagonal moves
+		// between closed nodes.
+		l.Grid.AllowCornerCutting = true
 
 		x, y := l.XY(test.path[0])
 		for _, u := range l.Nodes() {
@@ -0,0 +1,279 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
+	"github.com/gonum/graph/internal/set"
+)
+
+// JumpPointSearch finds the shortest path from s to t in the grid g using jump
+// point search, returning the path, its cost and the number of nodes expanded
+// during the search. Jump point search prunes the symmetric paths that A* would
+// explore by jumping along straight and diagonal lines until a node with a
+// forced neighbor is found, so far fewer nodes are expanded than by AStar on
+// the equivalent graph.
+//
+// The grid must allow diagonal moves, must not wrap, must have the same
+// movement cost for every open node and must have a diagonal step length
+// between one and two orthogonal steps. JumpPointSearch will panic if g does
+// not meet these requirements. Both the corner cutting and the no corner
+// cutting movement rules of the grid are supported.
+//
+// The returned path includes every tile visited between s and t. If t is not
+// reachable from s, JumpPointSearch returns a nil path and infinite cost.
+//
+// See Harabor and Grastien, "Online Graph Pruning for Pathfinding on Grid Maps",
+// AAAI 2011.
+func JumpPointSearch(s, t graph.Node, g *grid.Grid) (path []graph.Node, weight float64, expanded int) {
+	cost := uniformCost(g)
+	if !g.HasOpen(s) || !g.HasOpen(t) {
+		return nil, math.Inf(1), 0
+	}
+	j := jumper{g: g, cut: g.AllowCornerCutting}
+	j.rows, j.cols = g.Dims()
+	j.tr, j.tc = g.RowCol(t.ID())
+
+	// Moves between jump points follow unobstructed
+	// straight or diagonal lines, so their weight
+	// is given by the octile distance.
+	dist := func(u, v graph.Node) float64 { return cost * g.Octile(u, v) }
+
+	tid := t.ID()
+	parent := make(map[int]graph.Node)
+	closed := make(set.Ints)
+	open := &aStarQueue{indexOf: make(map[int]int)}
+	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: dist(s, t)})
+
+	for open.Len() != 0 {
+		u := heap.Pop(open).(aStarNode)
+		uid := u.node.ID()
+		expanded++
+		if uid == tid {
+			return j.path(s, u.node, parent), u.gscore, expanded
+		}
+		closed.Add(uid)
+
+		ur, uc := g.RowCol(uid)
+		var dr, dc int
+		if p, ok := parent[uid]; ok {
+			pr, pc := g.RowCol(p.ID())
+			dr, dc = sign(ur-pr), sign(uc-pc)
+		}
+		for _, d := range j.successors(ur, uc, dr, dc) {
+			jr, jc, ok := j.jump(ur, uc, d[0], d[1])
+			if !ok {
+				continue
+			}
+			v := g.NodeAt(jr, jc)
+			vid := v.ID()
+			if closed.Has(vid) {
+				continue
+			}
+			gscore := u.gscore + dist(u.node, v)
+			if n, ok := open.node(vid); !ok {
+				parent[vid] = u.node
+				heap.Push(open, aStarNode{node: v, gscore: gscore, fscore: gscore + dist(v, t)})
+			} else if gscore < n.gscore {
+				parent[vid] = u.node
+				open.update(vid, gscore, gscore+dist(v, t))
+			}
+		}
+	}
+
+	return nil, math.Inf(1), expanded
+}
+
+// uniformCost returns the movement cost shared by the open nodes of g. It
+// panics if g is not a grid that jump point search can be performed on.
+func uniformCost(g *grid.Grid) float64 {
+	switch {
+	case !g.AllowDiagonal:
+		panic("jump point search: grid without diagonal moves")
+	case g.WrapHorizontal || g.WrapVertical:
+		panic("jump point search: wrapped grid")
+	case g.DiagonalLength != 0 && !(1 <= g.DiagonalLength && g.DiagonalLength <= 2):
+		panic("jump point search: diagonal length outside [1, 2]")
+	}
+	cost := g.MinCost()
+	rows, cols := g.Dims()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if g.HasOpen(g.NodeAt(r, c)) && g.Cost(r, c) != cost {
+				panic("jump point search: non-uniform movement cost")
+			}
+		}
+	}
+	return cost
+}
+
+// jumper holds the state for jump point search on a grid.
+type jumper struct {
+	g          *grid.Grid
+	rows, cols int
+
+	// cut is whether diagonal moves may
+	// pass between closed tiles.
+	cut bool
+
+	// tr and tc are the row and
+	// column of the target.
+	tr, tc int
+}
+
+// open returns whether the tile at (r, c) is in the grid and open.
+func (j jumper) open(r, c int) bool {
+	return 0 <= r && r < j.rows && 0 <= c && c < j.cols && j.g.HasOpen(j.g.NodeAt(r, c))
+}
+
+// successors returns the directions to search from the tile at (r, c)
+// when it was reached moving in the direction (dr, dc). A zero direction
+// indicates the start node, for which all directions are returned.
+func (j jumper) successors(r, c, dr, dc int) [][2]int {
+	if dr == 0 && dc == 0 {
+		return [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+	}
+	if !j.cut {
+		return j.successorsNoCut(r, c, dr, dc)
+	}
+	var dirs [][2]int
+	switch {
+	case dr != 0 && dc != 0:
+		dirs = append(dirs, [2]int{dr, 0}, [2]int{0, dc}, [2]int{dr, dc})
+		if !j.open(r-dr, c) {
+			dirs = append(dirs, [2]int{-dr, dc})
+		}
+		if !j.open(r, c-dc) {
+			dirs = append(dirs, [2]int{dr, -dc})
+		}
+	case dr == 0:
+		dirs = append(dirs, [2]int{0, dc})
+		if !j.open(r+1, c) {
+			dirs = append(dirs, [2]int{1, dc})
+		}
+		if !j.open(r-1, c) {
+			dirs = append(dirs, [2]int{-1, dc})
+		}
+	default:
+		dirs = append(dirs, [2]int{dr, 0})
+		if !j.open(r, c+1) {
+			dirs = append(dirs, [2]int{dr, 1})
+		}
+		if !j.open(r, c-1) {
+			dirs = append(dirs, [2]int{dr, -1})
+		}
+	}
+	return dirs
+}
+
+// successorsNoCut returns the directions to search from the tile at (r, c)
+// when it was reached moving in the direction (dr, dc) and diagonal moves
+// require both of the tiles they pass between to be open. A diagonal move
+// has no forced neighbors under this rule, and a straight move has a forced
+// neighbor to its side where the tile beside the previous tile is closed.
+func (j jumper) successorsNoCut(r, c, dr, dc int) [][2]int {
+	if dr != 0 && dc != 0 {
+		return [][2]int{{dr, 0}, {0, dc}, {dr, dc}}
+	}
+	dirs := [][2]int{{dr, dc}}
+	for _, s := range []int{-1, 1} {
+		if dr == 0 && !j.open(r+s, c-dc) {
+			dirs = append(dirs, [2]int{s, 0}, [2]int{s, dc})
+		}
+		if dc == 0 && !j.open(r-dr, c+s) {
+			dirs = append(dirs, [2]int{0, s}, [2]int{dr, s})
+		}
+	}
+	return dirs
+}
+
+// jump moves from (r, c) in the direction (dr, dc) until the target, a node
+// with a forced neighbor or a closed tile is reached. It returns the position
+// of the jump point and whether one was found.
+func (j jumper) jump(r, c, dr, dc int) (jr, jc int, ok bool) {
+	for {
+		diagonal := dr != 0 && dc != 0
+		if diagonal && !j.cut && (!j.open(r+dr, c) || !j.open(r, c+dc)) {
+			return 0, 0, false
+		}
+		r, c = r+dr, c+dc
+		if !j.open(r, c) {
+			return 0, 0, false
+		}
+		if r == j.tr && c == j.tc {
+			return r, c, true
+		}
+		if j.forced(r, c, dr, dc) {
+			return r, c, true
+		}
+		if diagonal {
+			// A diagonal move stops where a straight
+			// jump from the node finds a jump point.
+			if _, _, ok := j.jump(r, c, dr, 0); ok {
+				return r, c, true
+			}
+			if _, _, ok := j.jump(r, c, 0, dc); ok {
+				return r, c, true
+			}
+		}
+	}
+}
+
+// forced returns whether the tile at (r, c) has a forced neighbor when
+// reached moving in the direction (dr, dc).
+func (j jumper) forced(r, c, dr, dc int) bool {
+	if !j.cut {
+		switch {
+		case dr != 0 && dc != 0:
+			return false
+		case dr == 0:
+			return (j.open(r+1, c) && !j.open(r+1, c-dc)) || (j.open(r-1, c) && !j.open(r-1, c-dc))
+		default:
+			return (j.open(r, c+1) && !j.open(r-dr, c+1)) || (j.open(r, c-1) && !j.open(r-dr, c-1))
+		}
+	}
+	switch {
+	case dr != 0 && dc != 0:
+		return (j.open(r-dr, c+dc) && !j.open(r-dr, c)) || (j.open(r+dr, c-dc) && !j.open(r, c-dc))
+	case dr == 0:
+		return (j.open(r+1, c+dc) && !j.open(r+1, c)) || (j.open(r-1, c+dc) && !j.open(r-1, c))
+	default:
+		return (j.open(r+dr, c+1) && !j.open(r, c+1)) || (j.open(r+dr, c-1) && !j.open(r, c-1))
+	}
+}
+
+// path returns the path from s to t following the jump point parents,
+// filling in the tiles between consecutive jump points.
+func (j jumper) path(s, t graph.Node, parent map[int]graph.Node) []graph.Node {
+	path := []graph.Node{t}
+	for n := t; n.ID() != s.ID(); {
+		p := parent[n.ID()]
+		nr, nc := j.g.RowCol(n.ID())
+		pr, pc := j.g.RowCol(p.ID())
+		dr, dc := sign(pr-nr), sign(pc-nc)
+		for r, c := nr+dr, nc+dc; r != pr || c != pc; r, c = r+dr, c+dc {
+			path = append(path, j.g.NodeAt(r, c))
+		}
+		path = append(path, p)
+		n = p
+	}
+	reverse(path)
+	return path
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph/grid"
+	"github.com/gonum/graph/simple"
+)
+
+func randomTileGrid(r, c int, p float64, src *rand.Rand) *grid.Grid {
+	g := grid.NewGrid(r, c, true)
+	g.AllowDiagonal = true
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if src.Float64() < p {
+				g.Set(i, j, false)
+			}
+		}
+	}
+	return g
+}
+
+var jumpPointSearchTests = []struct {
+	name   string
+	cut    bool
+	unit   bool
+	length float64
+	cost   float64
+}{
+	{name: "corner cutting", cut: true},
+	{name: "no corner cutting"},
+	{name: "unit diagonal", unit: true},
+	{name: "unit diagonal with corner cutting", cut: true, unit: true},
+	{name: "long diagonal", length: 2},
+	{name: "short diagonal with corner cutting", cut: true, length: 1.2},
+	{name: "uniform cost", cost: 3},
+}
+
+func TestJumpPointSearch(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range jumpPointSearchTests {
+		for _, p := range []float64{0, 0.1, 0.2, 0.3, 0.4} {
+			for trial := 0; trial < 10; trial++ {
+				g := randomTileGrid(15, 20, p, src)
+				g.AllowCornerCutting = test.cut
+				g.UnitEdgeWeight = test.unit
+				g.DiagonalLength = test.length
+				if test.cost != 0 {
+					for r := 0; r < 15; r++ {
+						for c := 0; c < 20; c++ {
+							g.SetCost(r, c, test.cost)
+						}
+					}
+				}
+				s, u := simple.Node(src.Intn(300)), simple.Node(src.Intn(300))
+				g.Set(s.ID()/20, s.ID()%20, true)
+				g.Set(u.ID()/20, u.ID()%20, true)
+
+				pt, _ := AStar(s, u, g, g.Heuristic)
+				_, wantWeight := pt.To(u)
+
+				path, weight, _ := JumpPointSearch(s, u, g)
+				if math.IsInf(wantWeight, 1) {
+					if path != nil || !math.IsInf(weight, 1) {
+						t.Errorf("unexpected result for unreachable target for %q with p=%v: got path %v weight %v",
+							test.name, p, path, weight)
+					}
+					continue
+				}
+				if math.Abs(weight-wantWeight) > 1e-9 {
+					t.Errorf("unexpected weight for %q with p=%v from %d to %d: got:%v want:%v\n%s",
+						test.name, p, s, u, weight, wantWeight, g)
+					continue
+				}
+
+				if len(path) == 0 || path[0].ID() != s.ID() || path[len(path)-1].ID() != u.ID() {
+					t.Errorf("unexpected path ends for %q with p=%v from %d to %d: %v", test.name, p, s, u, path)
+					continue
+				}
+				var sum float64
+				for i := 1; i < len(path); i++ {
+					w, ok := g.Weight(path[i-1], path[i])
+					if !ok {
+						t.Errorf("unexpected step for %q with p=%v from %d to %d", test.name, p, path[i-1].ID(), path[i].ID())
+					}
+					sum += w
+				}
+				if math.Abs(sum-weight) > 1e-9 {
+					t.Errorf("path weight does not match returned weight for %q with p=%v: got:%v want:%v",
+						test.name, p, sum, weight)
+				}
+			}
+		}
+	}
+}
+
+func TestJumpPointSearchUnsupported(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		edit func(g *grid.Grid)
+	}{
+		{name: "no diagonal", edit: func(g *grid.Grid) { g.AllowDiagonal = false }},
+		{name: "wrap horizontal", edit: func(g *grid.Grid) { g.WrapHorizontal = true }},
+		{name: "wrap vertical", edit: func(g *grid.Grid) { g.WrapVertical = true }},
+		{name: "terrain cost", edit: func(g *grid.Grid) { g.SetCost(1, 1, 5) }},
+		{name: "short diagonal", edit: func(g *grid.Grid) { g.DiagonalLength = 0.5 }},
+		{name: "long diagonal", edit: func(g *grid.Grid) { g.DiagonalLength = 3 }},
+	} {
+		g := grid.NewGrid(3, 4, true)
+		g.AllowDiagonal = true
+		test.edit(g)
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			JumpPointSearch(simple.Node(0), simple.Node(11), g)
+			return false
+		}()
+		if !panicked {
+			t.Errorf("expected panic for %s grid", test.name)
+		}
+	}
+}
+
+func TestJumpPointSearchExpansions(t *testing.T) {
+	g := grid.NewGridFrom(
+		"..............................",
+		"..............................",
+		"..........*...................",
+		"..........*...................",
+		"..........*.........*.........",
+		"..........*.........*.........",
+		"..........*.........*.........",
+		"....................*.........",
+		"....................*.........",
+		"..............................",
+	)
+	g.AllowDiagonal = true
+	s, u := simple.Node(0), simple.Node(9*30+29)
+
+	pt, aStarExpanded := AStar(s, u, g, g.Octile)
+	_, wantWeight := pt.To(u)
+	_, weight, expanded := JumpPointSearch(s, u, g)
+	if math.Abs(weight-wantWeight) > 1e-9 {
+		t.Errorf("unexpected weight: got:%v want:%v", weight, wantWeight)
+	}
+	if expanded*5 > aStarExpanded {
+		t.Errorf("unexpectedly many expansions: jump point search:%d A*:%d", expanded, aStarExpanded)
+	}
+}
+
+func TestJumpPointSearchSameNode(t *testing.T) {
+	g := grid.NewGrid(3, 3, true)
+	g.AllowDiagonal = true
+	path, weight, expanded := JumpPointSearch(simple.Node(4), simple.Node(4), g)
+	if len(path) != 1 || path[0].ID() != 4 || weight != 0 || expanded != 1 {
+		t.Errorf("unexpected result for same node: got path %v weight %v expanded %d", path, weight, expanded)
+	}
+	path, weight, _ = JumpPointSearch(simple.Node(4), simple.Node(9), g)
+	if path != nil || !math.IsInf(weight, 1) {
+		t.Errorf("unexpected result for absent node: got path %v weight %v", path, weight)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/topo"
+)
+
+// LongestPath returns a longest path in the directed acyclic graph g and its
+// length. If the graph does not implement graph.Weighter, UniformCost is used.
+// When more than one longest path exists, which of them is returned is not
+// specified. Longest paths may start at any node in g, so a graph with no
+// positive length paths will return a single node path with zero length.
+//
+// If g contains a cycle, LongestPath returns a nil path and a topo.Unorderable
+// error describing the cyclic components of g.
+//
+// The time complexity of LongestPath is O(|V|+|E|) after the topological sort
+// of g.
+func LongestPath(g graph.Directed) (path []graph.Node, length float64, err error) {
+	sorted, err := topo.Sort(g)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(sorted) == 0 {
+		return nil, 0, nil
+	}
+	var weight Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	indexOf := make(map[int]int, len(sorted))
+	for i, n := range sorted {
+		indexOf[n.ID()] = i
+	}
+	dist := make([]float64, len(sorted))
+	prev := make([]int, len(sorted))
+	for i := range prev {
+		prev[i] = -1
+	}
+	for i, u := range sorted {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if j == i {
+				// Self edges are cycles, but are not
+				// reported by the topological sort.
+				return nil, 0, topo.Unorderable{{u}}
+			}
+			w, ok := weight(u, v)
+			if !ok {
+				panic("longest path: unexpected invalid weight")
+			}
+			if joint := dist[i] + w; joint > dist[j] {
+				dist[j] = joint
+				prev[j] = i
+			}
+		}
+	}
+
+	end := 0
+	for i, d := range dist {
+		if d > dist[end] {
+			end = i
+		}
+	}
+	length = dist[end]
+	for i := end; i >= 0; i = prev[i] {
+		path = append(path, sorted[i])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, length, nil
+}
@@ -0,0 +1,114 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/topo"
+)
+
+var longestPathTests = []struct {
+	name  string
+	edges []simple.Edge
+	unit  bool
+
+	want   []int
+	length float64
+	cyclic bool
+}{
+	{
+		name: "empty",
+	},
+	{
+		// A critical path schedule where edge weights are
+		// the durations of the task at the edge source.
+		name: "schedule",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(0), T: simple.Node(2), W: 3},
+			{F: simple.Node(1), T: simple.Node(3), W: 4},
+			{F: simple.Node(2), T: simple.Node(3), W: 2},
+			{F: simple.Node(2), T: simple.Node(4), W: 2},
+			{F: simple.Node(3), T: simple.Node(5), W: 5},
+			{F: simple.Node(4), T: simple.Node(5), W: 6},
+		},
+		want:   []int{0, 1, 3, 5},
+		length: 12,
+	},
+	{
+		name: "unit",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 10},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(1)},
+		},
+		unit:   true,
+		want:   []int{0, 2, 3, 1},
+		length: 3,
+	},
+	{
+		name: "negative",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: -1},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+		},
+		want:   []int{1, 2},
+		length: 2,
+	},
+	{
+		name: "cyclic",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(0), W: 1},
+		},
+		cyclic: true,
+	},
+}
+
+func TestLongestPath(t *testing.T) {
+	for _, test := range longestPathTests {
+		dg := simple.NewDirectedGraph(0, math.Inf(1))
+		for _, e := range test.edges {
+			dg.SetEdge(e)
+		}
+		var g graph.Directed = dg
+		if test.unit {
+			// Hide the graph.Weighter implementation.
+			g = struct{ graph.Directed }{dg}
+		}
+
+		p, length, err := LongestPath(g)
+		if test.cyclic {
+			if _, ok := err.(topo.Unorderable); !ok {
+				t.Errorf("expected topo.Unorderable error for %q: got:%v", test.name, err)
+			}
+			if p != nil {
+				t.Errorf("unexpected path for cyclic graph %q: got:%v", test.name, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", test.name, err)
+			continue
+		}
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		if length != test.length {
+			t.Errorf("unexpected length for %q: got:%v want:%v", test.name, length, test.length)
+		}
+	}
+}
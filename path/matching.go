@@ -0,0 +1,895 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// MinimumWeightPerfectMatching returns a minimum weight perfect matching of the
+// undirected graph g, the total weight of the matching and whether a perfect
+// matching exists. If the graph does not implement graph.Weighter, UniformCost
+// is used.
+//
+// A perfect matching is a set of edges such that every node of g is the end of
+// exactly one edge in the set. If g has an odd number of nodes or its structure
+// otherwise prevents a perfect matching, the returned matching is nil and ok is
+// false. The returned edges hold the weight of the matched edge in g, and are
+// ordered by the ID of their From node, which is always lower than the ID of
+// their To node.
+//
+// MinimumWeightPerfectMatching uses Edmonds' blossom algorithm with a time
+// complexity of O(|V|^3).
+func MinimumWeightPerfectMatching(g graph.Undirected) (matching []graph.Edge, weight float64, ok bool) {
+	var weightOf Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weightOf = wg.Weight
+	} else {
+		weightOf = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	if len(nodes)%2 != 0 {
+		return nil, 0, false
+	}
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	var edges []weightedPair
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if j <= i {
+				continue
+			}
+			w, ok := weightOf(u, v)
+			if !ok {
+				panic("matching: unexpected invalid weight")
+			}
+			edges = append(edges, weightedPair{i: i, j: j, w: w})
+		}
+	}
+
+	mate, ok := minWeightPerfectMatching(len(nodes), edges)
+	if !ok {
+		return nil, 0, false
+	}
+	for i, j := range mate {
+		if j < i {
+			continue
+		}
+		w, _ := weightOf(nodes[i], nodes[j])
+		matching = append(matching, simple.Edge{F: nodes[i], T: nodes[j], W: w})
+		weight += w
+	}
+	return matching, weight, true
+}
+
+// weightedPair is a weighted edge between the vertices i and j.
+type weightedPair struct {
+	i, j int
+	w    float64
+}
+
+// minWeightPerfectMatching returns the mate of each vertex in a minimum weight
+// perfect matching of the graph with n vertices and the given edges, and whether
+// a perfect matching exists.
+func minWeightPerfectMatching(n int, edges []weightedPair) (mate []int, ok bool) {
+	if n == 0 {
+		return nil, true
+	}
+	if n%2 != 0 || len(edges) == 0 {
+		return nil, false
+	}
+
+	// A maximum weight matching among the maximum
+	// cardinality matchings with the edge weights
+	// reflected about the maximum weight is a
+	// minimum weight perfect matching if the
+	// matching is perfect.
+	maxWeight := edges[0].w
+	for _, e := range edges[1:] {
+		if e.w > maxWeight {
+			maxWeight = e.w
+		}
+	}
+	reflected := make([]weightedPair, len(edges))
+	for k, e := range edges {
+		reflected[k] = weightedPair{i: e.i, j: e.j, w: maxWeight - e.w}
+	}
+
+	mate = newBlossomMatcher(n, reflected).maxWeightMatching(true)
+	for _, m := range mate {
+		if m < 0 {
+			return nil, false
+		}
+	}
+	return mate, true
+}
+
+// blossomMatcher holds the state of Edmonds' blossom algorithm for maximum
+// weight matching in general graphs using the primal-dual method. It follows
+// the formulation of Galil, "Efficient algorithms for finding maximum matching
+// in graphs", ACM Computing Surveys, 1986.
+//
+// Vertices are numbered 0 to n-1 and non-trivial blossoms are numbered n to 2n-1.
+// Each edge k has two endpoints, 2k and 2k+1, where endpoint 2k is vertex i and
+// endpoint 2k+1 is vertex j of the edge.
+type blossomMatcher struct {
+	n     int
+	edges []weightedPair
+
+	// endpoint holds the vertex of each edge endpoint.
+	endpoint []int
+	// neighbend holds the remote endpoints of the
+	// edges incident to each vertex.
+	neighbend [][]int
+
+	// mate holds the remote endpoint of the matched
+	// edge of each vertex, or -1 if it is single.
+	mate []int
+
+	// label holds the label of each top-level blossom
+	// and vertex: 0 for unlabeled, 1 for S and 2 for
+	// T. The value 5 marks breadcrumbs during blossom
+	// scans. labelend holds the endpoint through which
+	// the label was assigned, or -1.
+	label    []int
+	labelend []int
+
+	// inblossom holds the top-level blossom of each
+	// vertex.
+	inblossom []int
+
+	// blossomparent, blossomchilds, blossombase and
+	// blossomendps describe the structure of each
+	// blossom. The children of a blossom are ordered
+	// around the blossom starting at the base and
+	// blossomendps holds the endpoints of the edges
+	// connecting consecutive children.
+	blossomparent []int
+	blossomchilds [][]int
+	blossombase   []int
+	blossomendps  [][]int
+
+	// bestedge holds the least-slack edge to a
+	// different S-blossom for each vertex and
+	// top-level blossom, and blossombestedges
+	// holds the list of such edges for each
+	// non-trivial S-blossom.
+	bestedge         []int
+	blossombestedges [][]int
+
+	unusedblossoms []int
+
+	// dualvar holds the dual variables of the
+	// vertices followed by those of the blossoms.
+	dualvar []float64
+
+	// allowedge marks the edges with zero slack.
+	allowedge []bool
+
+	queue []int
+}
+
+func newBlossomMatcher(n int, edges []weightedPair) *blossomMatcher {
+	m := &blossomMatcher{
+		n:     n,
+		edges: edges,
+
+		endpoint:  make([]int, 2*len(edges)),
+		neighbend: make([][]int, n),
+
+		mate:     make([]int, n),
+		label:    make([]int, 2*n),
+		labelend: make([]int, 2*n),
+
+		inblossom: make([]int, n),
+
+		blossomparent: make([]int, 2*n),
+		blossomchilds: make([][]int, 2*n),
+		blossombase:   make([]int, 2*n),
+		blossomendps:  make([][]int, 2*n),
+
+		bestedge:         make([]int, 2*n),
+		blossombestedges: make([][]int, 2*n),
+
+		dualvar:   make([]float64, 2*n),
+		allowedge: make([]bool, len(edges)),
+	}
+
+	var maxWeight float64
+	for k, e := range edges {
+		m.endpoint[2*k] = e.i
+		m.endpoint[2*k+1] = e.j
+		m.neighbend[e.i] = append(m.neighbend[e.i], 2*k+1)
+		m.neighbend[e.j] = append(m.neighbend[e.j], 2*k)
+		if e.w > maxWeight {
+			maxWeight = e.w
+		}
+	}
+	for i := range m.mate {
+		m.mate[i] = -1
+		m.inblossom[i] = i
+		m.blossombase[i] = i
+		m.dualvar[i] = maxWeight
+	}
+	for b := range m.labelend {
+		m.labelend[b] = -1
+		m.blossomparent[b] = -1
+		m.bestedge[b] = -1
+	}
+	for b := n; b < 2*n; b++ {
+		m.blossombase[b] = -1
+		m.unusedblossoms = append(m.unusedblossoms, b)
+	}
+	return m
+}
+
+// slack returns the slack of edge k, which must not be
+// internal to a blossom.
+func (m *blossomMatcher) slack(k int) float64 {
+	e := m.edges[k]
+	return m.dualvar[e.i] + m.dualvar[e.j] - 2*e.w
+}
+
+// leaves appends the vertices contained in blossom b to dst.
+func (m *blossomMatcher) leaves(b int, dst []int) []int {
+	if b < m.n {
+		return append(dst, b)
+	}
+	for _, t := range m.blossomchilds[b] {
+		dst = m.leaves(t, dst)
+	}
+	return dst
+}
+
+// assignLabel assigns label t to the top-level blossom containing vertex w,
+// coming through endpoint p, and propagates S labels through matched edges.
+func (m *blossomMatcher) assignLabel(w, t, p int) {
+	for {
+		b := m.inblossom[w]
+		m.label[w], m.label[b] = t, t
+		m.labelend[w], m.labelend[b] = p, p
+		m.bestedge[w], m.bestedge[b] = -1, -1
+		if t == 1 {
+			// b became an S-vertex or S-blossom;
+			// add its vertices to the queue.
+			m.queue = m.leaves(b, m.queue)
+			return
+		}
+		// b became a T-vertex or T-blossom; assign
+		// an S label to its mate.
+		base := m.blossombase[b]
+		w, t, p = m.endpoint[m.mate[base]], 1, m.mate[base]^1
+	}
+}
+
+// scanBlossom traces back from vertices v and w to discover either a new
+// blossom or an augmenting path. It returns the base vertex of the new
+// blossom or -1 if an augmenting path was found.
+func (m *blossomMatcher) scanBlossom(v, w int) int {
+	var path []int
+	base := -1
+	for v != -1 || w != -1 {
+		b := m.inblossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.blossombase[b]
+			break
+		}
+		path = append(path, b)
+		m.label[b] = 5
+		if m.labelend[b] == -1 {
+			// The base of blossom b is single;
+			// stop tracing this path.
+			v = -1
+		} else {
+			v = m.endpoint[m.labelend[b]]
+			b = m.inblossom[v]
+			// b is a T-blossom; trace one more step back.
+			v = m.endpoint[m.labelend[b]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return base
+}
+
+// addBlossom constructs a new blossom with the given base, containing edge k
+// which connects a pair of S vertices.
+func (m *blossomMatcher) addBlossom(base, k int) {
+	v := m.edges[k].i
+	w := m.edges[k].j
+	bb := m.inblossom[base]
+	bv := m.inblossom[v]
+	bw := m.inblossom[w]
+
+	b := m.unusedblossoms[len(m.unusedblossoms)-1]
+	m.unusedblossoms = m.unusedblossoms[:len(m.unusedblossoms)-1]
+	m.blossombase[b] = base
+	m.blossomparent[b] = -1
+	m.blossomparent[bb] = b
+
+	// Trace back from v to base.
+	var path, endps []int
+	for bv != bb {
+		m.blossomparent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, m.labelend[bv])
+		v = m.endpoint[m.labelend[bv]]
+		bv = m.inblossom[v]
+	}
+	path = append(path, bb)
+	reverseInts(path)
+	reverseInts(endps)
+	endps = append(endps, 2*k)
+
+	// Trace back from w to base.
+	for bw != bb {
+		m.blossomparent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, m.labelend[bw]^1)
+		w = m.endpoint[m.labelend[bw]]
+		bw = m.inblossom[w]
+	}
+	m.blossomchilds[b] = path
+	m.blossomendps[b] = endps
+
+	m.label[b] = 1
+	m.labelend[b] = m.labelend[bb]
+	m.dualvar[b] = 0
+
+	// Relabel vertices.
+	for _, v := range m.leaves(b, nil) {
+		if m.label[m.inblossom[v]] == 2 {
+			// This T-vertex now turns into an
+			// S-vertex because it becomes part
+			// of an S-blossom.
+			m.queue = append(m.queue, v)
+		}
+		m.inblossom[v] = b
+	}
+
+	// Compute blossombestedges[b].
+	bestedgeto := make([]int, 2*m.n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+	for _, bv := range path {
+		var nblists [][]int
+		if m.blossombestedges[bv] == nil {
+			// This subblossom does not have a list of least-slack
+			// edges; get the information from the vertices.
+			for _, v := range m.leaves(bv, nil) {
+				nblist := make([]int, len(m.neighbend[v]))
+				for k, p := range m.neighbend[v] {
+					nblist[k] = p / 2
+				}
+				nblists = append(nblists, nblist)
+			}
+		} else {
+			nblists = [][]int{m.blossombestedges[bv]}
+		}
+		for _, nblist := range nblists {
+			for _, k := range nblist {
+				i, j := m.edges[k].i, m.edges[k].j
+				if m.inblossom[j] == b {
+					i, j = j, i
+				}
+				bj := m.inblossom[j]
+				if bj != b && m.label[bj] == 1 && (bestedgeto[bj] == -1 || m.slack(k) < m.slack(bestedgeto[bj])) {
+					bestedgeto[bj] = k
+				}
+			}
+		}
+		// Forget about least-slack edges of the subblossom.
+		m.blossombestedges[bv] = nil
+		m.bestedge[bv] = -1
+	}
+	best := make([]int, 0, len(bestedgeto))
+	for _, k := range bestedgeto {
+		if k != -1 {
+			best = append(best, k)
+		}
+	}
+	m.blossombestedges[b] = best
+
+	// Select bestedge[b].
+	m.bestedge[b] = -1
+	for _, k := range best {
+		if m.bestedge[b] == -1 || m.slack(k) < m.slack(m.bestedge[b]) {
+			m.bestedge[b] = k
+		}
+	}
+}
+
+// expandBlossom expands the top-level blossom b. If endStage is true, zero-dual
+// sub-blossoms are expanded recursively; otherwise the labels of the
+// sub-blossoms of a T-blossom are restored.
+func (m *blossomMatcher) expandBlossom(b int, endStage bool) {
+	// Convert sub-blossoms into top-level blossoms.
+	for _, s := range m.blossomchilds[b] {
+		m.blossomparent[s] = -1
+		switch {
+		case s < m.n:
+			m.inblossom[s] = s
+		case endStage && m.dualvar[s] == 0:
+			// Recursively expand this sub-blossom.
+			m.expandBlossom(s, endStage)
+		default:
+			for _, v := range m.leaves(s, nil) {
+				m.inblossom[v] = s
+			}
+		}
+	}
+
+	// If we expand a T-blossom during a stage, its sub-blossoms
+	// must be relabeled.
+	if !endStage && m.label[b] == 2 {
+		childs := m.blossomchilds[b]
+		endps := m.blossomendps[b]
+
+		// Start at the sub-blossom through which the expanding
+		// blossom obtained its label, and relabel sub-blossoms
+		// until we reach the base. Figure out through which
+		// sub-blossom the expanding blossom obtained its label
+		// initially.
+		entrychild := m.inblossom[m.endpoint[m.labelend[b]^1]]
+		j := indexOf(childs, entrychild)
+		var jstep, endptrick int
+		if j&1 != 0 {
+			// Start index is odd; go forward and wrap.
+			j -= len(childs)
+			jstep = 1
+			endptrick = 0
+		} else {
+			// Start index is even; go backward.
+			jstep = -1
+			endptrick = 1
+		}
+
+		// Move along the blossom until we get to the base.
+		p := m.labelend[b]
+		for j != 0 {
+			// Relabel the T-sub-blossom.
+			m.label[m.endpoint[p^1]] = 0
+			m.label[m.endpoint[at(endps, j-endptrick)^endptrick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+			// Step to the next S-sub-blossom and note its
+			// forward endpoint.
+			m.allowedge[at(endps, j-endptrick)/2] = true
+			j += jstep
+			p = at(endps, j-endptrick) ^ endptrick
+			// Step to the next T-sub-blossom.
+			m.allowedge[p/2] = true
+			j += jstep
+		}
+
+		// Relabel the base T-sub-blossom without stepping through
+		// to its mate, so don't call assignLabel.
+		bv := at(childs, j)
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelend[m.endpoint[p^1]], m.labelend[bv] = p, p
+		m.bestedge[bv] = -1
+
+		// Continue along the blossom until we get back to entrychild.
+		j += jstep
+		for at(childs, j) != entrychild {
+			// Examine the vertices of the sub-blossom to see
+			// whether it is reachable from a neighboring
+			// S-vertex outside the expanding blossom.
+			bv := at(childs, j)
+			if m.label[bv] == 1 {
+				// This sub-blossom just got label S through one
+				// of its neighbors; leave it.
+				j += jstep
+				continue
+			}
+			v := -1
+			for _, u := range m.leaves(bv, nil) {
+				if m.label[u] != 0 {
+					v = u
+					break
+				}
+			}
+			// If the sub-blossom contains a reachable vertex,
+			// assign label T to the sub-blossom.
+			if v != -1 {
+				m.label[v] = 0
+				m.label[m.endpoint[m.mate[m.blossombase[bv]]]] = 0
+				m.assignLabel(v, 2, m.labelend[v])
+			}
+			j += jstep
+		}
+	}
+
+	// Recycle the blossom number.
+	m.label[b], m.labelend[b] = -1, -1
+	m.blossomchilds[b], m.blossomendps[b] = nil, nil
+	m.blossombase[b] = -1
+	m.blossombestedges[b] = nil
+	m.bestedge[b] = -1
+	m.unusedblossoms = append(m.unusedblossoms, b)
+}
+
+// augmentBlossom swaps matched and unmatched edges over an alternating path
+// through blossom b between vertex v and the base vertex.
+func (m *blossomMatcher) augmentBlossom(b, v int) {
+	// Bubble up through the blossom tree from vertex v to an
+	// immediate sub-blossom of b.
+	t := v
+	for m.blossomparent[t] != b {
+		t = m.blossomparent[t]
+	}
+	// Recursively deal with the first sub-blossom.
+	if t >= m.n {
+		m.augmentBlossom(t, v)
+	}
+
+	// Decide in which direction we will go round the blossom.
+	childs := m.blossomchilds[b]
+	endps := m.blossomendps[b]
+	i := indexOf(childs, t)
+	j := i
+	var jstep, endptrick int
+	if i&1 != 0 {
+		// Start index is odd; go forward and wrap.
+		j -= len(childs)
+		jstep = 1
+		endptrick = 0
+	} else {
+		// Start index is even; go backward.
+		jstep = -1
+		endptrick = 1
+	}
+
+	// Move along the blossom until we get to the base.
+	for j != 0 {
+		// Step to the next sub-blossom and augment it recursively.
+		j += jstep
+		t = at(childs, j)
+		p := at(endps, j-endptrick) ^ endptrick
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+		// Step to the next sub-blossom and augment it recursively.
+		j += jstep
+		t = at(childs, j)
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+		// Match the edge connecting those sub-blossoms.
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+
+	// Rotate the list of sub-blossoms to put the new base at
+	// the front.
+	m.blossomchilds[b] = append(append([]int(nil), childs[i:]...), childs[:i]...)
+	m.blossomendps[b] = append(append([]int(nil), endps[i:]...), endps[:i]...)
+	m.blossombase[b] = m.blossombase[m.blossomchilds[b][0]]
+}
+
+// augmentMatching swaps matched and unmatched edges over an alternating path
+// between two single vertices. The augmenting path runs through edge k, which
+// connects a pair of S vertices.
+func (m *blossomMatcher) augmentMatching(k int) {
+	e := m.edges[k]
+	for _, sp := range [2][2]int{{e.i, 2*k + 1}, {e.j, 2 * k}} {
+		s, p := sp[0], sp[1]
+		// Match vertex s to remote endpoint p. Then trace back from s
+		// until we find a single vertex, swapping matched and unmatched
+		// edges as we go.
+		for {
+			bs := m.inblossom[s]
+			// Augment through the S-blossom from s to base.
+			if bs >= m.n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+			// Trace one step back.
+			if m.labelend[bs] == -1 {
+				// Reached a single vertex; stop.
+				break
+			}
+			t := m.endpoint[m.labelend[bs]]
+			bt := m.inblossom[t]
+			// Trace one more step back.
+			s = m.endpoint[m.labelend[bt]]
+			j := m.endpoint[m.labelend[bt]^1]
+			// Augment through the T-blossom from j to base.
+			if bt >= m.n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelend[bt]
+			// Keep the opposite endpoint; it will be assigned to
+			// mate[s] in the next step.
+			p = m.labelend[bt] ^ 1
+		}
+	}
+}
+
+// maxWeightMatching returns the mate of each vertex in a maximum weight
+// matching, or -1 for unmatched vertices. If maxCardinality is true, the
+// matching is the maximum weight matching among the maximum cardinality
+// matchings.
+func (m *blossomMatcher) maxWeightMatching(maxCardinality bool) []int {
+	n := m.n
+
+	// Each iteration of this loop is a stage. A stage finds an
+	// augmenting path and uses that to improve the matching.
+	for stage := 0; stage < n; stage++ {
+		// Remove labels from top-level blossoms and vertices,
+		// and forget least-slack edges.
+		for b := range m.label {
+			m.label[b] = 0
+			m.bestedge[b] = -1
+		}
+		for b := n; b < 2*n; b++ {
+			m.blossombestedges[b] = nil
+		}
+		for k := range m.allowedge {
+			m.allowedge[k] = false
+		}
+		m.queue = m.queue[:0]
+
+		// Label single blossoms and vertices with S and put
+		// them in the queue.
+		for v := 0; v < n; v++ {
+			if m.mate[v] == -1 && m.label[m.inblossom[v]] == 0 {
+				m.assignLabel(v, 1, -1)
+			}
+		}
+
+		augmented := false
+		for {
+			// Continue labeling until all vertices which are reachable
+			// through an alternating path have got a label.
+			for len(m.queue) != 0 && !augmented {
+				v := m.queue[len(m.queue)-1]
+				m.queue = m.queue[:len(m.queue)-1]
+
+				for _, p := range m.neighbend[v] {
+					k := p / 2
+					w := m.endpoint[p]
+					if m.inblossom[v] == m.inblossom[w] {
+						// This edge is internal to a blossom.
+						continue
+					}
+					var kslack float64
+					if !m.allowedge[k] {
+						kslack = m.slack(k)
+						if kslack <= 0 {
+							// The edge has zero slack so
+							// is allowed.
+							m.allowedge[k] = true
+						}
+					}
+					switch {
+					case m.allowedge[k]:
+						switch {
+						case m.label[m.inblossom[w]] == 0:
+							// w is a free vertex or an unreached vertex
+							// inside a T-blossom; label w with T and
+							// its mate with S.
+							m.assignLabel(w, 2, p^1)
+						case m.label[m.inblossom[w]] == 1:
+							// w is an S-vertex so either we have
+							// discovered a new blossom or an
+							// augmenting path.
+							base := m.scanBlossom(v, w)
+							if base >= 0 {
+								m.addBlossom(base, k)
+							} else {
+								m.augmentMatching(k)
+								augmented = true
+							}
+						case m.label[w] == 0:
+							// w is inside a T-blossom, but w itself has not
+							// yet been reached from outside the blossom;
+							// mark it as reached for relabeling during
+							// T-blossom expansion.
+							m.label[w] = 2
+							m.labelend[w] = p ^ 1
+						}
+					case m.label[m.inblossom[w]] == 1:
+						// Keep track of the least-slack non-allowable edge
+						// to a different S-blossom.
+						b := m.inblossom[v]
+						if m.bestedge[b] == -1 || kslack < m.slack(m.bestedge[b]) {
+							m.bestedge[b] = k
+						}
+					case m.label[w] == 0:
+						// w is a free vertex or an unreached vertex inside
+						// a T-blossom; keep track of the least-slack edge
+						// that reaches w.
+						if m.bestedge[w] == -1 || kslack < m.slack(m.bestedge[w]) {
+							m.bestedge[w] = k
+						}
+					}
+					if augmented {
+						break
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+
+			// There is no augmenting path under these constraints;
+			// compute delta and reduce slack in the optimization
+			// problem.
+			deltatype := -1
+			var delta float64
+			var deltaedge, deltablossom int
+
+			// Compute delta1: the minimum value of any vertex dual.
+			if !maxCardinality {
+				deltatype = 1
+				delta = m.dualvar[0]
+				for _, d := range m.dualvar[1:n] {
+					if d < delta {
+						delta = d
+					}
+				}
+			}
+
+			// Compute delta2: the minimum slack on any edge between
+			// an S-vertex and a free vertex.
+			for v := 0; v < n; v++ {
+				if m.label[m.inblossom[v]] == 0 && m.bestedge[v] != -1 {
+					d := m.slack(m.bestedge[v])
+					if deltatype == -1 || d < delta {
+						delta = d
+						deltatype = 2
+						deltaedge = m.bestedge[v]
+					}
+				}
+			}
+
+			// Compute delta3: half the minimum slack on any edge
+			// between a pair of S-blossoms.
+			for b := 0; b < 2*n; b++ {
+				if m.blossomparent[b] == -1 && m.label[b] == 1 && m.bestedge[b] != -1 {
+					d := m.slack(m.bestedge[b]) / 2
+					if deltatype == -1 || d < delta {
+						delta = d
+						deltatype = 3
+						deltaedge = m.bestedge[b]
+					}
+				}
+			}
+
+			// Compute delta4: the minimum z variable of any T-blossom.
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 && m.label[b] == 2 &&
+					(deltatype == -1 || m.dualvar[b] < delta) {
+					delta = m.dualvar[b]
+					deltatype = 4
+					deltablossom = b
+				}
+			}
+
+			if deltatype == -1 {
+				// No further improvement possible; max-cardinality
+				// optimum reached. Do a final delta update to make
+				// the optimum verifiable.
+				deltatype = 1
+				delta = m.dualvar[0]
+				for _, d := range m.dualvar[1:n] {
+					if d < delta {
+						delta = d
+					}
+				}
+				if delta < 0 {
+					delta = 0
+				}
+			}
+
+			// Update dual variables according to delta.
+			for v := 0; v < n; v++ {
+				switch m.label[m.inblossom[v]] {
+				case 1:
+					m.dualvar[v] -= delta
+				case 2:
+					m.dualvar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 {
+					switch m.label[b] {
+					case 1:
+						m.dualvar[b] += delta
+					case 2:
+						m.dualvar[b] -= delta
+					}
+				}
+			}
+
+			// Take action at the point where the minimum delta occurred.
+			if deltatype == 1 {
+				// No further improvement possible; optimum reached.
+				break
+			}
+			switch deltatype {
+			case 2:
+				// Use the least-slack edge to continue the search.
+				m.allowedge[deltaedge] = true
+				i := m.edges[deltaedge].i
+				if m.label[m.inblossom[i]] == 0 {
+					i = m.edges[deltaedge].j
+				}
+				m.queue = append(m.queue, i)
+			case 3:
+				// Use the least-slack edge to continue the search.
+				m.allowedge[deltaedge] = true
+				m.queue = append(m.queue, m.edges[deltaedge].i)
+			case 4:
+				// Expand the least-z blossom.
+				m.expandBlossom(deltablossom, false)
+			}
+		}
+
+		// Stop when no more augmenting paths can be found.
+		if !augmented {
+			break
+		}
+
+		// End of a stage; expand all S-blossoms which have a zero dual.
+		for b := n; b < 2*n; b++ {
+			if m.blossomparent[b] == -1 && m.blossombase[b] >= 0 && m.label[b] == 1 && m.dualvar[b] == 0 {
+				m.expandBlossom(b, true)
+			}
+		}
+	}
+
+	// Transform mate such that mate[v] is the vertex
+	// to which v is paired.
+	mate := make([]int, n)
+	for v, p := range m.mate {
+		if p >= 0 {
+			mate[v] = m.endpoint[p]
+		} else {
+			mate[v] = -1
+		}
+	}
+	return mate
+}
+
+// at returns s[i], indexing from the end of s for negative i.
+func at(s []int, i int) int {
+	if i < 0 {
+		i += len(s)
+	}
+	return s[i]
+}
+
+// indexOf returns the index of the first occurrence of v in s.
+func indexOf(s []int, v int) int {
+	for i, e := range s {
+		if e == v {
+			return i
+		}
+	}
+	panic("matching: missing blossom child")
+}
+
+// reverseInts reverses the order of s in place.
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var matchingTests = []struct {
+	name  string
+	nodes []int
+	edges []simple.Edge
+
+	want       [][2]int
+	wantWeight float64
+	wantOK     bool
+}{
+	{
+		name:   "empty",
+		wantOK: true,
+	},
+	{
+		name:  "isolated nodes",
+		nodes: []int{0, 1},
+	},
+	{
+		name: "triangle",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(0), W: 1},
+		},
+	},
+	{
+		name: "star",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 1},
+		},
+	},
+	{
+		name: "path",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 5},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 5},
+		},
+		want:       [][2]int{{0, 1}, {2, 3}},
+		wantWeight: 10,
+		wantOK:     true,
+	},
+	{
+		name: "square with diagonal",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 4},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 4},
+			{F: simple.Node(3), T: simple.Node(0), W: 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+		},
+		want:       [][2]int{{0, 3}, {1, 2}},
+		wantWeight: 3,
+		wantOK:     true,
+	},
+	{
+		// The minimum weight perfect matching of
+		// the two triangles joined by a heavy edge
+		// must use the heavy edge, so the search
+		// must pass through an odd cycle.
+		name: "joined triangles",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(0), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+			{F: simple.Node(4), T: simple.Node(5), W: 1},
+			{F: simple.Node(5), T: simple.Node(3), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 10},
+		},
+		want:       [][2]int{{0, 1}, {2, 3}, {4, 5}},
+		wantWeight: 12,
+		wantOK:     true,
+	},
+	{
+		name: "negative weights",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: -1},
+			{F: simple.Node(1), T: simple.Node(2), W: -5},
+			{F: simple.Node(2), T: simple.Node(3), W: -1},
+			{F: simple.Node(3), T: simple.Node(0), W: -1},
+		},
+		want:       [][2]int{{0, 3}, {1, 2}},
+		wantWeight: -6,
+		wantOK:     true,
+	},
+	{
+		name:  "sparse IDs",
+		nodes: []int{10, 20, 30, 40},
+		edges: []simple.Edge{
+			{F: simple.Node(40), T: simple.Node(10), W: 1},
+			{F: simple.Node(10), T: simple.Node(30), W: 1},
+			{F: simple.Node(20), T: simple.Node(30), W: 1},
+		},
+		want:       [][2]int{{10, 40}, {20, 30}},
+		wantWeight: 2,
+		wantOK:     true,
+	},
+}
+
+func TestMinimumWeightPerfectMatching(t *testing.T) {
+	for _, test := range matchingTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for _, n := range test.nodes {
+			g.AddNode(simple.Node(n))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		matching, weight, ok := MinimumWeightPerfectMatching(g)
+		if ok != test.wantOK {
+			t.Errorf("unexpected existence of perfect matching for %q: got:%t want:%t", test.name, ok, test.wantOK)
+			continue
+		}
+		var got [][2]int
+		for _, e := range matching {
+			got = append(got, [2]int{e.From().ID(), e.To().ID()})
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected matching for %q: got:%v want:%v", test.name, got, test.want)
+		}
+		if weight != test.wantWeight {
+			t.Errorf("unexpected matching weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+	}
+}
+
+func TestMinimumWeightPerfectMatchingRandom(t *testing.T) {
+	const trials = 500
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < trials; i++ {
+		n := 2 * (1 + rnd.Intn(6))
+		density := rnd.Float64()
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < density {
+					var w float64
+					if i%2 == 0 {
+						w = float64(rnd.Intn(10))
+					} else {
+						w = rnd.Float64()
+					}
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: w})
+				}
+			}
+		}
+
+		want := bruteForceMatchingWeight(g)
+		matching, weight, ok := MinimumWeightPerfectMatching(g)
+		if ok != !math.IsInf(want, 1) {
+			t.Errorf("unexpected existence of perfect matching for trial %d: got:%t want:%t", i, ok, !ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(weight-want) > 1e-9 {
+			t.Errorf("unexpected matching weight for trial %d: got:%v want:%v", i, weight, want)
+		}
+
+		matched := make(map[int]bool)
+		var sum float64
+		for _, e := range matching {
+			u, v := e.From(), e.To()
+			if !g.HasEdgeBetween(u, v) {
+				t.Errorf("matched edge %d-%d not in graph for trial %d", u.ID(), v.ID(), i)
+			}
+			if matched[u.ID()] || matched[v.ID()] {
+				t.Errorf("node matched more than once for trial %d", i)
+			}
+			matched[u.ID()] = true
+			matched[v.ID()] = true
+			sum += e.Weight()
+		}
+		if len(matched) != n {
+			t.Errorf("matching is not perfect for trial %d: matched %d of %d nodes", i, len(matched), n)
+		}
+		if sum != weight {
+			t.Errorf("returned weight does not match edges for trial %d: got:%v want:%v", i, weight, sum)
+		}
+	}
+}
+
+// bruteForceMatchingWeight returns the weight of a minimum weight perfect
+// matching of g found by exhaustive search, or +Inf if none exists.
+func bruteForceMatchingWeight(g graph.Undirected) float64 {
+	nodes := g.Nodes()
+	memo := make(map[uint64]float64)
+	var match func(set uint64) float64
+	match = func(set uint64) float64 {
+		if set == 0 {
+			return 0
+		}
+		if c, ok := memo[set]; ok {
+			return c
+		}
+		i := 0
+		for set&(1<<uint(i)) == 0 {
+			i++
+		}
+		best := math.Inf(1)
+		for j := i + 1; j < len(nodes); j++ {
+			if set&(1<<uint(j)) == 0 {
+				continue
+			}
+			e := g.EdgeBetween(nodes[i], nodes[j])
+			if e == nil {
+				continue
+			}
+			if c := e.Weight() + match(set&^(1<<uint(i)|1<<uint(j))); c < best {
+				best = c
+			}
+		}
+		memo[set] = best
+		return best
+	}
+	return match(1<<uint(len(nodes)) - 1)
+}
@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "github.com/gonum/graph"
+
+// NegativeCycle returns a negative weight cycle in the graph g if one exists.
+// The returned cycle starts and ends with the same node. If the graph does not
+// implement graph.Weighter, UniformCost is used. NegativeCycle reports the cycle
+// whose existence is indicated by a false ok return from FloydWarshall or
+// BellmanFordFrom. In an undirected graph an edge with negative weight forms a
+// negative cycle.
+//
+// The time complexity of NegativeCycle is O(|V|.|E|).
+func NegativeCycle(g graph.Graph) (cycle []graph.Node, found bool) {
+	var weight Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Perform a Bellman-Ford relaxation from a virtual
+	// source joined to every node with a zero weight
+	// edge, so every negative cycle is reachable.
+	dist := make([]float64, len(nodes))
+	prev := make([]int, len(nodes))
+	for i := range prev {
+		prev[i] = -1
+	}
+	var last int
+	for i := 0; i < len(nodes); i++ {
+		last = -1
+		for j, u := range nodes {
+			for _, v := range g.From(u) {
+				k := indexOf[v.ID()]
+				w, ok := weight(u, v)
+				if !ok {
+					panic("negative cycle: unexpected invalid weight")
+				}
+				if joint := dist[j] + w; joint < dist[k] {
+					dist[k] = joint
+					prev[k] = j
+					last = k
+				}
+			}
+		}
+		if last < 0 {
+			break
+		}
+	}
+	if len(nodes) == 0 || last < 0 {
+		return nil, false
+	}
+
+	// A node relaxed in the final round is reachable from
+	// a negative cycle in the predecessor graph, so walking
+	// back len(nodes) steps must end on the cycle.
+	c := last
+	for i := 0; i < len(nodes); i++ {
+		c = prev[c]
+	}
+	cycle = []graph.Node{nodes[c]}
+	for n := prev[c]; n != c; n = prev[n] {
+		cycle = append(cycle, nodes[n])
+	}
+	cycle = append(cycle, nodes[c])
+	reverse(cycle)
+
+	return cycle, true
+}
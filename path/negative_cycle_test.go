@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path/internal/testgraphs"
+	"github.com/gonum/graph/simple"
+)
+
+func TestNegativeCycle(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetEdge(e)
+		}
+		checkNegativeCycle(t, test.Name, g.(graph.Graph), test.HasNegativeCycle)
+	}
+
+	// A negative cycle that is not reachable from
+	// the first nodes of the graph.
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 2},
+		{F: simple.Node(4), T: simple.Node(5), W: -1},
+		{F: simple.Node(5), T: simple.Node(3), W: -2},
+		{F: simple.Node(5), T: simple.Node(6), W: -10},
+	} {
+		g.SetEdge(e)
+	}
+	checkNegativeCycle(t, "unreachable cycle", g, true)
+}
+
+func checkNegativeCycle(t *testing.T, name string, g graph.Graph, want bool) {
+	cycle, found := NegativeCycle(g)
+	if found != want {
+		t.Errorf("%q: unexpected negative cycle result: got:%t want:%t", name, found, want)
+	}
+	if _, ok := FloydWarshall(g); ok == found {
+		t.Errorf("%q: negative cycle result disagrees with FloydWarshall", name)
+	}
+	if !found {
+		if cycle != nil {
+			t.Errorf("%q: unexpected cycle: %v", name, cycle)
+		}
+		return
+	}
+	if len(cycle) < 3 || cycle[0].ID() != cycle[len(cycle)-1].ID() {
+		t.Errorf("%q: returned nodes are not a cycle: %v", name, cycle)
+		return
+	}
+	var w float64
+	for i, u := range cycle[:len(cycle)-1] {
+		v := cycle[i+1]
+		e := g.Edge(u, v)
+		if e == nil {
+			t.Errorf("%q: cycle uses missing edge %d->%d", name, u.ID(), v.ID())
+			return
+		}
+		w += e.Weight()
+	}
+	if w >= 0 {
+		t.Errorf("%q: cycle %v has non-negative weight %v", name, cycle, w)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "github.com/gonum/graph"
+
+// SmoothPath returns a copy of path with intermediate nodes removed where a
+// straight line of sight exists between non-adjacent nodes of the path. The
+// path is pulled taut greedily from its start, in the manner of the string
+// pulling step of Theta*: following nodes are skipped while they are visible
+// from the last retained node, and the node before the first node that is not
+// visible is retained. Nodes beyond that first hidden node are not examined, so
+// a later node visible from a retained node is not necessarily joined to it.
+// The first and last nodes of the path are always retained.
+//
+// The lineOfSight function reports whether a straight path is available from
+// a to b. If lineOfSight is nil, a line of sight is only considered to exist
+// when g holds an edge from a to b, so intermediate nodes are removed only
+// where g provides a direct shortcut.
+//
+// SmoothPath does not check that path is a path in g.
+func SmoothPath(path []graph.Node, g graph.Graph, lineOfSight func(a, b graph.Node) bool) []graph.Node {
+	if len(path) < 3 {
+		return append([]graph.Node(nil), path...)
+	}
+	if lineOfSight == nil {
+		lineOfSight = func(a, b graph.Node) bool {
+			return g.Edge(a, b) != nil
+		}
+	}
+
+	smoothed := []graph.Node{path[0]}
+	anchor := path[0]
+	for i := 1; i < len(path)-1; i++ {
+		if !lineOfSight(anchor, path[i+1]) {
+			anchor = path[i]
+			smoothed = append(smoothed, anchor)
+		}
+	}
+	return append(smoothed, path[len(path)-1])
+}
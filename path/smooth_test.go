@@ -0,0 +1,142 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
+	"github.com/gonum/graph/simple"
+)
+
+// gridLineOfSight returns a line of sight function for g that
+// samples the straight segment between nodes and requires every
+// sampled grid position to be open.
+func gridLineOfSight(g *grid.Grid) func(a, b graph.Node) bool {
+	return func(a, b graph.Node) bool {
+		ax, ay := g.XY(a)
+		bx, by := g.XY(b)
+		const steps = 100
+		for i := 0; i <= steps; i++ {
+			f := float64(i) / steps
+			r := int(math.Floor(ay + f*(by-ay) + 0.5))
+			c := int(math.Floor(ax + f*(bx-ax) + 0.5))
+			if !g.HasOpen(g.NodeAt(r, c)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+var smoothPathTests = []struct {
+	name     string
+	g        *grid.Grid
+	path     []int
+	useSight bool
+	want     []int
+}{
+	{
+		name: "empty",
+		g:    grid.NewGrid(3, 3, true),
+		want: nil,
+	},
+	{
+		name: "single edge",
+		g:    grid.NewGrid(3, 3, true),
+		path: []int{0, 1},
+		want: []int{0, 1},
+	},
+	{
+		name:     "open corner",
+		g:        grid.NewGrid(5, 5, true),
+		path:     []int{0, 1, 2, 3, 4, 9, 14, 19, 24},
+		useSight: true,
+		want:     []int{0, 24},
+	},
+	{
+		name: "wall",
+		g: grid.NewGridFrom(
+			".....",
+			".***.",
+			".....",
+		),
+		path:     []int{0, 1, 2, 3, 4, 9, 14},
+		useSight: true,
+		want:     []int{0, 4, 14},
+	},
+	{
+		name: "staircase",
+		g: grid.NewGridFrom(
+			"..***",
+			"...**",
+			"*....",
+		),
+		path:     []int{0, 1, 6, 7, 12, 13, 14},
+		useSight: true,
+		want:     []int{0, 13, 14},
+	},
+	{
+		name: "diagonal edges",
+		g: func() *grid.Grid {
+			g := grid.NewGrid(3, 3, true)
+			g.AllowDiagonal = true
+			return g
+		}(),
+		path: []int{0, 1, 4, 5, 8},
+		want: []int{0, 4, 8},
+	},
+	{
+		name: "no diagonal edges",
+		g:    grid.NewGrid(3, 3, true),
+		path: []int{0, 1, 4, 5, 8},
+		want: []int{0, 1, 4, 5, 8},
+	},
+}
+
+func TestSmoothPath(t *testing.T) {
+	for _, test := range smoothPathTests {
+		var path []graph.Node
+		for _, id := range test.path {
+			path = append(path, simple.Node(id))
+		}
+		var sight func(a, b graph.Node) bool
+		if test.useSight {
+			sight = gridLineOfSight(test.g)
+		}
+
+		got := SmoothPath(path, test.g, sight)
+		var gotIDs []int
+		for _, n := range got {
+			gotIDs = append(gotIDs, n.ID())
+		}
+		if !reflect.DeepEqual(gotIDs, test.want) {
+			t.Errorf("unexpected smoothed path for %q: got:%v want:%v", test.name, gotIDs, test.want)
+		}
+		if len(got) != 0 && &got[0] == &path[0] {
+			t.Errorf("smoothed path for %q shares storage with input path", test.name)
+		}
+	}
+}
+
+func TestSmoothPathGreedy(t *testing.T) {
+	// Node 3 is visible from 0, but 2 is not, so
+	// smoothing retains 1 and continues from there.
+	visible := map[[2]int]bool{{0, 1}: true, {0, 3}: true, {1, 2}: true, {1, 3}: true, {1, 4}: true}
+	sight := func(a, b graph.Node) bool { return visible[[2]int{a.ID(), b.ID()}] }
+	path := []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2), simple.Node(3), simple.Node(4)}
+
+	got := SmoothPath(path, simple.NewDirectedGraph(0, math.Inf(1)), sight)
+	var gotIDs []int
+	for _, n := range got {
+		gotIDs = append(gotIDs, n.ID())
+	}
+	if want := []int{0, 1, 4}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("unexpected smoothed path: got:%v want:%v", gotIDs, want)
+	}
+}
@@ -5,7 +5,6 @@
 package path
 
 import (
-	"container/heap"
 	"math"
 	"sort"
 
@@ -31,40 +30,49 @@ func Prim(dst graph.UndirectedBuilder, g UndirectedWeighter) float64 {
 		return 0
 	}
 
-	q := &primQueue{
-		indexOf: make(map[int]int, len(nodes)-1),
-		nodes:   make([]simple.Edge, 0, len(nodes)-1),
-	}
+	// q holds the nodes not yet connected to the minimum
+	// spanning forest keyed on the minimum edge weight to
+	// a connected node, and nearest holds that connected
+	// node for each queued node.
+	q := simple.NewNodePriorityQueue()
+	nearest := make(map[int]graph.Node)
+	nodeOf := make(map[int]graph.Node, len(nodes))
 	for _, u := range nodes[1:] {
-		heap.Push(q, simple.Edge{F: u, W: math.Inf(1)})
+		nodeOf[u.ID()] = u
+		q.Push(u.ID(), math.Inf(1))
 	}
 
 	u := nodes[0]
 	for _, v := range g.From(u) {
+		if !q.Has(v.ID()) {
+			continue
+		}
 		w, ok := g.Weight(u, v)
 		if !ok {
 			panic("prim: unexpected invalid weight")
 		}
-		q.update(v, u, w)
+		q.DecreaseKey(v.ID(), w)
+		nearest[v.ID()] = u
 	}
 
 	var w float64
 	for q.Len() > 0 {
-		e := heap.Pop(q).(simple.Edge)
-		if e.To() != nil && g.HasEdgeBetween(e.From(), e.To()) {
-			dst.SetEdge(e)
-			w += e.Weight()
+		uid, key := q.Pop()
+		u = nodeOf[uid]
+		if v, ok := nearest[uid]; ok {
+			dst.SetEdge(simple.Edge{F: u, T: v, W: key})
+			w += key
 		}
 
-		u = e.From()
 		for _, n := range g.From(u) {
-			if key, ok := q.key(n); ok {
+			if key, ok := q.Priority(n.ID()); ok {
 				w, ok := g.Weight(u, n)
 				if !ok {
 					panic("prim: unexpected invalid weight")
 				}
 				if w < key {
-					q.update(n, u, w)
+					q.DecreaseKey(n.ID(), w)
+					nearest[n.ID()] = u
 				}
 			}
 		}
@@ -72,66 +80,6 @@ func Prim(dst graph.UndirectedBuilder, g UndirectedWeighter) float64 {
 	return w
 }
 
-// primQueue is a Prim's priority queue. The priority queue is a
-// queue of edge From nodes keyed on the minimum edge weight to
-// a node in the set of nodes already connected to the minimum
-// spanning forest.
-type primQueue struct {
-	indexOf map[int]int
-	nodes   []simple.Edge
-}
-
-func (q *primQueue) Less(i, j int) bool {
-	return q.nodes[i].Weight() < q.nodes[j].Weight()
-}
-
-func (q *primQueue) Swap(i, j int) {
-	q.indexOf[q.nodes[i].From().ID()] = j
-	q.indexOf[q.nodes[j].From().ID()] = i
-	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
-}
-
-func (q *primQueue) Len() int {
-	return len(q.nodes)
-}
-
-func (q *primQueue) Push(x interface{}) {
-	n := x.(simple.Edge)
-	q.indexOf[n.From().ID()] = len(q.nodes)
-	q.nodes = append(q.nodes, n)
-}
-
-func (q *primQueue) Pop() interface{} {
-	n := q.nodes[len(q.nodes)-1]
-	q.nodes = q.nodes[:len(q.nodes)-1]
-	delete(q.indexOf, n.From().ID())
-	return n
-}
-
-// key returns the key for the node u and whether the node is
-// in the queue. If the node is not in the queue, key is returned
-// as +Inf.
-func (q *primQueue) key(u graph.Node) (key float64, ok bool) {
-	i, ok := q.indexOf[u.ID()]
-	if !ok {
-		return math.Inf(1), false
-	}
-	return q.nodes[i].Weight(), ok
-}
-
-// update updates u's position in the queue with the new closest
-// MST-connected neighbour, v, and the key weight between u and v.
-func (q *primQueue) update(u, v graph.Node, key float64) {
-	id := u.ID()
-	i, ok := q.indexOf[id]
-	if !ok {
-		return
-	}
-	q.nodes[i].T = v
-	q.nodes[i].W = key
-	heap.Fix(q, i)
-}
-
 // UndirectedWeightLister is an undirected graph that returns edge weights and
 // the set of edges in the graph.
 type UndirectedWeightLister interface {
@@ -159,15 +107,15 @@ func Kruskal(dst graph.UndirectedBuilder, g UndirectedWeightLister) float64 {
 	}
 	sort.Sort(byWeight(ascend))
 
-	ds := newDisjointSet()
+	ds := simple.NewDisjointSet()
 	for _, node := range g.Nodes() {
-		ds.makeSet(node.ID())
+		ds.MakeSet(node.ID())
 	}
 
 	var w float64
 	for _, e := range ascend {
-		if s1, s2 := ds.find(e.From().ID()), ds.find(e.To().ID()); s1 != s2 {
-			ds.union(s1, s2)
+		if s1, s2 := ds.Find(e.From().ID()), ds.Find(e.To().ID()); s1 != s2 {
+			ds.Union(s1, s2)
 			dst.SetEdge(e)
 			w += e.Weight()
 		}
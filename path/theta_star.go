@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/set"
+)
+
+// ThetaStar finds an any-angle path from s to t in g using the Theta* algorithm,
+// returning the nodes of the path and its cost. Consecutive nodes in the returned
+// path are either joined by an edge of g or have a line of sight between them,
+// so the path may cut across the graph rather than following its edges.
+//
+// During the expansion of a node u, ThetaStar considers joining each neighbor v
+// of u directly to the parent of u. If lineOfSight reports that a straight path
+// exists from the parent of u to v, the cost of that step is given by cost.
+// Otherwise the edge from u to v is used with its weight from g, or UniformCost
+// if g does not implement graph.Weighter. If lineOfSight is nil or never returns
+// true between non-adjacent nodes, ThetaStar finds the same path cost as AStar.
+// ThetaStar will panic if lineOfSight is not nil and cost is nil.
+//
+// If h is nil, ThetaStar will use the g.HeuristicCost method if g implements
+// HeuristicCoster, falling back to NullHeuristic otherwise. The returned path
+// is the shortest any-angle path only when h is admissible with respect to cost.
+// If t is not reachable from s, ThetaStar returns a nil path and infinite cost.
+// ThetaStar will panic if g has a reachable negative edge weight.
+func ThetaStar(s, t graph.Node, g graph.Graph, cost func(a, b graph.Node) float64, h Heuristic, lineOfSight func(a, b graph.Node) bool) (path []graph.Node, weight float64) {
+	if !g.Has(s) || !g.Has(t) {
+		return nil, math.Inf(1)
+	}
+	if lineOfSight != nil && cost == nil {
+		panic("theta*: nil cost with line of sight")
+	}
+	visit := visitorFor(g, nil, "theta*")
+	if h == nil {
+		if g, ok := g.(HeuristicCoster); ok {
+			h = g.HeuristicCost
+		} else {
+			h = NullHeuristic
+		}
+	}
+
+	gscore := map[int]float64{s.ID(): 0}
+	parent := map[int]graph.Node{s.ID(): s}
+	closed := make(set.Ints)
+	open := &aStarQueue{indexOf: make(map[int]int)}
+	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: h(s, t)})
+
+	var u aStarNode
+	relax := func(v graph.Node, w float64) bool {
+		vid := v.ID()
+		if closed.Has(vid) {
+			return true
+		}
+		if w < 0 {
+			panic("theta*: negative edge weight")
+		}
+
+		// Prefer joining v to the parent of u when
+		// there is a line of sight between them.
+		p := parent[u.node.ID()]
+		g := u.gscore + w
+		if lineOfSight != nil && p.ID() != u.node.ID() && lineOfSight(p, v) {
+			g = gscore[p.ID()] + cost(p, v)
+		} else {
+			p = u.node
+		}
+
+		if _, ok := open.node(vid); !ok {
+			gscore[vid] = g
+			parent[vid] = p
+			heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
+		} else if g < gscore[vid] {
+			gscore[vid] = g
+			parent[vid] = p
+			open.update(vid, g, g+h(v, t))
+		}
+		return true
+	}
+
+	tid := t.ID()
+	for open.Len() != 0 {
+		u = heap.Pop(open).(aStarNode)
+		uid := u.node.ID()
+		if uid == tid {
+			// Follow the parents back to s.
+			for n := u.node; ; n = parent[n.ID()] {
+				path = append(path, n)
+				if n.ID() == s.ID() {
+					break
+				}
+			}
+			reverse(path)
+			return path, u.gscore
+		}
+		closed.Add(uid)
+		visit(u.node, relax)
+	}
+
+	return nil, math.Inf(1)
+}
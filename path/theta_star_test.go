@@ -0,0 +1,137 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/grid"
+	"github.com/gonum/graph/simple"
+)
+
+// euclidean returns the Euclidean distance function for nodes of g.
+func euclidean(g *grid.Grid) func(a, b graph.Node) float64 {
+	return func(a, b graph.Node) float64 {
+		ax, ay := g.XY(a)
+		bx, by := g.XY(b)
+		return math.Hypot(ax-bx, ay-by)
+	}
+}
+
+func diagonalGrid(rows ...string) *grid.Grid {
+	g := grid.NewGridFrom(rows...)
+	g.AllowDiagonal = true
+	g.AllowCornerCutting = true
+	return g
+}
+
+var thetaStarTests = []struct {
+	name string
+	g    *grid.Grid
+	s, t int
+
+	wantPath   []int
+	wantWeight float64
+}{
+	{
+		name: "open",
+		g: diagonalGrid(
+			"...",
+			"...",
+			"...",
+			"...",
+			"...",
+		),
+		s: 0, t: 14,
+		wantPath:   []int{0, 14},
+		wantWeight: math.Hypot(4, 2),
+	},
+	{
+		name: "wall",
+		g: diagonalGrid(
+			".....",
+			".***.",
+			".....",
+		),
+		s: 0, t: 14,
+		wantPath:   []int{0, 3, 9, 14},
+		wantWeight: 4 + math.Sqrt2,
+	},
+	{
+		name: "same node",
+		g:    diagonalGrid("..."),
+		s:    1, t: 1,
+		wantPath:   []int{1},
+		wantWeight: 0,
+	},
+	{
+		name: "unreachable",
+		g: diagonalGrid(
+			".*.",
+			".*.",
+		),
+		s: 0, t: 2,
+		wantPath:   nil,
+		wantWeight: math.Inf(1),
+	},
+}
+
+func TestThetaStar(t *testing.T) {
+	for _, test := range thetaStarTests {
+		dist := euclidean(test.g)
+		path, weight := ThetaStar(simple.Node(test.s), simple.Node(test.t), test.g, dist, dist, gridLineOfSight(test.g))
+		var got []int
+		for _, n := range path {
+			got = append(got, n.ID())
+		}
+		if !equalInts(got, test.wantPath) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.wantPath)
+		}
+		if weight != test.wantWeight && math.Abs(weight-test.wantWeight) > 1e-10 {
+			t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+	}
+}
+
+func TestThetaStarNoLineOfSight(t *testing.T) {
+	for _, test := range aStarTests {
+		s, u := simple.Node(test.s), simple.Node(test.t)
+		pt, _ := AStar(s, u, test.g, test.heuristic)
+		_, wantWeight := pt.To(u)
+
+		never := func(a, b graph.Node) bool { return false }
+		for _, los := range []func(a, b graph.Node) bool{nil, never} {
+			cost := func(a, b graph.Node) float64 {
+				t.Fatalf("unexpected call to cost for %q", test.name)
+				return 0
+			}
+			path, weight := ThetaStar(s, u, test.g, cost, test.heuristic, los)
+			if weight != wantWeight {
+				t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, wantWeight)
+			}
+			// Without a line of sight every step of the
+			// path must follow an edge of the graph.
+			for i := 1; i < len(path); i++ {
+				if test.g.Edge(path[i-1], path[i]) == nil {
+					t.Errorf("unexpected step for %q from %d to %d", test.name, path[i-1].ID(), path[i].ID())
+				}
+			}
+		}
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if b[i] != v {
+			return false
+		}
+	}
+	return true
+}
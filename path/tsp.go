@@ -0,0 +1,148 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// TSPNearestNeighbor returns a travelling salesman tour of the graph g beginning
+// at start and the total weight of the tour, using the nearest neighbor
+// heuristic. The tour visits every node of g once and is returned without
+// repeating start at its end, the last node of the tour returning to start.
+// If g does not contain start, TSPNearestNeighbor returns a nil tour and +Inf
+// weight.
+//
+// If the graph does not implement graph.Weighter, UniformCost is used. The
+// heuristic assumes g is complete: the weight of an absent edge is the absent
+// weight of g, so if g is incomplete, the tour may use absent edges, and the
+// weight of the tour is +Inf when the absent weight is +Inf.
+//
+// The tour is not in general optimal. It may be improved by TwoOptImprove.
+// The time complexity of TSPNearestNeighbor is O(|V|^2).
+func TSPNearestNeighbor(start graph.Node, g graph.Graph) (tour []graph.Node, weight float64) {
+	if !g.Has(start) {
+		return nil, math.Inf(1)
+	}
+	weightOf := tourWeighting(g)
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	visited := make(map[int]bool, len(nodes))
+	visited[start.ID()] = true
+	tour = append(make([]graph.Node, 0, len(nodes)), start)
+	for u := start; len(tour) < len(nodes); {
+		var (
+			next graph.Node
+			best = math.Inf(1)
+		)
+		for _, v := range nodes {
+			if visited[v.ID()] {
+				continue
+			}
+			if w := weightOf(u, v); next == nil || w < best {
+				next = v
+				best = w
+			}
+		}
+		visited[next.ID()] = true
+		tour = append(tour, next)
+		u = next
+	}
+	return tour, tourWeight(tour, weightOf)
+}
+
+// TwoOptImprove returns the travelling salesman tour of the graph g obtained by
+// improving tour with the 2-opt local search heuristic, and the total weight of
+// the returned tour. The tour is a sequence of the nodes of g without repeating
+// the first node at its end, as returned by TSPNearestNeighbor, and is not
+// modified. The returned tour begins with the same node as tour.
+//
+// Segments of the tour are reversed while doing so reduces the weight of the
+// tour. The weights of both directions of edges are taken into account, so
+// TwoOptImprove may be used with directed graphs. The result is a local optimum
+// and is not in general an optimal tour.
+//
+// If the graph does not implement graph.Weighter, UniformCost is used. As for
+// TSPNearestNeighbor, g is assumed to be complete.
+func TwoOptImprove(tour []graph.Node, g graph.Graph) (improved []graph.Node, weight float64) {
+	weightOf := tourWeighting(g)
+	t := make([]graph.Node, len(tour))
+	copy(t, tour)
+	n := len(t)
+	if n < 4 {
+		return t, tourWeight(t, weightOf)
+	}
+
+	// fwd[i] and bwd[i] hold the weight of the first i
+	// edges of the tour traversed forwards and backwards,
+	// so the weight of a reversed segment is found in
+	// constant time.
+	fwd := make([]float64, n)
+	bwd := make([]float64, n)
+	sums := func() {
+		for i := 1; i < n; i++ {
+			fwd[i] = fwd[i-1] + weightOf(t[i-1], t[i])
+			bwd[i] = bwd[i-1] + weightOf(t[i], t[i-1])
+		}
+	}
+	sums()
+
+	for improving := true; improving; {
+		improving = false
+		for i := 0; i < n-2; i++ {
+			for j := i + 2; j < n; j++ {
+				if i == 0 && j == n-1 {
+					// The edges share the first node.
+					continue
+				}
+				a, b := t[i], t[i+1]
+				c, d := t[j], t[(j+1)%n]
+				delta := weightOf(a, c) + weightOf(b, d) - weightOf(a, b) - weightOf(c, d) +
+					(bwd[j] - bwd[i+1]) - (fwd[j] - fwd[i+1])
+				if !(delta < -1e-12*math.Abs(fwd[n-1])) {
+					continue
+				}
+				for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+					t[l], t[r] = t[r], t[l]
+				}
+				sums()
+				improving = true
+			}
+		}
+	}
+	return t, tourWeight(t, weightOf)
+}
+
+// tourWeighting returns a function returning the weight of the edge from u
+// to v in g, or the absent weight of g if there is no such edge.
+func tourWeighting(g graph.Graph) func(u, v graph.Node) float64 {
+	var weight Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+	return func(u, v graph.Node) float64 {
+		w, _ := weight(u, v)
+		return w
+	}
+}
+
+// tourWeight returns the total weight of the closed tour.
+func tourWeight(tour []graph.Node, weightOf func(u, v graph.Node) float64) float64 {
+	if len(tour) < 2 {
+		return 0
+	}
+	var w float64
+	for i, u := range tour {
+		w += weightOf(u, tour[(i+1)%len(tour)])
+	}
+	return w
+}
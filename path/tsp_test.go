@@ -0,0 +1,149 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// euclideanGraph returns a complete undirected graph with
+// edge weights given by the distances between the points.
+func euclideanGraph(points [][2]float64) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for i, p := range points {
+		g.AddNode(simple.Node(i))
+		for j, q := range points[:i] {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j), W: math.Hypot(p[0]-q[0], p[1]-q[1])})
+		}
+	}
+	return g
+}
+
+func TestTSPNearestNeighbor(t *testing.T) {
+	// The points lie on a line, so the nearest neighbor
+	// tour from 0 goes right along the line and returns.
+	points := [][2]float64{{0, 0}, {3, 0}, {1, 0}, {6, 0}}
+	g := euclideanGraph(points)
+	tour, weight := TSPNearestNeighbor(simple.Node(0), g)
+	want := []int{0, 2, 1, 3}
+	if got := tourIDs(tour); !equalInts(got, want) {
+		t.Errorf("unexpected tour: got:%v want:%v", got, want)
+	}
+	if weight != 12 {
+		t.Errorf("unexpected tour weight: got:%v want:12", weight)
+	}
+
+	tour, weight = TSPNearestNeighbor(simple.Node(10), g)
+	if tour != nil || !math.IsInf(weight, 1) {
+		t.Errorf("unexpected result for absent start: got:(%v, %v) want:(nil, +Inf)", tour, weight)
+	}
+
+	// A missing edge has the absent weight of the graph.
+	g.RemoveEdge(simple.Edge{F: simple.Node(3), T: simple.Node(0)})
+	_, weight = TSPNearestNeighbor(simple.Node(0), g)
+	if !math.IsInf(weight, 1) {
+		t.Errorf("unexpected weight for tour using absent edge: got:%v want:+Inf", weight)
+	}
+}
+
+func TestTwoOptImproveConvex(t *testing.T) {
+	// Points in convex position have a unique tour
+	// without crossing edges, the tour around their
+	// hull, and this is the only 2-opt local optimum.
+	const n = 30
+	rnd := rand.New(rand.NewSource(1))
+	angles := make([]float64, n)
+	for i := range angles {
+		angles[i] = 2 * math.Pi * float64(i) / n
+	}
+	perm := rnd.Perm(n)
+	points := make([][2]float64, n)
+	for i, p := range perm {
+		points[p] = [2]float64{math.Cos(angles[i]), math.Sin(angles[i])}
+	}
+	g := euclideanGraph(points)
+	optimal := float64(n) * 2 * math.Sin(math.Pi/n)
+
+	for start := 0; start < n; start += 7 {
+		tour, nnWeight := TSPNearestNeighbor(simple.Node(start), g)
+		checkTour(t, g, tour, start)
+		improved, weight := TwoOptImprove(tour, g)
+		checkTour(t, g, improved, start)
+		if weight > nnWeight {
+			t.Errorf("2-opt increased tour weight from %d: %v > %v", start, weight, nnWeight)
+		}
+		if math.Abs(weight-optimal) > 1e-9 {
+			t.Errorf("unexpected improved tour weight from %d: got:%v want:%v", start, weight, optimal)
+		}
+
+		// A random tour is improved to the optimum too.
+		random := make([]graph.Node, n)
+		for i, p := range rnd.Perm(n) {
+			random[i] = simple.Node(p)
+		}
+		_, weight = TwoOptImprove(random, g)
+		if math.Abs(weight-optimal) > 1e-9 {
+			t.Errorf("unexpected improved weight of random tour: got:%v want:%v", weight, optimal)
+		}
+	}
+}
+
+func TestTwoOptImproveDirected(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		n := 2 + rnd.Intn(10)
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(100))})
+				}
+			}
+		}
+		tour, nnWeight := TSPNearestNeighbor(simple.Node(0), g)
+		improved, weight := TwoOptImprove(tour, g)
+		checkTour(t, g, improved, 0)
+		if weight > nnWeight {
+			t.Errorf("2-opt increased tour weight for trial %d: %v > %v", trial, weight, nnWeight)
+		}
+		var sum float64
+		for i, u := range improved {
+			sum += g.Edge(u, improved[(i+1)%n]).Weight()
+		}
+		if sum != weight {
+			t.Errorf("returned weight does not match tour for trial %d: got:%v want:%v", trial, weight, sum)
+		}
+	}
+}
+
+func checkTour(t *testing.T, g graph.Graph, tour []graph.Node, start int) {
+	if len(tour) != len(g.Nodes()) {
+		t.Errorf("unexpected tour length: got:%d want:%d", len(tour), len(g.Nodes()))
+		return
+	}
+	if tour[0].ID() != start {
+		t.Errorf("unexpected tour start: got:%d want:%d", tour[0].ID(), start)
+	}
+	seen := make(map[int]bool)
+	for _, n := range tour {
+		if seen[n.ID()] {
+			t.Errorf("node %d visited more than once", n.ID())
+		}
+		seen[n.ID()] = true
+	}
+}
+
+func tourIDs(tour []graph.Node) []int {
+	ids := make([]int, len(tour))
+	for i, n := range tour {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -30,6 +30,48 @@ func UniformCost(g graph.Graph) Weighting {
 	}
 }
 
+// visitorFor returns a function that calls fn with each node directly reachable
+// from u in g and the weight of the joining edge until fn returns false. If weight
+// is nil, the edge weights of g are used, or UniformCost if g does not implement
+// graph.Weighter. When g implements graph.FromVisitor, its VisitFrom method is used
+// to avoid allocating a neighbor slice for each node. The returned function panics
+// with a message prefixed by name if an edge has an invalid weight.
+func visitorFor(g graph.Graph, weight Weighting, name string) func(u graph.Node, fn func(v graph.Node, w float64) bool) {
+	vg, canVisit := g.(graph.FromVisitor)
+	if weight == nil {
+		if wg, ok := g.(graph.Weighter); ok {
+			if canVisit {
+				return vg.VisitFrom
+			}
+			weight = wg.Weight
+		} else {
+			weight = UniformCost(g)
+		}
+	}
+	if canVisit {
+		return func(u graph.Node, fn func(graph.Node, float64) bool) {
+			vg.VisitFrom(u, func(v graph.Node, _ float64) bool {
+				w, ok := weight(u, v)
+				if !ok {
+					panic(name + ": unexpected invalid weight")
+				}
+				return fn(v, w)
+			})
+		}
+	}
+	return func(u graph.Node, fn func(graph.Node, float64) bool) {
+		for _, v := range g.From(u) {
+			w, ok := weight(u, v)
+			if !ok {
+				panic(name + ": unexpected invalid weight")
+			}
+			if !fn(v, w) {
+				return
+			}
+		}
+	}
+}
+
 // Heuristic returns an estimate of the cost of travelling between two nodes.
 type Heuristic func(x, y graph.Node) float64
 
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import "math"
+
+// Reverse copies the nodes of src into dst and adds each edge of src to dst
+// with its from and to nodes swapped, without first clearing the destination.
+// Edge weights are preserved. Reverse will panic if a node ID in the source
+// graph matches a node ID in the destination.
+//
+// For read-only use, a Transposed view of src avoids the copy.
+func Reverse(dst DirectedBuilder, src Directed) {
+	nodes := src.Nodes()
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+	for _, u := range nodes {
+		for _, v := range src.From(u) {
+			dst.SetEdge(reversedEdge{src.Edge(u, v)})
+		}
+	}
+}
+
+// Transposed is a directed graph view of G with the direction of all edges
+// reversed.
+type Transposed struct {
+	G Directed
+}
+
+var (
+	_ Directed = Transposed{}
+	_ Weighter = Transposed{}
+)
+
+// Has returns whether the node exists within the graph.
+func (g Transposed) Has(n Node) bool { return g.G.Has(n) }
+
+// Nodes returns all the nodes in the graph.
+func (g Transposed) Nodes() []Node { return g.G.Nodes() }
+
+// From returns all nodes in g that can be reached directly from u.
+func (g Transposed) From(u Node) []Node { return g.G.To(u) }
+
+// To returns all nodes in g that can reach directly to v.
+func (g Transposed) To(v Node) []Node { return g.G.From(v) }
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g Transposed) HasEdgeBetween(x, y Node) bool { return g.G.HasEdgeBetween(x, y) }
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g Transposed) HasEdgeFromTo(u, v Node) bool { return g.G.HasEdgeFromTo(v, u) }
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+// The returned edge is the edge from v to u in G with its nodes swapped.
+func (g Transposed) Edge(u, v Node) Edge {
+	e := g.G.Edge(v, u)
+	if e == nil {
+		return nil
+	}
+	return reversedEdge{e}
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If G implements Weighter, the weight of the edge from y to x in G is returned. Otherwise,
+// if x and y are the same node zero is returned, and if there is no joining edge between the
+// two nodes the weight value returned is +Inf. Weight returns true if an edge exists between
+// x and y or if x and y have the same ID, false otherwise.
+func (g Transposed) Weight(x, y Node) (w float64, ok bool) {
+	if wg, ok := g.G.(Weighter); ok {
+		return wg.Weight(y, x)
+	}
+	if e := g.G.Edge(y, x); e != nil {
+		return e.Weight(), true
+	}
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	return math.Inf(1), false
+}
+
+// reversedEdge is an edge with its from and to nodes swapped.
+type reversedEdge struct {
+	Edge
+}
+
+func (e reversedEdge) From() Node { return e.Edge.To() }
+func (e reversedEdge) To() Node   { return e.Edge.From() }
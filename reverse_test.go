@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestReverse(t *testing.T) {
+	g := directedFrom([]int{5}, []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(0), W: 3},
+		{F: simple.Node(0), T: simple.Node(2), W: 4},
+		{F: simple.Node(3), T: simple.Node(4), W: 5},
+	})
+
+	dst := simple.NewDirectedGraph(0, math.Inf(1))
+	graph.Reverse(dst, g)
+	for _, r := range []graph.Directed{dst, graph.Transposed{G: g}} {
+		if len(r.Nodes()) != len(g.Nodes()) {
+			t.Errorf("unexpected number of nodes in %T: got:%d want:%d", r, len(r.Nodes()), len(g.Nodes()))
+		}
+		for _, u := range g.Nodes() {
+			if !sameIDs(r.From(u), g.To(u)) {
+				t.Errorf("unexpected from nodes of %d in %T", u.ID(), r)
+			}
+			if !sameIDs(r.To(u), g.From(u)) {
+				t.Errorf("unexpected to nodes of %d in %T", u.ID(), r)
+			}
+			for _, v := range g.Nodes() {
+				if r.HasEdgeFromTo(u, v) != g.HasEdgeFromTo(v, u) {
+					t.Errorf("unexpected edge existence from %d to %d in %T", u.ID(), v.ID(), r)
+				}
+				re := r.Edge(u, v)
+				ge := g.Edge(v, u)
+				if (re == nil) != (ge == nil) {
+					t.Errorf("unexpected edge from %d to %d in %T", u.ID(), v.ID(), r)
+					continue
+				}
+				if re == nil {
+					continue
+				}
+				if re.From().ID() != u.ID() || re.To().ID() != v.ID() || re.Weight() != ge.Weight() {
+					t.Errorf("unexpected edge from %d to %d in %T: got:%d->%d w=%v want w=%v",
+						u.ID(), v.ID(), r, re.From().ID(), re.To().ID(), re.Weight(), ge.Weight())
+				}
+				if w, ok := r.(graph.Weighter).Weight(u, v); !ok || w != ge.Weight() {
+					t.Errorf("unexpected weight from %d to %d in %T: got:(%v, %t) want:(%v, true)",
+						u.ID(), v.ID(), r, w, ok, ge.Weight())
+				}
+			}
+		}
+	}
+}
+
+func sameIDs(a, b []graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[int]bool)
+	for _, n := range a {
+		ids[n.ID()] = true
+	}
+	for _, n := range b {
+		if !ids[n.ID()] {
+			return false
+		}
+	}
+	return true
+}
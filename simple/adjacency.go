@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/matrix/mat64"
+)
+
+// AdjacencyMatrix returns the dense weight matrix of g and the nodes of g sorted
+// by ID, which define the row and column ordering of the matrix. The matrix entry
+// at G_{ij} is the weight of the edge from nodes[i] to nodes[j], or +Inf if there
+// is no such edge. If g implements graph.Weighter its Weight method is used for
+// edge weights and the diagonal, otherwise edges have unit weight and the diagonal
+// is zero. The matrix of an undirected graph is symmetric.
+func AdjacencyMatrix(g graph.Graph) (m *mat64.Dense, nodes []graph.Node) {
+	nodes = g.Nodes()
+	if len(nodes) == 0 {
+		return &mat64.Dense{}, nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	weight := func(_, _ graph.Node) float64 { return 1 }
+	self := func(graph.Node) float64 { return 0 }
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+		self = func(u graph.Node) float64 { return weight(u, u) }
+	}
+
+	data := make([]float64, len(nodes)*len(nodes))
+	for i := range data {
+		data[i] = math.Inf(1)
+	}
+	m = mat64.NewDense(len(nodes), len(nodes), data)
+	for i, u := range nodes {
+		m.Set(i, i, self(u))
+		for _, v := range g.From(u) {
+			m.Set(i, indexOf[v.ID()], weight(u, v))
+		}
+	}
+	return m, nodes
+}
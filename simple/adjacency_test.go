@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	inf := math.Inf(1)
+
+	d := NewDirectedGraph(0, inf)
+	d.SetEdge(Edge{F: Node(5), T: Node(1), W: 2})
+	d.SetEdge(Edge{F: Node(1), T: Node(3), W: 3})
+	d.SetEdge(Edge{F: Node(3), T: Node(1), W: 4})
+
+	u := NewUndirectedGraph(0, inf)
+	u.SetEdge(Edge{F: Node(5), T: Node(1), W: 2})
+	u.SetEdge(Edge{F: Node(1), T: Node(3), W: 3})
+
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+		want *mat64.Dense
+	}{
+		{
+			name: "directed",
+			g:    d,
+			want: mat64.NewDense(3, 3, []float64{
+				0, 3, inf,
+				4, 0, inf,
+				2, inf, 0,
+			}),
+		},
+		{
+			name: "undirected",
+			g:    u,
+			want: mat64.NewDense(3, 3, []float64{
+				0, 3, 2,
+				3, 0, inf,
+				2, inf, 0,
+			}),
+		},
+		{
+			name: "unweighted",
+			g:    struct{ graph.Directed }{d},
+			want: mat64.NewDense(3, 3, []float64{
+				0, 1, inf,
+				1, 0, inf,
+				1, inf, 0,
+			}),
+		},
+	} {
+		m, nodes := AdjacencyMatrix(test.g)
+		if !mat64.Equal(m, test.want) {
+			t.Errorf("unexpected adjacency matrix for %s graph:\ngot:\n%v\nwant:\n%v",
+				test.name, mat64.Formatted(m), mat64.Formatted(test.want))
+		}
+		for i, want := range []int{1, 3, 5} {
+			if nodes[i].ID() != want {
+				t.Errorf("unexpected node order for %s graph: got:%v want:[1 3 5]", test.name, nodes)
+				break
+			}
+		}
+	}
+
+	m, nodes := AdjacencyMatrix(NewDirectedGraph(0, inf))
+	if r, c := m.Dims(); r != 0 || c != 0 || nodes != nil {
+		t.Errorf("unexpected result for empty graph: dims=(%d,%d) nodes=%v", r, c, nodes)
+	}
+}
+
+func TestDirectedMatrixFromData(t *testing.T) {
+	inf := math.Inf(1)
+
+	g := NewDirectedGraph(0, inf)
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 2})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 3})
+	g.SetEdge(Edge{F: Node(2), T: Node(0), W: 4})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 5})
+
+	m, nodes := AdjacencyMatrix(g)
+	dm := NewDirectedMatrixFromData(len(nodes), m.RawMatrix().Data, 0, inf)
+	for _, u := range g.Nodes() {
+		for _, v := range g.Nodes() {
+			if u.ID() == v.ID() {
+				continue
+			}
+			if g.HasEdgeFromTo(u, v) != dm.HasEdgeFromTo(u, v) {
+				t.Errorf("unexpected edge state from %d to %d", u.ID(), v.ID())
+			}
+			want, _ := g.Weight(u, v)
+			got, _ := dm.Weight(u, v)
+			if got != want {
+				t.Errorf("unexpected weight from %d to %d: got:%v want:%v", u.ID(), v.ID(), got, want)
+			}
+		}
+	}
+
+	// The caller's data is neither modified
+	// nor shared with the graph.
+	data := []float64{1, 2, 3, 4}
+	dm = NewDirectedMatrixFromData(2, data, 0, inf)
+	if want := []float64{1, 2, 3, 4}; !reflect.DeepEqual(data, want) {
+		t.Errorf("data modified by construction: got:%v want:%v", data, want)
+	}
+	data[1] = 5
+	if w, _ := dm.Weight(Node(0), Node(1)); w != 2 {
+		t.Errorf("graph altered by change to data: got weight:%v want:2", w)
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		NewDirectedMatrixFromData(3, make([]float64, 8), 0, inf)
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for mismatched data length")
+	}
+}
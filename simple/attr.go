@@ -0,0 +1,260 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// DirectedAttrGraph is a DirectedGraph that holds an arbitrary value for each
+// node and a set of keyed attributes for each edge. Values and attributes are
+// removed when their node or edge is removed from the graph. Changing the
+// weight of an edge with SetEdge or SetEdgeWeight retains its attributes.
+type DirectedAttrGraph struct {
+	*DirectedGraph
+	attrs attrStore
+}
+
+// NewDirectedAttrGraph returns a DirectedAttrGraph with the specified self and
+// absent edge weight values.
+func NewDirectedAttrGraph(self, absent float64) *DirectedAttrGraph {
+	return &DirectedAttrGraph{
+		DirectedGraph: NewDirectedGraph(self, absent),
+		attrs:         newAttrStore(),
+	}
+}
+
+// NodeValue returns the value held for the node with the given ID and whether
+// a value is held.
+func (g *DirectedAttrGraph) NodeValue(id int) (v interface{}, ok bool) {
+	v, ok = g.attrs.nodes[id]
+	return v, ok
+}
+
+// SetNodeValue sets the value held for the node with the given ID. SetNodeValue
+// will panic if the node is not in the graph.
+func (g *DirectedAttrGraph) SetNodeValue(id int, v interface{}) {
+	if g.Node(id) == nil {
+		panic("simple: set value of absent node")
+	}
+	g.attrs.nodes[id] = v
+}
+
+// EdgeAttr returns the value of the attribute key for the edge from u to v and
+// whether the attribute is set.
+func (g *DirectedAttrGraph) EdgeAttr(u, v graph.Node, key string) (value interface{}, ok bool) {
+	value, ok = g.attrs.edges[edgeKey{from: u.ID(), to: v.ID()}][key]
+	return value, ok
+}
+
+// SetEdgeAttr sets the value of the attribute key for the edge from u to v.
+// SetEdgeAttr will panic if the edge is not in the graph.
+func (g *DirectedAttrGraph) SetEdgeAttr(u, v graph.Node, key string, value interface{}) {
+	if !g.HasEdgeFromTo(u, v) {
+		panic("simple: set attribute of absent edge")
+	}
+	g.attrs.set(edgeKey{from: u.ID(), to: v.ID()}, key, value)
+}
+
+// EdgeAttrs returns a copy of the attributes of the edge from u to v, or nil
+// if the edge has no attributes.
+func (g *DirectedAttrGraph) EdgeAttrs(u, v graph.Node) map[string]interface{} {
+	return g.attrs.copyOf(edgeKey{from: u.ID(), to: v.ID()})
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it and
+// their attributes. If the node is not in the graph it is a no-op.
+func (g *DirectedAttrGraph) RemoveNode(n graph.Node) {
+	g.forgetNode(n)
+	g.DirectedGraph.RemoveNode(n)
+}
+
+// RemoveEdge removes e and its attributes from the graph, leaving the terminal
+// nodes. If the edge does not exist it is a no-op.
+func (g *DirectedAttrGraph) RemoveEdge(e graph.Edge) {
+	delete(g.attrs.edges, edgeKey{from: e.From().ID(), to: e.To().ID()})
+	g.DirectedGraph.RemoveEdge(e)
+}
+
+// ClearEdges removes all the edges from g and their attributes, leaving its
+// nodes and their values.
+func (g *DirectedAttrGraph) ClearEdges() {
+	g.attrs.edges = make(map[edgeKey]map[string]interface{})
+	g.DirectedGraph.ClearEdges()
+}
+
+// Contract contracts the edges between u and v, merging v into u, and returns
+// the merged node as described by DirectedGraph.Contract. The value of v and
+// the attributes of the edges of v are discarded.
+func (g *DirectedAttrGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	if u.ID() != v.ID() {
+		g.forgetNode(v)
+	}
+	return g.DirectedGraph.Contract(u, v, resolve)
+}
+
+// KeepReachableFrom removes every node of g that is not reachable from any of
+// the given roots, as described by DirectedGraph.KeepReachableFrom, along with
+// the values of the removed nodes and the attributes of their edges.
+func (g *DirectedAttrGraph) KeepReachableFrom(roots []graph.Node) {
+	reached := g.reachableFrom(roots)
+	for _, n := range g.Nodes() {
+		if !reached.Has(n.ID()) {
+			g.RemoveNode(n)
+		}
+	}
+}
+
+// forgetNode deletes the value of n and the attributes of its edges.
+func (g *DirectedAttrGraph) forgetNode(n graph.Node) {
+	id := n.ID()
+	delete(g.attrs.nodes, id)
+	for _, v := range g.From(n) {
+		delete(g.attrs.edges, edgeKey{from: id, to: v.ID()})
+	}
+	for _, u := range g.To(n) {
+		delete(g.attrs.edges, edgeKey{from: u.ID(), to: id})
+	}
+}
+
+// UndirectedAttrGraph is an UndirectedGraph that holds an arbitrary value for
+// each node and a set of keyed attributes for each edge. Values and attributes
+// are removed when their node or edge is removed from the graph. Changing the
+// weight of an edge with SetEdge or SetEdgeWeight retains its attributes.
+type UndirectedAttrGraph struct {
+	*UndirectedGraph
+	attrs attrStore
+}
+
+// NewUndirectedAttrGraph returns an UndirectedAttrGraph with the specified self
+// and absent edge weight values.
+func NewUndirectedAttrGraph(self, absent float64) *UndirectedAttrGraph {
+	return &UndirectedAttrGraph{
+		UndirectedGraph: NewUndirectedGraph(self, absent),
+		attrs:           newAttrStore(),
+	}
+}
+
+// NodeValue returns the value held for the node with the given ID and whether
+// a value is held.
+func (g *UndirectedAttrGraph) NodeValue(id int) (v interface{}, ok bool) {
+	v, ok = g.attrs.nodes[id]
+	return v, ok
+}
+
+// SetNodeValue sets the value held for the node with the given ID. SetNodeValue
+// will panic if the node is not in the graph.
+func (g *UndirectedAttrGraph) SetNodeValue(id int, v interface{}) {
+	if g.Node(id) == nil {
+		panic("simple: set value of absent node")
+	}
+	g.attrs.nodes[id] = v
+}
+
+// EdgeAttr returns the value of the attribute key for the edge between u and v
+// and whether the attribute is set.
+func (g *UndirectedAttrGraph) EdgeAttr(u, v graph.Node, key string) (value interface{}, ok bool) {
+	value, ok = g.attrs.edges[undirectedKey(u, v)][key]
+	return value, ok
+}
+
+// SetEdgeAttr sets the value of the attribute key for the edge between u and v.
+// SetEdgeAttr will panic if the edge is not in the graph.
+func (g *UndirectedAttrGraph) SetEdgeAttr(u, v graph.Node, key string, value interface{}) {
+	if !g.HasEdgeBetween(u, v) {
+		panic("simple: set attribute of absent edge")
+	}
+	g.attrs.set(undirectedKey(u, v), key, value)
+}
+
+// EdgeAttrs returns a copy of the attributes of the edge between u and v, or
+// nil if the edge has no attributes.
+func (g *UndirectedAttrGraph) EdgeAttrs(u, v graph.Node) map[string]interface{} {
+	return g.attrs.copyOf(undirectedKey(u, v))
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it and
+// their attributes. If the node is not in the graph it is a no-op.
+func (g *UndirectedAttrGraph) RemoveNode(n graph.Node) {
+	g.forgetNode(n)
+	g.UndirectedGraph.RemoveNode(n)
+}
+
+// RemoveEdge removes e and its attributes from the graph, leaving the terminal
+// nodes. If the edge does not exist it is a no-op.
+func (g *UndirectedAttrGraph) RemoveEdge(e graph.Edge) {
+	delete(g.attrs.edges, undirectedKey(e.From(), e.To()))
+	g.UndirectedGraph.RemoveEdge(e)
+}
+
+// ClearEdges removes all the edges from g and their attributes, leaving its
+// nodes and their values.
+func (g *UndirectedAttrGraph) ClearEdges() {
+	g.attrs.edges = make(map[edgeKey]map[string]interface{})
+	g.UndirectedGraph.ClearEdges()
+}
+
+// Contract contracts the edge between u and v, merging v into u, and returns
+// the merged node as described by UndirectedGraph.Contract. The value of v and
+// the attributes of the edges of v are discarded.
+func (g *UndirectedAttrGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	if u.ID() != v.ID() {
+		g.forgetNode(v)
+	}
+	return g.UndirectedGraph.Contract(u, v, resolve)
+}
+
+// forgetNode deletes the value of n and the attributes of its edges.
+func (g *UndirectedAttrGraph) forgetNode(n graph.Node) {
+	delete(g.attrs.nodes, n.ID())
+	for _, v := range g.From(n) {
+		delete(g.attrs.edges, undirectedKey(n, v))
+	}
+}
+
+// undirectedKey returns the key for the edge between u and v
+// with the lower ID first.
+func undirectedKey(u, v graph.Node) edgeKey {
+	uid, vid := u.ID(), v.ID()
+	if vid < uid {
+		uid, vid = vid, uid
+	}
+	return edgeKey{from: uid, to: vid}
+}
+
+// attrStore holds node values and edge attributes.
+type attrStore struct {
+	nodes map[int]interface{}
+	edges map[edgeKey]map[string]interface{}
+}
+
+func newAttrStore() attrStore {
+	return attrStore{
+		nodes: make(map[int]interface{}),
+		edges: make(map[edgeKey]map[string]interface{}),
+	}
+}
+
+// set sets the value of the attribute key for the edge with key k.
+func (s attrStore) set(k edgeKey, key string, value interface{}) {
+	attrs, ok := s.edges[k]
+	if !ok {
+		attrs = make(map[string]interface{})
+		s.edges[k] = attrs
+	}
+	attrs[key] = value
+}
+
+// copyOf returns a copy of the attributes of the edge with key k, or nil
+// if the edge has no attributes.
+func (s attrStore) copyOf(k edgeKey) map[string]interface{} {
+	attrs := s.edges[k]
+	if len(attrs) == 0 {
+		return nil
+	}
+	c := make(map[string]interface{}, len(attrs))
+	for key, value := range attrs {
+		c[key] = value
+	}
+	return c
+}
@@ -0,0 +1,231 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var (
+	_ graph.DirectedBuilder   = (*DirectedAttrGraph)(nil)
+	_ graph.NodeRemover       = (*DirectedAttrGraph)(nil)
+	_ graph.EdgeRemover       = (*DirectedAttrGraph)(nil)
+	_ graph.Weighter          = (*DirectedAttrGraph)(nil)
+	_ graph.NodeValuer        = (*DirectedAttrGraph)(nil)
+	_ graph.NodeValueSetter   = (*DirectedAttrGraph)(nil)
+	_ graph.EdgeAttrser       = (*DirectedAttrGraph)(nil)
+	_ graph.EdgeAttrSetter    = (*DirectedAttrGraph)(nil)
+	_ graph.UndirectedBuilder = (*UndirectedAttrGraph)(nil)
+	_ graph.NodeRemover       = (*UndirectedAttrGraph)(nil)
+	_ graph.EdgeRemover       = (*UndirectedAttrGraph)(nil)
+	_ graph.Weighter          = (*UndirectedAttrGraph)(nil)
+	_ graph.NodeValuer        = (*UndirectedAttrGraph)(nil)
+	_ graph.NodeValueSetter   = (*UndirectedAttrGraph)(nil)
+	_ graph.EdgeAttrser       = (*UndirectedAttrGraph)(nil)
+	_ graph.EdgeAttrSetter    = (*UndirectedAttrGraph)(nil)
+)
+
+type attrGraph interface {
+	graph.Graph
+	graph.Builder
+	graph.NodeRemover
+	graph.EdgeRemover
+	graph.NodeValuer
+	graph.NodeValueSetter
+	graph.EdgeAttrSetter
+	EdgeAttr(u, v graph.Node, key string) (interface{}, bool)
+	Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node
+}
+
+func TestAttrGraph(t *testing.T) {
+	for _, test := range []struct {
+		g        attrGraph
+		directed bool
+	}{
+		{g: NewDirectedAttrGraph(0, math.Inf(1)), directed: true},
+		{g: NewUndirectedAttrGraph(0, math.Inf(1)), directed: false},
+	} {
+		g := test.g
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.SetEdge(Edge{F: Node(1), T: Node(2), W: 1})
+		g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+
+		g.SetNodeValue(1, "one")
+		if v, ok := g.NodeValue(1); v != "one" || !ok {
+			t.Errorf("unexpected node value in %T: got:(%v, %t) want:(one, true)", g, v, ok)
+		}
+		if v, ok := g.NodeValue(0); v != nil || ok {
+			t.Errorf("unexpected node value for unset node in %T: got:(%v, %t)", g, v, ok)
+		}
+
+		g.SetEdgeAttr(Node(0), Node(1), "color", "red")
+		g.SetEdgeAttr(Node(0), Node(1), "color", "blue")
+		g.SetEdgeAttr(Node(1), Node(2), "capacity", 5)
+		if v, ok := g.EdgeAttr(Node(0), Node(1), "color"); v != "blue" || !ok {
+			t.Errorf("unexpected edge attribute in %T: got:(%v, %t) want:(blue, true)", g, v, ok)
+		}
+		if v, ok := g.EdgeAttr(Node(0), Node(1), "capacity"); v != nil || ok {
+			t.Errorf("unexpected unset edge attribute in %T: got:(%v, %t)", g, v, ok)
+		}
+		_, ok := g.EdgeAttr(Node(1), Node(0), "color")
+		if ok == test.directed {
+			t.Errorf("unexpected reverse edge attribute existence in %T: got:%t want:%t", g, ok, !test.directed)
+		}
+
+		// Removing an edge removes its attributes, even
+		// when the edge is later restored.
+		g.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		if _, ok := g.EdgeAttr(Node(0), Node(1), "color"); ok {
+			t.Errorf("edge attribute retained after edge removal in %T", g)
+		}
+
+		// Removing a node removes its value and the
+		// attributes of its edges.
+		g.RemoveNode(Node(1))
+		g.AddNode(Node(1))
+		g.SetEdge(Edge{F: Node(1), T: Node(2), W: 1})
+		if _, ok := g.NodeValue(1); ok {
+			t.Errorf("node value retained after node removal in %T", g)
+		}
+		if _, ok := g.EdgeAttr(Node(1), Node(2), "capacity"); ok {
+			t.Errorf("edge attribute retained after node removal in %T", g)
+		}
+
+		// Contraction discards the value of the
+		// merged node and its edge attributes.
+		g.SetNodeValue(3, "three")
+		g.SetEdgeAttr(Node(2), Node(3), "color", "green")
+		g.Contract(Node(2), Node(3), nil)
+		g.AddNode(Node(3))
+		g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+		if _, ok := g.NodeValue(3); ok {
+			t.Errorf("node value retained after contraction in %T", g)
+		}
+		if _, ok := g.EdgeAttr(Node(2), Node(3), "color"); ok {
+			t.Errorf("edge attribute retained after contraction in %T", g)
+		}
+
+		for _, fn := range []func(){
+			func() { g.SetNodeValue(10, "absent") },
+			func() { g.SetEdgeAttr(Node(0), Node(3), "color", "absent") },
+		} {
+			panicked := func() (panicked bool) {
+				defer func() {
+					panicked = recover() != nil
+				}()
+				fn()
+				return
+			}()
+			if !panicked {
+				t.Errorf("expected panic setting attribute of absent element in %T", g)
+			}
+		}
+	}
+}
+
+func TestEdgeAttrs(t *testing.T) {
+	g := NewUndirectedAttrGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	if attrs := g.EdgeAttrs(Node(0), Node(1)); attrs != nil {
+		t.Errorf("unexpected attributes for edge without attributes: %v", attrs)
+	}
+	g.SetEdgeAttr(Node(0), Node(1), "color", "red")
+	g.SetEdgeAttr(Node(1), Node(0), "width", 2)
+	attrs := g.EdgeAttrs(Node(1), Node(0))
+	want := map[string]interface{}{"color": "red", "width": 2}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("unexpected attributes: got:%v want:%v", attrs, want)
+	}
+	attrs["color"] = "blue"
+	if v, _ := g.EdgeAttr(Node(0), Node(1), "color"); v != "red" {
+		t.Error("returned attributes are not a copy")
+	}
+}
+
+func TestAttrGraphSetEdgeWeight(t *testing.T) {
+	for _, g := range []interface {
+		attrGraph
+		graph.Weighter
+		SetEdgeWeight(e graph.Edge, w float64)
+	}{
+		NewDirectedAttrGraph(0, math.Inf(1)),
+		NewUndirectedAttrGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.SetEdgeAttr(Node(0), Node(1), "label", "road")
+
+		g.SetEdgeWeight(Edge{F: Node(0), T: Node(1)}, 3)
+		if w, ok := g.Weight(Node(0), Node(1)); w != 3 || !ok {
+			t.Errorf("unexpected weight after SetEdgeWeight in %T: got:(%v, %t) want:(3, true)", g, w, ok)
+		}
+		if v, ok := g.EdgeAttr(Node(0), Node(1), "label"); v != "road" || !ok {
+			t.Errorf("edge attribute lost after SetEdgeWeight in %T: got:(%v, %t) want:(road, true)", g, v, ok)
+		}
+
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 5})
+		if v, ok := g.EdgeAttr(Node(0), Node(1), "label"); v != "road" || !ok {
+			t.Errorf("edge attribute lost after SetEdge in %T: got:(%v, %t) want:(road, true)", g, v, ok)
+		}
+	}
+}
+
+func TestAttrGraphClearEdges(t *testing.T) {
+	for _, g := range []interface {
+		attrGraph
+		ClearEdges()
+	}{
+		NewDirectedAttrGraph(0, math.Inf(1)),
+		NewUndirectedAttrGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.SetNodeValue(0, "zero")
+		g.SetEdgeAttr(Node(0), Node(1), "label", "road")
+
+		g.ClearEdges()
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		if _, ok := g.EdgeAttr(Node(0), Node(1), "label"); ok {
+			t.Errorf("edge attribute retained after ClearEdges in %T", g)
+		}
+		if v, ok := g.NodeValue(0); v != "zero" || !ok {
+			t.Errorf("node value lost after ClearEdges in %T: got:(%v, %t)", g, v, ok)
+		}
+	}
+}
+
+func TestAttrGraphKeepReachableFrom(t *testing.T) {
+	g := NewDirectedAttrGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+	g.SetNodeValue(0, "root")
+	g.SetNodeValue(2, "stale")
+	g.SetEdgeAttr(Node(0), Node(1), "color", "blue")
+	g.SetEdgeAttr(Node(2), Node(3), "color", "red")
+
+	g.KeepReachableFrom([]graph.Node{Node(0)})
+	if g.Has(Node(2)) || g.Has(Node(3)) {
+		t.Fatal("unreachable nodes retained")
+	}
+	if v, ok := g.NodeValue(0); v != "root" || !ok {
+		t.Errorf("value of reachable node lost: got:(%v, %t)", v, ok)
+	}
+	if v, ok := g.EdgeAttr(Node(0), Node(1), "color"); v != "blue" || !ok {
+		t.Errorf("attribute of reachable edge lost: got:(%v, %t)", v, ok)
+	}
+
+	// Reusing the IDs of the removed nodes
+	// must not resurrect their attributes.
+	g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+	if v, ok := g.NodeValue(2); ok {
+		t.Errorf("value of removed node retained: got:%v", v)
+	}
+	if v, ok := g.EdgeAttr(Node(2), Node(3), "color"); ok {
+		t.Errorf("attribute of removed edge retained: got:%v", v)
+	}
+}
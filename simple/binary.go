@@ -0,0 +1,179 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// binaryMagic identifies the binary graph format written by WriteBinary.
+// The final byte is the format version.
+var binaryMagic = [4]byte{'g', 'r', 'p', 1}
+
+// binaryDirected is the flag marking a directed graph in the binary format.
+const binaryDirected = 1 << 0
+
+// WriteBinary writes g to w in a compact binary format that can be read by
+// ReadBinary. The format holds whether g is directed, the ID of each node of g
+// and the terminal node IDs and weight of each edge of g, so weights including
+// NaN and infinite values are preserved exactly. Edges of undirected graphs are
+// written once.
+//
+// The format is a four byte header followed by a flags byte, the number of
+// nodes as a uvarint and the node IDs as varints, then the number of edges as
+// a uvarint and for each edge the from and to node IDs as varints followed by
+// the IEEE 754 bits of its weight as a little-endian uint64. Nodes and edges
+// are written in ID order, so equal graphs are written identically.
+//
+// WriteBinary returns an error without writing to w if g has a self edge,
+// since the format cannot be read back by ReadBinary.
+func WriteBinary(w io.Writer, g graph.Graph) error {
+	_, directed := g.(graph.Directed)
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	var edges []graph.Edge
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if v.ID() == u.ID() {
+				return fmt.Errorf("simple: self edge on node %d", u.ID())
+			}
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			edges = append(edges, g.Edge(u, v))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(x uint64) {
+		bw.Write(buf[:binary.PutUvarint(buf[:], x)])
+	}
+	putVarint := func(x int64) {
+		bw.Write(buf[:binary.PutVarint(buf[:], x)])
+	}
+
+	bw.Write(binaryMagic[:])
+	var flags byte
+	if directed {
+		flags |= binaryDirected
+	}
+	bw.WriteByte(flags)
+	putUvarint(uint64(len(nodes)))
+	for _, n := range nodes {
+		putVarint(int64(n.ID()))
+	}
+	putUvarint(uint64(len(edges)))
+	for _, e := range edges {
+		putVarint(int64(e.From().ID()))
+		putVarint(int64(e.To().ID()))
+		binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(e.Weight()))
+		bw.Write(buf[:8])
+	}
+	return bw.Flush()
+}
+
+// ReadBinary reads a graph written by WriteBinary from r into dst. Nodes are
+// added to dst as Node values unless dst is a graph.Graph already holding a
+// node with the same ID, and edges are set as Edge values.
+//
+// ReadBinary returns an error if the input is not valid, if it holds a self
+// edge, or if the graph held in the input differs in directedness from dst.
+func ReadBinary(r io.Reader, dst graph.Builder) error {
+	br := bufio.NewReader(r)
+
+	var magic [4]byte
+	_, err := io.ReadFull(br, magic[:])
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if magic != binaryMagic {
+		return errors.New("simple: invalid binary graph header")
+	}
+	flags, err := br.ReadByte()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if flags&^binaryDirected != 0 {
+		return fmt.Errorf("simple: invalid binary graph flags: %#x", flags)
+	}
+	directed := flags&binaryDirected != 0
+	if _, ok := dst.(graph.Directed); ok != directed {
+		return errors.New("simple: binary graph directedness does not match destination")
+	}
+
+	id := func() (int, error) {
+		x, err := binary.ReadVarint(br)
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		if int64(int(x)) != x {
+			return 0, fmt.Errorf("simple: node ID out of range: %d", x)
+		}
+		return int(x), nil
+	}
+
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	has, _ := dst.(graph.Graph)
+	for i := uint64(0); i < n; i++ {
+		id, err := id()
+		if err != nil {
+			return err
+		}
+		if has != nil && has.Has(Node(id)) {
+			continue
+		}
+		dst.AddNode(Node(id))
+	}
+
+	m, err := binary.ReadUvarint(br)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	var buf [8]byte
+	for i := uint64(0); i < m; i++ {
+		fid, err := id()
+		if err != nil {
+			return err
+		}
+		tid, err := id()
+		if err != nil {
+			return err
+		}
+		if fid == tid {
+			return fmt.Errorf("simple: self edge on node %d", fid)
+		}
+		_, err = io.ReadFull(br, buf[:])
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		w := math.Float64frombits(binary.LittleEndian.Uint64(buf[:]))
+		dst.SetEdge(Edge{F: Node(fid), T: Node(tid), W: w})
+	}
+	return nil
+}
+
+// unexpectedEOF returns io.ErrUnexpectedEOF if err is io.EOF and err otherwise.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
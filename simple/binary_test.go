@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	weights := []float64{0, 1, -1, 0.1, math.Inf(1), math.Inf(-1), math.NaN(), math.Copysign(0, -1), math.MaxFloat64}
+	for i := 0; i < 200; i++ {
+		directed := i%2 == 0
+		var src, dst binaryGraph
+		if directed {
+			src = NewDirectedGraph(0, math.Inf(1))
+			dst = NewDirectedGraph(0, math.Inf(1))
+		} else {
+			src = NewUndirectedGraph(0, math.Inf(1))
+			dst = NewUndirectedGraph(0, math.Inf(1))
+		}
+
+		// Sparse IDs, including negative and large
+		// IDs, and isolated nodes.
+		var ids []int
+		for n := rnd.Intn(20); len(ids) < n; {
+			id := rnd.Intn(1000) - 500
+			if rnd.Intn(10) == 0 {
+				id *= 1 << 20
+			}
+			if src.Has(Node(id)) {
+				continue
+			}
+			src.AddNode(Node(id))
+			ids = append(ids, id)
+		}
+		for range ids {
+			if len(ids) < 2 {
+				break
+			}
+			u, v := ids[rnd.Intn(len(ids))], ids[rnd.Intn(len(ids))]
+			if u == v {
+				continue
+			}
+			w := weights[rnd.Intn(len(weights))]
+			if rnd.Intn(2) == 0 {
+				w = rnd.NormFloat64()
+			}
+			src.SetEdge(Edge{F: Node(u), T: Node(v), W: w})
+		}
+
+		var buf bytes.Buffer
+		err := WriteBinary(&buf, src)
+		if err != nil {
+			t.Fatalf("unexpected error writing graph %d: %v", i, err)
+		}
+		b := buf.Bytes()
+		err = ReadBinary(bytes.NewReader(b), dst)
+		if err != nil {
+			t.Fatalf("unexpected error reading graph %d: %v", i, err)
+		}
+		checkSameGraph(t, i, src, dst)
+
+		// The encoding is deterministic.
+		var again bytes.Buffer
+		WriteBinary(&again, dst)
+		if !bytes.Equal(b, again.Bytes()) {
+			t.Errorf("encoding of round-tripped graph %d differs", i)
+		}
+
+		// Every truncation of the encoding is an error.
+		for n := 0; n < len(b); n++ {
+			var dst graph.Builder = NewDirectedGraph(0, math.Inf(1))
+			if !directed {
+				dst = NewUndirectedGraph(0, math.Inf(1))
+			}
+			if err := ReadBinary(bytes.NewReader(b[:n]), dst); err != io.ErrUnexpectedEOF {
+				t.Errorf("unexpected error for graph %d truncated to %d bytes: got:%v want:%v", i, n, err, io.ErrUnexpectedEOF)
+			}
+		}
+	}
+}
+
+func TestBinaryDense(t *testing.T) {
+	src := NewUndirectedMatrix(4, 0, 0, math.Inf(1))
+	src.SetEdge(Edge{F: Node(0), T: Node(3), W: 2})
+	src.SetEdge(Edge{F: Node(1), T: Node(2), W: -1})
+	src.RemoveNode(Node(2))
+
+	var buf bytes.Buffer
+	err := WriteBinary(&buf, src)
+	if err != nil {
+		t.Fatalf("unexpected error writing graph: %v", err)
+	}
+	dst := NewUndirectedMatrix(2, 0, 0, math.Inf(1))
+	err = ReadBinary(&buf, dst)
+	if err != nil {
+		t.Fatalf("unexpected error reading graph: %v", err)
+	}
+	checkSameGraph(t, 0, src, dst)
+}
+
+func TestBinaryErrors(t *testing.T) {
+	var buf bytes.Buffer
+	WriteBinary(&buf, NewDirectedGraph(0, math.Inf(1)))
+	b := buf.Bytes()
+
+	if err := ReadBinary(bytes.NewReader(b), NewUndirectedGraph(0, math.Inf(1))); err == nil {
+		t.Error("expected error reading directed graph into undirected graph")
+	}
+	bad := append([]byte(nil), b...)
+	bad[0] = 'x'
+	if err := ReadBinary(bytes.NewReader(bad), NewDirectedGraph(0, math.Inf(1))); err == nil {
+		t.Error("expected error for invalid header")
+	}
+	bad = append([]byte(nil), b...)
+	bad[4] = 0x80
+	if err := ReadBinary(bytes.NewReader(bad), NewDirectedGraph(0, math.Inf(1))); err == nil {
+		t.Error("expected error for invalid flags")
+	}
+
+	// A graph holding node 3 and an edge from 3 to 3.
+	selfEdge := append([]byte(nil), b[:5]...)
+	var v [binary.MaxVarintLen64]byte
+	uvarint := func(x uint64) { selfEdge = append(selfEdge, v[:binary.PutUvarint(v[:], x)]...) }
+	varint := func(x int64) { selfEdge = append(selfEdge, v[:binary.PutVarint(v[:], x)]...) }
+	uvarint(1)
+	varint(3)
+	uvarint(1)
+	varint(3)
+	varint(3)
+	var w [8]byte
+	binary.LittleEndian.PutUint64(w[:], math.Float64bits(1))
+	selfEdge = append(selfEdge, w[:]...)
+	err := ReadBinary(bytes.NewReader(selfEdge), NewDirectedGraph(0, math.Inf(1)))
+	if err == nil || err.Error() != "simple: self edge on node 3" {
+		t.Errorf("unexpected error for self edge: got:%v want:simple: self edge on node 3", err)
+	}
+
+	// Self edges are not written, since they
+	// cannot be read back.
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.AddNode(Node(3))
+	buf.Reset()
+	err = WriteBinary(&buf, withSelfEdges{g})
+	if err == nil || err.Error() != "simple: self edge on node 3" {
+		t.Errorf("unexpected error writing self edge: got:%v want:simple: self edge on node 3", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected output writing self edge: got:%d bytes", buf.Len())
+	}
+}
+
+type binaryGraph interface {
+	graph.Graph
+	graph.Builder
+}
+
+// checkSameGraph checks that got has the same nodes and edges as want.
+func checkSameGraph(t *testing.T, i int, want, got graph.Graph) {
+	if w, g := sortedIDs(want.Nodes()), sortedIDs(got.Nodes()); !reflect.DeepEqual(w, g) {
+		t.Errorf("unexpected nodes for graph %d: got:%v want:%v", i, g, w)
+	}
+	for _, u := range want.Nodes() {
+		if w, g := sortedIDs(want.From(u)), sortedIDs(got.From(u)); !reflect.DeepEqual(w, g) {
+			t.Errorf("unexpected neighbors of %d for graph %d: got:%v want:%v", u.ID(), i, g, w)
+			continue
+		}
+		for _, v := range want.From(u) {
+			w := want.Edge(u, v).Weight()
+			g := got.Edge(u, v).Weight()
+			if !isSame(w, g) || math.Signbit(w) != math.Signbit(g) {
+				t.Errorf("unexpected weight for %d-%d for graph %d: got:%v want:%v", u.ID(), v.ID(), i, g, w)
+			}
+		}
+	}
+}
+
+func sortedIDs(nodes []graph.Node) []int {
+	ids := nodeIDs(nodes)
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,313 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// DirectedBitMatrix represents an unweighted directed graph using an
+// adjacency bit matrix such that all IDs are in a contiguous block from
+// 0 to n-1. Edges have unit weight and are stored as a single bit, so
+// edges stored in the graph are not recoverable.
+type DirectedBitMatrix struct {
+	n     int
+	words int
+	bits  []uint64
+}
+
+// NewDirectedBitMatrix creates a directed bit matrix graph with n nodes
+// and no edges.
+func NewDirectedBitMatrix(n int) *DirectedBitMatrix {
+	words := (n + wordBits - 1) / wordBits
+	return &DirectedBitMatrix{
+		n:     n,
+		words: words,
+		bits:  make([]uint64, n*words),
+	}
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *DirectedBitMatrix) Node(id int) graph.Node {
+	if !g.has(id) {
+		return nil
+	}
+	return Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *DirectedBitMatrix) Has(n graph.Node) bool {
+	return g.has(n.ID())
+}
+
+func (g *DirectedBitMatrix) has(id int) bool {
+	return 0 <= id && id < g.n
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *DirectedBitMatrix) Nodes() []graph.Node {
+	nodes := make([]graph.Node, g.n)
+	for i := range nodes {
+		nodes[i] = Node(i)
+	}
+	return nodes
+}
+
+// Edges returns all the edges in the graph.
+func (g *DirectedBitMatrix) Edges() []graph.Edge {
+	var edges []graph.Edge
+	for i := 0; i < g.n; i++ {
+		visitBits(g.row(i), func(j int) bool {
+			edges = append(edges, Edge{F: Node(i), T: Node(j), W: 1})
+			return true
+		})
+	}
+	return edges
+}
+
+// Order returns the number of nodes in g.
+func (g *DirectedBitMatrix) Order() int {
+	return g.n
+}
+
+// Size returns the number of edges in g. Edges in opposite directions
+// between a pair of nodes are counted separately.
+func (g *DirectedBitMatrix) Size() int {
+	var n int
+	for _, w := range g.bits {
+		n += onesCount(w)
+	}
+	return n
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+func (g *DirectedBitMatrix) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for i := 0; i < g.n; i++ {
+		ok := true
+		visitBits(g.row(i), func(j int) bool {
+			ok = fn(Edge{F: Node(i), T: Node(j), W: 1}, 1)
+			return ok
+		})
+		if !ok {
+			return
+		}
+	}
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *DirectedBitMatrix) From(n graph.Node) []graph.Node {
+	id := n.ID()
+	if !g.has(id) {
+		return nil
+	}
+	var neighbors []graph.Node
+	visitBits(g.row(id), func(j int) bool {
+		neighbors = append(neighbors, Node(j))
+		return true
+	})
+	return neighbors
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *DirectedBitMatrix) To(n graph.Node) []graph.Node {
+	id := n.ID()
+	if !g.has(id) {
+		return nil
+	}
+	var neighbors []graph.Node
+	for i := 0; i < g.n; i++ {
+		if g.isSet(i, id) {
+			neighbors = append(neighbors, Node(i))
+		}
+	}
+	return neighbors
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *DirectedBitMatrix) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	visitBits(g.row(id), func(j int) bool {
+		return fn(Node(j), 1)
+	})
+}
+
+// VisitTo calls fn with each node that can reach directly to n and the weight
+// of the joining edge until fn returns false.
+func (g *DirectedBitMatrix) VisitTo(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	for i := 0; i < g.n; i++ {
+		if g.isSet(i, id) && !fn(Node(i), 1) {
+			return
+		}
+	}
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *DirectedBitMatrix) HasEdgeBetween(x, y graph.Node) bool {
+	xid := x.ID()
+	if !g.has(xid) {
+		return false
+	}
+	yid := y.ID()
+	if !g.has(yid) {
+		return false
+	}
+	return g.isSet(xid, yid) || g.isSet(yid, xid)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *DirectedBitMatrix) Edge(u, v graph.Node) graph.Edge {
+	if g.HasEdgeFromTo(u, v) {
+		return Edge{F: Node(u.ID()), T: Node(v.ID()), W: 1}
+	}
+	return nil
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *DirectedBitMatrix) HasEdgeFromTo(u, v graph.Node) bool {
+	uid := u.ID()
+	if !g.has(uid) {
+		return false
+	}
+	vid := v.ID()
+	if !g.has(vid) {
+		return false
+	}
+	return g.isSet(uid, vid)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// The weight of an existing edge is 1. If x and y are the same node the weight returned is 0,
+// and if there is no joining edge between the two nodes the weight returned is +Inf. Weight
+// returns true if an edge exists between x and y or if x and y have the same ID, false otherwise.
+func (g *DirectedBitMatrix) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	if g.HasEdgeFromTo(x, y) {
+		return 1, true
+	}
+	return math.Inf(1), false
+}
+
+// SetEdge sets e, an edge from one node to another. The weight of e is ignored. If the
+// ends of the edge are not in g or the edge is a self loop, SetEdge panics.
+func (g *DirectedBitMatrix) SetEdge(e graph.Edge) {
+	fid := e.From().ID()
+	tid := e.To().ID()
+	if fid == tid || !g.has(fid) || !g.has(tid) {
+		panic("simple: set illegal edge")
+	}
+	g.bits[fid*g.words+tid/wordBits] |= 1 << uint(tid%wordBits)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
+// it is a no-op.
+func (g *DirectedBitMatrix) RemoveEdge(e graph.Edge) {
+	fid := e.From().ID()
+	if !g.has(fid) {
+		return
+	}
+	tid := e.To().ID()
+	if !g.has(tid) {
+		return
+	}
+	g.bits[fid*g.words+tid/wordBits] &^= 1 << uint(tid%wordBits)
+}
+
+// Degree returns the in+out degree of n in g, the sum of
+// its in-degree and out-degree.
+func (g *DirectedBitMatrix) Degree(n graph.Node) int {
+	return g.InDegree(n) + g.OutDegree(n)
+}
+
+// InDegree returns the number of edges leading to n in g. Since g holds no
+// self edges, the in-degree of n is the number of nodes returned by To(n).
+func (g *DirectedBitMatrix) InDegree(n graph.Node) int {
+	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
+	var deg int
+	for i := 0; i < g.n; i++ {
+		if g.isSet(i, id) {
+			deg++
+		}
+	}
+	return deg
+}
+
+// OutDegree returns the number of edges leading from n in g. Since g holds no
+// self edges, the out-degree of n is the number of nodes returned by From(n).
+func (g *DirectedBitMatrix) OutDegree(n graph.Node) int {
+	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
+	var deg int
+	for _, w := range g.row(id) {
+		deg += onesCount(w)
+	}
+	return deg
+}
+
+func (g *DirectedBitMatrix) row(i int) []uint64 {
+	return g.bits[i*g.words : (i+1)*g.words]
+}
+
+func (g *DirectedBitMatrix) isSet(i, j int) bool {
+	return g.bits[i*g.words+j/wordBits]&(1<<uint(j%wordBits)) != 0
+}
+
+const wordBits = 64
+
+// visitBits calls fn with the index of each set bit in row in ascending order
+// until fn returns false.
+func visitBits(row []uint64, fn func(int) bool) {
+	for i, w := range row {
+		for w != 0 {
+			if !fn(i*wordBits + trailingZeros(w)) {
+				return
+			}
+			w &= w - 1
+		}
+	}
+}
+
+// onesCount returns the number of set bits in w.
+func onesCount(w uint64) int {
+	var n int
+	for ; w != 0; w &= w - 1 {
+		n++
+	}
+	return n
+}
+
+// deBruijn64 is a de Bruijn sequence used to find the index of the
+// lowest set bit of a word.
+const deBruijn64 = 0x03f79d71b4ca8b09
+
+var deBruijn64Index = [64]byte{
+	0, 1, 56, 2, 57, 49, 28, 3, 61, 58, 42, 50, 38, 29, 17, 4,
+	62, 47, 59, 36, 45, 43, 51, 22, 53, 39, 33, 30, 24, 18, 12, 5,
+	63, 55, 48, 27, 60, 41, 37, 16, 46, 35, 44, 21, 52, 32, 23, 11,
+	54, 26, 40, 15, 34, 20, 31, 10, 25, 14, 19, 9, 13, 8, 7, 6,
+}
+
+// trailingZeros returns the number of trailing zero bits in the non-zero word w.
+func trailingZeros(w uint64) int {
+	return int(deBruijn64Index[(w&-w)*deBruijn64>>58])
+}
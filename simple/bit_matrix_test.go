@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+var (
+	_ graph.Directed   = (*DirectedBitMatrix)(nil)
+	_ graph.Weighter   = (*DirectedBitMatrix)(nil)
+	_ graph.Undirected = (*UndirectedBitMatrix)(nil)
+	_ graph.Weighter   = (*UndirectedBitMatrix)(nil)
+)
+
+func TestTrailingZeros(t *testing.T) {
+	for i := uint(0); i < 64; i++ {
+		w := uint64(1) << i
+		if got := trailingZeros(w); got != int(i) {
+			t.Errorf("unexpected trailing zeros for 1<<%d: got:%d", i, got)
+		}
+		if got := trailingZeros(w | 1<<63); got != int(i) {
+			t.Errorf("unexpected trailing zeros for 1<<%d|1<<63: got:%d", i, got)
+		}
+	}
+}
+
+func TestBitMatrix(t *testing.T) {
+	const n = 150
+	rnd := rand.New(rand.NewSource(1))
+
+	dm := NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	db := NewDirectedBitMatrix(n)
+	um := NewUndirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	ub := NewUndirectedBitMatrix(n)
+	for i := 0; i < 2000; i++ {
+		u, v := Node(rnd.Intn(n)), Node(rnd.Intn(n))
+		if u == v {
+			continue
+		}
+		e := Edge{F: u, T: v, W: 1}
+		if rnd.Float64() < 0.1 {
+			for _, g := range []graph.EdgeRemover{dm, db, um, ub} {
+				g.RemoveEdge(e)
+			}
+			continue
+		}
+		for _, g := range []graph.EdgeSetter{dm, db, um, ub} {
+			g.SetEdge(e)
+		}
+	}
+
+	for _, pair := range []struct {
+		want, got graph.Graph
+	}{
+		{want: dm, got: db},
+		{want: um, got: ub},
+	} {
+		if len(pair.got.Nodes()) != n {
+			t.Errorf("unexpected number of nodes in %T: got:%d want:%d", pair.got, len(pair.got.Nodes()), n)
+		}
+		if got, want := len(pair.got.(edgeLister).Edges()), len(pair.want.(edgeLister).Edges()); got != want {
+			t.Errorf("unexpected number of edges in %T: got:%d want:%d", pair.got, got, want)
+		}
+		for _, u := range pair.want.Nodes() {
+			if !sameNodes(pair.got.From(u), pair.want.From(u)) {
+				t.Errorf("unexpected from nodes of %d in %T", u.ID(), pair.got)
+			}
+			if d, ok := pair.want.(graph.Directed); ok {
+				if !sameNodes(pair.got.(graph.Directed).To(u), d.To(u)) {
+					t.Errorf("unexpected to nodes of %d in %T", u.ID(), pair.got)
+				}
+			}
+			if got, want := pair.got.(degreer).Degree(u), pair.want.(degreer).Degree(u); got != want {
+				t.Errorf("unexpected degree of %d in %T: got:%d want:%d", u.ID(), pair.got, got, want)
+			}
+			for _, v := range pair.want.Nodes() {
+				gw, gok := pair.got.(graph.Weighter).Weight(u, v)
+				ww, wok := pair.want.(graph.Weighter).Weight(u, v)
+				if gw != ww || gok != wok {
+					t.Errorf("unexpected weight from %d to %d in %T: got:(%v, %t) want:(%v, %t)",
+						u.ID(), v.ID(), pair.got, gw, gok, ww, wok)
+				}
+				if pair.got.HasEdgeBetween(u, v) != pair.want.HasEdgeBetween(u, v) {
+					t.Errorf("unexpected edge existence between %d and %d in %T", u.ID(), v.ID(), pair.got)
+				}
+			}
+		}
+	}
+}
+
+type edgeLister interface {
+	Edges() []graph.Edge
+}
+
+type degreer interface {
+	Degree(graph.Node) int
+}
+
+func sameNodes(a, b []graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Sort(ordered.ByID(a))
+	sort.Sort(ordered.ByID(b))
+	for i := range a {
+		if a[i].ID() != b[i].ID() {
+			return false
+		}
+	}
+	return true
+}
+
+func randomDenseGraph(g interface {
+	graph.Graph
+	graph.EdgeSetter
+}, p float64) graph.Graph {
+	rnd := rand.New(rand.NewSource(1))
+	nodes := g.Nodes()
+	for _, u := range nodes {
+		for _, v := range nodes {
+			if u.ID() != v.ID() && rnd.Float64() < p {
+				g.SetEdge(Edge{F: u, T: v, W: 1})
+			}
+		}
+	}
+	return g
+}
+
+func BenchmarkNewDirectedMatrix(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewDirectedMatrix(1000, math.Inf(1), 0, math.Inf(1))
+	}
+}
+
+func BenchmarkNewDirectedBitMatrix(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewDirectedBitMatrix(1000)
+	}
+}
+
+func BenchmarkFromDirectedMatrix(b *testing.B) {
+	benchmarkFrom(b, randomDenseGraph(NewDirectedMatrix(1000, math.Inf(1), 0, math.Inf(1)), 0.01))
+}
+
+func BenchmarkFromDirectedBitMatrix(b *testing.B) {
+	benchmarkFrom(b, randomDenseGraph(NewDirectedBitMatrix(1000), 0.01))
+}
+
+func benchmarkFrom(b *testing.B, g graph.Graph) {
+	nodes := g.Nodes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, u := range nodes {
+			g.From(u)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// UndirectedBitMatrix represents an unweighted undirected graph using an
+// adjacency bit matrix such that all IDs are in a contiguous block from
+// 0 to n-1. Edges have unit weight and are stored as a single bit, so
+// edges stored in the graph are not recoverable.
+type UndirectedBitMatrix struct {
+	n     int
+	words int
+	bits  []uint64
+}
+
+// NewUndirectedBitMatrix creates an undirected bit matrix graph with n nodes
+// and no edges.
+func NewUndirectedBitMatrix(n int) *UndirectedBitMatrix {
+	words := (n + wordBits - 1) / wordBits
+	return &UndirectedBitMatrix{
+		n:     n,
+		words: words,
+		bits:  make([]uint64, n*words),
+	}
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *UndirectedBitMatrix) Node(id int) graph.Node {
+	if !g.has(id) {
+		return nil
+	}
+	return Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *UndirectedBitMatrix) Has(n graph.Node) bool {
+	return g.has(n.ID())
+}
+
+func (g *UndirectedBitMatrix) has(id int) bool {
+	return 0 <= id && id < g.n
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *UndirectedBitMatrix) Nodes() []graph.Node {
+	nodes := make([]graph.Node, g.n)
+	for i := range nodes {
+		nodes[i] = Node(i)
+	}
+	return nodes
+}
+
+// Edges returns all the edges in the graph.
+func (g *UndirectedBitMatrix) Edges() []graph.Edge {
+	var edges []graph.Edge
+	for i := 0; i < g.n; i++ {
+		visitBits(g.row(i), func(j int) bool {
+			if i < j {
+				edges = append(edges, Edge{F: Node(i), T: Node(j), W: 1})
+			}
+			return true
+		})
+	}
+	return edges
+}
+
+// Order returns the number of nodes in g.
+func (g *UndirectedBitMatrix) Order() int {
+	return g.n
+}
+
+// Size returns the number of edges in g.
+func (g *UndirectedBitMatrix) Size() int {
+	var n int
+	for _, w := range g.bits {
+		n += onesCount(w)
+	}
+	return n / 2
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+// Each edge is visited once.
+func (g *UndirectedBitMatrix) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for i := 0; i < g.n; i++ {
+		ok := true
+		visitBits(g.row(i), func(j int) bool {
+			if i < j {
+				ok = fn(Edge{F: Node(i), T: Node(j), W: 1}, 1)
+			}
+			return ok
+		})
+		if !ok {
+			return
+		}
+	}
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *UndirectedBitMatrix) From(n graph.Node) []graph.Node {
+	id := n.ID()
+	if !g.has(id) {
+		return nil
+	}
+	var neighbors []graph.Node
+	visitBits(g.row(id), func(j int) bool {
+		neighbors = append(neighbors, Node(j))
+		return true
+	})
+	return neighbors
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *UndirectedBitMatrix) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	visitBits(g.row(id), func(j int) bool {
+		return fn(Node(j), 1)
+	})
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *UndirectedBitMatrix) HasEdgeBetween(u, v graph.Node) bool {
+	uid := u.ID()
+	if !g.has(uid) {
+		return false
+	}
+	vid := v.ID()
+	if !g.has(vid) {
+		return false
+	}
+	return g.isSet(uid, vid)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *UndirectedBitMatrix) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g *UndirectedBitMatrix) EdgeBetween(u, v graph.Node) graph.Edge {
+	if g.HasEdgeBetween(u, v) {
+		return Edge{F: Node(u.ID()), T: Node(v.ID()), W: 1}
+	}
+	return nil
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// The weight of an existing edge is 1. If x and y are the same node the weight returned is 0,
+// and if there is no joining edge between the two nodes the weight returned is +Inf. Weight
+// returns true if an edge exists between x and y or if x and y have the same ID, false otherwise.
+func (g *UndirectedBitMatrix) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	if g.HasEdgeBetween(x, y) {
+		return 1, true
+	}
+	return math.Inf(1), false
+}
+
+// SetEdge sets e, an edge from one node to another. The weight of e is ignored. If the
+// ends of the edge are not in g or the edge is a self loop, SetEdge panics.
+func (g *UndirectedBitMatrix) SetEdge(e graph.Edge) {
+	fid := e.From().ID()
+	tid := e.To().ID()
+	if fid == tid || !g.has(fid) || !g.has(tid) {
+		panic("simple: set illegal edge")
+	}
+	g.bits[fid*g.words+tid/wordBits] |= 1 << uint(tid%wordBits)
+	g.bits[tid*g.words+fid/wordBits] |= 1 << uint(fid%wordBits)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
+// it is a no-op.
+func (g *UndirectedBitMatrix) RemoveEdge(e graph.Edge) {
+	fid := e.From().ID()
+	if !g.has(fid) {
+		return
+	}
+	tid := e.To().ID()
+	if !g.has(tid) {
+		return
+	}
+	g.bits[fid*g.words+tid/wordBits] &^= 1 << uint(tid%wordBits)
+	g.bits[tid*g.words+fid/wordBits] &^= 1 << uint(fid%wordBits)
+}
+
+// Degree returns the degree of n in g.
+func (g *UndirectedBitMatrix) Degree(n graph.Node) int {
+	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
+	var deg int
+	visitBits(g.row(id), func(int) bool { deg++; return true })
+	return deg
+}
+
+func (g *UndirectedBitMatrix) row(i int) []uint64 {
+	return g.bits[i*g.words : (i+1)*g.words]
+}
+
+func (g *UndirectedBitMatrix) isSet(i, j int) bool {
+	return g.bits[i*g.words+j/wordBits]&(1<<uint(j%wordBits)) != 0
+}
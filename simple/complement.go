@@ -0,0 +1,31 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// Complement returns the complement of the undirected graph g. The returned
+// graph holds the nodes of g and has a unit weight edge between each pair of
+// distinct nodes that are not adjacent in g. The returned graph has a self
+// weight of zero and an absent weight of +Inf.
+func Complement(g graph.Undirected) *UndirectedGraph {
+	c := NewUndirectedGraph(0, math.Inf(1))
+	nodes := g.Nodes()
+	for _, n := range nodes {
+		c.AddNode(n)
+	}
+	for i, u := range nodes {
+		for _, v := range nodes[i+1:] {
+			if !g.HasEdgeBetween(u, v) {
+				c.SetEdge(Edge{F: u, T: v, W: 1})
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComplement(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	// A path on four nodes with an isolated node.
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 2})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(2), T: Node(3), W: 2})
+	g.AddNode(Node(4))
+
+	c := Complement(g)
+	if len(c.Nodes()) != len(g.Nodes()) {
+		t.Fatalf("unexpected number of nodes: got:%d want:%d", len(c.Nodes()), len(g.Nodes()))
+	}
+	nodes := g.Nodes()
+	for _, u := range nodes {
+		if c.HasEdgeBetween(u, u) {
+			t.Errorf("unexpected self edge for %d", u.ID())
+		}
+		for _, v := range nodes {
+			if u.ID() == v.ID() {
+				continue
+			}
+			if c.HasEdgeBetween(u, v) == g.HasEdgeBetween(u, v) {
+				t.Errorf("unexpected edge state between %d and %d: got:%t want:%t",
+					u.ID(), v.ID(), c.HasEdgeBetween(u, v), !g.HasEdgeBetween(u, v))
+			}
+			if e := c.Edge(u, v); e != nil && e.Weight() != 1 {
+				t.Errorf("unexpected edge weight between %d and %d: got:%f want:1", u.ID(), v.ID(), e.Weight())
+			}
+		}
+	}
+	if got, want := len(c.Edges()), 5*4/2-3; got != want {
+		t.Errorf("unexpected number of edges: got:%d want:%d", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// Contract contracts the edge between u and v, merging v into u, and returns
+// the merged node. The edges of v are moved to u, and the edge between u and v
+// is dropped rather than becoming a self edge. If u and v share a neighbor, the
+// weight of the merged edge is given by resolve called with the weights of the
+// edges of u and v respectively, or the minimum of the weights if resolve is nil,
+// and the edge of u is rewritten as described for SetEdgeWeight. Edges moved
+// from v are replaced by Edge values. Contract does not require an edge between
+// u and v to exist. If u and v have the same ID, Contract is a no-op. Contract
+// will panic if u or v is not in the graph.
+func (g *UndirectedGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	if !g.Has(u) || !g.Has(v) {
+		panic("simple: contract absent node")
+	}
+	uid, vid := u.ID(), v.ID()
+	merged := g.nodes[uid]
+	if uid == vid {
+		return merged
+	}
+	if resolve == nil {
+		resolve = math.Min
+	}
+
+	for nid, e := range g.edges[vid] {
+		if nid == uid {
+			continue
+		}
+		if ue, ok := g.edges[uid][nid]; ok {
+			g.SetEdge(reweighted(ue, resolve(ue.Weight(), e.Weight())))
+			continue
+		}
+		g.SetEdge(Edge{F: merged, T: g.nodes[nid], W: e.Weight()})
+	}
+	g.RemoveNode(v)
+
+	return merged
+}
+
+// Contract contracts the edges between u and v, merging v into u, and returns
+// the merged node. The outbound and inbound edges of v are moved to u, and edges
+// between u and v are dropped rather than becoming self edges. If u and v share an
+// outbound or inbound neighbor, the weight of the merged edge is given by resolve
+// called with the weights of the edges of u and v respectively, or the minimum of
+// the weights if resolve is nil, and the edge of u is rewritten as described for
+// SetEdgeWeight. Edges moved from v are replaced by Edge values.
+// Contract does not require an edge between u and v to exist. If u and v have the
+// same ID, Contract is a no-op. Contract will panic if u or v is not in the graph.
+func (g *DirectedGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	if !g.Has(u) || !g.Has(v) {
+		panic("simple: contract absent node")
+	}
+	uid, vid := u.ID(), v.ID()
+	merged := g.Node(uid)
+	if uid == vid {
+		return merged
+	}
+	if resolve == nil {
+		resolve = math.Min
+	}
+
+	ui, vi := g.indexOf[uid], g.indexOf[vid]
+	for _, h := range g.from[vi] {
+		if h.id == uid {
+			continue
+		}
+		if k, ok := g.from[ui].find(h.id); ok {
+			ue := g.from[ui][k].edge
+			g.SetEdge(reweighted(ue, resolve(ue.Weight(), h.edge.Weight())))
+			continue
+		}
+		g.SetEdge(Edge{F: merged, T: g.Node(h.id), W: h.edge.Weight()})
+	}
+	for _, h := range g.to[vi] {
+		if h.id == uid {
+			continue
+		}
+		if k, ok := g.to[ui].find(h.id); ok {
+			ue := g.to[ui][k].edge
+			g.SetEdge(reweighted(ue, resolve(ue.Weight(), h.edge.Weight())))
+			continue
+		}
+		g.SetEdge(Edge{F: g.Node(h.id), T: merged, W: h.edge.Weight()})
+	}
+	g.RemoveNode(v)
+
+	return merged
+}
@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+func TestUndirectedContract(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 5})
+
+	if n := g.Contract(Node(0), Node(1), nil); n.ID() != 0 {
+		t.Errorf("unexpected merged node: got:%d want:0", n.ID())
+	}
+	if g.Has(Node(1)) {
+		t.Error("contracted node still in graph")
+	}
+	if len(g.Nodes()) != 2 || len(g.Edges()) != 1 {
+		t.Fatalf("unexpected graph size after first contraction: nodes=%d edges=%d", len(g.Nodes()), len(g.Edges()))
+	}
+	if w, ok := g.Weight(Node(0), Node(2)); !ok || w != 2 {
+		t.Errorf("unexpected weight after first contraction: got:(%v, %t) want:(2, true)", w, ok)
+	}
+
+	g.Contract(Node(2), Node(0), nil)
+	if len(g.Nodes()) != 1 || len(g.Edges()) != 0 || !g.Has(Node(2)) {
+		t.Errorf("unexpected graph after second contraction: nodes=%v edges=%v", g.Nodes(), g.Edges())
+	}
+
+	g = NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 5})
+	g.Contract(Node(0), Node(1), func(x, y float64) float64 { return x + y })
+	if w, ok := g.Weight(Node(0), Node(2)); !ok || w != 7 {
+		t.Errorf("unexpected resolved weight: got:(%v, %t) want:(7, true)", w, ok)
+	}
+}
+
+func TestDirectedContract(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 5})
+	g.SetEdge(Edge{F: Node(2), T: Node(1), W: 3})
+	g.SetEdge(Edge{F: Node(3), T: Node(1), W: 4})
+
+	g.Contract(Node(0), Node(1), nil)
+	if g.Has(Node(1)) {
+		t.Error("contracted node still in graph")
+	}
+	for _, test := range []struct {
+		u, v int
+		w    float64
+		ok   bool
+	}{
+		{u: 0, v: 2, w: 2, ok: true},
+		{u: 2, v: 0, w: 3, ok: true},
+		{u: 3, v: 0, w: 4, ok: true},
+		{u: 0, v: 3, w: math.Inf(1), ok: false},
+	} {
+		if w, ok := g.Weight(Node(test.u), Node(test.v)); ok != test.ok || w != test.w {
+			t.Errorf("unexpected weight from %d to %d after first contraction: got:(%v, %t) want:(%v, %t)",
+				test.u, test.v, w, ok, test.w, test.ok)
+		}
+	}
+	if len(g.Edges()) != 3 {
+		t.Errorf("unexpected number of edges after first contraction: got:%d want:3", len(g.Edges()))
+	}
+
+	g.Contract(Node(0), Node(2), nil)
+	g.Contract(Node(0), Node(3), nil)
+	if len(g.Nodes()) != 1 || len(g.Edges()) != 0 || !g.Has(Node(0)) {
+		t.Errorf("unexpected graph after contractions: nodes=%v edges=%v", g.Nodes(), g.Edges())
+	}
+}
+
+func TestContractReweighter(t *testing.T) {
+	for _, g := range []interface {
+		graph.Graph
+		graph.EdgeSetter
+		Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node
+	}{
+		NewDirectedGraph(0, math.Inf(1)),
+		NewUndirectedGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(labeledEdge{Edge: Edge{F: Node(0), T: Node(2), W: 5}, label: "road"})
+		g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+		g.Contract(Node(0), Node(1), nil)
+
+		e := g.Edge(Node(0), Node(2))
+		l, ok := e.(labeledEdge)
+		if !ok || l.label != "road" || l.W != 2 {
+			t.Errorf("unexpected merged edge in %T: got:%#v want:labeled road edge with weight 2", g, e)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+// wpDijkstraEdges is the example graph from the Wikipedia Dijkstra's
+// algorithm page, with node IDs shifted to start at zero.
+var wpDijkstraEdges = []Edge{
+	{F: Node(0), T: Node(1), W: 7},
+	{F: Node(0), T: Node(2), W: 9},
+	{F: Node(0), T: Node(5), W: 14},
+	{F: Node(1), T: Node(2), W: 10},
+	{F: Node(1), T: Node(3), W: 15},
+	{F: Node(2), T: Node(3), W: 11},
+	{F: Node(2), T: Node(5), W: 2},
+	{F: Node(3), T: Node(4), W: 6},
+	{F: Node(4), T: Node(5), W: 9},
+}
+
+type weightedGraph interface {
+	graph.Graph
+	graph.Weighter
+}
+
+// sameGraph checks that a and b have the same nodes, edges and edge weights.
+func sameGraph(t *testing.T, name string, a, b weightedGraph) {
+	nodes := a.Nodes()
+	if len(nodes) != len(b.Nodes()) {
+		t.Errorf("%s: unexpected number of nodes: got:%d want:%d", name, len(b.Nodes()), len(nodes))
+		return
+	}
+	for _, u := range nodes {
+		if !b.Has(u) {
+			t.Errorf("%s: missing node %d", name, u.ID())
+		}
+		for _, v := range nodes {
+			if u.ID() == v.ID() {
+				continue
+			}
+			ea := a.Edge(u, v)
+			eb := b.Edge(u, v)
+			if (ea == nil) != (eb == nil) {
+				t.Errorf("%s: unexpected edge state from %d to %d: got:%v want:%v", name, u.ID(), v.ID(), eb, ea)
+				continue
+			}
+			wa, _ := a.Weight(u, v)
+			wb, _ := b.Weight(u, v)
+			if !isSame(wa, wb) {
+				t.Errorf("%s: unexpected weight from %d to %d: got:%v want:%v", name, u.ID(), v.ID(), wb, wa)
+			}
+		}
+	}
+}
+
+func TestConvertDirected(t *testing.T) {
+	inf := math.Inf(1)
+	g := NewDirectedGraph(0, inf)
+	for _, e := range wpDijkstraEdges {
+		g.SetEdge(e)
+	}
+
+	m, err := NewDirectedMatrixFromGraph(g, 0, inf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sameGraph(t, "sparse to dense directed", g, m)
+
+	s := NewDirectedGraph(0, inf)
+	graph.Copy(s, m)
+	sameGraph(t, "dense to sparse directed", m, s)
+	sameGraph(t, "round trip directed", g, s)
+}
+
+func TestConvertUndirected(t *testing.T) {
+	inf := math.Inf(1)
+	g := NewUndirectedGraph(0, inf)
+	for _, e := range wpDijkstraEdges {
+		g.SetEdge(e)
+	}
+
+	m, err := NewUndirectedMatrixFromGraph(g, 0, inf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sameGraph(t, "sparse to dense undirected", g, m)
+
+	s := NewUndirectedGraph(0, inf)
+	graph.Copy(s, m)
+	sameGraph(t, "dense to sparse undirected", m, s)
+	sameGraph(t, "round trip undirected", g, s)
+}
+
+func TestConvertNonContiguous(t *testing.T) {
+	d := NewDirectedGraph(0, math.Inf(1))
+	d.SetEdge(Edge{F: Node(0), T: Node(2)})
+	if _, err := NewDirectedMatrixFromGraph(d, 0, math.Inf(1)); err == nil {
+		t.Error("expected error for non-contiguous directed graph")
+	}
+
+	u := NewUndirectedGraph(0, math.Inf(1))
+	u.SetEdge(Edge{F: Node(1), T: Node(2)})
+	if _, err := NewUndirectedMatrixFromGraph(u, 0, math.Inf(1)); err == nil {
+		t.Error("expected error for non-contiguous undirected graph")
+	}
+}
+
+func TestConvertSelfEdge(t *testing.T) {
+	inf := math.Inf(1)
+	d := NewDirectedGraph(0, inf)
+	u := NewUndirectedGraph(0, inf)
+	for _, e := range wpDijkstraEdges {
+		d.SetEdge(e)
+		u.SetEdge(e)
+	}
+
+	// Self edges cannot be held on the
+	// diagonal with the self weight.
+	_, err := NewDirectedMatrixFromGraph(loopedDirected{DirectedGraph: d, loop: Node(2)}, 0, inf)
+	if err == nil {
+		t.Error("expected error for directed graph with self edge")
+	}
+	_, err = NewUndirectedMatrixFromGraph(loopedUndirected{UndirectedGraph: u, loop: Node(2)}, 0, inf)
+	if err == nil {
+		t.Error("expected error for undirected graph with self edge")
+	}
+}
+
+// loopedDirected is a DirectedGraph with
+// a self edge on the loop node.
+type loopedDirected struct {
+	*DirectedGraph
+	loop graph.Node
+}
+
+func (g loopedDirected) From(n graph.Node) []graph.Node {
+	if n.ID() == g.loop.ID() {
+		return append(g.DirectedGraph.From(n), n)
+	}
+	return g.DirectedGraph.From(n)
+}
+
+func (g loopedDirected) Edge(u, v graph.Node) graph.Edge {
+	if u.ID() == g.loop.ID() && v.ID() == g.loop.ID() {
+		return Edge{F: u, T: v, W: 1}
+	}
+	return g.DirectedGraph.Edge(u, v)
+}
+
+// loopedUndirected is an UndirectedGraph
+// with a self edge on the loop node.
+type loopedUndirected struct {
+	*UndirectedGraph
+	loop graph.Node
+}
+
+func (g loopedUndirected) From(n graph.Node) []graph.Node {
+	if n.ID() == g.loop.ID() {
+		return append(g.UndirectedGraph.From(n), n)
+	}
+	return g.UndirectedGraph.From(n)
+}
+
+func (g loopedUndirected) Edge(u, v graph.Node) graph.Edge {
+	if u.ID() == g.loop.ID() && v.ID() == g.loop.ID() {
+		return Edge{F: u, T: v, W: 1}
+	}
+	return g.UndirectedGraph.Edge(u, v)
+}
@@ -0,0 +1,446 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// DirectedCSR is an immutable directed graph held in compressed sparse row
+// form. The outbound and inbound edges of each node are held in contiguous
+// slices sorted by node ID, so DirectedCSR is suited to read-heavy workloads
+// on graphs that do not change after construction. Edges are stored implicitly
+// as an edge weight, so edges stored in the graph are not recoverable.
+type DirectedCSR struct {
+	nodes   []graph.Node
+	indexOf map[int]int
+
+	from, to csr
+
+	self, absent float64
+}
+
+// NewDirectedCSR returns a DirectedCSR holding the nodes and edges of g, with
+// the specified self and absent edge weight values. Edge weights are taken from
+// g if it implements graph.Weighter, otherwise from the edges of g. An error
+// is returned if g has a self edge.
+func NewDirectedCSR(g graph.Directed, self, absent float64) (*DirectedCSR, error) {
+	nodes, indexOf := csrNodes(g)
+	weight := csrWeight(g)
+	from, err := newCSR(nodes, indexOf, g.From, weight)
+	if err != nil {
+		return nil, err
+	}
+	to, err := newCSR(nodes, indexOf, g.To, func(u, v graph.Node) float64 {
+		return weight(v, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DirectedCSR{
+		nodes:   nodes,
+		indexOf: indexOf,
+
+		from: from,
+		to:   to,
+
+		self:   self,
+		absent: absent,
+	}, nil
+}
+
+// Has returns whether the node exists within the graph.
+func (g *DirectedCSR) Has(n graph.Node) bool {
+	_, ok := g.indexOf[n.ID()]
+	return ok
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *DirectedCSR) Nodes() []graph.Node {
+	nodes := make([]graph.Node, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+// Order returns the number of nodes in g.
+func (g *DirectedCSR) Order() int {
+	return len(g.nodes)
+}
+
+// Size returns the number of edges in g. Edges in opposite directions
+// between a pair of nodes are counted separately.
+func (g *DirectedCSR) Size() int {
+	return len(g.from.targets)
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+func (g *DirectedCSR) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for i, u := range g.nodes {
+		for k := g.from.offsets[i]; k < g.from.offsets[i+1]; k++ {
+			w := g.from.weights[k]
+			if !fn(Edge{F: u, T: g.nodes[g.from.targets[k]], W: w}, w) {
+				return
+			}
+		}
+	}
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *DirectedCSR) From(n graph.Node) []graph.Node {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return nil
+	}
+	return g.from.nodes(i, g.nodes)
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *DirectedCSR) To(n graph.Node) []graph.Node {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return nil
+	}
+	return g.to.nodes(i, g.nodes)
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *DirectedCSR) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	if i, ok := g.indexOf[n.ID()]; ok {
+		g.from.visit(i, g.nodes, fn)
+	}
+}
+
+// VisitTo calls fn with each node that can reach directly to n and the weight
+// of the joining edge until fn returns false.
+func (g *DirectedCSR) VisitTo(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	if i, ok := g.indexOf[n.ID()]; ok {
+		g.to.visit(i, g.nodes, fn)
+	}
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *DirectedCSR) HasEdgeBetween(x, y graph.Node) bool {
+	return g.HasEdgeFromTo(x, y) || g.HasEdgeFromTo(y, x)
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *DirectedCSR) HasEdgeFromTo(u, v graph.Node) bool {
+	_, ok := g.weight(u, v)
+	return ok
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *DirectedCSR) Edge(u, v graph.Node) graph.Edge {
+	w, ok := g.weight(u, v)
+	if !ok {
+		return nil
+	}
+	return Edge{F: g.nodes[g.indexOf[u.ID()]], T: g.nodes[g.indexOf[v.ID()]], W: w}
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (g *DirectedCSR) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return g.self, true
+	}
+	if w, ok := g.weight(x, y); ok {
+		return w, true
+	}
+	return g.absent, false
+}
+
+func (g *DirectedCSR) weight(u, v graph.Node) (w float64, ok bool) {
+	i, ok := g.indexOf[u.ID()]
+	if !ok {
+		return 0, false
+	}
+	j, ok := g.indexOf[v.ID()]
+	if !ok {
+		return 0, false
+	}
+	return g.from.find(i, j)
+}
+
+// Degree returns the in+out degree of n in g, the sum of
+// its in-degree and out-degree.
+func (g *DirectedCSR) Degree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return g.from.degree(i) + g.to.degree(i)
+}
+
+// InDegree returns the number of edges leading to n in g. Since g holds no
+// self edges, the in-degree of n is the number of nodes returned by To(n).
+func (g *DirectedCSR) InDegree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return g.to.degree(i)
+}
+
+// OutDegree returns the number of edges leading from n in g. Since g holds no
+// self edges, the out-degree of n is the number of nodes returned by From(n).
+func (g *DirectedCSR) OutDegree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return g.from.degree(i)
+}
+
+// UndirectedCSR is an immutable undirected graph held in compressed sparse
+// row form. The edges of each node are held in contiguous slices sorted by
+// node ID, so UndirectedCSR is suited to read-heavy workloads on graphs that
+// do not change after construction. Edges are stored implicitly as an edge
+// weight, so edges stored in the graph are not recoverable.
+type UndirectedCSR struct {
+	nodes   []graph.Node
+	indexOf map[int]int
+
+	edges csr
+
+	self, absent float64
+}
+
+// NewUndirectedCSR returns an UndirectedCSR holding the nodes and edges of g,
+// with the specified self and absent edge weight values. Edge weights are taken
+// from g if it implements graph.Weighter, otherwise from the edges of g. An
+// error is returned if g has a self edge.
+func NewUndirectedCSR(g graph.Undirected, self, absent float64) (*UndirectedCSR, error) {
+	nodes, indexOf := csrNodes(g)
+	edges, err := newCSR(nodes, indexOf, g.From, csrWeight(g))
+	if err != nil {
+		return nil, err
+	}
+	return &UndirectedCSR{
+		nodes:   nodes,
+		indexOf: indexOf,
+
+		edges: edges,
+
+		self:   self,
+		absent: absent,
+	}, nil
+}
+
+// Has returns whether the node exists within the graph.
+func (g *UndirectedCSR) Has(n graph.Node) bool {
+	_, ok := g.indexOf[n.ID()]
+	return ok
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *UndirectedCSR) Nodes() []graph.Node {
+	nodes := make([]graph.Node, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+// Order returns the number of nodes in g.
+func (g *UndirectedCSR) Order() int {
+	return len(g.nodes)
+}
+
+// Size returns the number of edges in g.
+func (g *UndirectedCSR) Size() int {
+	return len(g.edges.targets) / 2
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+// Each edge is visited once.
+func (g *UndirectedCSR) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for i, u := range g.nodes {
+		for k := g.edges.offsets[i]; k < g.edges.offsets[i+1]; k++ {
+			j := g.edges.targets[k]
+			if j < i {
+				continue
+			}
+			w := g.edges.weights[k]
+			if !fn(Edge{F: u, T: g.nodes[j], W: w}, w) {
+				return
+			}
+		}
+	}
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *UndirectedCSR) From(n graph.Node) []graph.Node {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return nil
+	}
+	return g.edges.nodes(i, g.nodes)
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *UndirectedCSR) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	if i, ok := g.indexOf[n.ID()]; ok {
+		g.edges.visit(i, g.nodes, fn)
+	}
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *UndirectedCSR) HasEdgeBetween(x, y graph.Node) bool {
+	_, ok := g.weight(x, y)
+	return ok
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *UndirectedCSR) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g *UndirectedCSR) EdgeBetween(x, y graph.Node) graph.Edge {
+	w, ok := g.weight(x, y)
+	if !ok {
+		return nil
+	}
+	return Edge{F: g.nodes[g.indexOf[x.ID()]], T: g.nodes[g.indexOf[y.ID()]], W: w}
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (g *UndirectedCSR) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return g.self, true
+	}
+	if w, ok := g.weight(x, y); ok {
+		return w, true
+	}
+	return g.absent, false
+}
+
+func (g *UndirectedCSR) weight(u, v graph.Node) (w float64, ok bool) {
+	i, ok := g.indexOf[u.ID()]
+	if !ok {
+		return 0, false
+	}
+	j, ok := g.indexOf[v.ID()]
+	if !ok {
+		return 0, false
+	}
+	return g.edges.find(i, j)
+}
+
+// Degree returns the degree of n in g.
+func (g *UndirectedCSR) Degree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return g.edges.degree(i)
+}
+
+// csr is a compressed sparse row adjacency list. The
+// adjacent node indices of the node with index i are
+// held in targets[offsets[i]:offsets[i+1]] in ascending
+// order, with the corresponding edge weights in weights.
+type csr struct {
+	offsets []int
+	targets []int
+	weights []float64
+}
+
+// newCSR returns the compressed sparse row form of the adjacency defined by
+// adjacent for the given nodes. An error is returned if any node is adjacent to
+// itself.
+func newCSR(nodes []graph.Node, indexOf map[int]int, adjacent func(graph.Node) []graph.Node, weight func(u, v graph.Node) float64) (csr, error) {
+	c := csr{offsets: make([]int, len(nodes)+1)}
+	for i, u := range nodes {
+		adj := adjacent(u)
+		sort.Sort(ordered.ByID(adj))
+		for _, v := range adj {
+			if v.ID() == u.ID() {
+				return csr{}, fmt.Errorf("simple: self edge on node %d", u.ID())
+			}
+			c.targets = append(c.targets, indexOf[v.ID()])
+			c.weights = append(c.weights, weight(u, v))
+		}
+		c.offsets[i+1] = len(c.targets)
+	}
+	return c, nil
+}
+
+// nodes returns the nodes adjacent to the node with index i.
+func (c csr) nodes(i int, nodes []graph.Node) []graph.Node {
+	targets := c.targets[c.offsets[i]:c.offsets[i+1]]
+	if len(targets) == 0 {
+		return nil
+	}
+	adj := make([]graph.Node, len(targets))
+	for k, j := range targets {
+		adj[k] = nodes[j]
+	}
+	return adj
+}
+
+// visit calls fn with each node adjacent to the node with index i and the
+// weight of the joining edge until fn returns false.
+func (c csr) visit(i int, nodes []graph.Node, fn func(graph.Node, float64) bool) {
+	for k := c.offsets[i]; k < c.offsets[i+1]; k++ {
+		if !fn(nodes[c.targets[k]], c.weights[k]) {
+			return
+		}
+	}
+}
+
+// find returns the weight of the edge from the node with index i to
+// the node with index j and whether the edge exists.
+func (c csr) find(i, j int) (w float64, ok bool) {
+	lo, hi := c.offsets[i], c.offsets[i+1]
+	k := lo + sort.SearchInts(c.targets[lo:hi], j)
+	if k < hi && c.targets[k] == j {
+		return c.weights[k], true
+	}
+	return 0, false
+}
+
+// degree returns the number of nodes adjacent to the node with index i.
+func (c csr) degree(i int) int {
+	return c.offsets[i+1] - c.offsets[i]
+}
+
+// csrNodes returns the nodes of g sorted by ID and a mapping from node IDs
+// to their index in the sorted nodes.
+func csrNodes(g graph.Graph) ([]graph.Node, map[int]int) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	return nodes, indexOf
+}
+
+// csrWeight returns a function returning the weight of the edge from u to v in g.
+func csrWeight(g graph.Graph) func(u, v graph.Node) float64 {
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return func(u, v graph.Node) float64 {
+		return g.Edge(u, v).Weight()
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/traverse"
+)
+
+var (
+	_ graph.Directed   = (*simple.DirectedCSR)(nil)
+	_ graph.Weighter   = (*simple.DirectedCSR)(nil)
+	_ graph.Undirected = (*simple.UndirectedCSR)(nil)
+	_ graph.Weighter   = (*simple.UndirectedCSR)(nil)
+)
+
+// randomWeighted fills dst with m random edges between n nodes
+// with uniformly distributed weights in [0, 1).
+func randomWeighted(dst graph.Builder, n, m int, src *rand.Rand) {
+	for i := 0; i < n; i++ {
+		dst.AddNode(simple.Node(i))
+	}
+	for i := 0; i < m; i++ {
+		u, v := simple.Node(src.Intn(n)), simple.Node(src.Intn(n))
+		if u == v {
+			continue
+		}
+		dst.SetEdge(simple.Edge{F: u, T: v, W: src.Float64()})
+	}
+}
+
+func TestCSR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	dg := simple.NewDirectedGraph(0, math.Inf(1))
+	randomWeighted(dg, 200, 1000, rnd)
+	dg.AddNode(simple.Node(500))
+	ug := simple.NewUndirectedGraph(0, math.Inf(1))
+	randomWeighted(ug, 200, 1000, rnd)
+	ug.AddNode(simple.Node(500))
+
+	dc, err := simple.NewDirectedCSR(dg, 0, math.Inf(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc, err := simple.NewUndirectedCSR(ug, 0, math.Inf(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, pair := range []struct {
+		want, got graph.Graph
+	}{
+		{want: dg, got: dc},
+		{want: ug, got: uc},
+	} {
+		nodes := pair.want.Nodes()
+		if len(pair.got.Nodes()) != len(nodes) {
+			t.Errorf("unexpected number of nodes in %T: got:%d want:%d", pair.got, len(pair.got.Nodes()), len(nodes))
+		}
+		for _, u := range nodes {
+			if !sameIDSet(pair.got.From(u), pair.want.From(u)) {
+				t.Errorf("unexpected from nodes of %d in %T", u.ID(), pair.got)
+			}
+			if d, ok := pair.want.(graph.Directed); ok {
+				if !sameIDSet(pair.got.(graph.Directed).To(u), d.To(u)) {
+					t.Errorf("unexpected to nodes of %d in %T", u.ID(), pair.got)
+				}
+			}
+			for _, v := range nodes {
+				gw, gok := pair.got.(graph.Weighter).Weight(u, v)
+				ww, wok := pair.want.(graph.Weighter).Weight(u, v)
+				if gw != ww || gok != wok {
+					t.Errorf("unexpected weight from %d to %d in %T: got:(%v, %t) want:(%v, %t)",
+						u.ID(), v.ID(), pair.got, gw, gok, ww, wok)
+				}
+				if pair.got.HasEdgeBetween(u, v) != pair.want.HasEdgeBetween(u, v) {
+					t.Errorf("unexpected edge existence between %d and %d in %T", u.ID(), v.ID(), pair.got)
+				}
+			}
+		}
+
+		// Search results must be identical on both representations.
+		for _, u := range nodes[:10] {
+			if got, want := traverse.Layers(pair.got, u), traverse.Layers(pair.want, u); !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected breadth-first layers from %d in %T", u.ID(), pair.got)
+			}
+			got := path.DijkstraFrom(u, pair.got)
+			want := path.DijkstraFrom(u, pair.want)
+			for _, v := range nodes {
+				if got.WeightTo(v) != want.WeightTo(v) {
+					t.Errorf("unexpected shortest path weight from %d to %d in %T: got:%v want:%v",
+						u.ID(), v.ID(), pair.got, got.WeightTo(v), want.WeightTo(v))
+				}
+			}
+		}
+	}
+}
+
+func sameIDSet(a, b []graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[int]bool, len(a))
+	for _, n := range a {
+		ids[n.ID()] = true
+	}
+	for _, n := range b {
+		if !ids[n.ID()] {
+			return false
+		}
+	}
+	return true
+}
+
+var benchGraphs struct {
+	mapped *simple.DirectedGraph
+	csr    *simple.DirectedCSR
+}
+
+// benchGraph returns a random directed graph with about one million
+// edges in adjacency list and compressed sparse row forms.
+func benchGraph() (*simple.DirectedGraph, *simple.DirectedCSR) {
+	if benchGraphs.mapped == nil {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		randomWeighted(g, 1e5, 1e6, rand.New(rand.NewSource(1)))
+		benchGraphs.mapped = g
+		c, err := simple.NewDirectedCSR(g, 0, math.Inf(1))
+		if err != nil {
+			panic(err)
+		}
+		benchGraphs.csr = c
+	}
+	return benchGraphs.mapped, benchGraphs.csr
+}
+
+func BenchmarkBreadthFirstDirectedGraph(b *testing.B) {
+	g, _ := benchGraph()
+	benchmarkBreadthFirst(b, g)
+}
+
+func BenchmarkBreadthFirstDirectedCSR(b *testing.B) {
+	_, g := benchGraph()
+	benchmarkBreadthFirst(b, g)
+}
+
+func benchmarkBreadthFirst(b *testing.B, g graph.Graph) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var bf traverse.BreadthFirst
+		bf.Walk(g, simple.Node(0), nil)
+	}
+}
+
+func BenchmarkDijkstraDirectedGraph(b *testing.B) {
+	g, _ := benchGraph()
+	benchmarkDijkstra(b, g)
+}
+
+func BenchmarkDijkstraDirectedCSR(b *testing.B) {
+	_, g := benchGraph()
+	benchmarkDijkstra(b, g)
+}
+
+func benchmarkDijkstra(b *testing.B, g graph.Graph) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		path.DijkstraFrom(simple.Node(0), g)
+	}
+}
+
+func BenchmarkBuildTraverseDirectedGraph(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		randomWeighted(g, 1e5, 1e6, rand.New(rand.NewSource(1)))
+		var bf traverse.BreadthFirst
+		bf.Walk(g, simple.Node(0), nil)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/edgecsv"
+)
+
+// ReadEdgeListCSV reads an edge list from r and returns the graph it describes
+// and a mapping from the names used in the list to node IDs in the graph.
+//
+// Each comma separated record of the list holds the names of the from and to
+// nodes of an edge and optionally the weight of the edge. Fields may be quoted
+// as described for encoding/csv. Edges without a weight are given unit
+// weight. The returned graph is a *DirectedGraph if directed is true and an
+// *UndirectedGraph otherwise. A repeated edge replaces the earlier edge and
+// self edges result in an error.
+func ReadEdgeListCSV(r io.Reader, directed bool) (graph.Graph, map[string]int, error) {
+	var g graph.Builder
+	if directed {
+		g = NewDirectedGraph(0, math.Inf(1))
+	} else {
+		g = NewUndirectedGraph(0, math.Inf(1))
+	}
+	names := NewNodeNamer(g)
+
+	er := edgecsv.NewReader(r, ',', 0, false)
+	for {
+		e, err := er.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*edgecsv.Error); ok {
+				err = fmt.Errorf("simple: %v", err)
+			}
+			return nil, nil, err
+		}
+		names.SetEdgeByName(e.From, e.To, e.Weight)
+	}
+	ids := make(map[string]int, len(names.ids))
+	for name, id := range names.ids {
+		ids[name.(string)] = id
+	}
+	return g.(graph.Graph), ids, nil
+}
@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+func TestReadEdgeListCSV(t *testing.T) {
+	const data = `alice,bob,2.5
+bob, carol
+"carol, jr",alice,-1
+`
+	for _, directed := range []bool{true, false} {
+		g, ids, err := ReadEdgeListCSV(strings.NewReader(data), directed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := g.(graph.Directed); ok != directed {
+			t.Errorf("unexpected graph directedness: got:%t want:%t", ok, directed)
+		}
+		if len(ids) != 4 || len(g.Nodes()) != 4 {
+			t.Errorf("unexpected number of nodes: got:%d names and %d nodes want:4", len(ids), len(g.Nodes()))
+		}
+		for _, test := range []struct {
+			from, to string
+			weight   float64
+		}{
+			{from: "alice", to: "bob", weight: 2.5},
+			{from: "bob", to: "carol", weight: 1},
+			{from: "carol, jr", to: "alice", weight: -1},
+		} {
+			u, uok := ids[test.from]
+			v, vok := ids[test.to]
+			if !uok || !vok {
+				t.Errorf("missing name mapping for %q or %q", test.from, test.to)
+				continue
+			}
+			e := g.Edge(Node(u), Node(v))
+			if e == nil {
+				t.Errorf("missing edge %q-%q", test.from, test.to)
+				continue
+			}
+			if e.Weight() != test.weight {
+				t.Errorf("unexpected weight for %q-%q: got:%v want:%v", test.from, test.to, e.Weight(), test.weight)
+			}
+		}
+		if e := g.Edge(Node(ids["bob"]), Node(ids["alice"])); (e != nil) == directed {
+			t.Errorf("unexpected reverse edge existence: got:%t want:%t", e != nil, !directed)
+		}
+	}
+}
+
+func TestReadEdgeListCSVErrors(t *testing.T) {
+	for _, data := range []string{
+		"a\n",
+		"a,b,1,2\n",
+		"a,b,heavy\n",
+		"a,b\nc,c\n",
+		"a,\"b\n",
+	} {
+		_, _, err := ReadEdgeListCSV(strings.NewReader(data), true)
+		if err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var (
+	_ graph.DirectedDegreer = (*DirectedGraph)(nil)
+	_ graph.DirectedDegreer = (*DirectedMatrix)(nil)
+	_ graph.DirectedDegreer = (*DirectedBitMatrix)(nil)
+	_ graph.DirectedDegreer = (*DirectedCSR)(nil)
+)
+
+func TestDirectedDegree(t *testing.T) {
+	const n = 40
+	rnd := rand.New(rand.NewSource(1))
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	dm := NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	db := NewDirectedBitMatrix(n)
+	for i := 0; i < n; i++ {
+		dg.AddNode(Node(i))
+	}
+	for i := 0; i < 300; i++ {
+		u, v := Node(rnd.Intn(n)), Node(rnd.Intn(n))
+		for _, g := range []graph.EdgeSetter{dg, dm, db} {
+			if u == v {
+				// Self edges are rejected and must
+				// not contribute to degree.
+				if !panics(func() { g.SetEdge(Edge{F: u, T: v, W: 1}) }) {
+					t.Errorf("expected panic for self edge in %T", g)
+				}
+				continue
+			}
+			g.SetEdge(Edge{F: u, T: v, W: 1})
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		g    interface {
+			graph.Directed
+			graph.DirectedDegreer
+			Degree(graph.Node) int
+		}
+	}{
+		{name: "DirectedGraph", g: dg},
+		{name: "DirectedMatrix", g: dm},
+		{name: "DirectedBitMatrix", g: db},
+		{name: "DirectedCSR", g: mustDirectedCSR(dg)},
+	} {
+		for _, u := range test.g.Nodes() {
+			in, out := test.g.InDegree(u), test.g.OutDegree(u)
+			if want := len(test.g.To(u)); in != want {
+				t.Errorf("unexpected in-degree of %d in %s: got:%d want:%d", u.ID(), test.name, in, want)
+			}
+			if want := len(test.g.From(u)); out != want {
+				t.Errorf("unexpected out-degree of %d in %s: got:%d want:%d", u.ID(), test.name, out, want)
+			}
+			if deg := test.g.Degree(u); deg != in+out {
+				t.Errorf("unexpected degree of %d in %s: got:%d want:%d", u.ID(), test.name, deg, in+out)
+			}
+		}
+		absent := Node(n)
+		if test.g.InDegree(absent) != 0 || test.g.OutDegree(absent) != 0 || test.g.Degree(absent) != 0 {
+			t.Errorf("unexpected non-zero degree for absent node in %s", test.name)
+		}
+	}
+
+	// An antiparallel pair of edges contributes one
+	// to each of the in- and out-degree of each node,
+	// and a rejected self edge contributes nothing.
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	panics(func() { g.SetEdge(Edge{F: Node(0), T: Node(0), W: 1}) })
+	for _, id := range []int{0, 1} {
+		if g.InDegree(Node(id)) != 1 || g.OutDegree(Node(id)) != 1 || g.Degree(Node(id)) != 2 {
+			t.Errorf("unexpected degrees for node %d of antiparallel pair: in:%d out:%d degree:%d",
+				id, g.InDegree(Node(id)), g.OutDegree(Node(id)), g.Degree(Node(id)))
+		}
+	}
+}
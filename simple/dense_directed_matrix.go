@@ -5,8 +5,12 @@
 package simple
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 
+	"golang.org/x/tools/container/intsets"
+
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/matrix/mat64"
@@ -15,11 +19,17 @@ import (
 // DirectedMatrix represents a directed graph using an adjacency
 // matrix such that all IDs are in a contiguous block from 0 to n-1.
 // Edges are stored implicitly as an edge weight, so edges stored in
-// the graph are not recoverable.
+// the graph are not recoverable. Removing nodes leaves gaps in the
+// block of IDs until the graph is compacted with Crunch.
 type DirectedMatrix struct {
 	mat   *mat64.Dense
 	nodes []graph.Node
 
+	// removed holds the IDs of nodes
+	// within the matrix that have been
+	// removed from the graph.
+	removed intsets.Sparse
+
 	self   float64
 	absent float64
 }
@@ -45,6 +55,28 @@ func NewDirectedMatrix(n int, init, self, absent float64) *DirectedMatrix {
 	}
 }
 
+// NewDirectedMatrixFromData creates a directed dense graph with n nodes from
+// the row-major weight matrix held in data, such that the entry at data[i*n+j]
+// is the weight of the edge from node i to node j. Entries equal to absent
+// indicate that there is no edge. The diagonal of the matrix is set to self,
+// which specifies the cost of self connection. The graph holds a copy of data,
+// so data is not modified and later changes to data do not alter the graph. If
+// len(data) != n*n, NewDirectedMatrixFromData will panic.
+func NewDirectedMatrixFromData(n int, data []float64, self, absent float64) *DirectedMatrix {
+	if len(data) != n*n {
+		panic("simple: data length mismatch")
+	}
+	data = append([]float64(nil), data...)
+	for i := 0; i < len(data); i += n + 1 {
+		data[i] = self
+	}
+	return &DirectedMatrix{
+		mat:    mat64.NewDense(n, n, data),
+		self:   self,
+		absent: absent,
+	}
+}
+
 // NewDirectedMatrixFrom creates a directed dense graph with the given nodes.
 // The IDs of the nodes must be contiguous from 0 to len(nodes)-1, but may
 // be in any order. If IDs are not contiguous NewDirectedMatrixFrom will panic.
@@ -63,6 +95,33 @@ func NewDirectedMatrixFrom(nodes []graph.Node, init, self, absent float64) *Dire
 	return g
 }
 
+// NewDirectedMatrixFromGraph creates a directed dense graph holding the nodes and edges
+// of g, with edge weights taken from the edges of g. The IDs of the nodes of g
+// must be contiguous from 0 to n-1, otherwise an error is returned. The matrix
+// diagonal holds the self connection cost, so an error is also returned if g
+// has a self edge. The self parameter specifies the cost of self connection,
+// and absent specifies the weight returned for absent edges.
+func NewDirectedMatrixFromGraph(g graph.Directed, self, absent float64) (*DirectedMatrix, error) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for i, n := range nodes {
+		if i != n.ID() {
+			return nil, errors.New("simple: non-contiguous node IDs")
+		}
+	}
+	m := NewDirectedMatrix(len(nodes), absent, self, absent)
+	m.nodes = nodes
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if u.ID() == v.ID() {
+				return nil, fmt.Errorf("simple: self edge on node %d", u.ID())
+			}
+			m.SetEdge(g.Edge(u, v))
+		}
+	}
+	return m, nil
+}
+
 // Node returns the node in the graph with the given ID.
 func (g *DirectedMatrix) Node(id int) graph.Node {
 	if !g.has(id) {
@@ -81,22 +140,175 @@ func (g *DirectedMatrix) Has(n graph.Node) bool {
 
 func (g *DirectedMatrix) has(id int) bool {
 	r, _ := g.mat.Dims()
-	return 0 <= id && id < r
+	return 0 <= id && id < r && !g.removed.Has(id)
 }
 
 // Nodes returns all the nodes in the graph.
 func (g *DirectedMatrix) Nodes() []graph.Node {
+	r, _ := g.mat.Dims()
+	nodes := make([]graph.Node, 0, r-g.removed.Len())
+	for i := 0; i < r; i++ {
+		if g.removed.Has(i) {
+			continue
+		}
+		nodes = append(nodes, g.Node(i))
+	}
+	return nodes
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID
+// is the lowest ID of a removed node if there is one, otherwise it is the order
+// of the matrix. The returned ID does not become a valid ID in g until it is added
+// to g.
+func (g *DirectedMatrix) NewNodeID() int {
+	if g.removed.Len() != 0 {
+		return g.removed.Min()
+	}
+	r, _ := g.mat.Dims()
+	return r
+}
+
+// AddNode adds n to the graph with no edges. If the ID of n is beyond the order
+// of the matrix, the matrix is grown to hold it and the IDs between are left as
+// removed nodes. AddNode panics if the added node ID matches an existing node ID
+// or is negative.
+func (g *DirectedMatrix) AddNode(n graph.Node) {
+	id := n.ID()
+	if id < 0 {
+		panic(fmt.Sprintf("simple: invalid node ID: %d", id))
+	}
+	if g.has(id) {
+		panic(fmt.Sprintf("simple: node ID collision: %d", id))
+	}
+	r, _ := g.mat.Dims()
+	if id >= r {
+		g.Grow(id - r + 1)
+		for i := r; i < id; i++ {
+			g.removed.Insert(i)
+			if g.nodes != nil {
+				g.nodes[i] = nil
+			}
+		}
+	}
+	g.removed.Remove(id)
+	if g.nodes == nil {
+		if n == Node(id) {
+			return
+		}
+		r, _ = g.mat.Dims()
+		g.nodes = make([]graph.Node, r)
+		for i := range g.nodes {
+			if !g.removed.Has(i) {
+				g.nodes[i] = Node(i)
+			}
+		}
+	}
+	g.nodes[id] = n
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. The
+// row and column of the matrix for n are retained until the graph is compacted
+// with Crunch. If the node is not in the graph it is a no-op.
+func (g *DirectedMatrix) RemoveNode(n graph.Node) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		if i == id {
+			continue
+		}
+		g.mat.Set(id, i, g.absent)
+		g.mat.Set(i, id, g.absent)
+	}
+	g.removed.Insert(id)
 	if g.nodes != nil {
-		nodes := make([]graph.Node, len(g.nodes))
-		copy(nodes, g.nodes)
-		return nodes
+		g.nodes[id] = nil
+	}
+}
+
+// Grow adds n nodes to the graph with IDs following the current order of the
+// matrix and no edges. The capacity of the matrix is at least doubled when it
+// needs to be reallocated, so repeated growth has amortized linear cost.
+func (g *DirectedMatrix) Grow(n int) {
+	if n < 0 {
+		panic("simple: negative growth")
+	}
+	if n == 0 {
+		return
 	}
 	r, _ := g.mat.Dims()
-	nodes := make([]graph.Node, r)
+	size := r + n
+	if capacity, _ := g.mat.Caps(); size > capacity {
+		c := 2 * capacity
+		if c < size {
+			c = size
+		}
+		m := mat64.NewDense(c, c, nil).Slice(0, size, 0, size).(*mat64.Dense)
+		if r != 0 {
+			m.Copy(g.mat)
+		}
+		g.mat = m
+	} else {
+		g.mat = g.mat.Grow(n, n).(*mat64.Dense)
+	}
+	for i := r; i < size; i++ {
+		for j := 0; j < size; j++ {
+			g.mat.Set(i, j, g.absent)
+			g.mat.Set(j, i, g.absent)
+		}
+		g.mat.Set(i, i, g.self)
+	}
+	if g.nodes != nil {
+		for i := r; i < size; i++ {
+			g.nodes = append(g.nodes, Node(i))
+		}
+	}
+}
+
+// Crunch compacts the graph, removing the rows and columns of removed nodes
+// from the matrix and reassigning node IDs to be contiguous from 0 to n-1 while
+// retaining their order. Crunch returns a mapping from the old node IDs to the
+// new node IDs. Nodes with a changed ID are replaced by Node values.
+func (g *DirectedMatrix) Crunch() map[int]int {
+	r, _ := g.mat.Dims()
+	var keep []int
 	for i := 0; i < r; i++ {
-		nodes[i] = Node(i)
+		if !g.removed.Has(i) {
+			keep = append(keep, i)
+		}
 	}
-	return nodes
+	ids := make(map[int]int, len(keep))
+	for i, id := range keep {
+		ids[id] = i
+	}
+	if len(keep) == r {
+		return ids
+	}
+
+	n := len(keep)
+	data := make([]float64, n*n)
+	for i, u := range keep {
+		for j, v := range keep {
+			data[i*n+j] = g.mat.At(u, v)
+		}
+	}
+	g.mat = mat64.NewDense(n, n, data)
+	if g.nodes != nil {
+		nodes := make([]graph.Node, n)
+		for i, id := range keep {
+			if i == id {
+				nodes[i] = g.nodes[id]
+			} else {
+				nodes[i] = Node(i)
+			}
+		}
+		g.nodes = nodes
+	}
+	g.removed.Clear()
+
+	return ids
 }
 
 // Edges returns all the edges in the graph.
@@ -116,6 +328,44 @@ func (g *DirectedMatrix) Edges() []graph.Edge {
 	return edges
 }
 
+// Order returns the number of nodes in g.
+func (g *DirectedMatrix) Order() int {
+	r, _ := g.mat.Dims()
+	return r - g.removed.Len()
+}
+
+// Size returns the number of edges in g. Edges in opposite directions
+// between a pair of nodes are counted separately.
+func (g *DirectedMatrix) Size() int {
+	var n int
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < r; j++ {
+			if i != j && !isSame(g.mat.At(i, j), g.absent) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+func (g *DirectedMatrix) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < r; j++ {
+			if i == j {
+				continue
+			}
+			if w := g.mat.At(i, j); !isSame(w, g.absent) {
+				if !fn(Edge{F: g.Node(i), T: g.Node(j), W: w}, w) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // From returns all nodes in g that can be reached directly from n.
 func (g *DirectedMatrix) From(n graph.Node) []graph.Node {
 	id := n.ID()
@@ -154,6 +404,46 @@ func (g *DirectedMatrix) To(n graph.Node) []graph.Node {
 	return neighbors
 }
 
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *DirectedMatrix) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	_, c := g.mat.Dims()
+	for j := 0; j < c; j++ {
+		if j == id {
+			continue
+		}
+		if w := g.mat.At(id, j); !isSame(w, g.absent) {
+			if !fn(g.Node(j), w) {
+				return
+			}
+		}
+	}
+}
+
+// VisitTo calls fn with each node that can reach directly to n and the weight
+// of the joining edge until fn returns false.
+func (g *DirectedMatrix) VisitTo(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		if i == id {
+			continue
+		}
+		if w := g.mat.At(i, id); !isSame(w, g.absent) {
+			if !fn(g.Node(i), w) {
+				return
+			}
+		}
+	}
+}
+
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
 // considering direction.
 func (g *DirectedMatrix) HasEdgeBetween(x, y graph.Node) bool {
@@ -200,55 +490,74 @@ func (g *DirectedMatrix) Weight(x, y graph.Node) (w float64, ok bool) {
 	if xid == yid {
 		return g.self, true
 	}
-	if g.has(xid) && g.has(yid) {
+	if g.HasEdgeFromTo(x, y) {
 		return g.mat.At(xid, yid), true
 	}
 	return g.absent, false
 }
 
-// SetEdge sets e, an edge from one node to another. If the ends of the edge are not in g
-// or the edge is a self loop, SetEdge panics.
+// SetEdge sets e, an edge from one node to another. Only the edge from e.From() to e.To()
+// is set; any edge in the reverse direction is left unaltered. If the ends of the edge are
+// not in g or the edge is a self loop, SetEdge panics.
 func (g *DirectedMatrix) SetEdge(e graph.Edge) {
 	fid := e.From().ID()
 	tid := e.To().ID()
-	if fid == tid {
+	if fid == tid || !g.has(fid) || !g.has(tid) {
 		panic("simple: set illegal edge")
 	}
 	g.mat.Set(fid, tid, e.Weight())
 }
 
-// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
-// it is a no-op.
+// RemoveEdge removes e from the graph, leaving the terminal nodes. Any edge in the reverse
+// direction is left unaltered. If the edge does not exist or is a self edge it is a no-op.
 func (g *DirectedMatrix) RemoveEdge(e graph.Edge) {
 	fid := e.From().ID()
 	if !g.has(fid) {
 		return
 	}
 	tid := e.To().ID()
-	if !g.has(tid) {
+	if !g.has(tid) || fid == tid {
+		// The diagonal holds the self connection
+		// cost, which is not an edge.
 		return
 	}
 	g.mat.Set(fid, tid, g.absent)
 }
 
-// Degree returns the in+out degree of n in g.
+// Degree returns the in+out degree of n in g, the sum of
+// its in-degree and out-degree.
 func (g *DirectedMatrix) Degree(n graph.Node) int {
+	return g.InDegree(n) + g.OutDegree(n)
+}
+
+// InDegree returns the number of edges leading to n in g. Since g holds no
+// self edges, the in-degree of n is the number of nodes returned by To(n).
+func (g *DirectedMatrix) InDegree(n graph.Node) int {
 	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
 	var deg int
-	r, c := g.mat.Dims()
+	r, _ := g.mat.Dims()
 	for i := 0; i < r; i++ {
-		if i == id {
-			continue
-		}
-		if !isSame(g.mat.At(id, i), g.absent) {
+		if i != id && !isSame(g.mat.At(i, id), g.absent) {
 			deg++
 		}
 	}
-	for i := 0; i < c; i++ {
-		if i == id {
-			continue
-		}
-		if !isSame(g.mat.At(i, id), g.absent) {
+	return deg
+}
+
+// OutDegree returns the number of edges leading from n in g. Since g holds no
+// self edges, the out-degree of n is the number of nodes returned by From(n).
+func (g *DirectedMatrix) OutDegree(n graph.Node) int {
+	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
+	var deg int
+	_, c := g.mat.Dims()
+	for j := 0; j < c; j++ {
+		if j != id && !isSame(g.mat.At(id, j), g.absent) {
 			deg++
 		}
 	}
@@ -5,8 +5,12 @@
 package simple
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 
+	"golang.org/x/tools/container/intsets"
+
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/matrix/mat64"
@@ -15,11 +19,17 @@ import (
 // UndirectedMatrix represents an undirected graph using an adjacency
 // matrix such that all IDs are in a contiguous block from 0 to n-1.
 // Edges are stored implicitly as an edge weight, so edges stored in
-// the graph are not recoverable.
+// the graph are not recoverable. Removing nodes leaves gaps in the
+// block of IDs until the graph is compacted with Crunch.
 type UndirectedMatrix struct {
 	mat   *mat64.SymDense
 	nodes []graph.Node
 
+	// removed holds the IDs of nodes
+	// within the matrix that have been
+	// removed from the graph.
+	removed intsets.Sparse
+
 	self   float64
 	absent float64
 }
@@ -63,6 +73,33 @@ func NewUndirectedMatrixFrom(nodes []graph.Node, init, self, absent float64) *Un
 	return g
 }
 
+// NewUndirectedMatrixFromGraph creates an undirected dense graph holding the nodes and edges
+// of g, with edge weights taken from the edges of g. The IDs of the nodes of g
+// must be contiguous from 0 to n-1, otherwise an error is returned. The matrix
+// diagonal holds the self connection cost, so an error is also returned if g
+// has a self edge. The self parameter specifies the cost of self connection,
+// and absent specifies the weight returned for absent edges.
+func NewUndirectedMatrixFromGraph(g graph.Undirected, self, absent float64) (*UndirectedMatrix, error) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for i, n := range nodes {
+		if i != n.ID() {
+			return nil, errors.New("simple: non-contiguous node IDs")
+		}
+	}
+	m := NewUndirectedMatrix(len(nodes), absent, self, absent)
+	m.nodes = nodes
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if u.ID() == v.ID() {
+				return nil, fmt.Errorf("simple: self edge on node %d", u.ID())
+			}
+			m.SetEdge(g.Edge(u, v))
+		}
+	}
+	return m, nil
+}
+
 // Node returns the node in the graph with the given ID.
 func (g *UndirectedMatrix) Node(id int) graph.Node {
 	if !g.has(id) {
@@ -81,22 +118,171 @@ func (g *UndirectedMatrix) Has(n graph.Node) bool {
 
 func (g *UndirectedMatrix) has(id int) bool {
 	r := g.mat.Symmetric()
-	return 0 <= id && id < r
+	return 0 <= id && id < r && !g.removed.Has(id)
 }
 
 // Nodes returns all the nodes in the graph.
 func (g *UndirectedMatrix) Nodes() []graph.Node {
+	r := g.mat.Symmetric()
+	nodes := make([]graph.Node, 0, r-g.removed.Len())
+	for i := 0; i < r; i++ {
+		if g.removed.Has(i) {
+			continue
+		}
+		nodes = append(nodes, g.Node(i))
+	}
+	return nodes
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID
+// is the lowest ID of a removed node if there is one, otherwise it is the order
+// of the matrix. The returned ID does not become a valid ID in g until it is added
+// to g.
+func (g *UndirectedMatrix) NewNodeID() int {
+	if g.removed.Len() != 0 {
+		return g.removed.Min()
+	}
+	return g.mat.Symmetric()
+}
+
+// AddNode adds n to the graph with no edges. If the ID of n is beyond the order
+// of the matrix, the matrix is grown to hold it and the IDs between are left as
+// removed nodes. AddNode panics if the added node ID matches an existing node ID
+// or is negative.
+func (g *UndirectedMatrix) AddNode(n graph.Node) {
+	id := n.ID()
+	if id < 0 {
+		panic(fmt.Sprintf("simple: invalid node ID: %d", id))
+	}
+	if g.has(id) {
+		panic(fmt.Sprintf("simple: node ID collision: %d", id))
+	}
+	r := g.mat.Symmetric()
+	if id >= r {
+		g.Grow(id - r + 1)
+		for i := r; i < id; i++ {
+			g.removed.Insert(i)
+			if g.nodes != nil {
+				g.nodes[i] = nil
+			}
+		}
+	}
+	g.removed.Remove(id)
+	if g.nodes == nil {
+		if n == Node(id) {
+			return
+		}
+		g.nodes = make([]graph.Node, g.mat.Symmetric())
+		for i := range g.nodes {
+			if !g.removed.Has(i) {
+				g.nodes[i] = Node(i)
+			}
+		}
+	}
+	g.nodes[id] = n
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. The
+// row and column of the matrix for n are retained until the graph is compacted
+// with Crunch. If the node is not in the graph it is a no-op.
+func (g *UndirectedMatrix) RemoveNode(n graph.Node) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	r := g.mat.Symmetric()
+	for i := 0; i < r; i++ {
+		if i == id {
+			continue
+		}
+		g.mat.SetSym(id, i, g.absent)
+	}
+	g.removed.Insert(id)
+	if g.nodes != nil {
+		g.nodes[id] = nil
+	}
+}
+
+// Grow adds n nodes to the graph with IDs following the current order of the
+// matrix and no edges. The capacity of the matrix is at least doubled when it
+// needs to be reallocated, so repeated growth has amortized linear cost.
+func (g *UndirectedMatrix) Grow(n int) {
+	if n < 0 {
+		panic("simple: negative growth")
+	}
+	if n == 0 {
+		return
+	}
+	r := g.mat.Symmetric()
+	size := r + n
+	// The stride of the matrix is its capacity since
+	// it is never sliced away from its origin.
+	if capacity := g.mat.RawSymmetric().Stride; size > capacity {
+		c := 2 * capacity
+		if c < size {
+			c = size
+		}
+		m := mat64.NewSymDense(c, nil).SliceSquare(0, size).(*mat64.SymDense)
+		m.CopySym(g.mat)
+		g.mat = m
+	} else {
+		g.mat = g.mat.GrowSquare(n).(*mat64.SymDense)
+	}
+	for i := r; i < size; i++ {
+		for j := 0; j < size; j++ {
+			g.mat.SetSym(i, j, g.absent)
+		}
+		g.mat.SetSym(i, i, g.self)
+	}
 	if g.nodes != nil {
-		nodes := make([]graph.Node, len(g.nodes))
-		copy(nodes, g.nodes)
-		return nodes
+		for i := r; i < size; i++ {
+			g.nodes = append(g.nodes, Node(i))
+		}
 	}
+}
+
+// Crunch compacts the graph, removing the rows and columns of removed nodes
+// from the matrix and reassigning node IDs to be contiguous from 0 to n-1 while
+// retaining their order. Crunch returns a mapping from the old node IDs to the
+// new node IDs. Nodes with a changed ID are replaced by Node values.
+func (g *UndirectedMatrix) Crunch() map[int]int {
 	r := g.mat.Symmetric()
-	nodes := make([]graph.Node, r)
+	var keep []int
 	for i := 0; i < r; i++ {
-		nodes[i] = Node(i)
+		if !g.removed.Has(i) {
+			keep = append(keep, i)
+		}
 	}
-	return nodes
+	ids := make(map[int]int, len(keep))
+	for i, id := range keep {
+		ids[id] = i
+	}
+	if len(keep) == r {
+		return ids
+	}
+
+	n := len(keep)
+	m := mat64.NewSymDense(n, nil)
+	for i, u := range keep {
+		for j, v := range keep[i:] {
+			m.SetSym(i, i+j, g.mat.At(u, v))
+		}
+	}
+	g.mat = m
+	if g.nodes != nil {
+		nodes := make([]graph.Node, n)
+		for i, id := range keep {
+			if i == id {
+				nodes[i] = g.nodes[id]
+			} else {
+				nodes[i] = Node(i)
+			}
+		}
+		g.nodes = nodes
+	}
+	g.removed.Clear()
+
+	return ids
 }
 
 // Edges returns all the edges in the graph.
@@ -113,6 +299,41 @@ func (g *UndirectedMatrix) Edges() []graph.Edge {
 	return edges
 }
 
+// Order returns the number of nodes in g.
+func (g *UndirectedMatrix) Order() int {
+	r, _ := g.mat.Dims()
+	return r - g.removed.Len()
+}
+
+// Size returns the number of edges in g.
+func (g *UndirectedMatrix) Size() int {
+	var n int
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if !isSame(g.mat.At(i, j), g.absent) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+// Each edge is visited once.
+func (g *UndirectedMatrix) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	r, _ := g.mat.Dims()
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if w := g.mat.At(i, j); !isSame(w, g.absent) {
+				if !fn(Edge{F: g.Node(i), T: g.Node(j), W: w}, w) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // From returns all nodes in g that can be reached directly from n.
 func (g *UndirectedMatrix) From(n graph.Node) []graph.Node {
 	id := n.ID()
@@ -132,6 +353,26 @@ func (g *UndirectedMatrix) From(n graph.Node) []graph.Node {
 	return neighbors
 }
 
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *UndirectedMatrix) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	id := n.ID()
+	if !g.has(id) {
+		return
+	}
+	r := g.mat.Symmetric()
+	for i := 0; i < r; i++ {
+		if i == id {
+			continue
+		}
+		if w := g.mat.At(id, i); !isSame(w, g.absent) {
+			if !fn(g.Node(i), w) {
+				return
+			}
+		}
+	}
+}
+
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
 func (g *UndirectedMatrix) HasEdgeBetween(u, v graph.Node) bool {
 	uid := u.ID()
@@ -169,32 +410,35 @@ func (g *UndirectedMatrix) Weight(x, y graph.Node) (w float64, ok bool) {
 	if xid == yid {
 		return g.self, true
 	}
-	if g.has(xid) && g.has(yid) {
+	if g.HasEdgeBetween(x, y) {
 		return g.mat.At(xid, yid), true
 	}
 	return g.absent, false
 }
 
-// SetEdge sets e, an edge from one node to another. If the ends of the edge are not in g
-// or the edge is a self loop, SetEdge panics.
+// SetEdge sets e, an edge between two nodes. The edge is held symmetrically, so the order
+// of the terminal nodes is not significant. If the ends of the edge are not in g or the
+// edge is a self loop, SetEdge panics.
 func (g *UndirectedMatrix) SetEdge(e graph.Edge) {
 	fid := e.From().ID()
 	tid := e.To().ID()
-	if fid == tid {
+	if fid == tid || !g.has(fid) || !g.has(tid) {
 		panic("simple: set illegal edge")
 	}
 	g.mat.SetSym(fid, tid, e.Weight())
 }
 
-// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
-// it is a no-op.
+// RemoveEdge removes e from the graph, leaving the terminal nodes. The order of the terminal
+// nodes is not significant. If the edge does not exist or is a self edge it is a no-op.
 func (g *UndirectedMatrix) RemoveEdge(e graph.Edge) {
 	fid := e.From().ID()
 	if !g.has(fid) {
 		return
 	}
 	tid := e.To().ID()
-	if !g.has(tid) {
+	if !g.has(tid) || fid == tid {
+		// The diagonal holds the self connection
+		// cost, which is not an edge.
 		return
 	}
 	g.mat.SetSym(fid, tid, g.absent)
@@ -203,6 +447,9 @@ func (g *UndirectedMatrix) RemoveEdge(e graph.Edge) {
 // Degree returns the degree of n in g.
 func (g *UndirectedMatrix) Degree(n graph.Node) int {
 	id := n.ID()
+	if !g.has(id) {
+		return 0
+	}
 	var deg int
 	r := g.mat.Symmetric()
 	for i := 0; i < r; i++ {
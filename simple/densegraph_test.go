@@ -6,16 +6,31 @@ package simple
 
 import (
 	"math"
+	"reflect"
 	"sort"
 	"testing"
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/matrix/mat64"
 )
 
 var (
 	_ graph.Graph    = (*UndirectedMatrix)(nil)
 	_ graph.Directed = (*DirectedMatrix)(nil)
+
+	_ graph.Builder     = (*UndirectedMatrix)(nil)
+	_ graph.Builder     = (*DirectedMatrix)(nil)
+	_ graph.NodeRemover = (*UndirectedMatrix)(nil)
+	_ graph.NodeRemover = (*DirectedMatrix)(nil)
+
+	// The path search functions use these
+	// interfaces when they are available.
+	_ graph.Undirected  = (*UndirectedMatrix)(nil)
+	_ graph.Weighter    = (*UndirectedMatrix)(nil)
+	_ graph.Weighter    = (*DirectedMatrix)(nil)
+	_ graph.FromVisitor = (*UndirectedMatrix)(nil)
+	_ graph.FromVisitor = (*DirectedMatrix)(nil)
 )
 
 func TestBasicDenseImpassable(t *testing.T) {
@@ -138,3 +153,281 @@ func TestDenseLists(t *testing.T) {
 		t.Errorf("Removing edge didn't affect edge listing properly")
 	}
 }
+
+func TestWeightExistence(t *testing.T) {
+	for _, g := range []interface {
+		graph.Weighter
+		graph.EdgeSetter
+	}{
+		NewDirectedMatrix(4, math.Inf(1), 0, math.Inf(1)),
+		NewUndirectedMatrix(4, math.Inf(1), 0, math.Inf(1)),
+		NewDirectedGraph(0, math.Inf(1)),
+		NewUndirectedGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 2})
+		if a, ok := g.(graph.NodeAdder); ok && !g.(graph.Graph).Has(Node(2)) {
+			a.AddNode(Node(2))
+		}
+
+		if w, ok := g.Weight(Node(0), Node(1)); w != 2 || !ok {
+			t.Errorf("unexpected weight for existing edge in %T: got:(%v, %t) want:(2, true)", g, w, ok)
+		}
+		if w, ok := g.Weight(Node(1), Node(1)); w != 0 || !ok {
+			t.Errorf("unexpected weight for self in %T: got:(%v, %t) want:(0, true)", g, w, ok)
+		}
+		if w, ok := g.Weight(Node(0), Node(2)); !math.IsInf(w, 1) || ok {
+			t.Errorf("unexpected weight for absent edge in %T: got:(%v, %t) want:(+Inf, false)", g, w, ok)
+		}
+		if w, ok := g.Weight(Node(0), Node(10)); !math.IsInf(w, 1) || ok {
+			t.Errorf("unexpected weight for absent node in %T: got:(%v, %t) want:(+Inf, false)", g, w, ok)
+		}
+	}
+}
+
+func TestDenseResize(t *testing.T) {
+	type resizable interface {
+		graph.Graph
+		graph.Builder
+		graph.NodeRemover
+		graph.Weighter
+		Grow(int)
+		Crunch() map[int]int
+		Edges() []graph.Edge
+	}
+	for _, g := range []resizable{
+		NewDirectedMatrix(0, math.Inf(1), 0, math.Inf(1)),
+		NewUndirectedMatrix(0, math.Inf(1), 0, math.Inf(1)),
+		NewDirectedMatrix(2, math.Inf(1), 0, math.Inf(1)),
+		NewUndirectedMatrix(2, math.Inf(1), 0, math.Inf(1)),
+	} {
+		for i := len(g.Nodes()); i < 4; i++ {
+			if id := g.NewNodeID(); id != i {
+				t.Errorf("unexpected new node ID for %T: got:%d want:%d", g, id, i)
+			}
+			g.AddNode(Node(i))
+		}
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+		g.SetEdge(Edge{F: Node(2), T: Node(3), W: 3})
+
+		g.RemoveNode(Node(1))
+		if g.Has(Node(1)) {
+			t.Errorf("removed node still present in %T", g)
+		}
+		if g.HasEdgeBetween(Node(0), Node(1)) || g.HasEdgeBetween(Node(1), Node(2)) {
+			t.Errorf("edge of removed node still present in %T", g)
+		}
+		if id := g.NewNodeID(); id != 1 {
+			t.Errorf("unexpected new node ID for %T after removal: got:%d want:1", g, id)
+		}
+
+		g.Grow(3)
+		if len(g.Nodes()) != 6 {
+			t.Errorf("unexpected number of nodes in %T after growth: got:%d want:6", g, len(g.Nodes()))
+		}
+		g.SetEdge(Edge{F: Node(0), T: Node(5), W: 5})
+		g.AddNode(Node(9))
+		if len(g.Nodes()) != 7 || g.Has(Node(8)) {
+			t.Errorf("unexpected nodes in %T after adding beyond order: %v", g, g.Nodes())
+		}
+		g.SetEdge(Edge{F: Node(9), T: Node(3), W: 9})
+		g.RemoveNode(Node(4))
+
+		ids := g.Crunch()
+		wantIDs := map[int]int{0: 0, 2: 1, 3: 2, 5: 3, 6: 4, 9: 5}
+		if !reflect.DeepEqual(ids, wantIDs) {
+			t.Errorf("unexpected ID mapping for %T:\ngot: %v\nwant:%v", g, ids, wantIDs)
+		}
+		nodes := g.Nodes()
+		sort.Sort(ordered.ByID(nodes))
+		for i, n := range nodes {
+			if n.ID() != i {
+				t.Errorf("unexpected node IDs after crunch in %T: %v", g, nodes)
+				break
+			}
+		}
+		if len(g.Edges()) != 3 {
+			t.Errorf("unexpected number of edges after crunch in %T: got:%d want:3", g, len(g.Edges()))
+		}
+		for _, e := range []Edge{
+			{F: Node(ids[2]), T: Node(ids[3]), W: 3},
+			{F: Node(ids[0]), T: Node(ids[5]), W: 5},
+			{F: Node(ids[9]), T: Node(ids[3]), W: 9},
+		} {
+			if w, ok := g.Weight(e.F, e.T); !ok || w != e.W {
+				t.Errorf("unexpected weight from %d to %d after crunch in %T: got:(%v, %t) want:(%v, true)",
+					e.F.ID(), e.T.ID(), g, w, ok, e.W)
+			}
+		}
+	}
+}
+
+func TestDenseSetRemoveEdge(t *testing.T) {
+	for _, test := range []struct {
+		g interface {
+			graph.Graph
+			graph.Weighter
+			graph.EdgeSetter
+			graph.EdgeRemover
+			Matrix() mat64.Matrix
+		}
+		directed bool
+	}{
+		{g: NewDirectedMatrix(3, math.Inf(1), 0, math.Inf(1)), directed: true},
+		{g: NewUndirectedMatrix(3, math.Inf(1), 0, math.Inf(1)), directed: false},
+	} {
+		g := test.g
+		u, v := Node(0), Node(1)
+
+		g.SetEdge(Edge{F: u, T: v, W: 1})
+		if w, ok := g.Weight(u, v); w != 1 || !ok {
+			t.Errorf("unexpected weight for set edge in %T: got:(%v, %t) want:(1, true)", g, w, ok)
+		}
+		if w, ok := g.Weight(v, u); ok == test.directed {
+			t.Errorf("unexpected reverse edge existence in %T: got:(%v, %t) want:%t", g, w, ok, !test.directed)
+		}
+
+		g.SetEdge(Edge{F: u, T: v, W: 2})
+		if w, _ := g.Weight(u, v); w != 2 {
+			t.Errorf("unexpected weight for overwritten edge in %T: got:%v want:2", g, w)
+		}
+		if !test.directed {
+			if w, _ := g.Weight(v, u); w != 2 {
+				t.Errorf("unexpected weight for overwritten reverse edge in %T: got:%v want:2", g, w)
+			}
+		}
+
+		if test.directed {
+			g.SetEdge(Edge{F: v, T: u, W: 3})
+			g.RemoveEdge(Edge{F: u, T: v})
+			if g.Edge(u, v) != nil {
+				t.Errorf("removed edge still present in %T", g)
+			}
+			if w, ok := g.Weight(v, u); w != 3 || !ok {
+				t.Errorf("removing edge affected antiparallel edge in %T: got:(%v, %t) want:(3, true)", g, w, ok)
+			}
+			g.RemoveEdge(Edge{F: v, T: u})
+		} else {
+			g.RemoveEdge(Edge{F: v, T: u})
+		}
+		if g.HasEdgeBetween(u, v) {
+			t.Errorf("removed edge still present in %T", g)
+		}
+		if w, ok := g.Weight(u, v); !math.IsInf(w, 1) || ok {
+			t.Errorf("unexpected weight for removed edge in %T: got:(%v, %t) want:(+Inf, false)", g, w, ok)
+		}
+
+		// Removing an absent edge or an edge with
+		// absent terminal nodes is a no-op.
+		g.RemoveEdge(Edge{F: u, T: v})
+		g.RemoveEdge(Edge{F: u, T: Node(10)})
+
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			g.SetEdge(Edge{F: u, T: u, W: 1})
+			return
+		}()
+		if !panicked {
+			t.Errorf("expected panic for self edge in %T", g)
+		}
+		if w, ok := g.Weight(u, u); w != 0 || !ok {
+			t.Errorf("unexpected weight for self in %T after failed self edge: got:(%v, %t) want:(0, true)", g, w, ok)
+		}
+
+		// Removing a self edge must not alter the
+		// self connection cost held on the diagonal.
+		g.RemoveEdge(Edge{F: u, T: u})
+		if w := g.Matrix().At(0, 0); w != 0 {
+			t.Errorf("unexpected diagonal in %T after removing self edge: got:%v want:0", g, w)
+		}
+		if g.HasEdgeBetween(u, u) || len(g.From(u)) != 0 {
+			t.Errorf("unexpected self edge in %T after removing self edge", g)
+		}
+	}
+}
+
+func TestDenseOutOfRange(t *testing.T) {
+	const n = 3
+	for _, g := range []interface {
+		graph.Graph
+		graph.Weighter
+		graph.EdgeSetter
+		graph.EdgeRemover
+		Node(int) graph.Node
+		Degree(graph.Node) int
+	}{
+		NewDirectedMatrix(n, 1, 0, math.Inf(1)),
+		NewUndirectedMatrix(n, 1, 0, math.Inf(1)),
+		NewDirectedBitMatrix(n),
+		NewUndirectedBitMatrix(n),
+	} {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j {
+					g.SetEdge(Edge{F: Node(i), T: Node(j), W: 1})
+				}
+			}
+		}
+
+		for _, id := range []int{-1, n, n + 1} {
+			x := Node(id)
+			if g.Has(x) {
+				t.Errorf("unexpected node %d in %T", id, g)
+			}
+			if g.Node(id) != nil {
+				t.Errorf("unexpected non-nil node %d from %T", id, g)
+			}
+			if g.From(x) != nil {
+				t.Errorf("unexpected neighbors of absent node %d in %T", id, g)
+			}
+			if d := g.Degree(x); d != 0 {
+				t.Errorf("unexpected degree of absent node %d in %T: got:%d want:0", id, g, d)
+			}
+			if d, ok := g.(graph.Directed); ok && d.To(x) != nil {
+				t.Errorf("unexpected predecessors of absent node %d in %T", id, g)
+			}
+
+			for _, y := range []graph.Node{Node(0), Node(n - 1)} {
+				for _, e := range [][2]graph.Node{{x, y}, {y, x}} {
+					u, v := e[0], e[1]
+					if g.Edge(u, v) != nil || g.HasEdgeBetween(u, v) {
+						t.Errorf("unexpected edge between %d and %d in %T", u.ID(), v.ID(), g)
+					}
+					if d, ok := g.(graph.Directed); ok && d.HasEdgeFromTo(u, v) {
+						t.Errorf("unexpected edge from %d to %d in %T", u.ID(), v.ID(), g)
+					}
+					if w, ok := g.Weight(u, v); !math.IsInf(w, 1) || ok {
+						t.Errorf("unexpected weight between %d and %d in %T: got:(%v, %t) want:(+Inf, false)",
+							u.ID(), v.ID(), g, w, ok)
+					}
+
+					// Removing an edge to an absent node is a no-op.
+					g.RemoveEdge(Edge{F: u, T: v})
+
+					panicked := func() (panicked bool) {
+						defer func() {
+							panicked = recover() != nil
+						}()
+						g.SetEdge(Edge{F: u, T: v, W: 1})
+						return
+					}()
+					if !panicked {
+						t.Errorf("expected panic setting edge between %d and %d in %T", u.ID(), v.ID(), g)
+					}
+				}
+			}
+		}
+
+		// Failed operations must not have altered
+		// the edges between present nodes.
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && !g.HasEdgeBetween(Node(i), Node(j)) {
+					t.Errorf("edge between %d and %d lost after out of range operations in %T", i, j, g)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// Diff returns the differences between the graphs prev and next as reported
+// by graph.Diff with zero tolerance. Edges joining the same nodes in prev and
+// next that have different weights are returned in changedEdges as pairs of
+// the edge in prev and the edge in next.
+func Diff(prev, next graph.Graph) (addedNodes, removedNodes []graph.Node, addedEdges, removedEdges []graph.Edge, changedEdges [][2]graph.Edge) {
+	d := graph.Diff(prev, next, 0)
+	return d.AddedNodes, d.RemovedNodes, d.AddedEdges, d.RemovedEdges, d.ChangedEdges
+}
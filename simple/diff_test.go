@@ -0,0 +1,114 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+// edgeIDs returns the terminal node IDs and weights of edges.
+func edgeIDs(edges []graph.Edge) [][3]float64 {
+	var ids [][3]float64
+	for _, e := range edges {
+		ids = append(ids, [3]float64{float64(e.From().ID()), float64(e.To().ID()), e.Weight()})
+	}
+	return ids
+}
+
+func nodeIDs(nodes []graph.Node) []int {
+	var ids []int
+	for _, n := range nodes {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
+
+func TestDiffDirected(t *testing.T) {
+	prev := NewDirectedGraph(0, math.Inf(1))
+	prev.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	prev.SetEdge(Edge{F: Node(1), T: Node(2), W: 1})
+	prev.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+	prev.AddNode(Node(4))
+
+	next := NewDirectedGraph(0, math.Inf(1))
+	next.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	next.SetEdge(Edge{F: Node(2), T: Node(1), W: 1})
+	next.SetEdge(Edge{F: Node(2), T: Node(3), W: 2})
+	next.SetEdge(Edge{F: Node(3), T: Node(5), W: 1})
+
+	addedNodes, removedNodes, addedEdges, removedEdges, changedEdges := Diff(prev, next)
+	if got, want := nodeIDs(addedNodes), []int{5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected added nodes: got:%v want:%v", got, want)
+	}
+	if got, want := nodeIDs(removedNodes), []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected removed nodes: got:%v want:%v", got, want)
+	}
+	if got, want := edgeIDs(addedEdges), [][3]float64{{2, 1, 1}, {3, 5, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected added edges: got:%v want:%v", got, want)
+	}
+	if got, want := edgeIDs(removedEdges), [][3]float64{{1, 2, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected removed edges: got:%v want:%v", got, want)
+	}
+	if len(changedEdges) != 1 {
+		t.Fatalf("unexpected number of changed edges: got:%d want:1", len(changedEdges))
+	}
+	if got, want := edgeIDs(changedEdges[0][:]), [][3]float64{{2, 3, 1}, {2, 3, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected changed edge: got:%v want:%v", got, want)
+	}
+
+	addedNodes, removedNodes, addedEdges, removedEdges, changedEdges = Diff(next, next)
+	if addedNodes != nil || removedNodes != nil || addedEdges != nil || removedEdges != nil || changedEdges != nil {
+		t.Errorf("unexpected difference between identical graphs: %v %v %v %v %v",
+			addedNodes, removedNodes, addedEdges, removedEdges, changedEdges)
+	}
+}
+
+func TestDiffUndirected(t *testing.T) {
+	prev := NewUndirectedGraph(0, math.Inf(1))
+	prev.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	prev.SetEdge(Edge{F: Node(1), T: Node(2), W: 1})
+
+	// The edge between 0 and 1 is held in the opposite
+	// orientation in next but is the same edge.
+	next := NewUndirectedMatrix(4, math.Inf(1), 0, math.Inf(1))
+	next.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	next.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+
+	addedNodes, removedNodes, addedEdges, removedEdges, changedEdges := Diff(prev, next)
+	if got, want := nodeIDs(addedNodes), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected added nodes: got:%v want:%v", got, want)
+	}
+	if removedNodes != nil {
+		t.Errorf("unexpected removed nodes: got:%v want:[]", nodeIDs(removedNodes))
+	}
+	if got, want := edgeIDs(addedEdges), [][3]float64{{2, 3, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected added edges: got:%v want:%v", got, want)
+	}
+	if got, want := edgeIDs(removedEdges), [][3]float64{{1, 2, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected removed edges: got:%v want:%v", got, want)
+	}
+	if changedEdges != nil {
+		t.Errorf("unexpected changed edges: got:%v", changedEdges)
+	}
+}
+
+func TestDiffMixed(t *testing.T) {
+	// A directed graph is compared with an
+	// undirected graph as an undirected graph.
+	prev := NewDirectedGraph(0, math.Inf(1))
+	prev.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	next := NewUndirectedGraph(0, math.Inf(1))
+	next.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+
+	addedNodes, removedNodes, addedEdges, removedEdges, changedEdges := Diff(prev, next)
+	if addedNodes != nil || removedNodes != nil || addedEdges != nil || removedEdges != nil || changedEdges != nil {
+		t.Errorf("unexpected difference between mixed graphs: %v %v %v %v %v",
+			addedNodes, removedNodes, addedEdges, removedEdges, changedEdges)
+	}
+}
@@ -6,20 +6,33 @@ package simple
 
 import (
 	"fmt"
+	"sort"
 
 	"golang.org/x/tools/container/intsets"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
 )
 
 // DirectedGraph implements a generalized directed graph.
 type DirectedGraph struct {
-	nodes map[int]graph.Node
-	from  map[int]map[int]graph.Edge
-	to    map[int]map[int]graph.Edge
+	// nodes holds the nodes of the graph
+	// densely packed, with indexOf mapping
+	// node IDs to positions in nodes.
+	nodes   []graph.Node
+	indexOf map[int]int
+
+	// from and to hold the outbound and
+	// inbound edges of each node, in the
+	// same order as nodes.
+	from []edgeList
+	to   []edgeList
+	size int
 
 	self, absent float64
 
+	obs observers
+
 	freeIDs intsets.Sparse
 	usedIDs intsets.Sparse
 }
@@ -28,9 +41,7 @@ type DirectedGraph struct {
 // edge weight values.
 func NewDirectedGraph(self, absent float64) *DirectedGraph {
 	return &DirectedGraph{
-		nodes: make(map[int]graph.Node),
-		from:  make(map[int]map[int]graph.Edge),
-		to:    make(map[int]map[int]graph.Edge),
+		indexOf: make(map[int]int),
 
 		self:   self,
 		absent: absent,
@@ -64,40 +75,70 @@ func (g *DirectedGraph) NewNodeID() int {
 
 // AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
 func (g *DirectedGraph) AddNode(n graph.Node) {
-	if _, exists := g.nodes[n.ID()]; exists {
+	if _, exists := g.indexOf[n.ID()]; exists {
 		panic(fmt.Sprintf("simple: node ID collision: %d", n.ID()))
 	}
-	g.nodes[n.ID()] = n
-	g.from[n.ID()] = make(map[int]graph.Edge)
-	g.to[n.ID()] = make(map[int]graph.Edge)
+	g.indexOf[n.ID()] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+	g.from = append(g.from, nil)
+	g.to = append(g.to, nil)
 
 	g.freeIDs.Remove(n.ID())
 	g.usedIDs.Insert(n.ID())
+
+	g.obs.nodeAdded(n)
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
 // is not in the graph it is a no-op.
 func (g *DirectedGraph) RemoveNode(n graph.Node) {
-	if _, ok := g.nodes[n.ID()]; !ok {
+	id := n.ID()
+	i, ok := g.indexOf[id]
+	if !ok {
 		return
 	}
-	delete(g.nodes, n.ID())
+	n = g.nodes[i]
+	var removed []graph.Edge
+	if len(g.obs) != 0 {
+		removed = append(g.from[i].edges(), g.to[i].edges()...)
+	}
 
-	for from := range g.from[n.ID()] {
-		delete(g.to[from], n.ID())
+	for _, e := range g.from[i] {
+		g.to[g.indexOf[e.id]].remove(id)
 	}
-	delete(g.from, n.ID())
+	for _, e := range g.to[i] {
+		g.from[g.indexOf[e.id]].remove(id)
+	}
+	g.size -= len(g.from[i]) + len(g.to[i])
 
-	for to := range g.to[n.ID()] {
-		delete(g.from[to], n.ID())
+	// Move the last node into the vacated slot.
+	last := len(g.nodes) - 1
+	if i != last {
+		g.nodes[i] = g.nodes[last]
+		g.from[i] = g.from[last]
+		g.to[i] = g.to[last]
+		g.indexOf[g.nodes[i].ID()] = i
 	}
-	delete(g.to, n.ID())
+	g.nodes[last] = nil
+	g.from[last] = nil
+	g.to[last] = nil
+	g.nodes = g.nodes[:last]
+	g.from = g.from[:last]
+	g.to = g.to[:last]
+	delete(g.indexOf, id)
+
+	g.freeIDs.Insert(id)
+	g.usedIDs.Remove(id)
 
-	g.freeIDs.Insert(n.ID())
-	g.usedIDs.Remove(n.ID())
+	for _, e := range removed {
+		g.obs.edgeRemoved(e)
+	}
+	g.obs.nodeRemoved(n)
 }
 
 // SetEdge adds e, an edge from one node to another. If the nodes do not exist, they are added.
+// If an edge from e.From to e.To already exists it is replaced by e, so the weight of the
+// edge becomes the weight of e; the graph does not hold parallel edges.
 // It will panic if the IDs of the e.From and e.To are equal.
 func (g *DirectedGraph) SetEdge(e graph.Edge) {
 	var (
@@ -118,90 +159,217 @@ func (g *DirectedGraph) SetEdge(e graph.Edge) {
 		g.AddNode(to)
 	}
 
-	g.from[fid][tid] = e
-	g.to[tid][fid] = e
+	old, replaced := g.Weight(from, to)
+	if g.from[g.indexOf[fid]].set(tid, e) {
+		g.size++
+	}
+	g.to[g.indexOf[tid]].set(fid, e)
+
+	if replaced {
+		g.obs.edgeWeightChanged(e, old)
+	} else {
+		g.obs.edgeAdded(e)
+	}
 }
 
 // RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
 // it is a no-op.
 func (g *DirectedGraph) RemoveEdge(e graph.Edge) {
-	from, to := e.From(), e.To()
-	if _, ok := g.nodes[from.ID()]; !ok {
+	fid, tid := e.From().ID(), e.To().ID()
+	i, ok := g.indexOf[fid]
+	if !ok {
+		return
+	}
+	j, ok := g.indexOf[tid]
+	if !ok {
+		return
+	}
+
+	k, ok := g.from[i].find(tid)
+	if !ok {
+		return
+	}
+	removed := g.from[i][k].edge
+	g.from[i].remove(tid)
+	g.to[j].remove(fid)
+	g.size--
+
+	g.obs.edgeRemoved(removed)
+}
+
+// ClearEdges removes all the edges from g, leaving its nodes.
+func (g *DirectedGraph) ClearEdges() {
+	var removed []graph.Edge
+	if len(g.obs) != 0 {
+		removed = g.Edges()
+	}
+	for i := range g.nodes {
+		g.from[i] = nil
+		g.to[i] = nil
+	}
+	g.size = 0
+
+	for _, e := range removed {
+		g.obs.edgeRemoved(e)
+	}
+}
+
+// SetEdgeWeight sets the weight of the edge from the From node of e to the
+// To node of e to w. The weight of the stored edge is changed in both the
+// outbound and inbound edge lists of its nodes. If the stored edge is a
+// Reweighter, the edge returned by its WithWeight method is stored,
+// retaining its type and any label, otherwise it is replaced by an Edge
+// holding its terminal nodes and the new weight. If the edge is not in the
+// graph, e is added with the weight w as described for SetEdge.
+func (g *DirectedGraph) SetEdgeWeight(e graph.Edge, w float64) {
+	fid, tid := e.From().ID(), e.To().ID()
+	i, ok := g.indexOf[fid]
+	if !ok {
+		g.SetEdge(reweighted(e, w))
 		return
 	}
-	if _, ok := g.nodes[to.ID()]; !ok {
+	k, ok := g.from[i].find(tid)
+	if !ok {
+		g.SetEdge(reweighted(e, w))
 		return
 	}
+	old := g.from[i][k].edge
+	ne := reweighted(old, w)
+	g.from[i][k].edge = ne
+	g.to[g.indexOf[tid]].set(fid, ne)
 
-	delete(g.from[from.ID()], to.ID())
-	delete(g.to[to.ID()], from.ID())
+	g.obs.edgeWeightChanged(ne, old.Weight())
 }
 
 // Node returns the node in the graph with the given ID.
 func (g *DirectedGraph) Node(id int) graph.Node {
-	return g.nodes[id]
+	i, ok := g.indexOf[id]
+	if !ok {
+		return nil
+	}
+	return g.nodes[i]
 }
 
 // Has returns whether the node exists within the graph.
 func (g *DirectedGraph) Has(n graph.Node) bool {
-	_, ok := g.nodes[n.ID()]
+	_, ok := g.indexOf[n.ID()]
 
 	return ok
 }
 
 // Nodes returns all the nodes in the graph.
 func (g *DirectedGraph) Nodes() []graph.Node {
-	nodes := make([]graph.Node, len(g.from))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
-	}
+	nodes := make([]graph.Node, len(g.nodes))
+	copy(nodes, g.nodes)
 
 	return nodes
 }
 
+// NodesSorted returns all the nodes in the graph sorted ascending by ID.
+// Unlike Nodes, the order of the returned nodes is deterministic.
+func (g *DirectedGraph) NodesSorted() []graph.Node {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
 // Edges returns all the edges in the graph.
 func (g *DirectedGraph) Edges() []graph.Edge {
 	var edges []graph.Edge
-	for _, u := range g.nodes {
-		for _, e := range g.from[u.ID()] {
-			edges = append(edges, e)
+	for _, l := range g.from {
+		for _, e := range l {
+			edges = append(edges, e.edge)
 		}
 	}
 	return edges
 }
 
+// Order returns the number of nodes in g.
+func (g *DirectedGraph) Order() int {
+	return len(g.nodes)
+}
+
+// Size returns the number of edges in g. Edges in opposite directions
+// between a pair of nodes are counted separately.
+func (g *DirectedGraph) Size() int {
+	return g.size
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+func (g *DirectedGraph) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for _, l := range g.from {
+		for _, e := range l {
+			if !fn(e.edge, e.edge.Weight()) {
+				return
+			}
+		}
+	}
+}
+
 // From returns all nodes in g that can be reached directly from n.
 func (g *DirectedGraph) From(n graph.Node) []graph.Node {
-	if _, ok := g.from[n.ID()]; !ok {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
 		return nil
 	}
-
-	from := make([]graph.Node, len(g.from[n.ID()]))
-	i := 0
-	for id := range g.from[n.ID()] {
-		from[i] = g.nodes[id]
-		i++
-	}
-
-	return from
+	return g.nodesOf(g.from[i])
 }
 
 // To returns all nodes in g that can reach directly to n.
 func (g *DirectedGraph) To(n graph.Node) []graph.Node {
-	if _, ok := g.from[n.ID()]; !ok {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
 		return nil
 	}
+	return g.nodesOf(g.to[i])
+}
 
-	to := make([]graph.Node, len(g.to[n.ID()]))
-	i := 0
-	for id := range g.to[n.ID()] {
-		to[i] = g.nodes[id]
-		i++
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *DirectedGraph) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return
+	}
+	for _, e := range g.from[i] {
+		if !fn(g.nodes[g.indexOf[e.id]], e.edge.Weight()) {
+			return
+		}
 	}
+}
+
+// IncidentEdges returns the edges leaving n, out, and the edges entering n, in.
+// The edges are ordered by the ID of the node at the opposite end of the edge
+// from n. If n is not in g, IncidentEdges returns nil slices.
+func (g *DirectedGraph) IncidentEdges(n graph.Node) (out, in []graph.Edge) {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return nil, nil
+	}
+	return g.from[i].edges(), g.to[i].edges()
+}
+
+// VisitTo calls fn with each node that can reach directly to n and the weight
+// of the joining edge until fn returns false.
+func (g *DirectedGraph) VisitTo(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return
+	}
+	for _, e := range g.to[i] {
+		if !fn(g.nodes[g.indexOf[e.id]], e.edge.Weight()) {
+			return
+		}
+	}
+}
 
-	return to
+// nodesOf returns the nodes at the far end of the edges in l.
+func (g *DirectedGraph) nodesOf(l edgeList) []graph.Node {
+	nodes := make([]graph.Node, len(l))
+	for k, e := range l {
+		nodes[k] = g.nodes[g.indexOf[e.id]]
+	}
+	return nodes
 }
 
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
@@ -209,47 +377,49 @@ func (g *DirectedGraph) To(n graph.Node) []graph.Node {
 func (g *DirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
 	xid := x.ID()
 	yid := y.ID()
-	if _, ok := g.nodes[xid]; !ok {
+	i, ok := g.indexOf[xid]
+	if !ok {
 		return false
 	}
-	if _, ok := g.nodes[yid]; !ok {
+	j, ok := g.indexOf[yid]
+	if !ok {
 		return false
 	}
-	if _, ok := g.from[xid][yid]; ok {
+	if _, ok := g.from[i].find(yid); ok {
 		return true
 	}
-	_, ok := g.from[yid][xid]
+	_, ok = g.from[j].find(xid)
 	return ok
 }
 
 // Edge returns the edge from u to v if such an edge exists and nil otherwise.
 // The node v must be directly reachable from u as defined by the From method.
 func (g *DirectedGraph) Edge(u, v graph.Node) graph.Edge {
-	if _, ok := g.nodes[u.ID()]; !ok {
+	i, ok := g.indexOf[u.ID()]
+	if !ok {
 		return nil
 	}
-	if _, ok := g.nodes[v.ID()]; !ok {
+	if _, ok := g.indexOf[v.ID()]; !ok {
 		return nil
 	}
-	edge, ok := g.from[u.ID()][v.ID()]
+	k, ok := g.from[i].find(v.ID())
 	if !ok {
 		return nil
 	}
-	return edge
+	return g.from[i][k].edge
 }
 
 // HasEdgeFromTo returns whether an edge exists in the graph from u to v.
 func (g *DirectedGraph) HasEdgeFromTo(u, v graph.Node) bool {
-	if _, ok := g.nodes[u.ID()]; !ok {
-		return false
-	}
-	if _, ok := g.nodes[v.ID()]; !ok {
+	i, ok := g.indexOf[u.ID()]
+	if !ok {
 		return false
 	}
-	if _, ok := g.from[u.ID()][v.ID()]; !ok {
+	if _, ok := g.indexOf[v.ID()]; !ok {
 		return false
 	}
-	return true
+	_, ok = g.from[i].find(v.ID())
+	return ok
 }
 
 // Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
@@ -262,19 +432,97 @@ func (g *DirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
 	if xid == yid {
 		return g.self, true
 	}
-	if to, ok := g.from[xid]; ok {
-		if e, ok := to[yid]; ok {
-			return e.Weight(), true
+	if i, ok := g.indexOf[xid]; ok {
+		if k, ok := g.from[i].find(yid); ok {
+			return g.from[i][k].edge.Weight(), true
 		}
 	}
 	return g.absent, false
 }
 
-// Degree returns the in+out degree of n in g.
+// Degree returns the in+out degree of n in g, the sum of
+// its in-degree and out-degree.
 func (g *DirectedGraph) Degree(n graph.Node) int {
-	if _, ok := g.nodes[n.ID()]; !ok {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
 		return 0
 	}
 
-	return len(g.from[n.ID()]) + len(g.to[n.ID()])
+	return len(g.from[i]) + len(g.to[i])
+}
+
+// InDegree returns the number of edges leading to n in g. Since g holds no
+// self edges, the in-degree of n is the number of nodes returned by To(n).
+func (g *DirectedGraph) InDegree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return len(g.to[i])
+}
+
+// OutDegree returns the number of edges leading from n in g. Since g holds no
+// self edges, the out-degree of n is the number of nodes returned by From(n).
+func (g *DirectedGraph) OutDegree(n graph.Node) int {
+	i, ok := g.indexOf[n.ID()]
+	if !ok {
+		return 0
+	}
+	return len(g.from[i])
+}
+
+// halfEdge is an edge held in the adjacency list of one of its
+// terminal nodes, keyed by the ID of the node at the other end.
+type halfEdge struct {
+	id   int
+	edge graph.Edge
+}
+
+// edgeList is an adjacency list sorted ascending by node ID.
+type edgeList []halfEdge
+
+// find returns the index of the edge to the node with the given
+// ID and whether it exists in l.
+func (l edgeList) find(id int) (int, bool) {
+	k := sort.Search(len(l), func(k int) bool { return l[k].id >= id })
+	return k, k < len(l) && l[k].id == id
+}
+
+// set inserts or replaces the edge to the node with the given ID,
+// returning whether the edge was inserted.
+func (l *edgeList) set(id int, e graph.Edge) (inserted bool) {
+	k, ok := l.find(id)
+	if ok {
+		(*l)[k].edge = e
+		return false
+	}
+	*l = append(*l, halfEdge{})
+	copy((*l)[k+1:], (*l)[k:])
+	(*l)[k] = halfEdge{id: id, edge: e}
+	return true
+}
+
+// remove deletes the edge to the node with the given ID if it exists,
+// returning whether the edge was deleted.
+func (l *edgeList) remove(id int) (removed bool) {
+	k, ok := l.find(id)
+	if !ok {
+		return false
+	}
+	copy((*l)[k:], (*l)[k+1:])
+	(*l)[len(*l)-1] = halfEdge{}
+	*l = (*l)[:len(*l)-1]
+	return true
+}
+
+// edges returns the edges held in l.
+func (l edgeList) edges() []graph.Edge {
+	if len(l) == 0 {
+		return nil
+	}
+	edges := make([]graph.Edge, len(l))
+	for k, e := range l {
+		edges[k] = e.edge
+	}
+	return edges
 }
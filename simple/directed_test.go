@@ -6,6 +6,8 @@ package simple
 
 import (
 	"math"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/gonum/graph"
@@ -61,3 +63,270 @@ func TestIssue123DirectedGraph(t *testing.T) {
 	n2 := Node(g.NewNodeID())
 	g.AddNode(n2)
 }
+
+func TestNodesSortedDirectedGraph(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, id := range []int{5, 1, 9, 0, 3, 7} {
+		g.AddNode(Node(id))
+	}
+	want := []int{0, 1, 3, 5, 7, 9}
+	for i := 0; i < 10; i++ {
+		nodes := g.NodesSorted()
+		if len(nodes) != len(want) {
+			t.Fatalf("unexpected number of nodes: got:%d want:%d", len(nodes), len(want))
+		}
+		for j, n := range nodes {
+			if n.ID() != want[j] {
+				t.Fatalf("unexpected node order at %d: got:%d want:%d", j, n.ID(), want[j])
+			}
+		}
+	}
+}
+
+func TestRemoveNodeDirectedGraph(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(0), W: 3},
+		{F: Node(3), T: Node(1), W: 4},
+		{F: Node(1), T: Node(3), W: 5},
+	} {
+		g.SetEdge(e)
+	}
+
+	g.RemoveNode(Node(1))
+	if g.Has(Node(1)) || g.Node(1) != nil {
+		t.Fatal("removed node still in graph")
+	}
+	if n := len(g.Edges()); n != 1 {
+		t.Errorf("unexpected number of edges: got:%d want:1", n)
+	}
+	for _, id := range []int{0, 2, 3} {
+		if g.Node(id) == nil {
+			t.Errorf("missing node %d", id)
+		}
+		for _, n := range append(g.From(Node(id)), g.To(Node(id))...) {
+			if n.ID() == 1 {
+				t.Errorf("node %d still adjacent to removed node", id)
+			}
+		}
+	}
+	if w, ok := g.Weight(Node(2), Node(0)); !ok || w != 3 {
+		t.Errorf("unexpected weight for remaining edge: got:%v,%t want:3,true", w, ok)
+	}
+	if g.Degree(Node(3)) != 0 {
+		t.Errorf("unexpected degree for node 3: got:%d want:0", g.Degree(Node(3)))
+	}
+}
+
+func TestAntiparallelEdges(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    interface {
+			graph.Directed
+			graph.EdgeSetter
+			Edges() []graph.Edge
+		}
+	}{
+		{name: "DirectedGraph", g: NewDirectedGraph(0, math.Inf(1))},
+		{name: "DirectedMatrix", g: NewDirectedMatrix(3, math.Inf(1), 0, math.Inf(1))},
+		{name: "DirectedBitMatrix", g: NewDirectedBitMatrix(3)},
+	} {
+		for _, e := range []Edge{
+			{F: Node(0), T: Node(1), W: 1},
+			{F: Node(1), T: Node(0), W: 1},
+			{F: Node(1), T: Node(2), W: 1},
+			{F: Node(2), T: Node(1), W: 1},
+			{F: Node(0), T: Node(2), W: 1},
+		} {
+			test.g.SetEdge(e)
+		}
+		if !panics(func() { test.g.SetEdge(Edge{F: Node(1), T: Node(1), W: 1}) }) {
+			t.Errorf("expected panic for self edge in %s", test.name)
+		}
+
+		seen := make(map[[2]int]int)
+		for _, e := range test.g.Edges() {
+			seen[[2]int{e.From().ID(), e.To().ID()}]++
+		}
+		want := map[[2]int]int{{0, 1}: 1, {1, 0}: 1, {1, 2}: 1, {2, 1}: 1, {0, 2}: 1}
+		if !reflect.DeepEqual(seen, want) {
+			t.Errorf("unexpected edges for %s:\ngot: %v\nwant:%v", test.name, seen, want)
+		}
+	}
+
+	u := NewUndirectedGraph(0, math.Inf(1))
+	u.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	u.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	if !panics(func() { u.SetEdge(Edge{F: Node(1), T: Node(1), W: 1}) }) {
+		t.Error("expected panic for self edge in UndirectedGraph")
+	}
+	if n := len(u.Edges()); n != 1 {
+		t.Errorf("unexpected number of undirected edges for antiparallel pair: got:%d want:1", n)
+	}
+}
+
+func TestSelfEdgePanics(t *testing.T) {
+	for _, g := range []graph.EdgeSetter{
+		NewDirectedGraph(0, math.Inf(1)),
+		NewUndirectedGraph(0, math.Inf(1)),
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic for self edge in %T", g)
+				}
+			}()
+			g.SetEdge(Edge{F: Node(0), T: Node(0), W: 1})
+		}()
+	}
+}
+
+func TestDirectedIncidentEdges(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(2), W: 1},
+		{F: Node(0), T: Node(1), W: 2},
+		{F: Node(3), T: Node(0), W: 3},
+		{F: Node(1), T: Node(2), W: 4},
+	} {
+		g.SetEdge(e)
+	}
+
+	ids := func(edges []graph.Edge) [][3]float64 {
+		var ids [][3]float64
+		for _, e := range edges {
+			ids = append(ids, [3]float64{float64(e.From().ID()), float64(e.To().ID()), e.Weight()})
+		}
+		return ids
+	}
+
+	out, in := g.IncidentEdges(Node(0))
+	if got, want := ids(out), [][3]float64{{0, 1, 2}, {0, 2, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected out edges of 0: got:%v want:%v", got, want)
+	}
+	if got, want := ids(in), [][3]float64{{3, 0, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected in edges of 0: got:%v want:%v", got, want)
+	}
+
+	out, in = g.IncidentEdges(Node(2))
+	if out != nil {
+		t.Errorf("unexpected out edges of sink: got:%v want:[]", ids(out))
+	}
+	if got, want := ids(in), [][3]float64{{0, 2, 1}, {1, 2, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected in edges of 2: got:%v want:%v", got, want)
+	}
+
+	out, in = g.IncidentEdges(Node(4))
+	if out != nil || in != nil {
+		t.Errorf("unexpected incident edges of absent node: got:%v %v", ids(out), ids(in))
+	}
+}
+
+func TestDirectedSetEdgeWeight(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 2})
+
+	g.SetEdgeWeight(Edge{F: Node(0), T: Node(1)}, math.Inf(1))
+	if w, ok := g.Weight(Node(0), Node(1)); !math.IsInf(w, 1) || !ok {
+		t.Errorf("unexpected weight after update: got:(%v, %t) want:(+Inf, true)", w, ok)
+	}
+	if e := g.Edge(Node(0), Node(1)); e == nil || !math.IsInf(e.Weight(), 1) {
+		t.Errorf("unexpected edge after update: %v", e)
+	}
+	out, in := g.IncidentEdges(Node(1))
+	if len(out) != 1 || out[0].Weight() != 2 {
+		t.Errorf("reverse edge changed by update: %v", out)
+	}
+	if len(in) != 1 || !math.IsInf(in[0].Weight(), 1) {
+		t.Errorf("inbound edge not updated: %v", in)
+	}
+	if g.Size() != 2 {
+		t.Errorf("unexpected size after update: got:%d want:2", g.Size())
+	}
+
+	// An absent edge is distinguished from an edge
+	// with the absent weight.
+	if w, ok := g.Weight(Node(0), Node(2)); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected weight for absent edge: got:(%v, %t) want:(+Inf, false)", w, ok)
+	}
+	if e := g.Edge(Node(0), Node(2)); e != nil {
+		t.Errorf("unexpected edge for absent edge: %v", e)
+	}
+
+	// Setting the weight of an absent edge adds it.
+	g.SetEdgeWeight(Edge{F: Node(0), T: Node(2), W: 3}, 1)
+	if w, ok := g.Weight(Node(0), Node(2)); w != 1 || !ok {
+		t.Errorf("unexpected weight for added edge: got:(%v, %t) want:(1, true)", w, ok)
+	}
+	if g.HasEdgeFromTo(Node(2), Node(0)) {
+		t.Error("unexpected reverse edge for added edge")
+	}
+	if g.Size() != 3 {
+		t.Errorf("unexpected size after adding edge: got:%d want:3", g.Size())
+	}
+
+	// Labeled edges retain their type and label.
+	g.SetEdge(labeledEdge{Edge: Edge{F: Node(2), T: Node(3), W: 1}, label: "road"})
+	g.SetEdgeWeight(Edge{F: Node(2), T: Node(3)}, 4)
+	out, in = g.IncidentEdges(Node(3))
+	if len(out) != 0 || len(in) != 1 {
+		t.Fatalf("unexpected incident edges of labeled edge target: %v %v", out, in)
+	}
+	for _, e := range []graph.Edge{g.Edge(Node(2), Node(3)), in[0]} {
+		l, ok := e.(labeledEdge)
+		if !ok || l.label != "road" || l.Weight() != 4 {
+			t.Errorf("unexpected labeled edge after update: got:%#v want label road with weight 4", e)
+		}
+	}
+}
+
+// labeledEdge is an Edge with a label
+// that is retained by SetEdgeWeight.
+type labeledEdge struct {
+	Edge
+	label string
+}
+
+func (e labeledEdge) WithWeight(w float64) graph.Edge {
+	e.W = w
+	return e
+}
+
+func TestDirectedClearEdges(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 2})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 3})
+	g.AddNode(Node(3))
+	r := &recorder{}
+	g.RegisterObserver(r)
+
+	g.ClearEdges()
+	if got := len(g.Nodes()); got != 4 {
+		t.Errorf("unexpected number of nodes after ClearEdges: got:%d want:4", got)
+	}
+	if g.Size() != 0 || len(g.Edges()) != 0 {
+		t.Errorf("unexpected edges after ClearEdges: size=%d edges=%v", g.Size(), g.Edges())
+	}
+	for _, n := range g.Nodes() {
+		if len(g.From(n)) != 0 || len(g.To(n)) != 0 {
+			t.Errorf("unexpected neighbors of %d after ClearEdges", n.ID())
+		}
+	}
+	if err := g.Validate(); err != nil {
+		t.Errorf("invalid graph after ClearEdges: %v", err)
+	}
+	want := []string{"-edge 0-1", "-edge 1-0", "-edge 1-2"}
+	sort.Strings(r.events)
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("unexpected events:\ngot: %q\nwant:%q", r.events, want)
+	}
+
+	g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+	if !g.HasEdgeFromTo(Node(2), Node(3)) || g.Size() != 1 {
+		t.Error("failed to add edge after ClearEdges")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+// DisjointSet is a collection of non-overlapping sets of integer IDs
+// supporting union-find operations. Set membership queries use path
+// compression and sets are merged by rank, so a sequence of m operations
+// on n elements takes O(m α(n)) time.
+type DisjointSet struct {
+	// indexOf maps element IDs to
+	// indices into parent and rank.
+	indexOf map[int]int
+
+	// ids holds the element ID for
+	// each index.
+	ids    []int
+	parent []int
+	rank   []int
+}
+
+// NewDisjointSet returns a new empty DisjointSet.
+func NewDisjointSet() *DisjointSet {
+	return &DisjointSet{indexOf: make(map[int]int)}
+}
+
+// MakeSet adds id to the disjoint set as the sole member of a new set. If
+// id is already held by the disjoint set, MakeSet is a no-op.
+func (ds *DisjointSet) MakeSet(id int) {
+	if _, ok := ds.indexOf[id]; ok {
+		return
+	}
+	i := len(ds.ids)
+	ds.indexOf[id] = i
+	ds.ids = append(ds.ids, id)
+	ds.parent = append(ds.parent, i)
+	ds.rank = append(ds.rank, 0)
+}
+
+// Has returns whether id is held by the disjoint set.
+func (ds *DisjointSet) Has(id int) bool {
+	_, ok := ds.indexOf[id]
+	return ok
+}
+
+// Find returns the ID of the representative element of the set containing
+// id. Two elements are in the same set if and only if Find returns the same
+// representative for both. Find will panic if id is not held by the disjoint
+// set.
+func (ds *DisjointSet) Find(id int) int {
+	i, ok := ds.indexOf[id]
+	if !ok {
+		panic("simple: find absent element")
+	}
+	return ds.ids[ds.find(i)]
+}
+
+// find returns the index of the root of the set containing
+// the element at index i, compressing the path to the root.
+func (ds *DisjointSet) find(i int) int {
+	root := i
+	for ds.parent[root] != root {
+		root = ds.parent[root]
+	}
+	for ds.parent[i] != root {
+		i, ds.parent[i] = ds.parent[i], root
+	}
+	return root
+}
+
+// Union merges the sets containing a and b. Union will panic if either a or b
+// is not held by the disjoint set.
+func (ds *DisjointSet) Union(a, b int) {
+	i, ok := ds.indexOf[a]
+	if !ok {
+		panic("simple: union absent element")
+	}
+	j, ok := ds.indexOf[b]
+	if !ok {
+		panic("simple: union absent element")
+	}
+	x := ds.find(i)
+	y := ds.find(j)
+	if x == y {
+		return
+	}
+	switch {
+	case ds.rank[x] < ds.rank[y]:
+		ds.parent[x] = y
+	case ds.rank[y] < ds.rank[x]:
+		ds.parent[y] = x
+	default:
+		ds.parent[y] = x
+		ds.rank[x]++
+	}
+}
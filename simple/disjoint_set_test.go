@@ -0,0 +1,130 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDisjointSetMakeSet(t *testing.T) {
+	ds := NewDisjointSet()
+	if ds.Has(3) {
+		t.Error("unexpected element in empty disjoint set")
+	}
+
+	ds.MakeSet(3)
+	if !ds.Has(3) {
+		t.Fatal("MakeSet did not add element")
+	}
+	if got := ds.Find(3); got != 3 {
+		t.Errorf("unexpected representative for singleton set: got:%d want:3", got)
+	}
+
+	ds.MakeSet(5)
+	ds.Union(3, 5)
+	ds.MakeSet(5)
+	if ds.Find(3) != ds.Find(5) {
+		t.Error("repeated MakeSet separated an existing set")
+	}
+}
+
+func TestDisjointSetFind(t *testing.T) {
+	ds := NewDisjointSet()
+
+	ds.MakeSet(3)
+	ds.MakeSet(5)
+
+	if ds.Find(3) == ds.Find(5) {
+		t.Error("disjoint sets incorrectly found to be the same")
+	}
+}
+
+func TestDisjointSetUnion(t *testing.T) {
+	ds := NewDisjointSet()
+
+	ds.MakeSet(3)
+	ds.MakeSet(5)
+
+	ds.Union(3, 5)
+
+	if ds.Find(3) != ds.Find(5) {
+		t.Error("sets found to be disjoint after union")
+	}
+}
+
+func TestDisjointSetAbsent(t *testing.T) {
+	ds := NewDisjointSet()
+	ds.MakeSet(1)
+
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "find", fn: func() { ds.Find(2) }},
+		{name: "union first", fn: func() { ds.Union(2, 1) }},
+		{name: "union second", fn: func() { ds.Union(1, 2) }},
+	} {
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			test.fn()
+			return
+		}()
+		if !panicked {
+			t.Errorf("expected panic for %s of absent element", test.name)
+		}
+	}
+}
+
+func TestDisjointSetRandom(t *testing.T) {
+	const n = 500
+	rnd := rand.New(rand.NewSource(1))
+
+	// label is a naive reference implementation
+	// holding the set label of each element.
+	label := make(map[int]int)
+	ds := NewDisjointSet()
+	ids := rnd.Perm(n * 4)[:n]
+	for _, id := range ids {
+		ds.MakeSet(id)
+		label[id] = id
+	}
+
+	for k := 0; k < n; k++ {
+		a := ids[rnd.Intn(n)]
+		b := ids[rnd.Intn(n)]
+		ds.Union(a, b)
+		if la, lb := label[a], label[b]; la != lb {
+			for id, l := range label {
+				if l == lb {
+					label[id] = la
+				}
+			}
+		}
+
+		for i := 0; i < 10; i++ {
+			u := ids[rnd.Intn(n)]
+			v := ids[rnd.Intn(n)]
+			got := ds.Find(u) == ds.Find(v)
+			want := label[u] == label[v]
+			if got != want {
+				t.Fatalf("unexpected set membership for %d and %d after %d unions: got:%t want:%t",
+					u, v, k+1, got, want)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		r := ds.Find(id)
+		if label[r] != label[id] {
+			t.Errorf("representative %d of %d is not in the same set", r, id)
+		}
+		if ds.Find(r) != r {
+			t.Errorf("representative %d is not its own representative", r)
+		}
+	}
+}
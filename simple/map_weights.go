@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+// MapWeights replaces the weight of each edge in g with the result of applying
+// f to the weight. If an edge is a Reweighter, the edge returned by its
+// WithWeight method is stored, otherwise the edge is replaced by an Edge.
+func (g *DirectedGraph) MapWeights(f func(float64) float64) {
+	for i, l := range g.from {
+		fid := g.nodes[i].ID()
+		for k, h := range l {
+			e := h.edge
+			m := reweighted(e, f(e.Weight()))
+			l[k].edge = m
+			g.to[g.indexOf[h.id]].set(fid, m)
+			g.obs.edgeWeightChanged(m, e.Weight())
+		}
+	}
+}
+
+// MappedCopy returns a copy of g with the weight of each edge replaced by the
+// result of applying f to the weight. The nodes of g are shared with the copy,
+// and edges are rewritten as described for MapWeights.
+func (g *DirectedGraph) MappedCopy(f func(float64) float64) *DirectedGraph {
+	c := NewDirectedGraph(g.self, g.absent)
+	for _, n := range g.nodes {
+		c.AddNode(n)
+	}
+	for _, l := range g.from {
+		for _, h := range l {
+			e := h.edge
+			c.SetEdge(reweighted(e, f(e.Weight())))
+		}
+	}
+	return c
+}
+
+// MapWeights replaces the weight of each edge in g with the result of applying
+// f to the weight. If an edge is a Reweighter, the edge returned by its
+// WithWeight method is stored, otherwise the edge is replaced by an Edge.
+func (g *UndirectedGraph) MapWeights(f func(float64) float64) {
+	for uid, adj := range g.edges {
+		for vid, e := range adj {
+			if vid < uid {
+				continue
+			}
+			m := reweighted(e, f(e.Weight()))
+			g.edges[uid][vid] = m
+			g.edges[vid][uid] = m
+			g.obs.edgeWeightChanged(m, e.Weight())
+		}
+	}
+}
+
+// MappedCopy returns a copy of g with the weight of each edge replaced by the
+// result of applying f to the weight. The nodes of g are shared with the copy,
+// and edges are rewritten as described for MapWeights.
+func (g *UndirectedGraph) MappedCopy(f func(float64) float64) *UndirectedGraph {
+	c := NewUndirectedGraph(g.self, g.absent)
+	for _, n := range g.nodes {
+		c.AddNode(n)
+	}
+	for uid, adj := range g.edges {
+		for vid, e := range adj {
+			if vid < uid {
+				continue
+			}
+			c.SetEdge(reweighted(e, f(e.Weight())))
+		}
+	}
+	return c
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var mapWeightEdges = []Edge{
+	{F: Node(0), T: Node(1), W: 1},
+	{F: Node(1), T: Node(2), W: 2},
+	{F: Node(2), T: Node(0), W: 3},
+	{F: Node(0), T: Node(3), W: 4},
+}
+
+func TestMapWeights(t *testing.T) {
+	double := func(w float64) float64 { return 2 * w }
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range mapWeightEdges {
+		dg.SetEdge(e)
+		ug.SetEdge(e)
+	}
+	dg.AddNode(Node(4))
+	ug.AddNode(Node(4))
+
+	dc := dg.MappedCopy(double)
+	uc := ug.MappedCopy(double)
+	for _, g := range []interface {
+		graph.Graph
+		graph.Weighter
+	}{dg, ug} {
+		for _, e := range mapWeightEdges {
+			if w, _ := g.Weight(e.F, e.T); w != e.W {
+				t.Errorf("unexpected weight in %T after copy from %d to %d: got:%v want:%v",
+					g, e.F.ID(), e.T.ID(), w, e.W)
+			}
+		}
+	}
+
+	dg.MapWeights(double)
+	ug.MapWeights(double)
+	for _, g := range []interface {
+		graph.Graph
+		graph.Weighter
+	}{dg, ug, dc, uc} {
+		if len(g.Nodes()) != 5 {
+			t.Errorf("unexpected number of nodes in %T: got:%d want:5", g, len(g.Nodes()))
+		}
+		for _, e := range mapWeightEdges {
+			if w, _ := g.Weight(e.F, e.T); w != 2*e.W {
+				t.Errorf("unexpected mapped weight in %T from %d to %d: got:%v want:%v",
+					g, e.F.ID(), e.T.ID(), w, 2*e.W)
+			}
+			if got := g.Edge(e.F, e.T).Weight(); got != 2*e.W {
+				t.Errorf("unexpected mapped edge weight in %T from %d to %d: got:%v want:%v",
+					g, e.F.ID(), e.T.ID(), got, 2*e.W)
+			}
+		}
+	}
+	if w, _ := dg.Weight(Node(1), Node(0)); !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight for absent reverse edge: got:%v want:+Inf", w)
+	}
+	if w, _ := ug.Weight(Node(1), Node(0)); w != 2 {
+		t.Errorf("unexpected weight for undirected reverse edge: got:%v want:2", w)
+	}
+}
+
+func TestMapWeightsReweighter(t *testing.T) {
+	double := func(w float64) float64 { return 2 * w }
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, g := range []graph.EdgeSetter{dg, ug} {
+		g.SetEdge(labeledEdge{Edge: Edge{F: Node(0), T: Node(1), W: 1}, label: "road"})
+	}
+	dc := dg.MappedCopy(double)
+	uc := ug.MappedCopy(double)
+	dg.MapWeights(double)
+	ug.MapWeights(double)
+
+	for _, g := range []graph.Graph{dg, ug, dc, uc} {
+		e := g.Edge(Node(0), Node(1))
+		l, ok := e.(labeledEdge)
+		if !ok || l.label != "road" || l.W != 2 {
+			t.Errorf("unexpected mapped edge in %T: got:%#v want:labeled road edge with weight 2", g, e)
+		}
+	}
+	for _, g := range []*DirectedGraph{dg, dc} {
+		_, in := g.IncidentEdges(Node(1))
+		if len(in) != 1 {
+			t.Fatalf("unexpected number of inbound edges after mapping: got:%d want:1", len(in))
+		}
+		if l, ok := in[0].(labeledEdge); !ok || l.label != "road" || l.W != 2 {
+			t.Errorf("unexpected inbound edge after mapping: got:%#v want:labeled road edge with weight 2", in[0])
+		}
+	}
+}
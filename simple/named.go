@@ -0,0 +1,130 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// NodeNamer maintains a mapping between string names, or other comparable
+// keys, and the nodes of a graph, allowing a graph to be built from data that
+// identifies nodes by name or key. Node IDs are allocated by the wrapped graph,
+// so they never collide with the IDs of nodes already in the graph.
+//
+// Names are keys of type string. The methods that take a name are equivalent
+// to the corresponding methods that take a key.
+type NodeNamer struct {
+	g    graph.Builder
+	ids  map[interface{}]int
+	keys map[int]interface{}
+}
+
+// NewNodeNamer returns a NodeNamer that adds named nodes to g. Nodes already
+// in g have no name.
+func NewNodeNamer(g graph.Builder) *NodeNamer {
+	return &NodeNamer{
+		g:    g,
+		ids:  make(map[interface{}]int),
+		keys: make(map[int]interface{}),
+	}
+}
+
+// Graph returns the graph that n adds named nodes to.
+func (n *NodeNamer) Graph() graph.Builder {
+	return n.g
+}
+
+// Node returns the node with the given name, adding a new node to the graph
+// if the name is not yet known.
+func (n *NodeNamer) Node(name string) graph.Node {
+	return n.NodeFor(name)
+}
+
+// NodeFor returns the node with the given key, adding a new node to the graph
+// if the key is not yet known. NodeFor will panic if key is not comparable.
+func (n *NodeNamer) NodeFor(key interface{}) graph.Node {
+	if id, ok := n.ids[key]; ok {
+		return Node(id)
+	}
+	id := n.g.NewNodeID()
+	n.g.AddNode(Node(id))
+	n.ids[key] = id
+	n.keys[id] = key
+	return Node(id)
+}
+
+// ID returns the ID of the node with the given name and whether the name is
+// known. Unlike Node, ID does not add nodes to the graph.
+func (n *NodeNamer) ID(name string) (id int, ok bool) {
+	return n.IDFor(name)
+}
+
+// IDFor returns the ID of the node with the given key and whether the key is
+// known. Unlike NodeFor, IDFor does not add nodes to the graph.
+func (n *NodeNamer) IDFor(key interface{}) (id int, ok bool) {
+	id, ok = n.ids[key]
+	return id, ok
+}
+
+// Name returns the name of the node with the given ID and whether the node
+// has a name. A node with a key that is not a string has no name.
+func (n *NodeNamer) Name(id int) (name string, ok bool) {
+	name, ok = n.keys[id].(string)
+	return name, ok
+}
+
+// Key returns the key of the node with the given ID and whether the node
+// has a key.
+func (n *NodeNamer) Key(id int) (key interface{}, ok bool) {
+	key, ok = n.keys[id]
+	return key, ok
+}
+
+// Keys returns the keys of the given nodes, such as those of a path returned
+// by a shortest path search. Nodes without a key have a nil key.
+func (n *NodeNamer) Keys(nodes []graph.Node) []interface{} {
+	if nodes == nil {
+		return nil
+	}
+	keys := make([]interface{}, len(nodes))
+	for i, u := range nodes {
+		keys[i] = n.keys[u.ID()]
+	}
+	return keys
+}
+
+// SetEdgeByName sets an edge with the given weight from the node named from
+// to the node named to, adding nodes to the graph for unknown names.
+func (n *NodeNamer) SetEdgeByName(from, to string, weight float64) {
+	n.SetEdgeByKey(from, to, weight)
+}
+
+// SetEdgeByKey sets an edge with the given weight from the node with the key
+// from to the node with the key to, adding nodes to the graph for unknown keys.
+func (n *NodeNamer) SetEdgeByKey(from, to interface{}, weight float64) {
+	n.g.SetEdge(Edge{F: n.NodeFor(from), T: n.NodeFor(to), W: weight})
+}
+
+// RemoveByName removes the node with the given name and its edges from the
+// graph, and forgets the name. If the name is not known it is a no-op.
+// RemoveByName will panic if the wrapped graph is not a graph.NodeRemover.
+func (n *NodeNamer) RemoveByName(name string) {
+	n.RemoveByKey(name)
+}
+
+// RemoveByKey removes the node with the given key and its edges from the
+// graph, and forgets the key. If the key is not known it is a no-op.
+// RemoveByKey will panic if the wrapped graph is not a graph.NodeRemover.
+func (n *NodeNamer) RemoveByKey(key interface{}) {
+	id, ok := n.ids[key]
+	if !ok {
+		return
+	}
+	r, ok := n.g.(graph.NodeRemover)
+	if !ok {
+		panic("simple: remove from graph without node removal")
+	}
+	r.RemoveNode(Node(id))
+	delete(n.ids, key)
+	delete(n.keys, id)
+}
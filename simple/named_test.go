@@ -0,0 +1,161 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+func TestNodeNamer(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	n := NewNodeNamer(g)
+
+	if id, ok := n.ID("user:alice"); ok {
+		t.Errorf("unexpected ID for unknown name: %d", id)
+	}
+
+	alice := n.Node("user:alice")
+	if alice.ID() != 0 {
+		t.Errorf("unexpected ID for first name: got:%d want:0", alice.ID())
+	}
+	if id, ok := n.ID("user:alice"); id != 0 || !ok {
+		t.Errorf("unexpected ID for known name: got:(%d, %t) want:(0, true)", id, ok)
+	}
+	if again := n.Node("user:alice"); again.ID() != alice.ID() {
+		t.Errorf("name not interned: got:%d want:%d", again.ID(), alice.ID())
+	}
+
+	n.SetEdgeByName("user:alice", "user:bob", 2)
+	n.SetEdgeByName("user:bob", "user:carol", 3)
+	bob, _ := n.ID("user:bob")
+	carol, _ := n.ID("user:carol")
+	if len(g.Nodes()) != 3 {
+		t.Errorf("unexpected number of nodes: got:%d want:3", len(g.Nodes()))
+	}
+	if w, ok := g.Weight(Node(bob), Node(carol)); w != 3 || !ok {
+		t.Errorf("unexpected weight for named edge: got:(%v, %t) want:(3, true)", w, ok)
+	}
+	if name, ok := n.Name(carol); name != "user:carol" || !ok {
+		t.Errorf("unexpected name for ID %d: got:(%q, %t) want:(user:carol, true)", carol, name, ok)
+	}
+	if name, ok := n.Name(10); ok {
+		t.Errorf("unexpected name for unknown ID: %q", name)
+	}
+
+	n.RemoveByName("user:bob")
+	if g.Has(Node(bob)) || g.HasEdgeBetween(alice, Node(carol)) {
+		t.Error("named node not removed from graph")
+	}
+	if _, ok := n.ID("user:bob"); ok {
+		t.Error("removed name still known")
+	}
+	if _, ok := n.Name(bob); ok {
+		t.Error("removed ID still named")
+	}
+	n.RemoveByName("user:bob")
+
+	// The freed ID is reused by the graph.
+	if dave := n.Node("user:dave"); dave.ID() != bob {
+		t.Errorf("freed ID not reused: got:%d want:%d", dave.ID(), bob)
+	}
+}
+
+func TestNodeNamerKeys(t *testing.T) {
+	// IDs are allocated by the graph, so keyed
+	// nodes do not collide with existing nodes.
+	g := NewDirectedGraph(0, 0)
+	g.AddNode(Node(0))
+	g.AddNode(Node(1))
+	n := NewNodeNamer(g)
+
+	if id, ok := n.IDFor("db1.example.com"); ok {
+		t.Errorf("unexpected ID for unseen key: %d", id)
+	}
+	if k, ok := n.Key(0); ok {
+		t.Errorf("unexpected key for unkeyed node: %v", k)
+	}
+
+	type port struct {
+		host string
+		n    int
+	}
+	keys := []interface{}{"db1.example.com", port{"lb.example.com", 443}, 7}
+	var ids []int
+	for _, k := range keys {
+		id := n.NodeFor(k).ID()
+		if id == 0 || id == 1 {
+			t.Errorf("ID for %v collides with existing node: %d", k, id)
+		}
+		ids = append(ids, id)
+	}
+	for i, k := range keys {
+		if id := n.NodeFor(k).ID(); id != ids[i] {
+			t.Errorf("ID not stable for %v: got:%d want:%d", k, id, ids[i])
+		}
+		if id, ok := n.IDFor(k); id != ids[i] || !ok {
+			t.Errorf("unexpected lookup for %v: got:(%d, %t) want:(%d, true)", k, id, ok, ids[i])
+		}
+		if got, ok := n.Key(ids[i]); got != k || !ok {
+			t.Errorf("unexpected key for %d: got:(%v, %t) want:(%v, true)", ids[i], got, ok, k)
+		}
+	}
+	if name, ok := n.Name(ids[0]); name != "db1.example.com" || !ok {
+		t.Errorf("unexpected name for string key: got:(%q, %t)", name, ok)
+	}
+	if name, ok := n.Name(ids[2]); ok {
+		t.Errorf("unexpected name for non-string key: %q", name)
+	}
+	if len(g.Nodes()) != 2+len(keys) {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", len(g.Nodes()), 2+len(keys))
+	}
+
+	n.SetEdgeByKey(port{"lb.example.com", 443}, "db2.example.com", 1)
+	db2, _ := n.IDFor("db2.example.com")
+	if !g.HasEdgeFromTo(Node(ids[1]), Node(db2)) {
+		t.Error("missing edge set from keys")
+	}
+	got := n.Keys([]graph.Node{Node(ids[1]), Node(db2), Node(0)})
+	want := []interface{}{port{"lb.example.com", 443}, "db2.example.com", nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected keys: got:%v want:%v", got, want)
+	}
+	if n.Keys(nil) != nil {
+		t.Error("unexpected non-nil keys for nil nodes")
+	}
+
+	n.RemoveByKey(7)
+	if g.Has(Node(ids[2])) {
+		t.Error("keyed node not removed from graph")
+	}
+	if _, ok := n.IDFor(7); ok {
+		t.Error("removed key still known")
+	}
+}
+
+// builderOnly hides the node removal method of a graph.
+type builderOnly struct {
+	*UndirectedGraph
+}
+
+func (builderOnly) RemoveNode() {}
+
+func TestNodeNamerRemoveWithoutRemover(t *testing.T) {
+	n := NewNodeNamer(builderOnly{NewUndirectedGraph(0, math.Inf(1))})
+	n.Node("a")
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		n.RemoveByName("a")
+		return
+	}()
+	if !panicked {
+		t.Error("expected panic removing name from graph without node removal")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// RegisterObserver registers o to be notified of mutations of g. Observers
+// are notified synchronously in the order they were registered, after each
+// mutation that changes g. Registering an observer that is already registered
+// is a no-op. The dynamic type of o must be comparable.
+func (g *DirectedGraph) RegisterObserver(o graph.Observer) {
+	g.obs.register(o)
+}
+
+// UnregisterObserver stops o being notified of mutations of g. If o is not
+// registered it is a no-op.
+func (g *DirectedGraph) UnregisterObserver(o graph.Observer) {
+	g.obs.unregister(o)
+}
+
+// RegisterObserver registers o to be notified of mutations of g. Observers
+// are notified synchronously in the order they were registered, after each
+// mutation that changes g. Registering an observer that is already registered
+// is a no-op. The dynamic type of o must be comparable.
+func (g *UndirectedGraph) RegisterObserver(o graph.Observer) {
+	g.obs.register(o)
+}
+
+// UnregisterObserver stops o being notified of mutations of g. If o is not
+// registered it is a no-op.
+func (g *UndirectedGraph) UnregisterObserver(o graph.Observer) {
+	g.obs.unregister(o)
+}
+
+// observers is a list of graph observers. The list is replaced
+// rather than modified when an observer is unregistered so that
+// notification is not disturbed by callbacks that unregister.
+type observers []graph.Observer
+
+// register adds o to the list if it is not already present.
+func (l *observers) register(o graph.Observer) {
+	for _, r := range *l {
+		if r == o {
+			return
+		}
+	}
+	*l = append(*l, o)
+}
+
+// unregister removes o from the list if it is present.
+func (l *observers) unregister(o graph.Observer) {
+	for i, r := range *l {
+		if r == o {
+			n := make(observers, 0, len(*l)-1)
+			n = append(n, (*l)[:i]...)
+			*l = append(n, (*l)[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l observers) nodeAdded(n graph.Node) {
+	for _, o := range l {
+		o.NodeAdded(n)
+	}
+}
+
+func (l observers) nodeRemoved(n graph.Node) {
+	for _, o := range l {
+		o.NodeRemoved(n)
+	}
+}
+
+func (l observers) edgeAdded(e graph.Edge) {
+	for _, o := range l {
+		o.EdgeAdded(e)
+	}
+}
+
+func (l observers) edgeRemoved(e graph.Edge) {
+	for _, o := range l {
+		o.EdgeRemoved(e)
+	}
+}
+
+func (l observers) edgeWeightChanged(e graph.Edge, old float64) {
+	if isSame(e.Weight(), old) {
+		return
+	}
+	for _, o := range l {
+		o.EdgeWeightChanged(e, old)
+	}
+}
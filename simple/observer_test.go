@@ -0,0 +1,148 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+// recorder is a graph.Observer that records the events it is notified of.
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) NodeAdded(n graph.Node) {
+	r.events = append(r.events, fmt.Sprintf("+node %d", n.ID()))
+}
+
+func (r *recorder) NodeRemoved(n graph.Node) {
+	r.events = append(r.events, fmt.Sprintf("-node %d", n.ID()))
+}
+
+func (r *recorder) EdgeAdded(e graph.Edge) {
+	r.events = append(r.events, fmt.Sprintf("+edge %d-%d %v", e.From().ID(), e.To().ID(), e.Weight()))
+}
+
+func (r *recorder) EdgeRemoved(e graph.Edge) {
+	r.events = append(r.events, fmt.Sprintf("-edge %d-%d", e.From().ID(), e.To().ID()))
+}
+
+func (r *recorder) EdgeWeightChanged(e graph.Edge, old float64) {
+	r.events = append(r.events, fmt.Sprintf("~edge %d-%d %v->%v", e.From().ID(), e.To().ID(), old, e.Weight()))
+}
+
+func TestDirectedObserver(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	first, second := &recorder{}, &recorder{}
+	g.RegisterObserver(first)
+	g.RegisterObserver(first)
+	g.RegisterObserver(second)
+
+	g.AddNode(Node(0))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(2), T: Node(1), W: 3})
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 4})
+	g.SetEdgeWeight(Edge{F: Node(1), T: Node(2)}, 5)
+	g.RemoveEdge(Edge{F: Node(1), T: Node(0)})
+	g.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+	g.RemoveNode(Node(3))
+	g.UnregisterObserver(second)
+	g.RemoveNode(Node(1))
+	g.UnregisterObserver(second)
+	g.MapWeights(func(w float64) float64 { return w })
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 1})
+	g.MapWeights(func(w float64) float64 { return 2 * w })
+
+	want := []string{
+		"+node 0",
+		"+node 1",
+		"+edge 0-1 1",
+		"+node 2",
+		"+edge 1-2 2",
+		"+edge 2-1 3",
+		"~edge 0-1 1->4",
+		"~edge 1-2 2->5",
+		"-edge 0-1",
+	}
+	if !reflect.DeepEqual(second.events, want) {
+		t.Errorf("unexpected events for unregistered observer:\ngot: %q\nwant:%q", second.events, want)
+	}
+	want = append(want,
+		"-edge 1-2",
+		"-edge 2-1",
+		"-node 1",
+		"+edge 0-2 1",
+		"~edge 0-2 1->2",
+	)
+	if !reflect.DeepEqual(first.events, want) {
+		t.Errorf("unexpected events:\ngot: %q\nwant:%q", first.events, want)
+	}
+}
+
+func TestUndirectedObserver(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	first, second := &recorder{}, &recorder{}
+	g.RegisterObserver(first)
+	g.RegisterObserver(second)
+
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(2), T: Node(1), W: 2})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 3})
+	g.SetEdgeWeight(Edge{F: Node(1), T: Node(2)}, 4)
+	g.RemoveEdge(Edge{F: Node(0), T: Node(2)})
+	g.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+	g.RemoveNode(Node(3))
+	g.UnregisterObserver(first)
+	g.RemoveNode(Node(2))
+
+	want := []string{
+		"+node 0",
+		"+node 1",
+		"+edge 0-1 1",
+		"+node 2",
+		"+edge 2-1 2",
+		"~edge 1-0 1->3",
+		"~edge 2-1 2->4",
+		"-edge 1-0",
+	}
+	if !reflect.DeepEqual(first.events, want) {
+		t.Errorf("unexpected events for unregistered observer:\ngot: %q\nwant:%q", first.events, want)
+	}
+	want = append(want,
+		"-edge 2-1",
+		"-node 2",
+	)
+	if !reflect.DeepEqual(second.events, want) {
+		t.Errorf("unexpected events:\ngot: %q\nwant:%q", second.events, want)
+	}
+}
+
+func TestContractObserver(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	r := &recorder{}
+	g.RegisterObserver(r)
+
+	g.Contract(Node(0), Node(1), nil)
+
+	want := []string{
+		"+edge 0-2 2",
+		"-node 1",
+	}
+	// The edges removed with node 1 are
+	// reported in map iteration order.
+	if len(r.events) != 4 || !reflect.DeepEqual([]string{r.events[0], r.events[3]}, want) {
+		t.Errorf("unexpected events: %q", r.events)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// HasParallelEdges returns whether any two of the given edges join the same
+// pair of nodes. If directed is true, edges join the same pair of nodes only if
+// they have the same From and To nodes, otherwise the direction of the edges is
+// not considered.
+func HasParallelEdges(edges []graph.Edge, directed bool) bool {
+	seen := make(map[edgeKey]struct{}, len(edges))
+	for _, e := range edges {
+		k := parallelKey(e, directed)
+		if _, ok := seen[k]; ok {
+			return true
+		}
+		seen[k] = struct{}{}
+	}
+	return false
+}
+
+// SetEdges sets each of the given edges in dst in order, and returns the edges
+// that were collapsed into a later edge joining the same pair of nodes. Since
+// the graphs in this package do not hold parallel edges, setting an edge
+// replaces any edge already held between its nodes. Edges join the same pair of
+// nodes as described for HasParallelEdges, with directed true if dst is a
+// graph.Directed. Only collapses among the given edges are reported.
+func SetEdges(dst graph.EdgeSetter, edges []graph.Edge) (collapsed []graph.Edge) {
+	_, directed := dst.(graph.Directed)
+	last := make(map[edgeKey]int, len(edges))
+	for i, e := range edges {
+		k := parallelKey(e, directed)
+		if j, ok := last[k]; ok {
+			collapsed = append(collapsed, edges[j])
+		}
+		last[k] = i
+		dst.SetEdge(e)
+	}
+	return collapsed
+}
+
+// edgeKey is the pair of terminal node IDs of an edge.
+type edgeKey struct{ from, to int }
+
+// parallelKey returns the key identifying the pair of nodes joined by e.
+func parallelKey(e graph.Edge, directed bool) edgeKey {
+	if directed {
+		return edgeKey{from: e.From().ID(), to: e.To().ID()}
+	}
+	return undirectedKey(e.From(), e.To())
+}
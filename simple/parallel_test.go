@@ -0,0 +1,115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var parallelEdgesTests = []struct {
+	name  string
+	edges []graph.Edge
+
+	wantDirected   bool
+	wantUndirected bool
+}{
+	{name: "empty"},
+	{
+		name: "distinct",
+		edges: []graph.Edge{
+			Edge{F: Node(0), T: Node(1)},
+			Edge{F: Node(1), T: Node(2)},
+		},
+	},
+	{
+		name: "antiparallel",
+		edges: []graph.Edge{
+			Edge{F: Node(0), T: Node(1), W: 1},
+			Edge{F: Node(1), T: Node(0), W: 2},
+		},
+		wantUndirected: true,
+	},
+	{
+		name: "parallel",
+		edges: []graph.Edge{
+			Edge{F: Node(0), T: Node(1), W: 1},
+			Edge{F: Node(1), T: Node(2), W: 1},
+			Edge{F: Node(0), T: Node(1), W: 2},
+		},
+		wantDirected:   true,
+		wantUndirected: true,
+	},
+}
+
+func TestHasParallelEdges(t *testing.T) {
+	for _, test := range parallelEdgesTests {
+		if got := HasParallelEdges(test.edges, true); got != test.wantDirected {
+			t.Errorf("unexpected directed result for %q: got:%t want:%t", test.name, got, test.wantDirected)
+		}
+		if got := HasParallelEdges(test.edges, false); got != test.wantUndirected {
+			t.Errorf("unexpected undirected result for %q: got:%t want:%t", test.name, got, test.wantUndirected)
+		}
+	}
+}
+
+func TestSetEdges(t *testing.T) {
+	edges := []graph.Edge{
+		Edge{F: Node(0), T: Node(1), W: 1},
+		Edge{F: Node(1), T: Node(0), W: 2},
+		Edge{F: Node(1), T: Node(2), W: 3},
+		Edge{F: Node(0), T: Node(1), W: 4},
+	}
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	got := SetEdges(dg, edges)
+	if want := edgeIDs(edges[:1]); !reflect.DeepEqual(edgeIDs(got), want) {
+		t.Errorf("unexpected directed collapsed edges: got:%v want:%v", edgeIDs(got), want)
+	}
+	if w, _ := dg.Weight(Node(0), Node(1)); w != 4 {
+		t.Errorf("unexpected directed weight after collapse: got:%v want:4", w)
+	}
+	if w, _ := dg.Weight(Node(1), Node(0)); w != 2 {
+		t.Errorf("unexpected reverse directed weight: got:%v want:2", w)
+	}
+
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	got = SetEdges(ug, edges)
+	if want := edgeIDs(edges[:2]); !reflect.DeepEqual(edgeIDs(got), want) {
+		t.Errorf("unexpected undirected collapsed edges: got:%v want:%v", edgeIDs(got), want)
+	}
+	if w, _ := ug.Weight(Node(1), Node(0)); w != 4 {
+		t.Errorf("unexpected undirected weight after collapse: got:%v want:4", w)
+	}
+	if ug.Size() != 2 {
+		t.Errorf("unexpected undirected size: got:%d want:2", ug.Size())
+	}
+}
+
+func TestSetEdgeReplaces(t *testing.T) {
+	for _, g := range []interface {
+		graph.Graph
+		graph.Builder
+		graph.Weighter
+	}{
+		NewDirectedGraph(0, math.Inf(1)),
+		NewUndirectedGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 2})
+		if w, ok := g.Weight(Node(0), Node(1)); w != 2 || !ok {
+			t.Errorf("edge not replaced in %T: got weight:(%v, %t) want:(2, true)", g, w, ok)
+		}
+		if e := g.Edge(Node(0), Node(1)); e == nil || e.Weight() != 2 {
+			t.Errorf("unexpected edge in %T after replacement: %v", g, e)
+		}
+		if len(g.From(Node(0))) != 1 {
+			t.Errorf("parallel edge held in %T", g)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+// NodePriorityQueue is an indexed min-priority queue of node IDs. The index
+// held by the queue allows the priority of a queued ID to be decreased in
+// O(log n) time, making it suitable for best-first searches that relax
+// priorities in place rather than pushing duplicate entries.
+type NodePriorityQueue struct {
+	// indexOf maps queued IDs to
+	// their position in items.
+	indexOf map[int]int
+	items   []queueItem
+}
+
+type queueItem struct {
+	id       int
+	priority float64
+}
+
+// NewNodePriorityQueue returns a new empty NodePriorityQueue.
+func NewNodePriorityQueue() *NodePriorityQueue {
+	return &NodePriorityQueue{indexOf: make(map[int]int)}
+}
+
+// Len returns the number of IDs held in the queue.
+func (q *NodePriorityQueue) Len() int { return len(q.items) }
+
+// Has returns whether id is held in the queue.
+func (q *NodePriorityQueue) Has(id int) bool {
+	_, ok := q.indexOf[id]
+	return ok
+}
+
+// Priority returns the priority of id and whether id is held in the queue.
+func (q *NodePriorityQueue) Priority(id int) (priority float64, ok bool) {
+	i, ok := q.indexOf[id]
+	if !ok {
+		return 0, false
+	}
+	return q.items[i].priority, true
+}
+
+// Push adds id to the queue with the given priority. Push will panic if id
+// is already held in the queue.
+func (q *NodePriorityQueue) Push(id int, priority float64) {
+	if _, ok := q.indexOf[id]; ok {
+		panic("simple: push duplicate ID")
+	}
+	i := len(q.items)
+	q.indexOf[id] = i
+	q.items = append(q.items, queueItem{id: id, priority: priority})
+	q.up(i)
+}
+
+// Pop removes and returns the ID with the lowest priority in the queue, and
+// its priority. Pop will panic if the queue is empty.
+func (q *NodePriorityQueue) Pop() (id int, priority float64) {
+	if len(q.items) == 0 {
+		panic("simple: pop from empty queue")
+	}
+	top := q.items[0]
+	n := len(q.items) - 1
+	q.swap(0, n)
+	q.items = q.items[:n]
+	delete(q.indexOf, top.id)
+	q.down(0)
+	return top.id, top.priority
+}
+
+// DecreaseKey sets the priority of the queued id to newPriority. DecreaseKey
+// will panic if id is not held in the queue or if newPriority is greater
+// than the current priority of id.
+func (q *NodePriorityQueue) DecreaseKey(id int, newPriority float64) {
+	i, ok := q.indexOf[id]
+	if !ok {
+		panic("simple: decrease key of absent ID")
+	}
+	if newPriority > q.items[i].priority {
+		panic("simple: decrease key increases priority")
+	}
+	q.items[i].priority = newPriority
+	q.up(i)
+}
+
+func (q *NodePriorityQueue) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.indexOf[q.items[i].id] = i
+	q.indexOf[q.items[j].id] = j
+}
+
+func (q *NodePriorityQueue) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !(q.items[i].priority < q.items[p].priority) {
+			break
+		}
+		q.swap(i, p)
+		i = p
+	}
+}
+
+func (q *NodePriorityQueue) down(i int) {
+	n := len(q.items)
+	for {
+		l := 2*i + 1
+		if l >= n {
+			break
+		}
+		c := l
+		if r := l + 1; r < n && q.items[r].priority < q.items[l].priority {
+			c = r
+		}
+		if !(q.items[c].priority < q.items[i].priority) {
+			break
+		}
+		q.swap(i, c)
+		i = c
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNodePriorityQueue(t *testing.T) {
+	q := NewNodePriorityQueue()
+	q.Push(1, 5)
+	q.Push(2, 3)
+	q.Push(3, 4)
+	q.Push(4, 1)
+
+	if q.Len() != 4 {
+		t.Errorf("unexpected queue length: got:%d want:4", q.Len())
+	}
+	if p, ok := q.Priority(1); p != 5 || !ok {
+		t.Errorf("unexpected priority for queued ID: got:(%v, %t) want:(5, true)", p, ok)
+	}
+	if _, ok := q.Priority(5); ok {
+		t.Error("unexpected priority for absent ID")
+	}
+
+	q.DecreaseKey(1, 2)
+	q.DecreaseKey(3, 3)
+
+	want := []queueItem{{4, 1}, {1, 2}, {2, 3}, {3, 3}}
+	for i, w := range want {
+		id, p := q.Pop()
+		// IDs 2 and 3 have equal priority and
+		// may be returned in either order.
+		if p != w.priority || (i < 2 && id != w.id) {
+			t.Errorf("unexpected pop %d: got:(%d, %v) want:(%d, %v)", i, id, p, w.id, w.priority)
+		}
+		if q.Has(id) {
+			t.Errorf("popped ID %d still held in queue", id)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("unexpected queue length after draining: got:%d want:0", q.Len())
+	}
+
+	// IDs may be reused after being popped.
+	q.Push(4, 7)
+	if id, p := q.Pop(); id != 4 || p != 7 {
+		t.Errorf("unexpected pop of reused ID: got:(%d, %v) want:(4, 7)", id, p)
+	}
+}
+
+func TestNodePriorityQueuePanics(t *testing.T) {
+	q := NewNodePriorityQueue()
+	q.Push(1, 1)
+
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "duplicate push", fn: func() { q.Push(1, 0) }},
+		{name: "decrease key of absent ID", fn: func() { q.DecreaseKey(2, 0) }},
+		{name: "decrease key increasing priority", fn: func() { q.DecreaseKey(1, 2) }},
+		{name: "pop from empty queue", fn: func() { q.Pop(); q.Pop() }},
+	} {
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			test.fn()
+			return
+		}()
+		if !panicked {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func TestNodePriorityQueueRandom(t *testing.T) {
+	const n = 1000
+	rnd := rand.New(rand.NewSource(1))
+
+	q := NewNodePriorityQueue()
+	priority := make(map[int]float64)
+	for _, id := range rnd.Perm(n) {
+		p := rnd.Float64()
+		q.Push(id, p)
+		priority[id] = p
+	}
+	for i := 0; i < n; i++ {
+		id := rnd.Intn(n)
+		p := priority[id] * rnd.Float64()
+		q.DecreaseKey(id, p)
+		priority[id] = p
+	}
+
+	want := make([]float64, 0, n)
+	for _, p := range priority {
+		want = append(want, p)
+	}
+	sort.Float64s(want)
+
+	for i, w := range want {
+		id, p := q.Pop()
+		if p != w {
+			t.Fatalf("unexpected priority for pop %d: got:%v want:%v", i, p, w)
+		}
+		if p != priority[id] {
+			t.Fatalf("unexpected priority for ID %d: got:%v want:%v", id, p, priority[id])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"golang.org/x/tools/container/intsets"
+
+	"github.com/gonum/graph"
+)
+
+// KeepReachableFrom removes every node of g, and the edges attached to it,
+// that is not reachable from any of the given roots by following edges in
+// their direction. Roots that are not in g are ignored, so if no root is in g
+// all nodes are removed.
+func (g *DirectedGraph) KeepReachableFrom(roots []graph.Node) {
+	reached := g.reachableFrom(roots)
+	for _, n := range g.Nodes() {
+		if !reached.Has(n.ID()) {
+			g.RemoveNode(n)
+		}
+	}
+}
+
+// ReachableSubgraph returns a new graph holding the nodes of g that are
+// reachable from any of the given roots by following edges in their direction,
+// and the edges of g between them. The returned graph has the self and absent
+// weights of g and shares its nodes and edges. Roots that are not in g are
+// ignored.
+func (g *DirectedGraph) ReachableSubgraph(roots []graph.Node) *DirectedGraph {
+	reached := g.reachableFrom(roots)
+	sub := NewDirectedGraph(g.self, g.absent)
+	for _, n := range g.nodes {
+		if reached.Has(n.ID()) {
+			sub.AddNode(n)
+		}
+	}
+	for i, u := range g.nodes {
+		if !reached.Has(u.ID()) {
+			continue
+		}
+		// All nodes reachable from a reachable
+		// node are reachable.
+		for _, h := range g.from[i] {
+			sub.SetEdge(h.edge)
+		}
+	}
+	return sub
+}
+
+// reachableFrom returns the IDs of the nodes of g reachable from the roots.
+// The traversal is performed here rather than with traverse.DepthFirst since
+// the traverse tests depend on this package.
+func (g *DirectedGraph) reachableFrom(roots []graph.Node) *intsets.Sparse {
+	var (
+		reached intsets.Sparse
+		stack   []int
+	)
+	for _, r := range roots {
+		i, ok := g.indexOf[r.ID()]
+		if !ok || reached.Has(r.ID()) {
+			continue
+		}
+		reached.Insert(r.ID())
+		stack = append(stack, i)
+		for len(stack) != 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, h := range g.from[i] {
+				if reached.Insert(h.id) {
+					stack = append(stack, g.indexOf[h.id])
+				}
+			}
+		}
+	}
+	return &reached
+}
@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var reachableTests = []struct {
+	name  string
+	roots []int
+
+	wantNodes []int
+	wantEdges [][3]float64
+}{
+	{
+		name:      "single root",
+		roots:     []int{0},
+		wantNodes: []int{0, 1, 2},
+		wantEdges: [][3]float64{{0, 1, 1}, {1, 2, 2}, {2, 0, 3}},
+	},
+	{
+		name:      "multiple roots",
+		roots:     []int{3, 5},
+		wantNodes: []int{0, 1, 2, 3, 4, 5},
+		wantEdges: [][3]float64{{0, 1, 1}, {1, 2, 2}, {2, 0, 3}, {3, 1, 4}, {3, 4, 5}},
+	},
+	{
+		name:      "leaf root",
+		roots:     []int{4, 10},
+		wantNodes: []int{4},
+	},
+	{
+		name:  "absent root",
+		roots: []int{10},
+	},
+	{
+		name: "no roots",
+	},
+}
+
+// reachableGraph returns a graph with a cycle 0->1->2->0, entered from 3,
+// with 4 reachable only from 3 and an isolated node 5.
+func reachableGraph() *DirectedGraph {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(2), T: Node(0), W: 3})
+	g.SetEdge(Edge{F: Node(3), T: Node(1), W: 4})
+	g.SetEdge(Edge{F: Node(3), T: Node(4), W: 5})
+	g.AddNode(Node(5))
+	return g
+}
+
+func TestReachable(t *testing.T) {
+	for _, test := range reachableTests {
+		var roots []graph.Node
+		for _, id := range test.roots {
+			roots = append(roots, Node(id))
+		}
+
+		g := reachableGraph()
+		sub := g.ReachableSubgraph(roots)
+		if g.Size() != 5 || len(g.Nodes()) != 6 {
+			t.Errorf("ReachableSubgraph modified graph for %q", test.name)
+		}
+		checkReachable(t, "ReachableSubgraph", test.name, sub, test.wantNodes, test.wantEdges)
+
+		g.KeepReachableFrom(roots)
+		checkReachable(t, "KeepReachableFrom", test.name, g, test.wantNodes, test.wantEdges)
+	}
+}
+
+func checkReachable(t *testing.T, fn, name string, g *DirectedGraph, wantNodes []int, wantEdges [][3]float64) {
+	if got := nodeIDs(g.NodesSorted()); !reflect.DeepEqual(got, wantNodes) {
+		t.Errorf("unexpected nodes from %s for %q: got:%v want:%v", fn, name, got, wantNodes)
+	}
+	var edges []graph.Edge
+	for _, n := range g.NodesSorted() {
+		out, _ := g.IncidentEdges(n)
+		edges = append(edges, out...)
+	}
+	if got := edgeIDs(edges); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("unexpected edges from %s for %q: got:%v want:%v", fn, name, got, wantEdges)
+	}
+	if g.Size() != len(wantEdges) {
+		t.Errorf("unexpected size from %s for %q: got:%d want:%d", fn, name, g.Size(), len(wantEdges))
+	}
+}
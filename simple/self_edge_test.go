@@ -0,0 +1,199 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+// selfEdgeGraph is the set of methods checked for
+// conformance to the self edge convention.
+type selfEdgeGraph interface {
+	sizedGraph
+	graph.Weighter
+	Degree(graph.Node) int
+}
+
+type selfEdgeBuilder interface {
+	selfEdgeGraph
+	graph.EdgeSetter
+}
+
+// TestSelfEdgeConvention checks that all simple graph implementations
+// treat self edges identically: setting a self edge panics, leaving the
+// graph unaltered, and no node is adjacent to itself.
+func TestSelfEdgeConvention(t *testing.T) {
+	const n = 3
+	newBuilders := []func() selfEdgeBuilder{
+		func() selfEdgeBuilder {
+			g := NewDirectedGraph(0, math.Inf(1))
+			for i := 0; i < n; i++ {
+				g.AddNode(Node(i))
+			}
+			return g
+		},
+		func() selfEdgeBuilder {
+			g := NewUndirectedGraph(0, math.Inf(1))
+			for i := 0; i < n; i++ {
+				g.AddNode(Node(i))
+			}
+			return g
+		},
+		func() selfEdgeBuilder {
+			return NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+		},
+		func() selfEdgeBuilder {
+			return NewUndirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+		},
+		func() selfEdgeBuilder {
+			return NewDirectedBitMatrix(n)
+		},
+		func() selfEdgeBuilder {
+			return NewUndirectedBitMatrix(n)
+		},
+	}
+
+	for _, newBuilder := range newBuilders {
+		g := newBuilder()
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+
+		if !panics(func() { g.SetEdge(Edge{F: Node(0), T: Node(0), W: 1}) }) {
+			t.Errorf("expected panic for self edge in %T", g)
+		}
+		checkNoSelfEdges(t, g)
+	}
+
+	// The compressed sparse row graphs are immutable,
+	// so must reject self edges held by their source.
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < n; i++ {
+		dg.AddNode(Node(i))
+		ug.AddNode(Node(i))
+	}
+	dg.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	ug.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	checkNoSelfEdges(t, mustDirectedCSR(dg))
+	checkNoSelfEdges(t, mustUndirectedCSR(ug))
+	const want = "simple: self edge on node 0"
+	if _, err := NewDirectedCSR(withSelfEdges{dg}, 0, math.Inf(1)); err == nil || err.Error() != want {
+		t.Errorf("unexpected error for self edge in DirectedCSR source: got:%v want:%s", err, want)
+	}
+	if _, err := NewUndirectedCSR(withSelfEdges{ug}, 0, math.Inf(1)); err == nil || err.Error() != want {
+		t.Errorf("unexpected error for self edge in UndirectedCSR source: got:%v want:%s", err, want)
+	}
+}
+
+// checkNoSelfEdges checks that g holds a single edge between nodes 0 and 1,
+// and that no node in g is adjacent to itself.
+func checkNoSelfEdges(t *testing.T, g selfEdgeGraph) {
+	_, directed := g.(graph.Directed)
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			if v.ID() == u.ID() {
+				t.Errorf("unexpected self neighbor of %d in %T", u.ID(), g)
+			}
+		}
+		if g.HasEdgeBetween(u, u) || g.Edge(u, u) != nil {
+			t.Errorf("unexpected self edge for %d in %T", u.ID(), g)
+		}
+		if w, ok := g.Weight(u, u); w != 0 || !ok {
+			t.Errorf("unexpected self weight for %d in %T: got:(%v, %t) want:(0, true)", u.ID(), g, w, ok)
+		}
+	}
+
+	if size := g.Size(); size != 1 {
+		t.Errorf("unexpected size of %T: got:%d want:1", g, size)
+	}
+	var edges int
+	g.VisitEdges(func(e graph.Edge, _ float64) bool {
+		if e.From().ID() == e.To().ID() {
+			t.Errorf("unexpected self edge visited in %T", g)
+		}
+		edges++
+		return true
+	})
+	if edges != 1 {
+		t.Errorf("unexpected number of visited edges in %T: got:%d want:1", g, edges)
+	}
+
+	wantDegree := map[int]int{0: 1, 1: 1, 2: 0}
+	for id, want := range wantDegree {
+		if d := g.Degree(Node(id)); d != want {
+			t.Errorf("unexpected degree of %d in %T: got:%d want:%d", id, g, d, want)
+		}
+	}
+	if directed {
+		d := g.(graph.DirectedDegreer)
+		if in, out := d.InDegree(Node(0)), d.OutDegree(Node(0)); in != 0 || out != 1 {
+			t.Errorf("unexpected in and out degree of 0 in %T: got:(%d, %d) want:(0, 1)", g, in, out)
+		}
+		if in, out := d.InDegree(Node(1)), d.OutDegree(Node(1)); in != 1 || out != 0 {
+			t.Errorf("unexpected in and out degree of 1 in %T: got:(%d, %d) want:(1, 0)", g, in, out)
+		}
+	}
+}
+
+// panics returns whether fn panics.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return
+}
+
+// mustDirectedCSR returns a DirectedCSR holding g, panicking on error.
+func mustDirectedCSR(g graph.Directed) *DirectedCSR {
+	c, err := NewDirectedCSR(g, 0, math.Inf(1))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// mustUndirectedCSR returns an UndirectedCSR holding g, panicking on error.
+func mustUndirectedCSR(g graph.Undirected) *UndirectedCSR {
+	c, err := NewUndirectedCSR(g, 0, math.Inf(1))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// withSelfEdges wraps a graph, adding a self edge to every node.
+type withSelfEdges struct {
+	graph.Graph
+}
+
+func (g withSelfEdges) From(u graph.Node) []graph.Node {
+	return append(g.Graph.From(u), u)
+}
+
+func (g withSelfEdges) To(u graph.Node) []graph.Node {
+	return append(g.Graph.(graph.Directed).To(u), u)
+}
+
+func (g withSelfEdges) HasEdgeBetween(u, v graph.Node) bool {
+	return u.ID() == v.ID() || g.Graph.HasEdgeBetween(u, v)
+}
+
+func (g withSelfEdges) HasEdgeFromTo(u, v graph.Node) bool {
+	return u.ID() == v.ID() || g.Graph.(graph.Directed).HasEdgeFromTo(u, v)
+}
+
+func (g withSelfEdges) Edge(u, v graph.Node) graph.Edge {
+	if u.ID() == v.ID() {
+		return Edge{F: u, T: v, W: 1}
+	}
+	return g.Graph.Edge(u, v)
+}
+
+func (g withSelfEdges) EdgeBetween(u, v graph.Node) graph.Edge {
+	return g.Edge(u, v)
+}
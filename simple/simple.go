@@ -4,6 +4,15 @@
 
 // Package simple provides a suite of simple graph implementations satisfying
 // the gonum/graph interfaces.
+//
+// All graphs in the package are simple graphs: they hold no self edges and at
+// most one edge between an ordered pair of nodes for directed graphs, or an
+// unordered pair of nodes for undirected graphs. Attempting to set a self edge
+// causes a panic, the matrix and compressed sparse row constructors return an
+// error when given a graph holding a self edge, and the Weight method returns
+// the graph's self value for a node paired with itself. Consequently no node is
+// adjacent to itself, and self edges never contribute to degree, size or edge
+// listings.
 package simple
 
 import (
@@ -35,6 +44,30 @@ func (e Edge) To() graph.Node { return e.T }
 // Weight returns the weight of the edge.
 func (e Edge) Weight() float64 { return e.W }
 
+// WithWeight returns a copy of the edge with the weight w.
+func (e Edge) WithWeight(w float64) graph.Edge { return Edge{F: e.F, T: e.T, W: w} }
+
+// Reweighter is an edge that can return a copy of itself with a different
+// weight. Edge types holding labels or other data should implement Reweighter
+// to retain the data when their weight is changed by SetEdgeWeight.
+type Reweighter interface {
+	graph.Edge
+
+	// WithWeight returns a copy of the edge
+	// with the weight w.
+	WithWeight(w float64) graph.Edge
+}
+
+// reweighted returns e with the weight w, retaining the type of e if it
+// is a Reweighter. Otherwise an Edge with the terminal nodes of e and
+// the weight w is returned.
+func reweighted(e graph.Edge, w float64) graph.Edge {
+	if r, ok := e.(Reweighter); ok {
+		return r.WithWeight(w)
+	}
+	return Edge{F: e.From(), T: e.To(), W: w}
+}
+
 // maxInt is the maximum value of the machine-dependent int type.
 const maxInt int = int(^uint(0) >> 1)
 
@@ -0,0 +1,191 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+type sizedGraph interface {
+	graph.Graph
+	Order() int
+	Size() int
+	VisitEdges(func(graph.Edge, float64) bool)
+}
+
+func TestSize(t *testing.T) {
+	const n = 50
+	rnd := rand.New(rand.NewSource(1))
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	dm := NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	um := NewUndirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	db := NewDirectedBitMatrix(n)
+	ub := NewUndirectedBitMatrix(n)
+	for i := 0; i < n; i++ {
+		dg.AddNode(Node(i))
+		ug.AddNode(Node(i))
+	}
+
+	check := func(step string) {
+		for _, test := range []struct {
+			name  string
+			g     sizedGraph
+			edges []graph.Edge
+		}{
+			{name: "DirectedGraph", g: dg, edges: dg.Edges()},
+			{name: "UndirectedGraph", g: ug, edges: ug.Edges()},
+			{name: "DirectedMatrix", g: dm, edges: dm.Edges()},
+			{name: "UndirectedMatrix", g: um, edges: um.Edges()},
+			{name: "DirectedBitMatrix", g: db, edges: db.Edges()},
+			{name: "UndirectedBitMatrix", g: ub, edges: ub.Edges()},
+			{name: "DirectedCSR", g: mustDirectedCSR(dg), edges: dg.Edges()},
+			{name: "UndirectedCSR", g: mustUndirectedCSR(ug), edges: ug.Edges()},
+		} {
+			checkSize(t, step+" "+test.name, test.g, test.edges)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		u, v := Node(rnd.Intn(n)), Node(rnd.Intn(n))
+		e := Edge{F: u, T: v, W: rnd.Float64()}
+		if u == v {
+			// Self edges are rejected and must
+			// not contribute to size.
+			for _, g := range []graph.EdgeSetter{dg, ug, dm, um, db, ub} {
+				if !panics(func() { g.SetEdge(e) }) {
+					t.Errorf("expected panic for self edge in %T", g)
+				}
+			}
+			for _, g := range []graph.EdgeRemover{dg, ug, dm, um, db, ub} {
+				g.RemoveEdge(e)
+			}
+			continue
+		}
+		if rnd.Float64() < 0.2 {
+			for _, g := range []graph.EdgeRemover{dg, ug, dm, um, db, ub} {
+				g.RemoveEdge(e)
+			}
+			continue
+		}
+		for _, g := range []graph.EdgeSetter{dg, ug, dm, um, db, ub} {
+			g.SetEdge(e)
+		}
+	}
+	check("random")
+
+	for _, id := range []int{0, 7, 13} {
+		for _, g := range []graph.NodeRemover{dg, ug, dm, um} {
+			g.RemoveNode(Node(id))
+		}
+		for _, g := range []graph.EdgeRemover{db, ub} {
+			for j := 0; j < n; j++ {
+				if j == id {
+					continue
+				}
+				g.RemoveEdge(Edge{F: Node(id), T: Node(j)})
+				g.RemoveEdge(Edge{F: Node(j), T: Node(id)})
+			}
+		}
+	}
+	check("removed nodes")
+}
+
+// checkSize checks the size and visited edges of g against the edges
+// held by g, which are expected to be edges.
+func checkSize(t *testing.T, name string, g sizedGraph, edges []graph.Edge) {
+	if g.Order() != len(g.Nodes()) {
+		t.Errorf("unexpected order for %s: got:%d want:%d", name, g.Order(), len(g.Nodes()))
+	}
+	if g.Size() != len(edges) {
+		t.Errorf("unexpected size for %s: got:%d want:%d", name, g.Size(), len(edges))
+	}
+
+	want := make(map[[2]int]float64)
+	for _, e := range edges {
+		want[[2]int{e.From().ID(), e.To().ID()}] = e.Weight()
+		if _, ok := g.(graph.Directed); !ok {
+			want[[2]int{e.To().ID(), e.From().ID()}] = e.Weight()
+		}
+	}
+	var count int
+	g.VisitEdges(func(e graph.Edge, w float64) bool {
+		count++
+		key := [2]int{e.From().ID(), e.To().ID()}
+		if ew, ok := want[key]; !ok || ew != w || e.Weight() != w {
+			t.Errorf("unexpected edge visited for %s: %d->%d weight %v", name, key[0], key[1], w)
+		}
+		return true
+	})
+	if count != len(edges) {
+		t.Errorf("unexpected number of visited edges for %s: got:%d want:%d", name, count, len(edges))
+	}
+
+	if len(edges) < 2 {
+		return
+	}
+	count = 0
+	g.VisitEdges(func(graph.Edge, float64) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("unexpected number of visited edges for %s after stop: got:%d want:1", name, count)
+	}
+}
+
+func TestSizeDirectedPairs(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 2})
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 3})
+	if g.Size() != 2 {
+		t.Errorf("unexpected size for directed pair: got:%d want:2", g.Size())
+	}
+	g.RemoveEdge(Edge{F: Node(1), T: Node(2)})
+	g.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+	g.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+	if g.Size() != 1 {
+		t.Errorf("unexpected size after edge removal: got:%d want:1", g.Size())
+	}
+	g.RemoveNode(Node(0))
+	if g.Size() != 0 {
+		t.Errorf("unexpected size after node removal: got:%d want:0", g.Size())
+	}
+
+	u := NewUndirectedGraph(0, math.Inf(1))
+	u.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	u.SetEdge(Edge{F: Node(1), T: Node(0), W: 2})
+	if u.Size() != 1 {
+		t.Errorf("unexpected size for undirected pair: got:%d want:1", u.Size())
+	}
+}
+
+func TestOrderSynchronized(t *testing.T) {
+	for _, g := range []interface {
+		graph.Graph
+		graph.Builder
+		graph.NodeRemover
+		Order() int
+	}{
+		NewSynchronizedDirectedGraph(0, math.Inf(1)),
+		NewSynchronizedUndirectedGraph(0, math.Inf(1)),
+	} {
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+		g.AddNode(Node(5))
+		if g.Order() != 3 {
+			t.Errorf("unexpected order for %T: got:%d want:3", g, g.Order())
+		}
+		g.RemoveNode(Node(0))
+		if g.Order() != 2 {
+			t.Errorf("unexpected order for %T after node removal: got:%d want:2", g, g.Order())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "github.com/gonum/graph"
+
+// BuildFromEdges returns a DirectedGraph with the specified self and absent
+// edge weight values, holding the edges received from the edges channel.
+// Nodes are added to the graph as the edges referring to them are received,
+// and a later edge between the same pair of nodes replaces an earlier one.
+// BuildFromEdges returns when the edges channel is closed.
+//
+// BuildFromEdges will panic if a self edge is received.
+func BuildFromEdges(edges <-chan graph.Edge, self, absent float64) *DirectedGraph {
+	g := NewDirectedGraph(self, absent)
+	for e := range edges {
+		g.SetEdge(e)
+	}
+	return g
+}
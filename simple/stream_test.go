@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+func TestBuildFromEdges(t *testing.T) {
+	const n = 100
+	for _, buffer := range []int{0, 1, n} {
+		edges := make(chan graph.Edge, buffer)
+		go func() {
+			for i := 0; i < n; i++ {
+				edges <- Edge{F: Node(i), T: Node((i + 1) % n), W: float64(i)}
+			}
+			// Replace the first edge.
+			edges <- Edge{F: Node(0), T: Node(1), W: -1}
+			close(edges)
+		}()
+
+		g := BuildFromEdges(edges, 0, math.Inf(1))
+		if len(g.Nodes()) != n {
+			t.Errorf("unexpected number of nodes with buffer %d: got:%d want:%d", buffer, len(g.Nodes()), n)
+		}
+		if g.Size() != n {
+			t.Errorf("unexpected number of edges with buffer %d: got:%d want:%d", buffer, g.Size(), n)
+		}
+		for i := 1; i < n; i++ {
+			if w, ok := g.Weight(Node(i), Node((i+1)%n)); w != float64(i) || !ok {
+				t.Errorf("unexpected weight for edge from %d with buffer %d: got:(%v, %t) want:(%d, true)", i, buffer, w, ok, i)
+			}
+		}
+		if w, _ := g.Weight(Node(0), Node(1)); w != -1 {
+			t.Errorf("unexpected weight for replaced edge with buffer %d: got:%v want:-1", buffer, w)
+		}
+	}
+
+	edges := make(chan graph.Edge)
+	close(edges)
+	if g := BuildFromEdges(edges, 0, math.Inf(1)); len(g.Nodes()) != 0 {
+		t.Errorf("unexpected nodes from empty stream: %v", g.Nodes())
+	}
+}
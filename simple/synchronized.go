@@ -0,0 +1,498 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"sync"
+
+	"github.com/gonum/graph"
+)
+
+// SynchronizedDirectedGraph is a DirectedGraph that is safe for concurrent
+// use by multiple goroutines. Query methods take a shared read lock and
+// mutating methods take an exclusive write lock.
+//
+// Each method call is individually atomic; sequences of calls are not. In
+// particular, an ID returned by NewNodeID may be taken by another goroutine
+// before it is used. Algorithms that make many queries and need a consistent
+// view of the graph should operate on the graph returned by Snapshot.
+//
+// Methods of DirectedGraph that are not defined on SynchronizedDirectedGraph,
+// including observer registration, VisitEdges, IncidentEdges,
+// ReachableSubgraph and Validate, are not provided.
+type SynchronizedDirectedGraph struct {
+	mu sync.RWMutex
+	g  *DirectedGraph
+}
+
+// NewSynchronizedDirectedGraph returns a SynchronizedDirectedGraph with the
+// specified self and absent edge weight values.
+func NewSynchronizedDirectedGraph(self, absent float64) *SynchronizedDirectedGraph {
+	return &SynchronizedDirectedGraph{g: NewDirectedGraph(self, absent)}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID does
+// not become a valid ID in g until it is added to g.
+func (g *SynchronizedDirectedGraph) NewNodeID() int {
+	// NewNodeID may alter the free ID
+	// set, so requires the write lock.
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.NewNodeID()
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *SynchronizedDirectedGraph) AddNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.AddNode(n)
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *SynchronizedDirectedGraph) RemoveNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveNode(n)
+}
+
+// SetEdge adds e, an edge from one node to another. If the nodes do not exist, they are added.
+// It will panic if the IDs of the e.From and e.To are equal.
+func (g *SynchronizedDirectedGraph) SetEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetEdge(e)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
+// it is a no-op.
+func (g *SynchronizedDirectedGraph) RemoveEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveEdge(e)
+}
+
+// ClearEdges removes all the edges from g, leaving its nodes.
+func (g *SynchronizedDirectedGraph) ClearEdges() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.ClearEdges()
+}
+
+// SetEdgeWeight sets the weight of the edge from the From node of e to the
+// To node of e to w, as described for DirectedGraph.SetEdgeWeight.
+func (g *SynchronizedDirectedGraph) SetEdgeWeight(e graph.Edge, w float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetEdgeWeight(e, w)
+}
+
+// MapWeights replaces the weight of each edge in g with the result of applying
+// f to the weight. The write lock is held while f is called, so f must not call
+// the methods of g.
+func (g *SynchronizedDirectedGraph) MapWeights(f func(float64) float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.MapWeights(f)
+}
+
+// Contract contracts the edges between u and v, merging v into u, and returns
+// the merged node, as described for DirectedGraph.Contract. The write lock is
+// held while resolve is called, so resolve must not call the methods of g.
+func (g *SynchronizedDirectedGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.Contract(u, v, resolve)
+}
+
+// KeepReachableFrom removes every node of g, and the edges attached to it,
+// that is not reachable from any of the given roots, as described for
+// DirectedGraph.KeepReachableFrom.
+func (g *SynchronizedDirectedGraph) KeepReachableFrom(roots []graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.KeepReachableFrom(roots)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *SynchronizedDirectedGraph) Node(id int) graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *SynchronizedDirectedGraph) Has(n graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *SynchronizedDirectedGraph) Nodes() []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (g *SynchronizedDirectedGraph) Edges() []graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edges()
+}
+
+// Order returns the number of nodes in g.
+func (g *SynchronizedDirectedGraph) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Order()
+}
+
+// Size returns the number of edges in g. Edges in opposite directions
+// between a pair of nodes are counted separately.
+func (g *SynchronizedDirectedGraph) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Size()
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *SynchronizedDirectedGraph) From(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.From(n)
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *SynchronizedDirectedGraph) To(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.To(n)
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false. The neighbors of n are
+// collected while the read lock is held and fn is called after it is released,
+// so fn may call the methods of g.
+func (g *SynchronizedDirectedGraph) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	g.mu.RLock()
+	adj := neighborsOf(g.g.VisitFrom, n)
+	g.mu.RUnlock()
+	visitNeighbors(adj, fn)
+}
+
+// VisitTo calls fn with each node that can reach directly to n and the weight
+// of the joining edge until fn returns false. The neighbors of n are collected
+// while the read lock is held and fn is called after it is released, so fn may
+// call the methods of g.
+func (g *SynchronizedDirectedGraph) VisitTo(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	g.mu.RLock()
+	adj := neighborsOf(g.g.VisitTo, n)
+	g.mu.RUnlock()
+	visitNeighbors(adj, fn)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *SynchronizedDirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *SynchronizedDirectedGraph) Edge(u, v graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edge(u, v)
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *SynchronizedDirectedGraph) HasEdgeFromTo(u, v graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeFromTo(u, v)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (g *SynchronizedDirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Weight(x, y)
+}
+
+// Degree returns the in+out degree of n in g.
+func (g *SynchronizedDirectedGraph) Degree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Degree(n)
+}
+
+// InDegree returns the number of edges in g ending at n.
+func (g *SynchronizedDirectedGraph) InDegree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.InDegree(n)
+}
+
+// OutDegree returns the number of edges in g starting at n.
+func (g *SynchronizedDirectedGraph) OutDegree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.OutDegree(n)
+}
+
+// Snapshot returns an immutable copy of g. The returned graph is not affected
+// by later changes to g and may be used without locking.
+func (g *SynchronizedDirectedGraph) Snapshot() *DirectedCSR {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c, err := NewDirectedCSR(g.g, g.g.self, g.g.absent)
+	if err != nil {
+		// A DirectedGraph holds no self edges.
+		panic(err)
+	}
+	return c
+}
+
+// SynchronizedUndirectedGraph is an UndirectedGraph that is safe for concurrent
+// use by multiple goroutines. Query methods take a shared read lock and
+// mutating methods take an exclusive write lock.
+//
+// Each method call is individually atomic; sequences of calls are not. In
+// particular, an ID returned by NewNodeID may be taken by another goroutine
+// before it is used. Algorithms that make many queries and need a consistent
+// view of the graph should operate on the graph returned by Snapshot.
+//
+// Methods of UndirectedGraph that are not defined on SynchronizedUndirectedGraph,
+// including observer registration, VisitEdges and
+// IncidentEdges, are not provided.
+type SynchronizedUndirectedGraph struct {
+	mu sync.RWMutex
+	g  *UndirectedGraph
+}
+
+// NewSynchronizedUndirectedGraph returns a SynchronizedUndirectedGraph with the
+// specified self and absent edge weight values.
+func NewSynchronizedUndirectedGraph(self, absent float64) *SynchronizedUndirectedGraph {
+	return &SynchronizedUndirectedGraph{g: NewUndirectedGraph(self, absent)}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID does
+// not become a valid ID in g until it is added to g.
+func (g *SynchronizedUndirectedGraph) NewNodeID() int {
+	// NewNodeID may alter the free ID
+	// set, so requires the write lock.
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.NewNodeID()
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *SynchronizedUndirectedGraph) AddNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.AddNode(n)
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *SynchronizedUndirectedGraph) RemoveNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveNode(n)
+}
+
+// SetEdge adds e, an edge from one node to another. If the nodes do not exist, they are added.
+// It will panic if the IDs of the e.From and e.To are equal.
+func (g *SynchronizedUndirectedGraph) SetEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetEdge(e)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
+// it is a no-op.
+func (g *SynchronizedUndirectedGraph) RemoveEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveEdge(e)
+}
+
+// ClearEdges removes all the edges from g, leaving its nodes.
+func (g *SynchronizedUndirectedGraph) ClearEdges() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.ClearEdges()
+}
+
+// SetEdgeWeight sets the weight of the edge between the terminal nodes of e
+// to w, as described for UndirectedGraph.SetEdgeWeight.
+func (g *SynchronizedUndirectedGraph) SetEdgeWeight(e graph.Edge, w float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetEdgeWeight(e, w)
+}
+
+// MapWeights replaces the weight of each edge in g with the result of applying
+// f to the weight. The write lock is held while f is called, so f must not call
+// the methods of g.
+func (g *SynchronizedUndirectedGraph) MapWeights(f func(float64) float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.MapWeights(f)
+}
+
+// Contract contracts the edge between u and v, merging v into u, and returns
+// the merged node, as described for UndirectedGraph.Contract. The write lock is
+// held while resolve is called, so resolve must not call the methods of g.
+func (g *SynchronizedUndirectedGraph) Contract(u, v graph.Node, resolve func(x, y float64) float64) graph.Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.Contract(u, v, resolve)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *SynchronizedUndirectedGraph) Node(id int) graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *SynchronizedUndirectedGraph) Has(n graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *SynchronizedUndirectedGraph) Nodes() []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (g *SynchronizedUndirectedGraph) Edges() []graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edges()
+}
+
+// Order returns the number of nodes in g.
+func (g *SynchronizedUndirectedGraph) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Order()
+}
+
+// Size returns the number of edges in g.
+func (g *SynchronizedUndirectedGraph) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Size()
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *SynchronizedUndirectedGraph) From(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.From(n)
+}
+
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false. The neighbors of n are
+// collected while the read lock is held and fn is called after it is released,
+// so fn may call the methods of g.
+func (g *SynchronizedUndirectedGraph) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	g.mu.RLock()
+	adj := neighborsOf(g.g.VisitFrom, n)
+	g.mu.RUnlock()
+	visitNeighbors(adj, fn)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *SynchronizedUndirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *SynchronizedUndirectedGraph) Edge(u, v graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edge(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g *SynchronizedUndirectedGraph) EdgeBetween(x, y graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.EdgeBetween(x, y)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (g *SynchronizedUndirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Weight(x, y)
+}
+
+// Degree returns the degree of n in g.
+func (g *SynchronizedUndirectedGraph) Degree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Degree(n)
+}
+
+// Snapshot returns an immutable copy of g. The returned graph is not affected
+// by later changes to g and may be used without locking.
+func (g *SynchronizedUndirectedGraph) Snapshot() *UndirectedCSR {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c, err := NewUndirectedCSR(g.g, g.g.self, g.g.absent)
+	if err != nil {
+		// An UndirectedGraph holds no self edges.
+		panic(err)
+	}
+	return c
+}
+
+// weightedNeighbor is a neighboring node and
+// the weight of the edge joining it.
+type weightedNeighbor struct {
+	node   graph.Node
+	weight float64
+}
+
+// neighborsOf returns the neighbors of n visited by visit.
+func neighborsOf(visit func(graph.Node, func(graph.Node, float64) bool), n graph.Node) []weightedNeighbor {
+	var adj []weightedNeighbor
+	visit(n, func(v graph.Node, w float64) bool {
+		adj = append(adj, weightedNeighbor{node: v, weight: w})
+		return true
+	})
+	return adj
+}
+
+// visitNeighbors calls fn with each of the neighbors in adj until fn returns false.
+func visitNeighbors(adj []weightedNeighbor, fn func(graph.Node, float64) bool) {
+	for _, a := range adj {
+		if !fn(a.node, a.weight) {
+			return
+		}
+	}
+}
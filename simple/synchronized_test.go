@@ -0,0 +1,219 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var (
+	_ graph.DirectedBuilder   = (*SynchronizedDirectedGraph)(nil)
+	_ graph.NodeRemover       = (*SynchronizedDirectedGraph)(nil)
+	_ graph.EdgeRemover       = (*SynchronizedDirectedGraph)(nil)
+	_ graph.Weighter          = (*SynchronizedDirectedGraph)(nil)
+	_ graph.FromVisitor       = (*SynchronizedDirectedGraph)(nil)
+	_ graph.ToVisitor         = (*SynchronizedDirectedGraph)(nil)
+	_ graph.DirectedDegreer   = (*SynchronizedDirectedGraph)(nil)
+	_ graph.UndirectedBuilder = (*SynchronizedUndirectedGraph)(nil)
+	_ graph.NodeRemover       = (*SynchronizedUndirectedGraph)(nil)
+	_ graph.EdgeRemover       = (*SynchronizedUndirectedGraph)(nil)
+	_ graph.Weighter          = (*SynchronizedUndirectedGraph)(nil)
+	_ graph.FromVisitor       = (*SynchronizedUndirectedGraph)(nil)
+)
+
+// reachable returns the number of nodes reachable from
+// the node with ID 0 in g, using a breadth first search.
+func reachable(g graph.Graph) int {
+	if !g.Has(Node(0)) {
+		return 0
+	}
+	seen := map[int]bool{0: true}
+	queue := []graph.Node{Node(0)}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range g.From(u) {
+			if !seen[v.ID()] {
+				seen[v.ID()] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return len(seen)
+}
+
+func TestSynchronizedGraphConcurrent(t *testing.T) {
+	const (
+		n       = 50
+		writers = 4
+		readers = 4
+		ops     = 500
+	)
+
+	for _, g := range []interface {
+		graph.Graph
+		graph.EdgeSetter
+		graph.EdgeRemover
+		graph.NodeAdder
+		graph.NodeRemover
+		Size() int
+		Snapshot() sizedGraph
+	}{
+		syncDirected{NewSynchronizedDirectedGraph(0, math.Inf(1))},
+		syncUndirected{NewSynchronizedUndirectedGraph(0, math.Inf(1))},
+	} {
+		for i := 0; i < n; i++ {
+			g.AddNode(Node(i))
+		}
+
+		var wg sync.WaitGroup
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				rnd := rand.New(rand.NewSource(seed))
+				for i := 0; i < ops; i++ {
+					u, v := Node(rnd.Intn(n)), Node(rnd.Intn(n))
+					if u == v {
+						continue
+					}
+					if rnd.Intn(3) == 0 {
+						g.RemoveEdge(Edge{F: u, T: v})
+					} else {
+						g.SetEdge(Edge{F: u, T: v, W: 1})
+					}
+					if rnd.Intn(50) == 0 {
+						id := Node(n + rnd.Intn(n))
+						if !g.Has(id) {
+							// Another writer may add the
+							// node between Has and AddNode.
+							func() {
+								defer func() { recover() }()
+								g.AddNode(id)
+							}()
+						}
+						g.RemoveNode(id)
+					}
+				}
+			}(int64(w))
+		}
+		for r := 0; r < readers; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < ops/10; i++ {
+					if got := reachable(g); got > 2*n {
+						t.Errorf("unexpected number of reachable nodes in %T: %d", g, got)
+					}
+					s := g.Snapshot()
+					var edges int
+					s.VisitEdges(func(graph.Edge, float64) bool {
+						edges++
+						return true
+					})
+					if edges != s.Size() {
+						t.Errorf("inconsistent snapshot of %T: visited %d edges, size %d", g, edges, s.Size())
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		s := g.Snapshot()
+		size := s.Size()
+		if size != g.Size() {
+			t.Errorf("unexpected snapshot size for %T: got:%d want:%d", g, size, g.Size())
+		}
+		for i := 1; i < n; i++ {
+			g.SetEdge(Edge{F: Node(0), T: Node(i), W: 1})
+		}
+		if s.Size() != size {
+			t.Errorf("snapshot of %T altered by later mutation", g)
+		}
+		if got := reachable(g); got != n {
+			t.Errorf("unexpected number of reachable nodes in %T: got:%d want:%d", g, got, n)
+		}
+	}
+}
+
+// syncDirected and syncUndirected allow the synchronized
+// graphs' Snapshot methods to be called via an interface.
+type syncDirected struct{ *SynchronizedDirectedGraph }
+
+func (g syncDirected) Snapshot() sizedGraph { return g.SynchronizedDirectedGraph.Snapshot() }
+
+type syncUndirected struct{ *SynchronizedUndirectedGraph }
+
+func (g syncUndirected) Snapshot() sizedGraph { return g.SynchronizedUndirectedGraph.Snapshot() }
+
+func TestSynchronizedGraphVisitCallsGraph(t *testing.T) {
+	// Neighbor visits must not hold the lock while the callback
+	// is called, otherwise these mutations would deadlock.
+	d := NewSynchronizedDirectedGraph(0, math.Inf(1))
+	d.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	d.SetEdge(Edge{F: Node(2), T: Node(0), W: 1})
+	d.VisitFrom(Node(0), func(v graph.Node, w float64) bool {
+		d.SetEdgeWeight(d.Edge(Node(0), v), w+1)
+		return true
+	})
+	d.VisitTo(Node(0), func(v graph.Node, w float64) bool {
+		d.SetEdgeWeight(d.Edge(v, Node(0)), w+2)
+		return true
+	})
+	for _, test := range []struct {
+		u, v int
+		want float64
+	}{{u: 0, v: 1, want: 2}, {u: 2, v: 0, want: 3}} {
+		if w, _ := d.Weight(Node(test.u), Node(test.v)); w != test.want {
+			t.Errorf("unexpected weight of edge %d->%d: got:%v want:%v", test.u, test.v, w, test.want)
+		}
+	}
+
+	u := NewSynchronizedUndirectedGraph(0, math.Inf(1))
+	u.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	u.VisitFrom(Node(0), func(v graph.Node, w float64) bool {
+		u.SetEdgeWeight(u.Edge(Node(0), v), w+1)
+		return true
+	})
+	if w, _ := u.Weight(Node(1), Node(0)); w != 2 {
+		t.Errorf("unexpected weight of edge 0--1: got:%v want:2", w)
+	}
+}
+
+func TestSynchronizedGraphMutators(t *testing.T) {
+	d := NewSynchronizedDirectedGraph(0, math.Inf(1))
+	d.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	d.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	d.SetEdge(Edge{F: Node(3), T: Node(2), W: 3})
+	d.MapWeights(func(w float64) float64 { return 2 * w })
+	if w, _ := d.Weight(Node(1), Node(2)); w != 4 {
+		t.Errorf("unexpected mapped weight: got:%v want:4", w)
+	}
+	d.Contract(Node(1), Node(2), nil)
+	if d.Has(Node(2)) || !d.HasEdgeFromTo(Node(3), Node(1)) {
+		t.Errorf("unexpected directed graph after contraction: %v", d.Edges())
+	}
+	d.KeepReachableFrom([]graph.Node{Node(0)})
+	if got := d.Order(); got != 2 {
+		t.Errorf("unexpected order after keeping reachable nodes: got:%d want:2", got)
+	}
+
+	u := NewSynchronizedUndirectedGraph(0, math.Inf(1))
+	u.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	u.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	u.MapWeights(func(w float64) float64 { return w + 1 })
+	if w, _ := u.Weight(Node(2), Node(1)); w != 3 {
+		t.Errorf("unexpected mapped weight: got:%v want:3", w)
+	}
+	u.Contract(Node(0), Node(1), nil)
+	if u.Has(Node(1)) || !u.HasEdgeBetween(Node(0), Node(2)) {
+		t.Errorf("unexpected undirected graph after contraction: %v", u.Edges())
+	}
+}
@@ -6,19 +6,24 @@ package simple
 
 import (
 	"fmt"
+	"sort"
 
 	"golang.org/x/tools/container/intsets"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
 )
 
 // UndirectedGraph implements a generalized undirected graph.
 type UndirectedGraph struct {
 	nodes map[int]graph.Node
 	edges map[int]map[int]graph.Edge
+	size  int
 
 	self, absent float64
 
+	obs observers
+
 	freeIDs intsets.Sparse
 	usedIDs intsets.Sparse
 }
@@ -70,27 +75,41 @@ func (g *UndirectedGraph) AddNode(n graph.Node) {
 
 	g.freeIDs.Remove(n.ID())
 	g.usedIDs.Insert(n.ID())
+
+	g.obs.nodeAdded(n)
 }
 
 // RemoveNode removes n from the graph, as well as any edges attached to it. If the node
 // is not in the graph it is a no-op.
 func (g *UndirectedGraph) RemoveNode(n graph.Node) {
-	if _, ok := g.nodes[n.ID()]; !ok {
+	n, ok := g.nodes[n.ID()]
+	if !ok {
 		return
 	}
+	var removed []graph.Edge
+	if len(g.obs) != 0 {
+		removed = g.IncidentEdges(n)
+	}
 	delete(g.nodes, n.ID())
 
 	for from := range g.edges[n.ID()] {
 		delete(g.edges[from], n.ID())
 	}
+	g.size -= len(g.edges[n.ID()])
 	delete(g.edges, n.ID())
 
 	g.freeIDs.Insert(n.ID())
 	g.usedIDs.Remove(n.ID())
 
+	for _, e := range removed {
+		g.obs.edgeRemoved(e)
+	}
+	g.obs.nodeRemoved(n)
 }
 
 // SetEdge adds e, an edge from one node to another. If the nodes do not exist, they are added.
+// If an edge between e.From and e.To already exists in either direction it is replaced by e,
+// so the weight of the edge becomes the weight of e; the graph does not hold parallel edges.
 // It will panic if the IDs of the e.From and e.To are equal.
 func (g *UndirectedGraph) SetEdge(e graph.Edge) {
 	var (
@@ -111,8 +130,18 @@ func (g *UndirectedGraph) SetEdge(e graph.Edge) {
 		g.AddNode(to)
 	}
 
+	old, replaced := g.edges[fid][tid]
+	if !replaced {
+		g.size++
+	}
 	g.edges[fid][tid] = e
 	g.edges[tid][fid] = e
+
+	if replaced {
+		g.obs.edgeWeightChanged(e, old.Weight())
+	} else {
+		g.obs.edgeAdded(e)
+	}
 }
 
 // RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
@@ -126,8 +155,51 @@ func (g *UndirectedGraph) RemoveEdge(e graph.Edge) {
 		return
 	}
 
+	removed, ok := g.edges[from.ID()][to.ID()]
+	if !ok {
+		return
+	}
 	delete(g.edges[from.ID()], to.ID())
 	delete(g.edges[to.ID()], from.ID())
+	g.size--
+
+	g.obs.edgeRemoved(removed)
+}
+
+// ClearEdges removes all the edges from g, leaving its nodes.
+func (g *UndirectedGraph) ClearEdges() {
+	var removed []graph.Edge
+	if len(g.obs) != 0 {
+		removed = g.Edges()
+	}
+	for id := range g.edges {
+		g.edges[id] = make(map[int]graph.Edge)
+	}
+	g.size = 0
+
+	for _, e := range removed {
+		g.obs.edgeRemoved(e)
+	}
+}
+
+// SetEdgeWeight sets the weight of the edge between the terminal nodes of e
+// to w, so the edge is seen with the same weight from both of its nodes. If
+// the stored edge is a Reweighter, the edge returned by its WithWeight method
+// is stored, retaining its type and any label, otherwise it is replaced by an
+// Edge holding its terminal nodes and the new weight. If the edge is not in
+// the graph, e is added with the weight w as described for SetEdge.
+func (g *UndirectedGraph) SetEdgeWeight(e graph.Edge, w float64) {
+	fid, tid := e.From().ID(), e.To().ID()
+	old, ok := g.edges[fid][tid]
+	if !ok {
+		g.SetEdge(reweighted(e, w))
+		return
+	}
+	ne := reweighted(old, w)
+	g.edges[fid][tid] = ne
+	g.edges[tid][fid] = ne
+
+	g.obs.edgeWeightChanged(ne, old.Weight())
 }
 
 // Node returns the node in the graph with the given ID.
@@ -153,6 +225,14 @@ func (g *UndirectedGraph) Nodes() []graph.Node {
 	return nodes
 }
 
+// NodesSorted returns all the nodes in the graph sorted ascending by ID.
+// Unlike Nodes, the order of the returned nodes is deterministic.
+func (g *UndirectedGraph) NodesSorted() []graph.Node {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
 // Edges returns all the edges in the graph.
 func (g *UndirectedGraph) Edges() []graph.Edge {
 	var edges []graph.Edge
@@ -174,6 +254,31 @@ func (g *UndirectedGraph) Edges() []graph.Edge {
 	return edges
 }
 
+// Order returns the number of nodes in g.
+func (g *UndirectedGraph) Order() int {
+	return len(g.nodes)
+}
+
+// Size returns the number of edges in g.
+func (g *UndirectedGraph) Size() int {
+	return g.size
+}
+
+// VisitEdges calls fn with each edge in g and its weight until fn returns false.
+// Each edge is visited once.
+func (g *UndirectedGraph) VisitEdges(fn func(e graph.Edge, weight float64) bool) {
+	for uid, adj := range g.edges {
+		for vid, e := range adj {
+			if vid < uid {
+				continue
+			}
+			if !fn(e, e.Weight()) {
+				return
+			}
+		}
+	}
+}
+
 // From returns all nodes in g that can be reached directly from n.
 func (g *UndirectedGraph) From(n graph.Node) []graph.Node {
 	if !g.Has(n) {
@@ -190,6 +295,31 @@ func (g *UndirectedGraph) From(n graph.Node) []graph.Node {
 	return nodes
 }
 
+// VisitFrom calls fn with each node that can be reached directly from n and
+// the weight of the joining edge until fn returns false.
+func (g *UndirectedGraph) VisitFrom(n graph.Node, fn func(neighbor graph.Node, weight float64) bool) {
+	for id, e := range g.edges[n.ID()] {
+		if !fn(g.nodes[id], e.Weight()) {
+			return
+		}
+	}
+}
+
+// IncidentEdges returns the edges in g that have n as one of their terminal
+// nodes. The edges are returned as they were set, so n may be either the From
+// or the To node of each edge. If n is not in g, IncidentEdges returns nil.
+func (g *UndirectedGraph) IncidentEdges(n graph.Node) []graph.Edge {
+	adj := g.edges[n.ID()]
+	if len(adj) == 0 {
+		return nil
+	}
+	edges := make([]graph.Edge, 0, len(adj))
+	for _, e := range adj {
+		edges = append(edges, e)
+	}
+	return edges
+}
+
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
 func (g *UndirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
 	_, ok := g.edges[x.ID()][y.ID()]
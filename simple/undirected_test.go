@@ -6,6 +6,7 @@ package simple
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/gonum/graph"
@@ -41,6 +42,51 @@ func TestMaxID(t *testing.T) {
 	}
 }
 
+func TestNewNodeIDRandom(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    interface {
+			graph.Graph
+			graph.NodeAdder
+			graph.NodeRemover
+		}
+	}{
+		{name: "DirectedGraph", g: NewDirectedGraph(0, math.Inf(1))},
+		{name: "UndirectedGraph", g: NewUndirectedGraph(0, math.Inf(1))},
+		{name: "DirectedMatrix", g: NewDirectedMatrix(0, math.Inf(1), 0, math.Inf(1))},
+		{name: "UndirectedMatrix", g: NewUndirectedMatrix(0, math.Inf(1), 0, math.Inf(1))},
+	} {
+		rnd := rand.New(rand.NewSource(1))
+		var ids []int
+		for i := 0; i < 1000; i++ {
+			switch p := rnd.Float64(); {
+			case p < 0.3 && len(ids) != 0:
+				k := rnd.Intn(len(ids))
+				test.g.RemoveNode(Node(ids[k]))
+				ids[k] = ids[len(ids)-1]
+				ids = ids[:len(ids)-1]
+			case p < 0.4:
+				id := rnd.Intn(200)
+				if test.g.Has(Node(id)) {
+					continue
+				}
+				test.g.AddNode(Node(id))
+				ids = append(ids, id)
+			default:
+				id := test.g.NewNodeID()
+				if test.g.Has(Node(id)) {
+					t.Fatalf("NewNodeID returned existing ID %d for %s at step %d", id, test.name, i)
+				}
+				test.g.AddNode(Node(id))
+				ids = append(ids, id)
+			}
+			if n := len(test.g.Nodes()); n != len(ids) {
+				t.Fatalf("unexpected number of nodes for %s at step %d: got:%d want:%d", test.name, i, n, len(ids))
+			}
+		}
+	}
+}
+
 // Test for issue #123 https://github.com/gonum/graph/issues/123
 func TestIssue123UndirectedGraph(t *testing.T) {
 	defer func() {
@@ -61,3 +107,194 @@ func TestIssue123UndirectedGraph(t *testing.T) {
 	n2 := Node(g.NewNodeID())
 	g.AddNode(n2)
 }
+
+func TestNodesSortedUndirectedGraph(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	for _, id := range []int{5, 1, 9, 0, 3, 7} {
+		g.AddNode(Node(id))
+	}
+	want := []int{0, 1, 3, 5, 7, 9}
+	for i := 0; i < 10; i++ {
+		nodes := g.NodesSorted()
+		if len(nodes) != len(want) {
+			t.Fatalf("unexpected number of nodes: got:%d want:%d", len(nodes), len(want))
+		}
+		for j, n := range nodes {
+			if n.ID() != want[j] {
+				t.Fatalf("unexpected node order at %d: got:%d want:%d", j, n.ID(), want[j])
+			}
+		}
+	}
+}
+
+func TestUndirectedEdgeSymmetry(t *testing.T) {
+	type undirected interface {
+		graph.Undirected
+		graph.Weighter
+		graph.EdgeSetter
+		graph.EdgeRemover
+	}
+	for _, g := range []undirected{
+		NewUndirectedGraph(0, math.Inf(1)),
+		NewUndirectedMatrix(3, math.Inf(1), 0, math.Inf(1)),
+		NewUndirectedBitMatrix(3),
+	} {
+		u, v := Node(0), Node(2)
+		for _, e := range []Edge{
+			{F: u, T: v, W: 2},
+			// Overwrite the edge from the other direction.
+			{F: v, T: u, W: 3},
+		} {
+			g.SetEdge(e)
+			if !g.HasEdgeBetween(u, v) || !g.HasEdgeBetween(v, u) {
+				t.Errorf("edge not visible in both directions in %T", g)
+			}
+			if !sameNodes(g.From(u), []graph.Node{v}) || !sameNodes(g.From(v), []graph.Node{u}) {
+				t.Errorf("unexpected neighbors in %T: From(u)=%v From(v)=%v", g, g.From(u), g.From(v))
+			}
+
+			fwd, rev := g.EdgeBetween(u, v), g.EdgeBetween(v, u)
+			if fwd == nil || rev == nil {
+				t.Fatalf("missing edge in %T: %v %v", g, fwd, rev)
+			}
+			if fwd.Weight() != rev.Weight() {
+				t.Errorf("asymmetric edge weight in %T: got:%v and %v", g, fwd.Weight(), rev.Weight())
+			}
+			if ends := [2]int{fwd.From().ID(), fwd.To().ID()}; ends != [2]int{0, 2} && ends != [2]int{2, 0} {
+				t.Errorf("unexpected edge ends in %T: %v", g, ends)
+			}
+			if e := g.Edge(v, u); e == nil || e.Weight() != rev.Weight() {
+				t.Errorf("Edge does not match EdgeBetween in %T: %v %v", g, e, rev)
+			}
+
+			wf, okf := g.Weight(u, v)
+			wr, okr := g.Weight(v, u)
+			if wf != wr || !okf || !okr {
+				t.Errorf("asymmetric weight in %T: got:(%v, %t) and (%v, %t)", g, wf, okf, wr, okr)
+			}
+			if _, bits := g.(*UndirectedBitMatrix); !bits && wf != e.W {
+				t.Errorf("unexpected weight in %T: got:%v want:%v", g, wf, e.W)
+			}
+		}
+
+		g.RemoveEdge(Edge{F: v, T: u})
+		if g.HasEdgeBetween(u, v) || g.HasEdgeBetween(v, u) || g.EdgeBetween(u, v) != nil || g.EdgeBetween(v, u) != nil {
+			t.Errorf("edge removed in one direction is still present in %T", g)
+		}
+	}
+}
+
+func TestUndirectedIncidentEdges(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(2), W: 1},
+		{F: Node(1), T: Node(0), W: 2},
+		{F: Node(1), T: Node(2), W: 4},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(Node(3))
+
+	for _, test := range []struct {
+		n    graph.Node
+		want map[int]float64
+	}{
+		{n: Node(0), want: map[int]float64{2: 1, 1: 2}},
+		{n: Node(2), want: map[int]float64{0: 1, 1: 4}},
+		{n: Node(3), want: nil},
+		{n: Node(4), want: nil},
+	} {
+		edges := g.IncidentEdges(test.n)
+		if len(edges) != len(test.want) {
+			t.Errorf("unexpected number of incident edges of %d: got:%d want:%d", test.n.ID(), len(edges), len(test.want))
+			continue
+		}
+		for _, e := range edges {
+			other := e.To()
+			switch test.n.ID() {
+			case e.To().ID():
+				other = e.From()
+			case e.From().ID():
+			default:
+				t.Errorf("edge %v is not incident to %d", e, test.n.ID())
+				continue
+			}
+			if w, ok := test.want[other.ID()]; !ok || w != e.Weight() {
+				t.Errorf("unexpected incident edge of %d: %v", test.n.ID(), e)
+			}
+		}
+	}
+}
+
+func TestUndirectedSetEdgeWeight(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+
+	g.SetEdgeWeight(Edge{F: Node(1), T: Node(0)}, 5)
+	for _, p := range [][2]int{{0, 1}, {1, 0}} {
+		u, v := Node(p[0]), Node(p[1])
+		if w, ok := g.Weight(u, v); w != 5 || !ok {
+			t.Errorf("unexpected weight for %d-%d after update: got:(%v, %t) want:(5, true)", p[0], p[1], w, ok)
+		}
+		if e := g.EdgeBetween(u, v); e == nil || e.Weight() != 5 {
+			t.Errorf("unexpected edge for %d-%d after update: %v", p[0], p[1], e)
+		}
+	}
+	if g.Size() != 1 {
+		t.Errorf("unexpected size after update: got:%d want:1", g.Size())
+	}
+
+	if w, ok := g.Weight(Node(0), Node(2)); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected weight for absent edge: got:(%v, %t) want:(+Inf, false)", w, ok)
+	}
+
+	// Setting the weight of an absent edge adds it.
+	g.SetEdgeWeight(Edge{F: Node(0), T: Node(2), W: 3}, 1)
+	if w, ok := g.Weight(Node(2), Node(0)); w != 1 || !ok {
+		t.Errorf("unexpected weight for added edge: got:(%v, %t) want:(1, true)", w, ok)
+	}
+	if g.Size() != 2 {
+		t.Errorf("unexpected size after adding edge: got:%d want:2", g.Size())
+	}
+
+	// Labeled edges retain their type and label
+	// when seen from both of their nodes.
+	g.SetEdge(labeledEdge{Edge: Edge{F: Node(2), T: Node(3), W: 1}, label: "road"})
+	g.SetEdgeWeight(Edge{F: Node(3), T: Node(2)}, 4)
+	for _, e := range []graph.Edge{g.EdgeBetween(Node(2), Node(3)), g.EdgeBetween(Node(3), Node(2))} {
+		l, ok := e.(labeledEdge)
+		if !ok || l.label != "road" || l.Weight() != 4 {
+			t.Errorf("unexpected labeled edge after update: got:%#v want label road with weight 4", e)
+		}
+	}
+}
+
+func TestUndirectedClearEdges(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 3})
+	g.AddNode(Node(3))
+	r := &recorder{}
+	g.RegisterObserver(r)
+
+	g.ClearEdges()
+	if got := len(g.Nodes()); got != 4 {
+		t.Errorf("unexpected number of nodes after ClearEdges: got:%d want:4", got)
+	}
+	if g.Size() != 0 || len(g.Edges()) != 0 {
+		t.Errorf("unexpected edges after ClearEdges: size=%d edges=%v", g.Size(), g.Edges())
+	}
+	for _, n := range g.Nodes() {
+		if len(g.From(n)) != 0 {
+			t.Errorf("unexpected neighbors of %d after ClearEdges", n.ID())
+		}
+	}
+	if len(r.events) != 2 {
+		t.Errorf("unexpected events: %q", r.events)
+	}
+
+	g.SetEdge(Edge{F: Node(2), T: Node(3), W: 1})
+	if !g.HasEdgeBetween(Node(2), Node(3)) || g.Size() != 1 {
+		t.Error("failed to add edge after ClearEdges")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "fmt"
+
+// Validate checks the internal consistency of g, returning an error describing
+// the first inconsistency found, or nil if g is consistent. Validate is intended
+// for debugging; a DirectedGraph modified only through its methods is always
+// consistent.
+//
+// Validate checks that the node index agrees with the stored nodes, that each
+// outbound adjacency entry has a matching inbound entry holding the same edge
+// and that the edge count agrees with the adjacency lists, and that the sets
+// of used and free node IDs agree with the nodes in the graph.
+func (g *DirectedGraph) Validate() error {
+	if len(g.indexOf) != len(g.nodes) {
+		return fmt.Errorf("simple: node index holds %d IDs for %d nodes", len(g.indexOf), len(g.nodes))
+	}
+	if len(g.from) != len(g.nodes) || len(g.to) != len(g.nodes) {
+		return fmt.Errorf("simple: %d outbound and %d inbound adjacency lists for %d nodes", len(g.from), len(g.to), len(g.nodes))
+	}
+	for i, n := range g.nodes {
+		if n == nil {
+			return fmt.Errorf("simple: nil node at position %d", i)
+		}
+		if j, ok := g.indexOf[n.ID()]; !ok || j != i {
+			return fmt.Errorf("simple: node %d at position %d indexed at position %d", n.ID(), i, j)
+		}
+	}
+
+	var out, in int
+	for i, n := range g.nodes {
+		uid := n.ID()
+		for k, e := range g.from[i] {
+			if k != 0 && g.from[i][k-1].id >= e.id {
+				return fmt.Errorf("simple: outbound edges of node %d not in strictly ascending order", uid)
+			}
+			if e.id == uid {
+				return fmt.Errorf("simple: self edge on node %d", uid)
+			}
+			if e.edge.From().ID() != uid || e.edge.To().ID() != e.id {
+				return fmt.Errorf("simple: edge %d->%d stored as outbound edge %d->%d", e.edge.From().ID(), e.edge.To().ID(), uid, e.id)
+			}
+			j, ok := g.indexOf[e.id]
+			if !ok {
+				return fmt.Errorf("simple: edge %d->%d to absent node", uid, e.id)
+			}
+			l, ok := g.to[j].find(uid)
+			if !ok {
+				return fmt.Errorf("simple: edge %d->%d has no inbound entry", uid, e.id)
+			}
+			if w := g.to[j][l].edge.Weight(); !isSame(w, e.edge.Weight()) {
+				return fmt.Errorf("simple: edge %d->%d has outbound weight %v and inbound weight %v", uid, e.id, e.edge.Weight(), w)
+			}
+		}
+		for k, e := range g.to[i] {
+			if k != 0 && g.to[i][k-1].id >= e.id {
+				return fmt.Errorf("simple: inbound edges of node %d not in strictly ascending order", uid)
+			}
+			if _, ok := g.indexOf[e.id]; !ok {
+				return fmt.Errorf("simple: edge %d->%d from absent node", e.id, uid)
+			}
+		}
+		out += len(g.from[i])
+		in += len(g.to[i])
+	}
+	if out != in {
+		// Every outbound entry has a matching inbound
+		// entry, so any excess inbound entries have no
+		// matching outbound entry.
+		return fmt.Errorf("simple: %d outbound edge entries and %d inbound edge entries", out, in)
+	}
+	if out != g.size {
+		return fmt.Errorf("simple: edge count %d does not match %d stored edges", g.size, out)
+	}
+
+	if g.usedIDs.Len() != len(g.nodes) {
+		return fmt.Errorf("simple: %d used IDs for %d nodes", g.usedIDs.Len(), len(g.nodes))
+	}
+	for _, n := range g.nodes {
+		if !g.usedIDs.Has(n.ID()) {
+			return fmt.Errorf("simple: node %d not in used IDs", n.ID())
+		}
+		if g.freeIDs.Has(n.ID()) {
+			return fmt.Errorf("simple: node %d in free IDs", n.ID())
+		}
+	}
+	return nil
+}
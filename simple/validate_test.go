@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func validGraph() *DirectedGraph {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	g.SetEdge(Edge{F: Node(2), T: Node(0), W: 3})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: 4})
+	return g
+}
+
+func TestValidate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := NewDirectedGraph(0, math.Inf(1))
+	for i := 0; i < 1000; i++ {
+		switch u, v := Node(rnd.Intn(20)), Node(rnd.Intn(20)); rnd.Intn(4) {
+		case 0:
+			g.RemoveNode(u)
+		case 1:
+			g.RemoveEdge(Edge{F: u, T: v})
+		default:
+			if u != v {
+				g.SetEdge(Edge{F: u, T: v, W: rnd.Float64()})
+			}
+		}
+		if err := g.Validate(); err != nil {
+			t.Fatalf("unexpected error after operation %d: %v", i, err)
+		}
+	}
+
+	// NaN weights are consistent with themselves.
+	g.SetEdge(Edge{F: Node(20), T: Node(21), W: math.NaN()})
+	if err := g.Validate(); err != nil {
+		t.Errorf("unexpected error for NaN weight: %v", err)
+	}
+}
+
+func TestValidateCorruption(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		corrupt func(g *DirectedGraph)
+		want    string
+	}{
+		{
+			name:    "missing index entry",
+			corrupt: func(g *DirectedGraph) { delete(g.indexOf, 2) },
+			want:    "simple: node index holds 2 IDs for 3 nodes",
+		},
+		{
+			name:    "wrong index entry",
+			corrupt: func(g *DirectedGraph) { g.indexOf[0], g.indexOf[1] = 1, 0 },
+			want:    "simple: node 0 at position 0 indexed at position 1",
+		},
+		{
+			name:    "missing inbound entry",
+			corrupt: func(g *DirectedGraph) { g.to[g.indexOf[1]].remove(0) },
+			want:    "simple: edge 0->1 has no inbound entry",
+		},
+		{
+			name:    "extra inbound entry",
+			corrupt: func(g *DirectedGraph) { g.to[g.indexOf[0]].set(1, Edge{F: Node(1), T: Node(0), W: 5}) },
+			want:    "simple: 4 outbound edge entries and 5 inbound edge entries",
+		},
+		{
+			name:    "mismatched weight",
+			corrupt: func(g *DirectedGraph) { g.to[g.indexOf[2]].set(1, Edge{F: Node(1), T: Node(2), W: 5}) },
+			want:    "simple: edge 1->2 has outbound weight 2 and inbound weight 5",
+		},
+		{
+			name:    "misplaced edge",
+			corrupt: func(g *DirectedGraph) { g.from[g.indexOf[1]][0].edge = Edge{F: Node(2), T: Node(1)} },
+			want:    "simple: edge 2->1 stored as outbound edge 1->2",
+		},
+		{
+			name:    "unordered edges",
+			corrupt: func(g *DirectedGraph) { l := g.from[g.indexOf[0]]; l[0], l[1] = l[1], l[0] },
+			want:    "simple: outbound edges of node 0 not in strictly ascending order",
+		},
+		{
+			name:    "wrong edge count",
+			corrupt: func(g *DirectedGraph) { g.size++ },
+			want:    "simple: edge count 5 does not match 4 stored edges",
+		},
+		{
+			name:    "free live ID",
+			corrupt: func(g *DirectedGraph) { g.freeIDs.Insert(1) },
+			want:    "simple: node 1 in free IDs",
+		},
+		{
+			name:    "unused live ID",
+			corrupt: func(g *DirectedGraph) { g.usedIDs.Remove(2); g.usedIDs.Insert(7) },
+			want:    "simple: node 2 not in used IDs",
+		},
+		{
+			name:    "stale used ID",
+			corrupt: func(g *DirectedGraph) { g.usedIDs.Insert(7) },
+			want:    "simple: 4 used IDs for 3 nodes",
+		},
+	} {
+		g := validGraph()
+		if err := g.Validate(); err != nil {
+			t.Fatalf("unexpected error before corruption: %v", err)
+		}
+		test.corrupt(g)
+		err := g.Validate()
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %s: got:%v want:%s", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var (
+	_ graph.FromVisitor = (*DirectedGraph)(nil)
+	_ graph.ToVisitor   = (*DirectedGraph)(nil)
+	_ graph.FromVisitor = (*UndirectedGraph)(nil)
+	_ graph.FromVisitor = (*DirectedMatrix)(nil)
+	_ graph.ToVisitor   = (*DirectedMatrix)(nil)
+	_ graph.FromVisitor = (*UndirectedMatrix)(nil)
+	_ graph.FromVisitor = (*DirectedBitMatrix)(nil)
+	_ graph.ToVisitor   = (*DirectedBitMatrix)(nil)
+	_ graph.FromVisitor = (*UndirectedBitMatrix)(nil)
+	_ graph.FromVisitor = (*DirectedCSR)(nil)
+	_ graph.ToVisitor   = (*DirectedCSR)(nil)
+	_ graph.FromVisitor = (*UndirectedCSR)(nil)
+)
+
+func TestVisit(t *testing.T) {
+	const n = 100
+	rnd := rand.New(rand.NewSource(1))
+
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	dm := NewDirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	um := NewUndirectedMatrix(n, math.Inf(1), 0, math.Inf(1))
+	db := NewDirectedBitMatrix(n)
+	ub := NewUndirectedBitMatrix(n)
+	for i := 0; i < n; i++ {
+		dg.AddNode(Node(i))
+		ug.AddNode(Node(i))
+	}
+	for i := 0; i < 1000; i++ {
+		u, v := Node(rnd.Intn(n)), Node(rnd.Intn(n))
+		if u == v {
+			continue
+		}
+		e := Edge{F: u, T: v, W: rnd.Float64()}
+		for _, g := range []graph.EdgeSetter{dg, ug, dm, um, db, ub} {
+			g.SetEdge(e)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		g    weightedGraph
+	}{
+		{name: "DirectedGraph", g: dg},
+		{name: "UndirectedGraph", g: ug},
+		{name: "DirectedMatrix", g: dm},
+		{name: "UndirectedMatrix", g: um},
+		{name: "DirectedBitMatrix", g: db},
+		{name: "UndirectedBitMatrix", g: ub},
+		{name: "DirectedCSR", g: mustDirectedCSR(dg)},
+		{name: "UndirectedCSR", g: mustUndirectedCSR(ug)},
+	} {
+		for _, u := range test.g.Nodes() {
+			checkVisit(t, test.name+" VisitFrom", test.g, u, test.g.From(u),
+				test.g.(graph.FromVisitor).VisitFrom,
+				func(v graph.Node) (float64, bool) { return test.g.Weight(u, v) })
+			if d, ok := test.g.(graph.Directed); ok {
+				checkVisit(t, test.name+" VisitTo", test.g, u, d.To(u),
+					test.g.(graph.ToVisitor).VisitTo,
+					func(v graph.Node) (float64, bool) { return test.g.Weight(v, u) })
+			}
+		}
+
+		absent := Node(-1)
+		test.g.(graph.FromVisitor).VisitFrom(absent, func(graph.Node, float64) bool {
+			t.Errorf("unexpected visit from absent node in %s", test.name)
+			return true
+		})
+	}
+}
+
+func checkVisit(t *testing.T, name string, g graph.Graph, u graph.Node, want []graph.Node, visit func(graph.Node, func(graph.Node, float64) bool), weight func(graph.Node) (float64, bool)) {
+	var got []graph.Node
+	visit(u, func(v graph.Node, w float64) bool {
+		got = append(got, v)
+		if ew, ok := weight(v); !ok || ew != w {
+			t.Errorf("unexpected weight for %s of %d at %d: got:%v want:%v", name, u.ID(), v.ID(), w, ew)
+		}
+		return true
+	})
+	if !sameNodes(got, want) {
+		t.Errorf("unexpected nodes for %s of %d:\ngot: %v\nwant:%v", name, u.ID(), got, want)
+	}
+
+	if len(want) < 2 {
+		return
+	}
+	var count int
+	visit(u, func(graph.Node, float64) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("unexpected number of visits for %s of %d after stop: got:%d want:1", name, u.ID(), count)
+	}
+}
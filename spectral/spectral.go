@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spectral provides graph spectral analysis functions.
+package spectral
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/matrix/mat64"
+)
+
+// AdjacencyMatrix returns the weighted adjacency matrix of g with rows and
+// columns in the order of the nodes in order. If order is nil, the nodes of
+// g sorted by ID are used. Element i, j of the matrix holds the weight of
+// the edge from order[i] to order[j], or zero if there is no such edge. If the
+// graph does not implement graph.Weighter, edges have unit weight. Nodes of
+// g that are not in order are not represented in the matrix.
+func AdjacencyMatrix(g graph.Graph, order []graph.Node) *mat64.Dense {
+	order, indexOf := nodeOrder(g, order)
+	if len(order) == 0 {
+		return &mat64.Dense{}
+	}
+	weight := weightFunc(g)
+	a := mat64.NewDense(len(order), len(order), nil)
+	for i, u := range order {
+		for _, v := range g.From(u) {
+			j, ok := indexOf[v.ID()]
+			if !ok {
+				continue
+			}
+			a.Set(i, j, weight(u, v))
+		}
+	}
+	return a
+}
+
+// Laplacian returns the Laplacian matrix of the undirected graph g,
+//
+//  L = D - A
+//
+// where A is the weighted adjacency matrix of g returned by AdjacencyMatrix
+// and D is the diagonal matrix of weighted node degrees. If normalized is
+// true, the symmetric normalized Laplacian is returned,
+//
+//  L = I - D^{-1/2} A D^{-1/2}
+//
+// with the rows and columns of nodes with zero degree set to zero. The rows
+// and columns of the matrix are in the order of the nodes in order, or sorted
+// by ID if order is nil.
+func Laplacian(g graph.Undirected, normalized bool, order []graph.Node) *mat64.SymDense {
+	order, indexOf := nodeOrder(g, order)
+	if len(order) == 0 {
+		return &mat64.SymDense{}
+	}
+	weight := weightFunc(g)
+	n := len(order)
+	l := mat64.NewSymDense(n, nil)
+	deg := make([]float64, n)
+	for i, u := range order {
+		for _, v := range g.From(u) {
+			j, ok := indexOf[v.ID()]
+			if !ok {
+				continue
+			}
+			w := weight(u, v)
+			deg[i] += w
+			if j >= i {
+				l.SetSym(i, j, -w)
+			}
+		}
+	}
+	for i, d := range deg {
+		l.SetSym(i, i, l.At(i, i)+d)
+	}
+	if !normalized {
+		return l
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			if deg[i] == 0 || deg[j] == 0 {
+				l.SetSym(i, j, 0)
+				continue
+			}
+			l.SetSym(i, j, l.At(i, j)/math.Sqrt(deg[i]*deg[j]))
+		}
+	}
+	return l
+}
+
+// nodeOrder returns order and a mapping from node IDs to their positions in
+// order. If order is nil, the nodes of g sorted by ID are used.
+func nodeOrder(g graph.Graph, order []graph.Node) ([]graph.Node, map[int]int) {
+	if order == nil {
+		order = g.Nodes()
+		sort.Sort(ordered.ByID(order))
+	}
+	indexOf := make(map[int]int, len(order))
+	for i, n := range order {
+		indexOf[n.ID()] = i
+	}
+	return order, indexOf
+}
+
+// weightFunc returns a function returning the weight of the edge from u to v in g.
+func weightFunc(g graph.Graph) func(u, v graph.Node) float64 {
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return func(_, _ graph.Node) float64 { return 1 }
+}
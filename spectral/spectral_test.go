@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 3})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(0), W: 4})
+	g.AddNode(simple.Node(5))
+
+	got := AdjacencyMatrix(g, nil)
+	want := mat64.NewDense(4, 4, []float64{
+		0, 2, 0, 0,
+		0, 0, 3, 0,
+		4, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	if !mat64.Equal(got, want) {
+		t.Errorf("unexpected adjacency matrix:\ngot:\n%v\nwant:\n%v",
+			mat64.Formatted(got), mat64.Formatted(want))
+	}
+
+	// Use a reversed subset of the nodes and hide the
+	// graph.Weighter implementation for unit weights.
+	order := []graph.Node{simple.Node(2), simple.Node(1), simple.Node(0)}
+	got = AdjacencyMatrix(struct{ graph.Directed }{g}, order)
+	want = mat64.NewDense(3, 3, []float64{
+		0, 0, 1,
+		1, 0, 0,
+		0, 1, 0,
+	})
+	if !mat64.Equal(got, want) {
+		t.Errorf("unexpected ordered adjacency matrix:\ngot:\n%v\nwant:\n%v",
+			mat64.Formatted(got), mat64.Formatted(want))
+	}
+}
+
+func TestLaplacian(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	// An isolated node.
+	g.AddNode(simple.Node(3))
+	u := struct{ graph.Undirected }{g}
+
+	got := Laplacian(u, false, nil)
+	want := mat64.NewSymDense(4, []float64{
+		1, -1, 0, 0,
+		-1, 2, -1, 0,
+		0, -1, 1, 0,
+		0, 0, 0, 0,
+	})
+	if !mat64.Equal(got, want) {
+		t.Errorf("unexpected Laplacian:\ngot:\n%v\nwant:\n%v",
+			mat64.Formatted(got), mat64.Formatted(want))
+	}
+
+	got = Laplacian(u, true, nil)
+	r := -1 / math.Sqrt2
+	want = mat64.NewSymDense(4, []float64{
+		1, r, 0, 0,
+		r, 1, r, 0,
+		0, r, 1, 0,
+		0, 0, 0, 0,
+	})
+	if !mat64.EqualApprox(got, want, 1e-14) {
+		t.Errorf("unexpected normalized Laplacian:\ngot:\n%v\nwant:\n%v",
+			mat64.Formatted(got), mat64.Formatted(want))
+	}
+
+	// Weighted edges.
+	w := simple.NewUndirectedGraph(0, math.Inf(1))
+	w.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	w.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 0.5})
+	got = Laplacian(w, false, nil)
+	want = mat64.NewSymDense(3, []float64{
+		2, -2, 0,
+		-2, 2.5, -0.5,
+		0, -0.5, 0.5,
+	})
+	if !mat64.Equal(got, want) {
+		t.Errorf("unexpected weighted Laplacian:\ngot:\n%v\nwant:\n%v",
+			mat64.Formatted(got), mat64.Formatted(want))
+	}
+}
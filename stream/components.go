@@ -0,0 +1,58 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import "github.com/gonum/graph/simple"
+
+// Components is a Consumer finding the connected components of the nodes of a
+// stream, ignoring edge direction, using a union-find disjoint set. Components
+// uses O(|V|) memory.
+type Components struct {
+	sets  *simple.DisjointSet
+	count int
+}
+
+// NewComponents returns a new Components.
+func NewComponents() *Components {
+	return &Components{sets: simple.NewDisjointSet()}
+}
+
+// ConsumeEdge merges the components of u and v.
+func (c *Components) ConsumeEdge(u, v int, _ float64) {
+	c.add(u)
+	c.add(v)
+	if c.sets.Find(u) != c.sets.Find(v) {
+		c.sets.Union(u, v)
+		c.count--
+	}
+}
+
+// add adds the node with the given ID as a new
+// component if it has not been seen.
+func (c *Components) add(id int) {
+	if !c.sets.Has(id) {
+		c.sets.MakeSet(id)
+		c.count++
+	}
+}
+
+// Count returns the number of connected components seen in the stream.
+func (c *Components) Count() int { return c.count }
+
+// Connected returns whether the nodes with IDs u and v have been seen in the
+// stream and are in the same connected component.
+func (c *Components) Connected(u, v int) bool {
+	return c.sets.Has(u) && c.sets.Has(v) && c.sets.Find(u) == c.sets.Find(v)
+}
+
+// Component returns the ID of the representative node of the component
+// holding the node with the given ID and whether the node has been seen in
+// the stream. Nodes in the same component have the same representative.
+func (c *Components) Component(id int) (rep int, ok bool) {
+	if !c.sets.Has(id) {
+		return 0, false
+	}
+	return c.sets.Find(id), true
+}
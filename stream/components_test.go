@@ -0,0 +1,59 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph/topo"
+)
+
+func TestComponents(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		edges, g := randomStream(rnd, 100, 0.005+0.01*rnd.Float64())
+
+		c := NewComponents()
+		_, err := Process(NewSliceReader(edges), c)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var want int
+		for _, cc := range topo.ConnectedComponents(g) {
+			if len(cc) == 1 && len(g.From(cc[0])) == 0 {
+				// Isolated nodes do not
+				// appear in the stream.
+				continue
+			}
+			want++
+			for _, n := range cc[1:] {
+				if !c.Connected(cc[0].ID(), n.ID()) {
+					t.Errorf("nodes %d and %d not connected for trial %d", cc[0].ID(), n.ID(), trial)
+				}
+			}
+			rep, ok := c.Component(cc[0].ID())
+			if !ok {
+				t.Errorf("node %d not seen for trial %d", cc[0].ID(), trial)
+			}
+			if !c.Connected(rep, cc[0].ID()) {
+				t.Errorf("representative not in component for trial %d", trial)
+			}
+		}
+		if c.Count() != want {
+			t.Errorf("unexpected number of components for trial %d: got:%d want:%d", trial, c.Count(), want)
+		}
+	}
+
+	c := NewComponents()
+	c.ConsumeEdge(0, 1, 1)
+	if c.Connected(0, 2) {
+		t.Error("unexpected connection to unseen node")
+	}
+	if _, ok := c.Component(2); ok {
+		t.Error("unexpected component for unseen node")
+	}
+}
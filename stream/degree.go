@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+// Degrees is a Consumer counting the in and out degrees of the nodes of a
+// stream. A Degrees uses O(|V|) memory.
+//
+// Each edge of the stream adds to the out degree of its first node and the in
+// degree of its second node, so for a stream of directed edges the degree of a
+// node is the sum of its in and out degrees, and for a stream holding each
+// undirected edge once the degree of a node is its undirected degree.
+type Degrees struct {
+	degrees map[int]inOut
+	edges   int
+}
+
+type inOut struct {
+	in, out int
+}
+
+// NewDegrees returns a new Degrees.
+func NewDegrees() *Degrees {
+	return &Degrees{degrees: make(map[int]inOut)}
+}
+
+// ConsumeEdge counts the edge from u to v.
+func (d *Degrees) ConsumeEdge(u, v int, _ float64) {
+	c := d.degrees[u]
+	c.out++
+	d.degrees[u] = c
+	c = d.degrees[v]
+	c.in++
+	d.degrees[v] = c
+	d.edges++
+}
+
+// Nodes returns the number of nodes seen in the stream.
+func (d *Degrees) Nodes() int { return len(d.degrees) }
+
+// Edges returns the number of edges seen in the stream.
+func (d *Degrees) Edges() int { return d.edges }
+
+// Degree returns the degree of the node with the given ID.
+func (d *Degrees) Degree(id int) int {
+	c := d.degrees[id]
+	return c.in + c.out
+}
+
+// InDegree returns the in degree of the node with the given ID.
+func (d *Degrees) InDegree(id int) int {
+	return d.degrees[id].in
+}
+
+// OutDegree returns the out degree of the node with the given ID.
+func (d *Degrees) OutDegree(id int) int {
+	return d.degrees[id].out
+}
+
+// Distribution returns the degree distribution of the stream as a map from
+// degree to the number of nodes with that degree.
+func (d *Degrees) Distribution() map[int]int {
+	dist := make(map[int]int)
+	for _, c := range d.degrees {
+		dist[c.in+c.out]++
+	}
+	return dist
+}
@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDegrees(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	edges, g := randomStream(rnd, 200, 0.05)
+
+	d := NewDegrees()
+	_, err := Process(NewSliceReader(edges), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Edges() != len(g.Edges()) {
+		t.Errorf("unexpected number of edges: got:%d want:%d", d.Edges(), len(g.Edges()))
+	}
+
+	want := make(map[int]int)
+	var nodes int
+	for _, n := range g.Nodes() {
+		deg := len(g.From(n))
+		if deg == 0 {
+			// Isolated nodes do not
+			// appear in the stream.
+			continue
+		}
+		nodes++
+		want[deg]++
+		if got := d.Degree(n.ID()); got != deg {
+			t.Errorf("unexpected degree for node %d: got:%d want:%d", n.ID(), got, deg)
+		}
+		if d.InDegree(n.ID())+d.OutDegree(n.ID()) != deg {
+			t.Errorf("in and out degrees do not sum to degree for node %d", n.ID())
+		}
+	}
+	if d.Nodes() != nodes {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", d.Nodes(), nodes)
+	}
+	if got := d.Distribution(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected degree distribution: got:%v want:%v", got, want)
+	}
+	if d.Degree(-1) != 0 {
+		t.Errorf("unexpected degree for absent node: got:%d", d.Degree(-1))
+	}
+}
+
+func TestDegreesDirected(t *testing.T) {
+	d := NewDegrees()
+	_, err := Process(NewSliceReader([]Edge{{U: 0, V: 1}, {U: 0, V: 2}, {U: 2, V: 0}}), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		id      int
+		in, out int
+	}{
+		{id: 0, in: 1, out: 2},
+		{id: 1, in: 1, out: 0},
+		{id: 2, in: 1, out: 1},
+	} {
+		if in, out := d.InDegree(test.id), d.OutDegree(test.id); in != test.in || out != test.out {
+			t.Errorf("unexpected degrees for node %d: got:(in:%d out:%d) want:(in:%d out:%d)",
+				test.id, in, out, test.in, test.out)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// HeavyNodes is a Consumer tracking the nodes of a stream with the greatest
+// total weight of incident edges, using the Space-Saving algorithm. With unit
+// edge weights the total weight of a node is its degree. HeavyNodes uses O(k)
+// memory where k is the number of nodes tracked.
+//
+// Every node whose total weight is greater than the total weight of the stream
+// divided by k is tracked. The weights reported for tracked nodes are upper
+// bounds on their total weights, with a reported error bounding the amount by
+// which the weight may be overestimated.
+//
+// The algorithm is described in:
+//
+// Metwally A, Agrawal D and El Abbadi A. "Efficient Computation of Frequent and
+// Top-k Elements in Data Streams." In Proceedings of the 10th International
+// Conference on Database Theory (2005).
+type HeavyNodes struct {
+	k        int
+	counters counterHeap
+	indexOf  map[int]*counter
+}
+
+// NodeWeight is the weight of a node tracked by a HeavyNodes. The total weight
+// of the node is between Weight-Error and Weight.
+type NodeWeight struct {
+	ID     int
+	Weight float64
+	Error  float64
+}
+
+// NewHeavyNodes returns a new HeavyNodes tracking k nodes. NewHeavyNodes will
+// panic if k is less than one.
+func NewHeavyNodes(k int) *HeavyNodes {
+	if k < 1 {
+		panic("stream: non-positive number of heavy nodes")
+	}
+	return &HeavyNodes{k: k, indexOf: make(map[int]*counter)}
+}
+
+// ConsumeEdge adds w to the weights of u and v. ConsumeEdge will panic if w is
+// negative.
+func (h *HeavyNodes) ConsumeEdge(u, v int, w float64) {
+	if w < 0 {
+		panic("stream: negative edge weight")
+	}
+	h.add(u, w)
+	h.add(v, w)
+}
+
+// add adds w to the weight of the node with the given ID, replacing the
+// lightest tracked node if the node is not tracked and k nodes are tracked.
+func (h *HeavyNodes) add(id int, w float64) {
+	if c, ok := h.indexOf[id]; ok {
+		c.Weight += w
+		heap.Fix(&h.counters, c.index)
+		return
+	}
+	if len(h.counters) < h.k {
+		c := &counter{NodeWeight: NodeWeight{ID: id, Weight: w}}
+		h.indexOf[id] = c
+		heap.Push(&h.counters, c)
+		return
+	}
+	c := h.counters[0]
+	delete(h.indexOf, c.ID)
+	c.ID = id
+	c.Error = c.Weight
+	c.Weight += w
+	h.indexOf[id] = c
+	heap.Fix(&h.counters, 0)
+}
+
+// Top returns the tracked nodes in order of descending weight, with ties
+// broken by ascending ID.
+func (h *HeavyNodes) Top() []NodeWeight {
+	top := make([]NodeWeight, len(h.counters))
+	for i, c := range h.counters {
+		top[i] = c.NodeWeight
+	}
+	sort.Sort(byWeight(top))
+	return top
+}
+
+// counter is a tracked node.
+type counter struct {
+	NodeWeight
+	index int
+}
+
+// counterHeap is a min-heap of counters ordered by weight.
+type counterHeap []*counter
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].Weight < h[j].Weight }
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *counterHeap) Push(x interface{}) {
+	c := x.(*counter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+func (h *counterHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// byWeight sorts node weights by descending weight and ascending ID.
+type byWeight []NodeWeight
+
+func (w byWeight) Len() int { return len(w) }
+func (w byWeight) Less(i, j int) bool {
+	if w[i].Weight != w[j].Weight {
+		return w[i].Weight > w[j].Weight
+	}
+	return w[i].ID < w[j].ID
+}
+func (w byWeight) Swap(i, j int) { w[i], w[j] = w[j], w[i] }
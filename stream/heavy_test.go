@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestHeavyNodes(t *testing.T) {
+	const (
+		n    = 500
+		hubs = 5
+		k    = 50
+	)
+	rnd := rand.New(rand.NewSource(1))
+
+	// The stream links each node to a few random nodes
+	// and to each of a small number of hubs with high
+	// probability, so the hubs are much heavier than
+	// other nodes.
+	weight := make(map[int]float64)
+	var (
+		edges []Edge
+		total float64
+	)
+	add := func(u, v int) {
+		w := float64(1 + rnd.Intn(3))
+		edges = append(edges, Edge{U: u, V: v, W: w})
+		weight[u] += w
+		weight[v] += w
+		total += 2 * w
+	}
+	for u := hubs; u < n; u++ {
+		for h := 0; h < hubs; h++ {
+			if rnd.Float64() < 0.5 {
+				add(u, h)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			add(u, hubs+rnd.Intn(n-hubs))
+		}
+	}
+	for i := range edges {
+		j := i + rnd.Intn(len(edges)-i)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	h := NewHeavyNodes(k)
+	_, err := Process(NewSliceReader(edges), h)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	top := h.Top()
+	if len(top) != k {
+		t.Fatalf("unexpected number of tracked nodes: got:%d want:%d", len(top), k)
+	}
+	tracked := make(map[int]bool)
+	for i, nw := range top {
+		tracked[nw.ID] = true
+		if i != 0 && nw.Weight > top[i-1].Weight {
+			t.Errorf("tracked nodes not in descending order of weight at %d", i)
+		}
+		if w := weight[nw.ID]; w > nw.Weight || w < nw.Weight-nw.Error {
+			t.Errorf("true weight of node %d outside reported bounds: %v not in [%v, %v]",
+				nw.ID, w, nw.Weight-nw.Error, nw.Weight)
+		}
+	}
+	for id, w := range weight {
+		if w > total/k && !tracked[id] {
+			t.Errorf("heavy node %d with weight %v not tracked", id, w)
+		}
+	}
+
+	var hubIDs []int
+	for _, nw := range top[:hubs] {
+		hubIDs = append(hubIDs, nw.ID)
+	}
+	sort.Ints(hubIDs)
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(hubIDs, want) {
+		t.Errorf("unexpected heaviest nodes: got:%v want:%v", hubIDs, want)
+	}
+}
+
+func TestHeavyNodesExact(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	edges, g := randomStream(rnd, 50, 0.2)
+
+	// With capacity for every node the
+	// weights are exact.
+	h := NewHeavyNodes(len(g.Nodes()))
+	_, err := Process(NewSliceReader(edges), h)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, nw := range h.Top() {
+		var want float64
+		for _, v := range g.From(g.Node(nw.ID)) {
+			want += g.EdgeBetween(g.Node(nw.ID), v).Weight()
+		}
+		if nw.Weight != want || nw.Error != 0 {
+			t.Errorf("unexpected weight for node %d: got:(%v±%v) want:%v", nw.ID, nw.Weight, nw.Error, want)
+		}
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		h.ConsumeEdge(0, 1, math.Inf(-1))
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for negative weight")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stream provides summaries of graphs computed from streams of edges.
+//
+// Edges are read one at a time from an EdgeReader and passed to Consumers that
+// maintain summaries of the graph the stream describes, so the graph itself is
+// never held in memory. The memory use of each consumer is documented with its
+// type.
+package stream
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Consumer is a summary of a graph that is updated with each edge of a stream.
+type Consumer interface {
+	// ConsumeEdge updates the summary with
+	// the edge from u to v with weight w.
+	ConsumeEdge(u, v int, w float64)
+}
+
+// EdgeReader is a stream of edges.
+type EdgeReader interface {
+	// ReadEdge returns the next edge of the
+	// stream. At the end of the stream ReadEdge
+	// returns io.EOF.
+	ReadEdge() (u, v int, w float64, err error)
+}
+
+// Process reads edges from r until the end of the stream, passing each edge to
+// each of the consumers in turn. Process returns the number of edges read and
+// any error other than io.EOF returned by r.
+func Process(r EdgeReader, consumers ...Consumer) (n int, err error) {
+	for {
+		u, v, w, err := r.ReadEdge()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		for _, c := range consumers {
+			c.ConsumeEdge(u, v, w)
+		}
+		n++
+	}
+}
+
+// Edge is an edge of a stream.
+type Edge struct {
+	U, V int
+	W    float64
+}
+
+// SliceReader is an EdgeReader reading from a slice of edges.
+type SliceReader struct {
+	edges []Edge
+}
+
+// NewSliceReader returns a SliceReader reading the given edges in order.
+func NewSliceReader(edges []Edge) *SliceReader {
+	return &SliceReader{edges: edges}
+}
+
+// ReadEdge returns the next edge of the slice.
+func (r *SliceReader) ReadEdge() (u, v int, w float64, err error) {
+	if len(r.edges) == 0 {
+		return 0, 0, 0, io.EOF
+	}
+	e := r.edges[0]
+	r.edges = r.edges[1:]
+	return e.U, e.V, e.W, nil
+}
+
+// TextReader is an EdgeReader reading a text edge list. Each line of the list
+// holds the white space separated integer IDs of the nodes of an edge followed
+// optionally by the edge weight. Edges without a weight have unit weight. Blank
+// lines and lines beginning with '#' or '%' are ignored.
+type TextReader struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+// NewTextReader returns a TextReader reading from r.
+func NewTextReader(r io.Reader) *TextReader {
+	return &TextReader{sc: bufio.NewScanner(r)}
+}
+
+// ReadEdge returns the next edge of the list. Errors in the list are reported
+// with the line number at which they occur.
+func (r *TextReader) ReadEdge() (u, v int, w float64, err error) {
+	for r.sc.Scan() {
+		r.line++
+		fields := strings.Fields(r.sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "%") {
+			continue
+		}
+		if len(fields) != 2 && len(fields) != 3 {
+			return 0, 0, 0, fmt.Errorf("stream: line %d: invalid number of fields: %d", r.line, len(fields))
+		}
+		u, err = strconv.Atoi(fields[0])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("stream: line %d: invalid node ID %q", r.line, fields[0])
+		}
+		v, err = strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("stream: line %d: invalid node ID %q", r.line, fields[1])
+		}
+		w = 1
+		if len(fields) == 3 {
+			w, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return 0, 0, 0, fmt.Errorf("stream: line %d: invalid weight %q", r.line, fields[2])
+			}
+		}
+		return u, v, w, nil
+	}
+	if err := r.sc.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+	return 0, 0, 0, io.EOF
+}
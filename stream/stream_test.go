@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+// randomStream returns the edges of a random undirected graph with n nodes
+// and edge probability p in random order, and the graph they describe.
+func randomStream(rnd *rand.Rand, n int, p float64) ([]Edge, *simple.UndirectedGraph) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	var edges []Edge
+	for u := 0; u < n; u++ {
+		g.AddNode(simple.Node(u))
+		for v := 0; v < u; v++ {
+			if rnd.Float64() < p {
+				w := float64(1 + rnd.Intn(5))
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: w})
+				edges = append(edges, Edge{U: u, V: v, W: w})
+			}
+		}
+	}
+	for i := range edges {
+		j := i + rnd.Intn(len(edges)-i)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	return edges, g
+}
+
+func TestTextReader(t *testing.T) {
+	const list = `# a comment
+% another comment
+
+0 1
+1	2 2.5
+`
+	var edges []Edge
+	r := NewTextReader(strings.NewReader(list))
+	for {
+		u, v, w, err := r.ReadEdge()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		edges = append(edges, Edge{U: u, V: v, W: w})
+	}
+	want := []Edge{{U: 0, V: 1, W: 1}, {U: 1, V: 2, W: 2.5}}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("unexpected edges: got:%v want:%v", edges, want)
+	}
+
+	for _, test := range []struct {
+		list  string
+		wantN int
+		want  string
+	}{
+		{list: "0 1\n1\n", wantN: 1, want: "stream: line 2: invalid number of fields: 1"},
+		{list: "a 1\n", want: `stream: line 1: invalid node ID "a"`},
+		{list: "0 b\n", want: `stream: line 1: invalid node ID "b"`},
+		{list: "\n0 1 heavy\n", want: `stream: line 2: invalid weight "heavy"`},
+	} {
+		n, err := Process(NewTextReader(strings.NewReader(test.list)), NewDegrees())
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.list, err, test.want)
+		}
+		if n != test.wantN {
+			t.Errorf("unexpected number of edges read before error for %q: got:%d want:%d", test.list, n, test.wantN)
+		}
+	}
+}
+
+type errReader struct {
+	n int
+}
+
+var errStream = errors.New("stream failure")
+
+func (r *errReader) ReadEdge() (u, v int, w float64, err error) {
+	if r.n == 0 {
+		return 0, 0, 0, errStream
+	}
+	r.n--
+	return r.n, r.n + 1, 1, nil
+}
+
+func TestProcess(t *testing.T) {
+	edges := []Edge{{U: 0, V: 1, W: 1}, {U: 1, V: 2, W: 1}, {U: 3, V: 4, W: 1}}
+	d := NewDegrees()
+	c := NewComponents()
+	n, err := Process(NewSliceReader(edges), d, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != len(edges) {
+		t.Errorf("unexpected number of edges: got:%d want:%d", n, len(edges))
+	}
+	if d.Edges() != len(edges) || c.Count() != 2 {
+		t.Errorf("consumers not updated: got %d edges and %d components", d.Edges(), c.Count())
+	}
+
+	d = NewDegrees()
+	n, err = Process(&errReader{n: 3}, d)
+	if err != errStream {
+		t.Errorf("unexpected error: got:%v want:%v", err, errStream)
+	}
+	if n != 3 || d.Edges() != 3 {
+		t.Errorf("unexpected number of edges before error: got:%d consumed:%d want:3", n, d.Edges())
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import "math/rand"
+
+// Triangles is a Consumer estimating the number of triangles in the undirected
+// graph described by a stream, using the TRIÈST-IMPR reservoir sampling
+// algorithm. Triangles uses O(m) memory where m is the size of its reservoir of
+// sampled edges, and the estimate is exact while the stream holds no more than
+// m edges.
+//
+// The stream must hold each undirected edge at most once. Edge direction and
+// weights are ignored, and self edges are not counted.
+//
+// The algorithm is described in:
+//
+// De Stefani L et al. "TRIÈST: Counting Local and Global Triangles in Fully-Dynamic
+// Streams with Fixed Memory Size." In Proceedings of the 22nd ACM SIGKDD
+// International Conference on Knowledge Discovery and Data Mining (2016).
+type Triangles struct {
+	m int
+	t int
+
+	sample    []Edge
+	neighbors map[int]map[int]struct{}
+
+	estimate float64
+
+	rnd  func() float64
+	rndN func(int) int
+}
+
+// NewTriangles returns a new Triangles with a reservoir of m edges. If src is
+// not nil it is used as the random source, otherwise rand.Float64 and rand.Intn
+// are used. NewTriangles will panic if m is less than two.
+func NewTriangles(m int, src *rand.Rand) *Triangles {
+	if m < 2 {
+		panic("stream: reservoir size less than two")
+	}
+	t := &Triangles{
+		m:         m,
+		neighbors: make(map[int]map[int]struct{}),
+	}
+	if src == nil {
+		t.rnd = rand.Float64
+		t.rndN = rand.Intn
+	} else {
+		t.rnd = src.Float64
+		t.rndN = src.Intn
+	}
+	return t
+}
+
+// ConsumeEdge counts the triangles closed by the edge between u and v in the
+// sampled edges and updates the sample.
+func (t *Triangles) ConsumeEdge(u, v int, _ float64) {
+	if u == v {
+		return
+	}
+	t.t++
+
+	// Each triangle closed in the sample is weighted by
+	// the inverse of the probability that its other two
+	// edges are both held by the sample.
+	eta := float64(t.t-1) * float64(t.t-2) / (float64(t.m) * float64(t.m-1))
+	if eta < 1 {
+		eta = 1
+	}
+	nu, nv := t.neighbors[u], t.neighbors[v]
+	if len(nv) < len(nu) {
+		nu, nv = nv, nu
+	}
+	for w := range nu {
+		if _, ok := nv[w]; ok {
+			t.estimate += eta
+		}
+	}
+
+	switch {
+	case t.t <= t.m:
+		t.sample = append(t.sample, Edge{U: u, V: v})
+		t.link(u, v)
+	case t.rnd() < float64(t.m)/float64(t.t):
+		i := t.rndN(t.m)
+		old := t.sample[i]
+		t.unlink(old.U, old.V)
+		t.sample[i] = Edge{U: u, V: v}
+		t.link(u, v)
+	}
+}
+
+// link adds the edge between u and v to the sampled neighborhoods.
+func (t *Triangles) link(u, v int) {
+	for _, e := range [2][2]int{{u, v}, {v, u}} {
+		n, ok := t.neighbors[e[0]]
+		if !ok {
+			n = make(map[int]struct{})
+			t.neighbors[e[0]] = n
+		}
+		n[e[1]] = struct{}{}
+	}
+}
+
+// unlink removes the edge between u and v from the sampled neighborhoods.
+func (t *Triangles) unlink(u, v int) {
+	for _, e := range [2][2]int{{u, v}, {v, u}} {
+		n := t.neighbors[e[0]]
+		delete(n, e[1])
+		if len(n) == 0 {
+			delete(t.neighbors, e[0])
+		}
+	}
+}
+
+// Estimate returns the estimated number of triangles in the stream.
+func (t *Triangles) Estimate() float64 { return t.estimate }
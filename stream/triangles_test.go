@@ -0,0 +1,82 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// triangles returns the number of triangles in g.
+func triangles(g graph.Undirected) int {
+	var n int
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			if v.ID() <= u.ID() {
+				continue
+			}
+			for _, w := range g.From(v) {
+				if w.ID() > v.ID() && g.HasEdgeBetween(u, w) {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+func TestTrianglesExact(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	edges, g := randomStream(rnd, 100, 0.1)
+	want := float64(triangles(g))
+
+	tri := NewTriangles(len(edges), rnd)
+	_, err := Process(NewSliceReader(edges), tri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := tri.Estimate(); got != want {
+		t.Errorf("unexpected triangle count with complete sample: got:%v want:%v", got, want)
+	}
+}
+
+func TestTrianglesEstimate(t *testing.T) {
+	const trials = 20
+	rnd := rand.New(rand.NewSource(1))
+	edges, g := randomStream(rnd, 200, 0.2)
+	want := float64(triangles(g))
+
+	var mean float64
+	for i := 0; i < trials; i++ {
+		tri := NewTriangles(len(edges)/4, rnd)
+		_, err := Process(NewSliceReader(edges), tri)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mean += tri.Estimate() / trials
+	}
+	if math.Abs(mean-want)/want > 0.05 {
+		t.Errorf("unexpected mean triangle estimate: got:%v want:%v", mean, want)
+	}
+}
+
+func TestTrianglesIgnoresSelfEdges(t *testing.T) {
+	tri := NewTriangles(10, nil)
+	for _, e := range []Edge{{U: 0, V: 0}, {U: 0, V: 1}, {U: 1, V: 2}, {U: 1, V: 1}, {U: 2, V: 0}} {
+		tri.ConsumeEdge(e.U, e.V, 1)
+	}
+	if got := tri.Estimate(); got != 1 {
+		t.Errorf("unexpected triangle count: got:%v want:1", got)
+	}
+
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	if triangles(g) != 0 {
+		t.Error("unexpected triangles in empty graph")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestSubgraph(t *testing.T) {
+	src := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(0), W: 3},
+		{F: simple.Node(2), T: simple.Node(3), W: 4},
+		{F: simple.Node(3), T: simple.Node(1), W: 5},
+	} {
+		src.SetEdge(e)
+	}
+
+	nodes := []graph.Node{simple.Node(1), simple.Node(2), simple.Node(3), simple.Node(2), simple.Node(10)}
+
+	dst := simple.NewDirectedGraph(0, math.Inf(1))
+	graph.Subgraph(dst, src, nodes)
+	want := directedFrom([]int{1, 2, 3}, []simple.Edge{
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 4},
+		{F: simple.Node(3), T: simple.Node(1), W: 5},
+	})
+	if !graph.Equal(dst, want) {
+		t.Errorf("unexpected directed subgraph: %+v", graph.Diff(want, dst, 0))
+	}
+
+	udst := simple.NewUndirectedGraph(0, math.Inf(1))
+	graph.Subgraph(udst, src, nodes[:2])
+	uwant := undirectedFrom([]int{1, 2}, []simple.Edge{
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+	})
+	if !graph.Equal(udst, uwant) {
+		t.Errorf("unexpected undirected subgraph: %+v", graph.Diff(uwant, udst, 0))
+	}
+}
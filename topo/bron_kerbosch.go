@@ -108,6 +108,21 @@ func VertexOrdering(g graph.Undirected) (order []graph.Node, cores [][]graph.Nod
 	return l, cores
 }
 
+// KCore returns the core number of each node of the undirected graph g, keyed
+// by node ID. The core number of a node is the largest k such that the node
+// belongs to the k-core of g, the maximal subgraph of g in which all nodes have
+// degree at least k.
+func KCore(g graph.Undirected) map[int]int {
+	_, cores := VertexOrdering(g)
+	coreness := make(map[int]int)
+	for k, c := range cores {
+		for _, n := range c {
+			coreness[n.ID()] = k
+		}
+	}
+	return coreness
+}
+
 // BronKerbosch returns the set of maximal cliques of the undirected graph g.
 func BronKerbosch(g graph.Undirected) [][]graph.Node {
 	nodes := g.Nodes()
@@ -134,6 +134,31 @@ var bronKerboschTests = []struct {
 	},
 }
 
+func TestKCore(t *testing.T) {
+	for i, test := range vOrderTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		want := make(map[int]int)
+		for k, c := range test.wantCore {
+			for _, id := range c {
+				want[id] = k
+			}
+		}
+		got := KCore(g)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected core numbers for test %d:\ngot: %v\nwant:%v", i, got, want)
+		}
+	}
+}
+
 func TestBronKerbosch(t *testing.T) {
 	for i, test := range bronKerboschTests {
 		g := simple.NewUndirectedGraph(0, math.Inf(1))
@@ -0,0 +1,48 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// Condensation returns the condensation of the directed graph g, the directed
+// acyclic graph formed by contracting each strongly connected component of g
+// into a single node. The condensation has an edge between the nodes of two
+// components if any edge in g joins a node of the first component to a node of
+// the second. Edges in the condensation have unit weight.
+//
+// The nodes of the condensation are given IDs 0 to n-1, where n is the number
+// of strongly connected components in g, in a topological ordering of the
+// condensation. The returned membership maps the ID of each node in g to the
+// ID of the condensation node representing its component.
+func Condensation(g graph.Directed) (cond *simple.DirectedGraph, membership map[int]int) {
+	sccs := TarjanSCC(g)
+	cond = simple.NewDirectedGraph(0, math.Inf(1))
+	membership = make(map[int]int)
+	for i, scc := range sccs {
+		// TarjanSCC returns components in reverse
+		// topological order.
+		c := len(sccs) - 1 - i
+		cond.AddNode(simple.Node(c))
+		for _, n := range scc {
+			membership[n.ID()] = c
+		}
+	}
+	for _, u := range g.Nodes() {
+		cu := membership[u.ID()]
+		for _, v := range g.From(u) {
+			cv := membership[v.ID()]
+			if cu == cv || cond.HasEdgeFromTo(simple.Node(cu), simple.Node(cv)) {
+				continue
+			}
+			cond.SetEdge(simple.Edge{F: simple.Node(cu), T: simple.Node(cv), W: 1})
+		}
+	}
+	return cond, membership
+}
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+var condensationTests = []struct {
+	name string
+	g    []intset
+
+	// components is the node IDs of each
+	// strongly connected component of g,
+	// in topological order.
+	components [][]int
+	edges      [][2]int
+}{
+	{
+		name: "empty",
+	},
+	{
+		name: "cyclic",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2, 7),
+			2: linksTo(3, 6),
+			3: linksTo(4),
+			4: linksTo(2, 5),
+			6: linksTo(3, 5),
+			7: linksTo(0, 6),
+		},
+		components: [][]int{{0, 1, 7}, {2, 3, 4, 6}, {5}},
+		edges:      [][2]int{{0, 1}, {1, 2}},
+	},
+	{
+		name: "acyclic",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(3),
+			2: linksTo(3),
+			3: nil,
+		},
+		components: [][]int{{0}, {1}, {2}, {3}},
+		edges:      [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}},
+	},
+}
+
+func TestCondensation(t *testing.T) {
+	for _, test := range condensationTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		cond, membership := Condensation(g)
+
+		if _, err := Sort(cond); err != nil {
+			t.Errorf("condensation of %q is not acyclic: %v", test.name, err)
+		}
+		if n := len(cond.Nodes()); n != len(test.components) {
+			t.Errorf("unexpected number of components for %q: got:%d want:%d", test.name, n, len(test.components))
+			continue
+		}
+
+		// Node IDs are in a topological order, but are only
+		// unique up to the ordering of incomparable components,
+		// so identify components by their members.
+		idOf := make(map[int]int)
+		for _, c := range test.components {
+			var got []int
+			for id, m := range membership {
+				if m == membership[c[0]] {
+					got = append(got, id)
+				}
+			}
+			sort.Ints(got)
+			if !reflect.DeepEqual(got, c) {
+				t.Errorf("unexpected component members for %q: got:%v want:%v", test.name, got, c)
+			}
+			idOf[c[0]] = membership[c[0]]
+		}
+
+		gotEdges := make(map[[2]int]bool)
+		wantEdges := make(map[[2]int]bool)
+		for _, u := range cond.Nodes() {
+			for _, v := range cond.From(u) {
+				if u.ID() >= v.ID() {
+					t.Errorf("condensation node IDs of %q not in topological order: edge %d->%d", test.name, u.ID(), v.ID())
+				}
+				gotEdges[[2]int{u.ID(), v.ID()}] = true
+			}
+		}
+		for _, e := range test.edges {
+			wantEdges[[2]int{
+				idOf[test.components[e[0]][0]],
+				idOf[test.components[e[1]][0]],
+			}] = true
+		}
+		if !reflect.DeepEqual(gotEdges, wantEdges) {
+			t.Errorf("unexpected condensation edges for %q: got:%v want:%v", test.name, gotEdges, wantEdges)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/flow"
+	"github.com/gonum/graph/simple"
+)
+
+// VertexConnectivity returns the vertex connectivity of the undirected graph g,
+// the minimum number of nodes that must be removed from g to disconnect it.
+// Complete graphs can not be disconnected by removing nodes, and by convention
+// have a vertex connectivity of one less than their order. Graphs that are
+// already disconnected have a vertex connectivity of zero. Self-loops are
+// ignored.
+//
+// VertexConnectivity uses Even's algorithm, finding node-disjoint path counts
+// between pairs of nodes with the Edmonds-Karp maximum flow algorithm on a
+// graph where each node of g is split into an in and out node joined by a
+// unit capacity edge.
+func VertexConnectivity(g graph.Undirected) int {
+	nodes := g.Nodes()
+	if len(nodes) < 2 || len(ConnectedComponents(g)) != 1 {
+		return 0
+	}
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Node i of g is represented by the nodes
+	// 2i and 2i+1 of split, which are the in and
+	// out ends of the node respectively.
+	split := simple.NewDirectedGraph(0, math.Inf(1))
+	for i := range nodes {
+		split.SetEdge(simple.Edge{F: simple.Node(2 * i), T: simple.Node(2*i + 1), W: 1})
+	}
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if i == j {
+				continue
+			}
+			split.SetEdge(simple.Edge{F: simple.Node(2*i + 1), T: simple.Node(2 * j), W: 1})
+		}
+	}
+
+	// The minimum degree is an upper bound on
+	// the vertex connectivity.
+	k := minDegree(g)
+	for i := 0; i <= k && i < len(nodes); i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			if g.HasEdgeBetween(nodes[i], nodes[j]) {
+				continue
+			}
+			f, _ := flow.EdmondsKarp(simple.Node(2*i+1), simple.Node(2*j), split)
+			k = min(k, int(f))
+		}
+	}
+	return k
+}
+
+// EdgeConnectivity returns the edge connectivity of the undirected graph g,
+// the minimum number of edges that must be removed from g to disconnect it.
+// Graphs with fewer than two nodes and graphs that are already disconnected
+// have an edge connectivity of zero. Self-loops are ignored.
+//
+// EdgeConnectivity finds the edge-disjoint path counts between a single node
+// and each other node of g with the Edmonds-Karp maximum flow algorithm.
+func EdgeConnectivity(g graph.Undirected) int {
+	nodes := g.Nodes()
+	if len(nodes) < 2 || len(ConnectedComponents(g)) != 1 {
+		return 0
+	}
+
+	unit := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if u.ID() == v.ID() {
+				continue
+			}
+			unit.SetEdge(simple.Edge{F: simple.Node(u.ID()), T: simple.Node(v.ID()), W: 1})
+		}
+	}
+
+	// The minimum degree is an upper bound on
+	// the edge connectivity.
+	k := minDegree(g)
+	s := simple.Node(nodes[0].ID())
+	for _, t := range nodes[1:] {
+		f, _ := flow.EdmondsKarp(s, simple.Node(t.ID()), unit)
+		k = min(k, int(f))
+	}
+	return k
+}
+
+// minDegree returns the minimum degree of the nodes in g, ignoring self-loops.
+func minDegree(g graph.Undirected) int {
+	d := -1
+	for _, u := range g.Nodes() {
+		var n int
+		for _, v := range g.From(u) {
+			if v.ID() != u.ID() {
+				n++
+			}
+		}
+		if d < 0 || n < d {
+			d = n
+		}
+	}
+	return d
+}
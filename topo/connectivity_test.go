@@ -0,0 +1,198 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+var connectivityTests = []struct {
+	name string
+	g    []intset
+
+	vertex, edge int
+}{
+	{name: "single", g: []intset{0: nil}, vertex: 0, edge: 0},
+	{name: "disconnected", g: []intset{0: linksTo(1), 2: linksTo(3)}, vertex: 0, edge: 0},
+	{name: "path", g: []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(3)}, vertex: 1, edge: 1},
+	{name: "cycle", g: []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(3), 3: linksTo(0)}, vertex: 2, edge: 2},
+	{
+		name: "K5",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4),
+			1: linksTo(2, 3, 4),
+			2: linksTo(3, 4),
+			3: linksTo(4),
+		},
+		vertex: 4, edge: 4,
+	},
+	{
+		name: "bowtie",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			2: linksTo(3, 4),
+			3: linksTo(4),
+		},
+		vertex: 1, edge: 2,
+	},
+	{
+		name: "K3,3",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+		},
+		vertex: 3, edge: 3,
+	},
+}
+
+func TestConnectivity(t *testing.T) {
+	for _, test := range connectivityTests {
+		g := undirectedFrom(test.g)
+		if got := VertexConnectivity(g); got != test.vertex {
+			t.Errorf("unexpected vertex connectivity for %q: got:%d want:%d", test.name, got, test.vertex)
+		}
+		if got := EdgeConnectivity(g); got != test.edge {
+			t.Errorf("unexpected edge connectivity for %q: got:%d want:%d", test.name, got, test.edge)
+		}
+	}
+}
+
+func TestConnectivityBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 2; n <= 8; n++ {
+		for _, p := range []float64{0.3, 0.6, 0.9} {
+			for k := 0; k < 5; k++ {
+				adj := make([]intset, n)
+				for u := 0; u < n; u++ {
+					for v := u + 1; v < n; v++ {
+						if rnd.Float64() < p {
+							if adj[u] == nil {
+								adj[u] = make(intset)
+							}
+							adj[u][v] = struct{}{}
+						}
+					}
+				}
+				name := fmt.Sprintf("n=%d p=%v #%d", n, p, k)
+				g := undirectedFrom(adj)
+				if got, want := VertexConnectivity(g), bruteVertexConnectivity(n, adj); got != want {
+					t.Errorf("unexpected vertex connectivity for %q: got:%d want:%d", name, got, want)
+				}
+				if got, want := EdgeConnectivity(g), bruteEdgeConnectivity(n, adj); got != want {
+					t.Errorf("unexpected edge connectivity for %q: got:%d want:%d", name, got, want)
+				}
+			}
+		}
+	}
+}
+
+func undirectedFrom(adj []intset) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		// Add nodes that are not defined by an edge.
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+// bruteConnected returns whether the nodes of the n node graph adj that are
+// not in removed are connected when the edges in cut are also removed.
+func bruteConnected(n int, adj []intset, removed int, cut map[[2]int]bool) bool {
+	linked := func(u, v int) bool {
+		if u > v {
+			u, v = v, u
+		}
+		_, ok := adj[u][v]
+		return ok && !cut[[2]int{u, v}]
+	}
+	start := -1
+	for u := 0; u < n; u++ {
+		if removed&(1<<uint(u)) == 0 {
+			start = u
+			break
+		}
+	}
+	if start < 0 {
+		return true
+	}
+	seen := removed | 1<<uint(start)
+	stack := []int{start}
+	for len(stack) != 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for v := 0; v < n; v++ {
+			if seen&(1<<uint(v)) == 0 && linked(u, v) {
+				seen |= 1 << uint(v)
+				stack = append(stack, v)
+			}
+		}
+	}
+	return seen == 1<<uint(n)-1
+}
+
+func bruteVertexConnectivity(n int, adj []intset) int {
+	best := n - 1
+	for removed := 0; removed < 1<<uint(n); removed++ {
+		var size int
+		for u := 0; u < n; u++ {
+			if removed&(1<<uint(u)) != 0 {
+				size++
+			}
+		}
+		if size >= best || n-size < 2 {
+			continue
+		}
+		if !bruteConnected(n, adj, removed, nil) {
+			best = size
+		}
+	}
+	return best
+}
+
+func bruteEdgeConnectivity(n int, adj []intset) int {
+	var edges [][2]int
+	for u, e := range adj {
+		for v := range e {
+			edges = append(edges, [2]int{u, v})
+		}
+	}
+	// Try cuts of increasing size; the full edge
+	// set always disconnects a graph with more
+	// than one node.
+	for k := 0; ; k++ {
+		if bruteCut(n, adj, edges, k, 0, make(map[[2]int]bool)) {
+			return k
+		}
+	}
+}
+
+// bruteCut returns whether removing k more of edges[from:] in addition to the
+// edges in cut disconnects the graph adj.
+func bruteCut(n int, adj []intset, edges [][2]int, k, from int, cut map[[2]int]bool) bool {
+	if k == 0 {
+		return !bruteConnected(n, adj, 0, cut)
+	}
+	for i := from; i < len(edges); i++ {
+		cut[edges[i]] = true
+		ok := bruteCut(n, adj, edges, k-1, i+1, cut)
+		delete(cut, edges[i])
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,338 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// DefaultEditDistanceLimit is the largest graph order that EditDistance will
+// accept when the EditCosts MaxOrder field is zero.
+const DefaultEditDistanceLimit = 10
+
+// EditCosts holds the costs of graph edit operations used by EditDistance.
+// Any nil cost function is replaced by a default: substitutions cost zero,
+// and insertions and deletions cost one. Cost functions must not return
+// negative values.
+type EditCosts struct {
+	// NodeSubstitute returns the cost of
+	// substituting node a in the first graph
+	// with node b in the second graph. It
+	// may be used to compare node labels.
+	NodeSubstitute func(a, b graph.Node) float64
+	// NodeInsert and NodeDelete return the
+	// cost of inserting a node from the second
+	// graph and deleting a node from the first
+	// graph respectively.
+	NodeInsert, NodeDelete func(n graph.Node) float64
+
+	// EdgeSubstitute returns the cost of
+	// substituting edge a in the first graph
+	// with edge b in the second graph. It
+	// may be used to compare edge labels.
+	EdgeSubstitute func(a, b graph.Edge) float64
+	// EdgeInsert and EdgeDelete return the
+	// cost of inserting an edge from the second
+	// graph and deleting an edge from the first
+	// graph respectively.
+	EdgeInsert, EdgeDelete func(e graph.Edge) float64
+
+	// MaxOrder is the largest order of graph
+	// that will be accepted by EditDistance.
+	// If MaxOrder is zero, the value of
+	// DefaultEditDistanceLimit is used.
+	MaxOrder int
+}
+
+// EditDistance returns the graph edit distance between g1 and g2, the minimum
+// total cost of node and edge substitutions, insertions and deletions required
+// to transform g1 into a graph isomorphic to g2. The costs of each operation
+// are given by costs. The graphs are treated as directed if both implement
+// graph.Directed, otherwise both must be undirected.
+//
+// EditDistance performs an A* search over the assignments of nodes in g1 to
+// nodes in g2 and has a time complexity that is exponential in the order of
+// the graphs. An error is returned if either graph has more nodes than the
+// limit held in costs.
+func EditDistance(g1, g2 graph.Graph, costs EditCosts) (float64, error) {
+	_, d1 := g1.(graph.Directed)
+	_, d2 := g2.(graph.Directed)
+	if d1 != d2 {
+		return 0, errors.New("topo: cannot compare directed and undirected graphs")
+	}
+
+	limit := costs.MaxOrder
+	if limit == 0 {
+		limit = DefaultEditDistanceLimit
+	}
+	e := newEditor(g1, g2, d1, costs)
+	for _, n := range []int{len(e.nodes1), len(e.nodes2)} {
+		if n > limit {
+			return 0, fmt.Errorf("topo: graph order %d exceeds edit distance limit %d", n, limit)
+		}
+	}
+
+	return e.distance(), nil
+}
+
+// editor holds the state of a graph edit distance search.
+type editor struct {
+	g1, g2   graph.Graph
+	directed bool
+
+	nodes1, nodes2 []graph.Node
+
+	nodeSub          func(a, b graph.Node) float64
+	nodeIns, nodeDel func(n graph.Node) float64
+	edgeSub          func(a, b graph.Edge) float64
+	edgeIns, edgeDel func(e graph.Edge) float64
+}
+
+func newEditor(g1, g2 graph.Graph, directed bool, costs EditCosts) *editor {
+	e := &editor{
+		g1: g1, g2: g2,
+		directed: directed,
+
+		nodes1: g1.Nodes(),
+		nodes2: g2.Nodes(),
+
+		nodeSub: costs.NodeSubstitute,
+		nodeIns: costs.NodeInsert,
+		nodeDel: costs.NodeDelete,
+		edgeSub: costs.EdgeSubstitute,
+		edgeIns: costs.EdgeInsert,
+		edgeDel: costs.EdgeDelete,
+	}
+	sort.Sort(ordered.ByID(e.nodes1))
+	sort.Sort(ordered.ByID(e.nodes2))
+
+	if e.nodeSub == nil {
+		e.nodeSub = func(_, _ graph.Node) float64 { return 0 }
+	}
+	if e.nodeIns == nil {
+		e.nodeIns = func(graph.Node) float64 { return 1 }
+	}
+	if e.nodeDel == nil {
+		e.nodeDel = func(graph.Node) float64 { return 1 }
+	}
+	if e.edgeSub == nil {
+		e.edgeSub = func(_, _ graph.Edge) float64 { return 0 }
+	}
+	if e.edgeIns == nil {
+		e.edgeIns = func(graph.Edge) float64 { return 1 }
+	}
+	if e.edgeDel == nil {
+		e.edgeDel = func(graph.Edge) float64 { return 1 }
+	}
+	return e
+}
+
+// distance returns the edit distance between the editor's graphs.
+func (e *editor) distance() float64 {
+	del := make([]float64, len(e.nodes1))
+	for i, n := range e.nodes1 {
+		del[i] = e.nodeDel(n)
+	}
+	ins := make([]float64, len(e.nodes2))
+	for i, n := range e.nodes2 {
+		ins[i] = e.nodeIns(n)
+	}
+
+	open := &editQueue{}
+	start := editState{used: make([]bool, len(e.nodes2))}
+	start.bound = e.heuristic(start, del, ins)
+	heap.Push(open, start)
+	for {
+		s := heap.Pop(open).(editState)
+		if s.complete {
+			return s.cost
+		}
+
+		k := len(s.mapping)
+		if k == len(e.nodes1) {
+			s.cost += e.completion(s)
+			s.bound = s.cost
+			s.complete = true
+			heap.Push(open, s)
+			continue
+		}
+
+		// Consider deletion of node k of g1
+		// and its substitution with each of
+		// the unused nodes of g2.
+		for t := -1; t < len(e.nodes2); t++ {
+			if t >= 0 && s.used[t] {
+				continue
+			}
+			next := editState{
+				mapping: make([]int, k+1),
+				used:    make([]bool, len(s.used)),
+				cost:    s.cost + e.assignmentCost(s.mapping, t),
+			}
+			copy(next.mapping, s.mapping)
+			next.mapping[k] = t
+			copy(next.used, s.used)
+			if t >= 0 {
+				next.used[t] = true
+			}
+			next.bound = next.cost + e.heuristic(next, del, ins)
+			heap.Push(open, next)
+		}
+	}
+}
+
+// assignmentCost returns the cost of mapping the next unmapped node in the
+// first graph to the node t in the second graph given the current mapping.
+// If t is negative the node is deleted.
+func (e *editor) assignmentCost(mapping []int, t int) float64 {
+	k := len(mapping)
+	u := e.nodes1[k]
+	var c float64
+	if t < 0 {
+		c = e.nodeDel(u)
+	} else {
+		c = e.nodeSub(u, e.nodes2[t])
+	}
+	for j := 0; j <= k; j++ {
+		a := t
+		if j < k {
+			a = mapping[j]
+		}
+		c += e.edgeCost(e.nodes1[j], u, a, t)
+		if e.directed && j != k {
+			c += e.edgeCost(u, e.nodes1[j], t, a)
+		}
+	}
+	return c
+}
+
+// edgeCost returns the cost of editing the edge from x to y in the first graph
+// into the edge from node a to node b in the second graph, where negative
+// indices indicate deleted nodes.
+func (e *editor) edgeCost(x, y graph.Node, a, b int) float64 {
+	var e1, e2 graph.Edge
+	if e.hasEdge(e.g1, x, y) {
+		e1 = e.g1.Edge(x, y)
+	}
+	if a >= 0 && b >= 0 && e.hasEdge(e.g2, e.nodes2[a], e.nodes2[b]) {
+		e2 = e.g2.Edge(e.nodes2[a], e.nodes2[b])
+	}
+	switch {
+	case e1 != nil && e2 != nil:
+		return e.edgeSub(e1, e2)
+	case e1 != nil:
+		return e.edgeDel(e1)
+	case e2 != nil:
+		return e.edgeIns(e2)
+	default:
+		return 0
+	}
+}
+
+// hasEdge returns whether there is an edge from x to y in g, taking into account
+// the directedness of the graphs being compared.
+func (e *editor) hasEdge(g graph.Graph, x, y graph.Node) bool {
+	if e.directed {
+		return g.(graph.Directed).HasEdgeFromTo(x, y)
+	}
+	return g.HasEdgeBetween(x, y)
+}
+
+// completion returns the cost of inserting the nodes of the second graph that
+// are not used in the complete state s, and the edges incident to them.
+func (e *editor) completion(s editState) float64 {
+	var c float64
+	for i, u := range e.nodes2 {
+		if !s.used[i] {
+			c += e.nodeIns(u)
+		}
+		for j, v := range e.nodes2 {
+			if s.used[i] && s.used[j] || !e.directed && j < i {
+				continue
+			}
+			if e.hasEdge(e.g2, u, v) {
+				c += e.edgeIns(e.g2.Edge(u, v))
+			}
+		}
+	}
+	return c
+}
+
+// heuristic returns a lower bound on the cost of completing the partial state s.
+// Each remaining node of the first graph must either be substituted or deleted,
+// so any excess in the number of remaining nodes of one graph over the other
+// must be deleted or inserted.
+func (e *editor) heuristic(s editState, del, ins []float64) float64 {
+	rem1 := append([]float64(nil), del[len(s.mapping):]...)
+	var rem2 []float64
+	for i, used := range s.used {
+		if !used {
+			rem2 = append(rem2, ins[i])
+		}
+	}
+	excess := rem1
+	n := len(rem1) - len(rem2)
+	if n < 0 {
+		excess = rem2
+		n = -n
+	}
+	sort.Float64s(excess)
+	var h float64
+	for _, c := range excess[:n] {
+		h += c
+	}
+	return h
+}
+
+// editState is a partial assignment of the nodes of the first graph
+// to the nodes of the second graph.
+type editState struct {
+	// mapping holds the index into the
+	// second graph's nodes for each of the
+	// assigned nodes of the first graph, or
+	// -1 if the node has been deleted.
+	mapping []int
+	// used indicates which nodes of the
+	// second graph have been assigned.
+	used []bool
+
+	// cost is the cost of the edits so far
+	// and bound is cost plus the heuristic
+	// estimate of the cost to completion.
+	cost, bound float64
+
+	// complete indicates that the state
+	// includes the insertion of the unused
+	// nodes of the second graph.
+	complete bool
+}
+
+// editQueue is an A* priority queue of edit states.
+type editQueue []editState
+
+func (q editQueue) Len() int { return len(q) }
+func (q editQueue) Less(i, j int) bool {
+	if q[i].bound == q[j].bound {
+		// Prefer deeper states to reach a
+		// complete state sooner.
+		return len(q[i].mapping) > len(q[j].mapping)
+	}
+	return q[i].bound < q[j].bound
+}
+func (q editQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *editQueue) Push(x interface{}) { *q = append(*q, x.(editState)) }
+func (q *editQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	s := old[n]
+	*q = old[:n]
+	return s
+}
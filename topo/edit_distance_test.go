@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// labeled is a labeled graph node.
+type labeled struct {
+	id    int
+	label string
+}
+
+func (n labeled) ID() int { return n.id }
+
+var editDistanceTests = []struct {
+	name   string
+	g1, g2 []intset
+	want   float64
+}{
+	{
+		name: "empty",
+		want: 0,
+	},
+	{
+		name: "isomorphic paths",
+		g1:   []intset{0: linksTo(1), 1: linksTo(2)},
+		g2:   []intset{0: linksTo(2), 1: linksTo(2)},
+		want: 0,
+	},
+	{
+		name: "isomorphic cycles",
+		g1:   []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(3), 3: linksTo(4), 4: linksTo(0)},
+		g2:   []intset{0: linksTo(2), 2: linksTo(4), 4: linksTo(1), 1: linksTo(3), 3: linksTo(0)},
+		want: 0,
+	},
+	{
+		name: "edge addition",
+		g1:   []intset{0: linksTo(1), 1: linksTo(2), 2: nil},
+		g2:   []intset{0: linksTo(1, 2), 1: linksTo(2)},
+		want: 1,
+	},
+	{
+		name: "edge removal",
+		g1:   []intset{0: linksTo(1, 2, 3), 1: linksTo(2, 3), 2: linksTo(3)},
+		g2:   []intset{0: linksTo(1, 2, 3), 1: linksTo(2), 2: linksTo(3)},
+		want: 1,
+	},
+	{
+		name: "node and edge addition",
+		g1:   []intset{0: linksTo(1), 1: nil},
+		g2:   []intset{0: linksTo(1), 1: linksTo(2)},
+		want: 2,
+	},
+	{
+		name: "triangle to star",
+		g1:   []intset{0: linksTo(1, 2), 1: linksTo(2)},
+		g2:   []intset{0: linksTo(1, 2, 3)},
+		want: 3,
+	},
+	{
+		name: "delete everything",
+		g1:   []intset{0: linksTo(1, 2), 1: linksTo(2)},
+		want: 6,
+	},
+}
+
+func undirectedEditGraph(adj []intset) graph.Undirected {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range editDistanceTests {
+		g1 := undirectedEditGraph(test.g1)
+		g2 := undirectedEditGraph(test.g2)
+		for _, swap := range []bool{false, true} {
+			a, b := g1, g2
+			if swap {
+				a, b = b, a
+			}
+			got, err := EditDistance(a, b, EditCosts{})
+			if err != nil {
+				t.Errorf("unexpected error for %q: %v", test.name, err)
+				continue
+			}
+			if got != test.want {
+				t.Errorf("unexpected edit distance for %q swapped=%t: got:%v want:%v", test.name, swap, got, test.want)
+			}
+		}
+	}
+}
+
+func TestEditDistanceDirected(t *testing.T) {
+	g1 := simple.NewDirectedGraph(0, math.Inf(1))
+	g1.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g1.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+	// A path with one edge reversed.
+	g2 := simple.NewDirectedGraph(0, math.Inf(1))
+	g2.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g2.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1)})
+
+	got, err := EditDistance(g1, g2, EditCosts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("unexpected directed edit distance: got:%v want:2", got)
+	}
+
+	if _, err = EditDistance(g1, undirectedEditGraph(nil), EditCosts{}); err == nil {
+		t.Error("expected error for mixed directedness")
+	}
+}
+
+func TestEditDistanceLabeled(t *testing.T) {
+	// C-O-H versus C-N-H with node substitution
+	// costed by label comparison.
+	g1 := simple.NewUndirectedGraph(0, math.Inf(1))
+	g1.SetEdge(simple.Edge{F: labeled{0, "C"}, T: labeled{1, "O"}})
+	g1.SetEdge(simple.Edge{F: labeled{1, "O"}, T: labeled{2, "H"}})
+	g2 := simple.NewUndirectedGraph(0, math.Inf(1))
+	g2.SetEdge(simple.Edge{F: labeled{0, "H"}, T: labeled{1, "N"}})
+	g2.SetEdge(simple.Edge{F: labeled{1, "N"}, T: labeled{2, "C"}})
+
+	costs := EditCosts{
+		NodeSubstitute: func(a, b graph.Node) float64 {
+			if a.(labeled).label == b.(labeled).label {
+				return 0
+			}
+			return 1
+		},
+	}
+	got, err := EditDistance(g1, g2, costs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("unexpected labeled edit distance: got:%v want:1", got)
+	}
+}
+
+func TestEditDistanceLimit(t *testing.T) {
+	adj := make([]intset, DefaultEditDistanceLimit+1)
+	g := undirectedEditGraph(adj)
+	if _, err := EditDistance(g, g, EditCosts{}); err == nil {
+		t.Error("expected error for graph exceeding default limit")
+	}
+	if _, err := EditDistance(g, g, EditCosts{MaxOrder: len(adj)}); err != nil {
+		t.Errorf("unexpected error for graph within configured limit: %v", err)
+	}
+}
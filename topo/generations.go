@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import "github.com/gonum/graph"
+
+// TopologicalGenerations returns the nodes of the directed graph g grouped into
+// generations using Kahn's algorithm. The first generation holds the nodes of g
+// with no incoming edges, and each later generation holds the nodes whose
+// incoming edges are all from nodes in earlier generations. The nodes of a
+// generation do not depend on each other, so in a scheduling view they may be
+// processed concurrently. Nodes within each generation are sorted by ID.
+//
+// If g is not acyclic, the generations of the nodes that do not depend on a
+// cycle are returned with an Unorderable error listing the cyclic components
+// of g in topological order with their members sorted by ID. As for IsDAG, a
+// node with an edge to itself forms a cycle.
+func TopologicalGenerations(g graph.Directed) ([][]graph.Node, error) {
+	nodes := g.Nodes()
+	indegree := make(map[int]int, len(nodes))
+	var current []graph.Node
+	for _, n := range nodes {
+		indegree[n.ID()] = len(g.To(n))
+		if indegree[n.ID()] == 0 {
+			current = append(current, n)
+		}
+	}
+
+	var (
+		generations [][]graph.Node
+		seen        int
+	)
+	for len(current) != 0 {
+		lexical(current)
+		generations = append(generations, current)
+		seen += len(current)
+
+		var next []graph.Node
+		for _, u := range current {
+			for _, v := range g.From(u) {
+				indegree[v.ID()]--
+				if indegree[v.ID()] == 0 {
+					next = append(next, v)
+				}
+			}
+		}
+		current = next
+	}
+
+	if seen != len(nodes) {
+		// TarjanSCC returns the components in reverse
+		// topological order with sorted members.
+		var cyclic Unorderable
+		sccs := TarjanSCC(g)
+		for i := len(sccs) - 1; i >= 0; i-- {
+			c := sccs[i]
+			if len(c) > 1 || g.HasEdgeFromTo(c[0], c[0]) {
+				cyclic = append(cyclic, c)
+			}
+		}
+		return generations, cyclic
+	}
+	return generations, nil
+}
@@ -0,0 +1,167 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var topologicalGenerationsTests = []struct {
+	name  string
+	nodes []int
+	edges [][2]int
+	loops []int
+
+	want    [][]int
+	wantErr Unorderable
+}{
+	{
+		name: "empty",
+	},
+	{
+		name:  "isolated",
+		nodes: []int{2, 0, 1},
+		want:  [][]int{{0, 1, 2}},
+	},
+	{
+		name:  "diamond",
+		edges: [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {3, 4}},
+		want:  [][]int{{0}, {1, 2}, {3}, {4}},
+	},
+	{
+		// Node 3 depends on node 0 directly and on node 0
+		// through 1 and 2, so must wait for the longest chain.
+		name:  "uneven chains",
+		nodes: []int{5},
+		edges: [][2]int{{0, 1}, {1, 2}, {2, 3}, {0, 3}, {4, 3}},
+		want:  [][]int{{0, 4, 5}, {1}, {2}, {3}},
+	},
+	{
+		name:    "cycle",
+		edges:   [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 1}, {3, 4}, {5, 4}},
+		want:    [][]int{{0, 5}},
+		wantErr: Unorderable{{simple.Node(1), simple.Node(2), simple.Node(3)}},
+	},
+	{
+		name:    "self loop",
+		edges:   [][2]int{{0, 1}, {1, 2}, {3, 2}},
+		loops:   []int{1},
+		want:    [][]int{{0, 3}},
+		wantErr: Unorderable{{simple.Node(1)}},
+	},
+	{
+		name:  "self loop and cycle",
+		edges: [][2]int{{0, 1}, {1, 2}, {2, 1}, {2, 3}, {4, 3}},
+		loops: []int{3},
+		want:  [][]int{{0, 4}},
+		wantErr: Unorderable{
+			{simple.Node(1), simple.Node(2)},
+			{simple.Node(3)},
+		},
+	},
+}
+
+func TestTopologicalGenerations(t *testing.T) {
+	for _, test := range topologicalGenerationsTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for _, n := range test.nodes {
+			g.AddNode(simple.Node(n))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+		}
+
+		var d graph.Directed = g
+		if test.loops != nil {
+			d = newLoopedGraph(g, test.loops...)
+		}
+
+		generations, err := TopologicalGenerations(d)
+		if test.wantErr == nil {
+			if err != nil {
+				t.Errorf("unexpected error for %q: %v", test.name, err)
+			}
+		} else if !reflect.DeepEqual(err, test.wantErr) {
+			t.Errorf("unexpected error for %q: got:%v want:%v", test.name, err, test.wantErr)
+		}
+		if IsDAG(d) != (err == nil) {
+			t.Errorf("cyclicity for %q disagrees with IsDAG: got error:%v IsDAG:%t", test.name, err, IsDAG(d))
+		}
+		got := generationIDs(generations)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected generations for %q: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func generationIDs(generations [][]graph.Node) [][]int {
+	var ids [][]int
+	for _, gen := range generations {
+		var c []int
+		for _, n := range gen {
+			c = append(c, n.ID())
+		}
+		ids = append(ids, c)
+	}
+	return ids
+}
+
+// loopedGraph is a directed graph with self edges
+// added to the nodes of a simple.DirectedGraph.
+type loopedGraph struct {
+	*simple.DirectedGraph
+	loops map[int]bool
+}
+
+func newLoopedGraph(g *simple.DirectedGraph, loops ...int) loopedGraph {
+	l := loopedGraph{DirectedGraph: g, loops: make(map[int]bool)}
+	for _, id := range loops {
+		if !g.Has(simple.Node(id)) {
+			g.AddNode(simple.Node(id))
+		}
+		l.loops[id] = true
+	}
+	return l
+}
+
+func (g loopedGraph) From(n graph.Node) []graph.Node {
+	if g.loops[n.ID()] {
+		return append(g.DirectedGraph.From(n), n)
+	}
+	return g.DirectedGraph.From(n)
+}
+
+func (g loopedGraph) To(n graph.Node) []graph.Node {
+	if g.loops[n.ID()] {
+		return append(g.DirectedGraph.To(n), n)
+	}
+	return g.DirectedGraph.To(n)
+}
+
+func (g loopedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	return g.HasEdgeFromTo(x, y) || g.HasEdgeFromTo(y, x)
+}
+
+func (g loopedGraph) HasEdgeFromTo(u, v graph.Node) bool {
+	if u.ID() == v.ID() {
+		return g.loops[u.ID()]
+	}
+	return g.DirectedGraph.HasEdgeFromTo(u, v)
+}
+
+func (g loopedGraph) Edge(u, v graph.Node) graph.Edge {
+	if u.ID() == v.ID() {
+		if !g.loops[u.ID()] {
+			return nil
+		}
+		return simple.Edge{F: u, T: v, W: 1}
+	}
+	return g.DirectedGraph.Edge(u, v)
+}
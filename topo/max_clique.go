@@ -0,0 +1,110 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// MaximumIndependentSet returns a largest set of mutually non-adjacent nodes of
+// the undirected graph g. The set is found as a maximum clique of the complement
+// of g, so the time complexity of MaximumIndependentSet is exponential in the worst
+// case and it is suitable only for modestly sized graphs.
+func MaximumIndependentSet(g graph.Undirected) []graph.Node {
+	set, _ := MaximumWeightClique(simple.Complement(g), nil)
+	return set
+}
+
+// MaximumWeightClique returns a clique of the undirected graph g with the
+// maximum total node weight, and its weight. Node weights are given by the
+// weight function, or are unit weights if weight is nil, in which case a
+// maximum clique is returned. If g has no nodes, MaximumWeightClique returns
+// a nil clique with zero weight.
+//
+// MaximumWeightClique performs a branch and bound search over the cliques of g,
+// pruning branches whose total weight of candidate nodes can not improve on the
+// best clique found.
+func MaximumWeightClique(g graph.Undirected, weight func(graph.Node) float64) (clique []graph.Node, w float64) {
+	if weight == nil {
+		weight = func(graph.Node) float64 { return 1 }
+	}
+	nodes := g.Nodes()
+	if len(nodes) == 0 {
+		return nil, 0
+	}
+
+	s := maxWeightClique{
+		g:      g,
+		weight: make(map[int]float64, len(nodes)),
+		best:   math.Inf(-1),
+	}
+	for _, n := range nodes {
+		s.weight[n.ID()] = weight(n)
+	}
+
+	// Considering heavier nodes first finds
+	// heavy cliques early, improving pruning.
+	sort.Sort(byWeight{nodes: nodes, weight: s.weight})
+	s.extend(nil, 0, nodes)
+
+	return s.clique, s.best
+}
+
+// maxWeightClique holds the state of a maximum weight clique search.
+type maxWeightClique struct {
+	g      graph.Undirected
+	weight map[int]float64
+
+	clique []graph.Node
+	best   float64
+}
+
+// extend searches for cliques extending r, with weight wr, by nodes in p,
+// all of which are adjacent to every node in r.
+func (s *maxWeightClique) extend(r []graph.Node, wr float64, p []graph.Node) {
+	if len(r) != 0 && wr > s.best {
+		s.best = wr
+		s.clique = append([]graph.Node(nil), r...)
+	}
+
+	// bound[i] is the total positive weight of p[i:],
+	// an upper bound on the weight that can be added
+	// to r from those nodes.
+	bound := make([]float64, len(p)+1)
+	for i := len(p) - 1; i >= 0; i-- {
+		bound[i] = bound[i+1] + math.Max(0, s.weight[p[i].ID()])
+	}
+
+	for i, v := range p {
+		if wr+bound[i] <= s.best {
+			return
+		}
+		var np []graph.Node
+		for _, u := range p[i+1:] {
+			if s.g.HasEdgeBetween(u, v) {
+				np = append(np, u)
+			}
+		}
+		s.extend(append(r[:len(r):len(r)], v), wr+s.weight[v.ID()], np)
+	}
+}
+
+// byWeight sorts nodes by descending weight, and then by ID.
+type byWeight struct {
+	nodes  []graph.Node
+	weight map[int]float64
+}
+
+func (n byWeight) Len() int { return len(n.nodes) }
+func (n byWeight) Less(i, j int) bool {
+	wi := n.weight[n.nodes[i].ID()]
+	wj := n.weight[n.nodes[j].ID()]
+	return wi > wj || wi == wj && n.nodes[i].ID() < n.nodes[j].ID()
+}
+func (n byWeight) Swap(i, j int) { n.nodes[i], n.nodes[j] = n.nodes[j], n.nodes[i] }
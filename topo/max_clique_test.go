@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/gen"
+	"github.com/gonum/graph/simple"
+)
+
+func TestMaximumWeightClique(t *testing.T) {
+	var graphs []graph.Undirected
+	for _, test := range bronKerboschTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		graphs = append(graphs, g)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		err := gen.Gnp(g, 30, 0.4, rnd)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		graphs = append(graphs, g)
+	}
+
+	weights := []struct {
+		name string
+		fn   func(graph.Node) float64
+	}{
+		{name: "unit"},
+		{name: "id", fn: func(n graph.Node) float64 { return float64(n.ID() + 1) }},
+		{name: "alternating", fn: func(n graph.Node) float64 { return float64(1 + 10*(n.ID()%2)) }},
+	}
+
+	for i, g := range graphs {
+		for _, weight := range weights {
+			clique, w := MaximumWeightClique(g, weight.fn)
+
+			fn := weight.fn
+			if fn == nil {
+				fn = func(graph.Node) float64 { return 1 }
+			}
+
+			// With non-negative weights a maximum weight
+			// clique is a maximal clique.
+			want := math.Inf(-1)
+			for _, c := range BronKerbosch(g) {
+				var cw float64
+				for _, n := range c {
+					cw += fn(n)
+				}
+				want = math.Max(want, cw)
+			}
+			if w != want {
+				t.Errorf("unexpected clique weight for graph %d with %s weights: got:%v want:%v", i, weight.name, w, want)
+			}
+
+			var got float64
+			for j, u := range clique {
+				got += fn(u)
+				for _, v := range clique[j+1:] {
+					if !g.HasEdgeBetween(u, v) {
+						t.Errorf("returned nodes are not a clique for graph %d with %s weights: %v", i, weight.name, clique)
+					}
+				}
+			}
+			if got != w {
+				t.Errorf("returned weight does not match clique for graph %d with %s weights: got:%v want:%v", i, weight.name, w, got)
+			}
+		}
+	}
+
+	if c, w := MaximumWeightClique(simple.NewUndirectedGraph(0, math.Inf(1)), nil); c != nil || w != 0 {
+		t.Errorf("unexpected result for empty graph: clique=%v weight=%v", c, w)
+	}
+}
+
+func TestMaximumIndependentSet(t *testing.T) {
+	var graphs []graph.Undirected
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		err := gen.Gnp(g, 12, 0.3, rnd)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		graphs = append(graphs, g)
+	}
+
+	for i, g := range graphs {
+		set := MaximumIndependentSet(g)
+		for j, u := range set {
+			for _, v := range set[j+1:] {
+				if g.HasEdgeBetween(u, v) {
+					t.Errorf("returned nodes are not independent for graph %d: %v", i, set)
+				}
+			}
+		}
+
+		// Find the size of the largest independent
+		// set by exhaustive search.
+		nodes := g.Nodes()
+		var want int
+	subsets:
+		for mask := 0; mask < 1<<uint(len(nodes)); mask++ {
+			var size int
+			for j, u := range nodes {
+				if mask&(1<<uint(j)) == 0 {
+					continue
+				}
+				size++
+				for k, v := range nodes[j+1:] {
+					if mask&(1<<uint(j+1+k)) != 0 && g.HasEdgeBetween(u, v) {
+						continue subsets
+					}
+				}
+			}
+			if size > want {
+				want = size
+			}
+		}
+		if len(set) != want {
+			t.Errorf("unexpected independent set size for graph %d: got:%d want:%d", i, len(set), want)
+		}
+	}
+
+	if set := MaximumIndependentSet(simple.NewUndirectedGraph(0, math.Inf(1))); set != nil {
+		t.Errorf("unexpected result for empty graph: %v", set)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import "github.com/gonum/graph"
+
+// ReachabilityMatrix is the transitive closure of a directed graph held as
+// a bit matrix. Nodes in the same strongly connected component share a single
+// row of the matrix, so graphs with large strongly connected components are
+// held compactly.
+type ReachabilityMatrix struct {
+	// nodes hold the nodes of the analysed
+	// graph.
+	nodes []graph.Node
+	// indexOf contains a mapping between
+	// the id-dense representation of the
+	// graph and the potentially id-sparse
+	// nodes held in nodes.
+	indexOf map[int]int
+
+	// rowOf holds the index into rows
+	// for each node, mapped through
+	// indexOf.
+	rowOf []int
+	// rows holds the set of reachable
+	// node indices for each strongly
+	// connected component of the graph.
+	rows []bitset
+}
+
+// NewReachabilityMatrix returns the reachability matrix of the directed graph g.
+// A node is considered to be reachable from itself.
+//
+// The time complexity of NewReachabilityMatrix is O(|V|+|E|.|V|/w) and its space
+// complexity is O(|C|.|V|/w) where |C| is the number of strongly connected
+// components in g and w is the machine word size.
+func NewReachabilityMatrix(g graph.Directed) *ReachabilityMatrix {
+	nodes := g.Nodes()
+	m := &ReachabilityMatrix{
+		nodes:   nodes,
+		indexOf: make(map[int]int, len(nodes)),
+		rowOf:   make([]int, len(nodes)),
+	}
+	for i, n := range nodes {
+		m.indexOf[n.ID()] = i
+	}
+
+	// TarjanSCC returns the components in reverse
+	// topological order, so all components reachable
+	// from a component have been completed before
+	// the component is considered.
+	sccs := TarjanSCC(g)
+	m.rows = make([]bitset, len(sccs))
+	for c, scc := range sccs {
+		for _, n := range scc {
+			m.rowOf[m.indexOf[n.ID()]] = c
+		}
+	}
+	for c, scc := range sccs {
+		row := newBitset(len(nodes))
+		for _, u := range scc {
+			row.set(m.indexOf[u.ID()])
+			for _, v := range g.From(u) {
+				if r := m.rowOf[m.indexOf[v.ID()]]; r != c {
+					row.union(m.rows[r])
+				}
+			}
+		}
+		m.rows[c] = row
+	}
+
+	return m
+}
+
+// Reachable returns whether there is a path in the graph from u to v.
+func (m *ReachabilityMatrix) Reachable(u, v graph.Node) bool {
+	i, ok := m.indexOf[u.ID()]
+	if !ok {
+		return false
+	}
+	j, ok := m.indexOf[v.ID()]
+	if !ok {
+		return false
+	}
+	return m.rows[m.rowOf[i]].has(j)
+}
+
+// ReachableFrom returns all the nodes in the graph that are reachable from u,
+// including u itself.
+func (m *ReachabilityMatrix) ReachableFrom(u graph.Node) []graph.Node {
+	i, ok := m.indexOf[u.ID()]
+	if !ok {
+		return nil
+	}
+	var reached []graph.Node
+	m.rows[m.rowOf[i]].visit(func(j int) {
+		reached = append(reached, m.nodes[j])
+	})
+	return reached
+}
+
+// bitset is a fixed size set of non-negative integers.
+type bitset []uint64
+
+const wordBits = 64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+wordBits-1)/wordBits)
+}
+
+func (b bitset) set(i int) { b[i/wordBits] |= 1 << uint(i%wordBits) }
+
+func (b bitset) has(i int) bool { return b[i/wordBits]&(1<<uint(i%wordBits)) != 0 }
+
+// union performs an in-place union of b and o.
+func (b bitset) union(o bitset) {
+	for i, w := range o {
+		b[i] |= w
+	}
+}
+
+// visit calls fn for each member of b in ascending order.
+func (b bitset) visit(fn func(int)) {
+	for i, w := range b {
+		for j := 0; w != 0; j, w = j+1, w>>1 {
+			if w&1 != 0 {
+				fn(i*wordBits + j)
+			}
+		}
+	}
+}
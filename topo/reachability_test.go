@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+var reachabilityTests = []struct {
+	name string
+	g    []intset
+}{
+	{name: "batagelj zaversnik", g: batageljZaversnikGraph},
+	{
+		name: "cyclic",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2, 7),
+			2: linksTo(3, 6),
+			3: linksTo(4),
+			4: linksTo(2, 5),
+			6: linksTo(3, 5),
+			7: linksTo(0, 6),
+		},
+	},
+	{
+		name: "wide",
+		g: func() []intset {
+			// Ensure more than one word is used per row.
+			g := make([]intset, 200)
+			for i := 0; i < len(g)-1; i += 2 {
+				g[i] = linksTo(i + 1)
+				g[i+1] = linksTo((i + 3) % len(g))
+			}
+			return g
+		}(),
+	},
+}
+
+func TestReachabilityMatrix(t *testing.T) {
+	for _, test := range reachabilityTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				if !g.Has(simple.Node(v)) {
+					g.AddNode(simple.Node(v))
+				}
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		m := NewReachabilityMatrix(g)
+		nodes := g.Nodes()
+		for _, u := range nodes {
+			var want []int
+			for _, v := range nodes {
+				exists := PathExistsIn(g, u, v)
+				if got := m.Reachable(u, v); got != exists {
+					t.Errorf("unexpected reachability for %q from %d to %d: got:%t want:%t",
+						test.name, u.ID(), v.ID(), got, exists)
+				}
+				if exists {
+					want = append(want, v.ID())
+				}
+			}
+			sort.Ints(want)
+
+			from := m.ReachableFrom(u)
+			sort.Sort(ordered.ByID(from))
+			var got []int
+			for _, n := range from {
+				got = append(got, n.ID())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected reachable set for %q from %d:\ngot: %v\nwant:%v",
+					test.name, u.ID(), got, want)
+			}
+		}
+
+		if m.Reachable(simple.Node(-1), nodes[0]) || m.Reachable(nodes[0], simple.Node(-1)) {
+			t.Errorf("unexpected reachability for absent node in %q", test.name)
+		}
+		if m.ReachableFrom(simple.Node(-1)) != nil {
+			t.Errorf("unexpected reachable set for absent node in %q", test.name)
+		}
+	}
+}
@@ -96,27 +96,40 @@ func reverse(p []graph.Node) {
 // to the number of nodes is acyclic, unless you count reflexive edges as a cycle (which requires
 // only a little extra testing.)
 //
+// The output of TarjanSCC is deterministic. Nodes and their successors are visited in ascending
+// ID order, the components are returned in reverse topological order of the condensation of g
+// and the members of each component are sorted by ID.
+//
 func TarjanSCC(g graph.Directed) [][]graph.Node {
-	return tarjanSCCstabilized(g, nil)
+	nodes := g.Nodes()
+	lexical(nodes)
+	sccs := tarjanSCCFrom(nodes, func(n graph.Node) []graph.Node {
+		to := g.From(n)
+		lexical(to)
+		return to
+	})
+	for _, scc := range sccs {
+		lexical(scc)
+	}
+	return sccs
 }
 
 func tarjanSCCstabilized(g graph.Directed, order func([]graph.Node)) [][]graph.Node {
 	nodes := g.Nodes()
-	var succ func(graph.Node) []graph.Node
-	if order == nil {
-		succ = g.From
-	} else {
-		order(nodes)
-		reverse(nodes)
-
-		succ = func(n graph.Node) []graph.Node {
-			to := g.From(n)
-			order(to)
-			reverse(to)
-			return to
-		}
-	}
+	order(nodes)
+	reverse(nodes)
+	return tarjanSCCFrom(nodes, func(n graph.Node) []graph.Node {
+		to := g.From(n)
+		order(to)
+		reverse(to)
+		return to
+	})
+}
 
+// tarjanSCCFrom returns the strongly connected components of the graph
+// with the given nodes and successor function, visiting nodes and their
+// successors in the order they are provided.
+func tarjanSCCFrom(nodes []graph.Node, succ func(graph.Node) []graph.Node) [][]graph.Node {
 	t := tarjan{
 		succ: succ,
 
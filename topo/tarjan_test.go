@@ -7,21 +7,16 @@ package topo
 import (
 	"math"
 	"reflect"
-	"sort"
 	"testing"
 
 	"github.com/gonum/graph"
-	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/graph/simple"
 )
 
-type interval struct{ start, end int }
-
 var tarjanTests = []struct {
 	g []intset
 
-	ambiguousOrder []interval
-	want           [][]int
+	want [][]int
 
 	sortedLength      int
 	unorderableLength int
@@ -92,13 +87,11 @@ var tarjanTests = []struct {
 		},
 
 		// Node pairs (2, 3) and (4, 5) are not
-		// relatively orderable within each pair.
-		ambiguousOrder: []interval{
-			{0, 3}, // This includes node 6 since it only needs to be before 4 in topo sort.
-			{3, 5},
-		},
+		// relatively orderable within each pair,
+		// so their order is determined by the
+		// ascending ID order of the traversal.
 		want: [][]int{
-			{6}, {5}, {4}, {3}, {2}, {1}, {0},
+			{6}, {4}, {5}, {2}, {3}, {1}, {0},
 		},
 
 		sortedLength: 7,
@@ -113,13 +106,9 @@ var tarjanTests = []struct {
 			4: linksTo(3),
 		},
 
-		// SCCs are not relatively ordable.
-		ambiguousOrder: []interval{
-			{0, 2},
-		},
 		want: [][]int{
-			{0, 1, 2},
 			{3, 4},
+			{0, 1, 2},
 		},
 
 		sortedLength:      0,
@@ -159,6 +148,26 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestIsDAG(t *testing.T) {
+	for i, test := range tarjanTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		if got := IsDAG(g); got != test.sortable {
+			t.Errorf("unexpected DAG status for test %d: got:%t want:%t", i, got, test.sortable)
+		}
+	}
+	if !IsDAG(simple.NewDirectedGraph(0, math.Inf(1))) {
+		t.Error("unexpected DAG status for empty graph: got:false want:true")
+	}
+}
+
 func TestTarjanSCC(t *testing.T) {
 	for i, test := range tarjanTests {
 		g := simple.NewDirectedGraph(0, math.Inf(1))
@@ -171,23 +180,21 @@ func TestTarjanSCC(t *testing.T) {
 				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
 			}
 		}
-		gotSCCs := TarjanSCC(g)
-		// tarjan.strongconnect does range iteration over maps,
-		// so sort SCC members to ensure consistent ordering.
-		gotIDs := make([][]int, len(gotSCCs))
-		for i, scc := range gotSCCs {
-			gotIDs[i] = make([]int, len(scc))
-			for j, id := range scc {
-				gotIDs[i][j] = id.ID()
+		// TarjanSCC is deterministic, so repeated calls must
+		// return the same result despite map iteration in g.
+		for j := 0; j < 10; j++ {
+			gotSCCs := TarjanSCC(g)
+			gotIDs := make([][]int, len(gotSCCs))
+			for i, scc := range gotSCCs {
+				gotIDs[i] = make([]int, len(scc))
+				for j, id := range scc {
+					gotIDs[i][j] = id.ID()
+				}
+			}
+			if !reflect.DeepEqual(gotIDs, test.want) {
+				t.Errorf("unexpected Tarjan scc result for %d:\n\tgot:%v\n\twant:%v", i, gotIDs, test.want)
+				break
 			}
-			sort.Ints(gotIDs[i])
-		}
-		for _, iv := range test.ambiguousOrder {
-			sort.Sort(ordered.BySliceValues(test.want[iv.start:iv.end]))
-			sort.Sort(ordered.BySliceValues(gotIDs[iv.start:iv.end]))
-		}
-		if !reflect.DeepEqual(gotIDs, test.want) {
-			t.Errorf("unexpected Tarjan scc result for %d:\n\tgot:%v\n\twant:%v", i, gotIDs, test.want)
 		}
 	}
 }
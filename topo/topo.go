@@ -67,3 +67,80 @@ func ConnectedComponents(g graph.Undirected) [][]graph.Node {
 
 	return cc
 }
+
+// BreadthFirstForest returns the breadth-first traversal trees of g obtained
+// by walking from each node in g.Nodes() that has not been visited by an
+// earlier walk. Each tree is returned with its nodes in order of discovery,
+// the first node being the root of the tree. The trees are returned in the
+// order in which their roots were found.
+//
+// For undirected graphs the trees correspond to the connected components of
+// g. For directed graphs edges are followed in their forward direction, so a
+// node reachable from more than one root is placed in the first tree to
+// discover it.
+func BreadthFirstForest(g graph.Graph) [][]graph.Node {
+	var (
+		w      traverse.BreadthFirst
+		forest [][]graph.Node
+	)
+	for _, root := range g.Nodes() {
+		if w.Visited(root) {
+			continue
+		}
+		var tree []graph.Node
+		w.Walk(g, root, func(n graph.Node, _ int) bool {
+			tree = append(tree, n)
+			return false
+		})
+		forest = append(forest, tree)
+	}
+	return forest
+}
+
+// IsDAG returns whether the directed graph g is acyclic. A node with an edge
+// to itself forms a cycle. IsDAG performs a depth-first search that stops at
+// the first back edge found, so it is cheaper than TarjanSCC or Sort when only
+// the existence of a cycle is of interest.
+//
+// The time complexity of IsDAG is O(|V|+|E|).
+func IsDAG(g graph.Directed) bool {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	// frame is a node on the depth-first search
+	// stack with its unexplored successors.
+	type frame struct {
+		id   int
+		next []graph.Node
+	}
+
+	state := make(map[int]int)
+	var stack []frame
+	for _, root := range g.Nodes() {
+		if state[root.ID()] != unvisited {
+			continue
+		}
+		state[root.ID()] = onStack
+		stack = append(stack[:0], frame{id: root.ID(), next: g.From(root)})
+		for len(stack) != 0 {
+			top := &stack[len(stack)-1]
+			if len(top.next) == 0 {
+				state[top.id] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := top.next[0]
+			top.next = top.next[1:]
+			switch state[v.ID()] {
+			case onStack:
+				return false
+			case unvisited:
+				state[v.ID()] = onStack
+				stack = append(stack, frame{id: v.ID(), next: g.From(v)})
+			}
+		}
+	}
+	return true
+}
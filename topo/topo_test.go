@@ -174,3 +174,135 @@ func TestConnectedComponents(t *testing.T) {
 		}
 	}
 }
+
+func TestConnectedComponentSubgraph(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range batageljZaversnikGraph {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			if !g.Has(simple.Node(v)) {
+				g.AddNode(simple.Node(v))
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	var giant []graph.Node
+	for _, c := range ConnectedComponents(g) {
+		if len(c) > len(giant) {
+			giant = c
+		}
+	}
+
+	sub := simple.NewUndirectedGraph(0, math.Inf(1))
+	graph.Subgraph(sub, g, giant)
+	if len(sub.Nodes()) != len(giant) {
+		t.Errorf("unexpected number of nodes in subgraph: got:%d want:%d", len(sub.Nodes()), len(giant))
+	}
+	if cc := ConnectedComponents(sub); len(cc) != 1 {
+		t.Errorf("unexpected number of connected components in giant component: got:%d want:1", len(cc))
+	}
+}
+
+var breadthFirstForestTests = []struct {
+	g        []intset
+	directed bool
+	want     [][]int
+}{
+	{
+		g: batageljZaversnikGraph,
+		want: [][]int{
+			{0},
+			{1, 2, 3, 4, 5},
+			{6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		},
+	},
+	{
+		g: []intset{
+			0: linksTo(1),
+			1: nil,
+			2: linksTo(1),
+			3: linksTo(4),
+			4: linksTo(3),
+		},
+		directed: true,
+	},
+}
+
+func TestBreadthFirstForest(t *testing.T) {
+	for i, test := range breadthFirstForestTests {
+		var g interface {
+			graph.Graph
+			AddNode(graph.Node)
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewUndirectedGraph(0, math.Inf(1))
+		}
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				if !g.Has(simple.Node(v)) {
+					g.AddNode(simple.Node(v))
+				}
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		forest := BreadthFirstForest(g)
+
+		seen := make(map[int]bool)
+		for _, tree := range forest {
+			if len(tree) == 0 {
+				t.Errorf("unexpected empty tree in test %d", i)
+				continue
+			}
+			// Every node after the root must be reachable from the root
+			// and must have been discovered from an earlier node in the tree.
+			for k, n := range tree {
+				if seen[n.ID()] {
+					t.Errorf("node %d appears in more than one tree in test %d", n.ID(), i)
+				}
+				seen[n.ID()] = true
+				if k == 0 {
+					continue
+				}
+				var found bool
+				for _, u := range tree[:k] {
+					if g.Edge(u, n) != nil {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("node %d not discovered from earlier tree node in test %d", n.ID(), i)
+				}
+			}
+		}
+		if len(seen) != len(test.g) {
+			t.Errorf("unexpected number of nodes in forest for test %d: got:%d want:%d", i, len(seen), len(test.g))
+		}
+
+		if test.want == nil {
+			continue
+		}
+		got := make([][]int, len(forest))
+		for j, c := range forest {
+			ids := make([]int, len(c))
+			for k, n := range c {
+				ids[k] = n.ID()
+			}
+			sort.Ints(ids)
+			got[j] = ids
+		}
+		sort.Sort(ordered.BySliceValues(got))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected breadth-first forest for test %d:\ngot: %v\nwant:%v", i, got, test.want)
+		}
+	}
+}
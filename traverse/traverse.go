@@ -36,26 +36,30 @@ func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.N
 		depth     int
 		children  int
 		untilNext = 1
+
+		t graph.Node
 	)
+	visit := func(n graph.Node, _ float64) bool {
+		if b.EdgeFilter != nil && !b.EdgeFilter(g.Edge(t, n)) {
+			return true
+		}
+		if b.visited.Has(n.ID()) {
+			return true
+		}
+		if b.Visit != nil {
+			b.Visit(t, n)
+		}
+		b.visited.Insert(n.ID())
+		children++
+		b.queue.Enqueue(n)
+		return true
+	}
 	for b.queue.Len() > 0 {
-		t := b.queue.Dequeue()
+		t = b.queue.Dequeue()
 		if until != nil && until(t, depth) {
 			return t
 		}
-		for _, n := range g.From(t) {
-			if b.EdgeFilter != nil && !b.EdgeFilter(g.Edge(t, n)) {
-				continue
-			}
-			if b.visited.Has(n.ID()) {
-				continue
-			}
-			if b.Visit != nil {
-				b.Visit(t, n)
-			}
-			b.visited.Insert(n.ID())
-			children++
-			b.queue.Enqueue(n)
-		}
+		visitFrom(g, t, visit)
 		if untilNext--; untilNext == 0 {
 			depth++
 			untilNext = children
@@ -104,6 +108,24 @@ func (b *BreadthFirst) Reset() {
 	}
 }
 
+// Layers returns the breadth-first depth of each node in g that is reachable from
+// the given node, keyed by node ID. The from node has depth zero. Nodes that are not
+// reachable from the from node are not included in the returned map. If from is not
+// in g, Layers returns nil. Edges are followed according to g.From, so for directed
+// graphs layers follow edge direction.
+func Layers(g graph.Graph, from graph.Node) map[int]int {
+	if !g.Has(from) {
+		return nil
+	}
+	layers := make(map[int]int)
+	var b BreadthFirst
+	b.Walk(g, from, func(n graph.Node, d int) bool {
+		layers[n.ID()] = d
+		return false
+	})
+	return layers
+}
+
 // DepthFirst implements stateful depth-first graph traversal.
 type DepthFirst struct {
 	EdgeFilter func(graph.Edge) bool
@@ -124,24 +146,27 @@ func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node)
 	d.stack.Push(from)
 	d.visited.Insert(from.ID())
 
+	var t graph.Node
+	visit := func(n graph.Node, _ float64) bool {
+		if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(t, n)) {
+			return true
+		}
+		if d.visited.Has(n.ID()) {
+			return true
+		}
+		if d.Visit != nil {
+			d.Visit(t, n)
+		}
+		d.visited.Insert(n.ID())
+		d.stack.Push(n)
+		return true
+	}
 	for d.stack.Len() > 0 {
-		t := d.stack.Pop()
+		t = d.stack.Pop()
 		if until != nil && until(t) {
 			return t
 		}
-		for _, n := range g.From(t) {
-			if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(t, n)) {
-				continue
-			}
-			if d.visited.Has(n.ID()) {
-				continue
-			}
-			if d.Visit != nil {
-				d.Visit(t, n)
-			}
-			d.visited.Insert(n.ID())
-			d.stack.Push(n)
-		}
+		visitFrom(g, t, visit)
 	}
 
 	return nil
@@ -184,3 +209,18 @@ func (d *DepthFirst) Reset() {
 		d.visited.Clear()
 	}
 }
+
+// visitFrom calls fn with each node directly reachable from u in g. If g
+// implements graph.FromVisitor its VisitFrom method is used, avoiding the
+// allocation of a neighbor slice, otherwise the weight passed to fn is zero.
+func visitFrom(g graph.Graph, u graph.Node, fn func(graph.Node, float64) bool) {
+	if vg, ok := g.(graph.FromVisitor); ok {
+		vg.VisitFrom(u, fn)
+		return
+	}
+	for _, v := range g.From(u) {
+		if !fn(v, 0) {
+			return
+		}
+	}
+}
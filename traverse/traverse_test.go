@@ -170,6 +170,46 @@ func TestBreadthFirst(t *testing.T) {
 	}
 }
 
+func TestLayers(t *testing.T) {
+	for i, test := range breadthFirstTests {
+		if test.edge != nil || test.until != nil {
+			continue
+		}
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		want := make(map[int]int)
+		for d, layer := range test.want {
+			for _, id := range layer {
+				want[id] = d
+			}
+		}
+		got := Layers(g, test.from)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected layers for test %d:\ngot: %v\nwant:%v", i, got, want)
+		}
+	}
+
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1)})
+	want := map[int]int{1: 0, 2: 1}
+	if got := Layers(g, simple.Node(1)); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected layers for directed graph:\ngot: %v\nwant:%v", got, want)
+	}
+	if got := Layers(g, simple.Node(-1)); got != nil {
+		t.Errorf("unexpected layers for absent node: %v", got)
+	}
+}
+
 var depthFirstTests = []struct {
 	g     []set
 	from  graph.Node
@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"golang.org/x/tools/container/intsets"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/linear"
+)
+
+// Order specifies the order in which Walk traverses a graph.
+type Order int
+
+const (
+	// BreadthFirstOrder specifies a breadth-first traversal.
+	BreadthFirstOrder Order = iota
+	// DepthFirstOrder specifies a depth-first traversal.
+	DepthFirstOrder
+)
+
+// Visitor receives the events of a traversal performed by Walk.
+type Visitor interface {
+	// ShouldVisit returns whether the undiscovered
+	// node n should be included in the traversal.
+	// ShouldVisit may be called more than once for
+	// a node that is not included.
+	ShouldVisit(n graph.Node) bool
+
+	// Discover is called when n is first reached by
+	// the traversal. The traversal is terminated if
+	// Discover returns false.
+	Discover(n graph.Node) bool
+
+	// Examine is called for each edge leading from
+	// a node being expanded by the traversal, whether
+	// or not the edge leads to a discovered node.
+	Examine(e graph.Edge)
+
+	// Finish is called when all the edges leading
+	// from n have been examined and, in a depth-first
+	// traversal, all the nodes discovered from n have
+	// been finished.
+	Finish(n graph.Node)
+}
+
+// VisitorFuncs is a Visitor that calls its non-nil function fields. A nil
+// ShouldVisitFunc includes all nodes and a nil DiscoverFunc never terminates
+// the traversal.
+type VisitorFuncs struct {
+	ShouldVisitFunc func(graph.Node) bool
+	DiscoverFunc    func(graph.Node) bool
+	ExamineFunc     func(graph.Edge)
+	FinishFunc      func(graph.Node)
+}
+
+// ShouldVisit returns the result of calling v.ShouldVisitFunc, or true
+// if it is nil.
+func (v VisitorFuncs) ShouldVisit(n graph.Node) bool {
+	return v.ShouldVisitFunc == nil || v.ShouldVisitFunc(n)
+}
+
+// Discover returns the result of calling v.DiscoverFunc, or true if it
+// is nil.
+func (v VisitorFuncs) Discover(n graph.Node) bool {
+	return v.DiscoverFunc == nil || v.DiscoverFunc(n)
+}
+
+// Examine calls v.ExamineFunc if it is not nil.
+func (v VisitorFuncs) Examine(e graph.Edge) {
+	if v.ExamineFunc != nil {
+		v.ExamineFunc(e)
+	}
+}
+
+// Finish calls v.FinishFunc if it is not nil.
+func (v VisitorFuncs) Finish(n graph.Node) {
+	if v.FinishFunc != nil {
+		v.FinishFunc(n)
+	}
+}
+
+// Walk traverses g from the given node in the specified order, reporting the
+// events of the traversal to v. Only nodes for which v.ShouldVisit returns true
+// are discovered, including the from node. Walk returns the node for which
+// v.Discover returned false, terminating the traversal, or nil if the traversal
+// completed. Walk will panic if order is not a valid Order.
+func Walk(g graph.Graph, from graph.Node, v Visitor, order Order) graph.Node {
+	switch order {
+	case BreadthFirstOrder:
+		return walkBreadthFirst(g, from, v)
+	case DepthFirstOrder:
+		return walkDepthFirst(g, from, v)
+	default:
+		panic("traverse: invalid order")
+	}
+}
+
+func walkBreadthFirst(g graph.Graph, from graph.Node, v Visitor) graph.Node {
+	if !v.ShouldVisit(from) {
+		return nil
+	}
+	var discovered intsets.Sparse
+	discovered.Insert(from.ID())
+	if !v.Discover(from) {
+		return from
+	}
+
+	var queue linear.NodeQueue
+	queue.Enqueue(from)
+	for queue.Len() > 0 {
+		u := queue.Dequeue()
+		for _, n := range g.From(u) {
+			v.Examine(g.Edge(u, n))
+			if discovered.Has(n.ID()) || !v.ShouldVisit(n) {
+				continue
+			}
+			discovered.Insert(n.ID())
+			if !v.Discover(n) {
+				return n
+			}
+			queue.Enqueue(n)
+		}
+		v.Finish(u)
+	}
+	return nil
+}
+
+func walkDepthFirst(g graph.Graph, from graph.Node, v Visitor) graph.Node {
+	if !v.ShouldVisit(from) {
+		return nil
+	}
+	var discovered intsets.Sparse
+	discovered.Insert(from.ID())
+	if !v.Discover(from) {
+		return from
+	}
+
+	// frame holds a node being expanded
+	// and its unexamined neighbors.
+	type frame struct {
+		u    graph.Node
+		next []graph.Node
+	}
+	stack := []frame{{u: from, next: g.From(from)}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.next) == 0 {
+			v.Finish(top.u)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		n := top.next[0]
+		top.next = top.next[1:]
+		v.Examine(g.Edge(top.u, n))
+		if discovered.Has(n.ID()) || !v.ShouldVisit(n) {
+			continue
+		}
+		discovered.Insert(n.ID())
+		if !v.Discover(n) {
+			return n
+		}
+		stack = append(stack, frame{u: n, next: g.From(n)})
+	}
+	return nil
+}
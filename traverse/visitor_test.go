@@ -0,0 +1,139 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var walkTests = []struct {
+	name        string
+	order       Order
+	shouldVisit func(graph.Node) bool
+	stopAt      int
+
+	want      []string
+	wantFinal int
+}{
+	{
+		name:  "breadth first",
+		order: BreadthFirstOrder,
+		want: []string{
+			"D0", "E0-1", "D1", "E0-2", "D2", "F0",
+			"E1-3", "D3", "F1", "E2-3", "F2", "E3-4", "D4", "F3", "F4",
+		},
+		wantFinal: -1,
+	},
+	{
+		name:  "depth first",
+		order: DepthFirstOrder,
+		want: []string{
+			"D0", "E0-1", "D1", "E1-3", "D3", "E3-4", "D4", "F4", "F3", "F1",
+			"E0-2", "D2", "E2-3", "F2", "F0",
+		},
+		wantFinal: -1,
+	},
+	{
+		name:        "breadth first filtered",
+		order:       BreadthFirstOrder,
+		shouldVisit: func(n graph.Node) bool { return n.ID() != 3 },
+		want: []string{
+			"D0", "E0-1", "D1", "E0-2", "D2", "F0", "E1-3", "F1", "E2-3", "F2",
+		},
+		wantFinal: -1,
+	},
+	{
+		name:        "depth first filtered",
+		order:       DepthFirstOrder,
+		shouldVisit: func(n graph.Node) bool { return n.ID() != 1 },
+		want: []string{
+			"D0", "E0-1", "E0-2", "D2", "E2-3", "D3", "E3-4", "D4", "F4", "F3", "F2", "F0",
+		},
+		wantFinal: -1,
+	},
+	{
+		name:        "excluded start",
+		order:       DepthFirstOrder,
+		shouldVisit: func(n graph.Node) bool { return n.ID() != 0 },
+		wantFinal:   -1,
+	},
+	{
+		name:   "breadth first terminated",
+		order:  BreadthFirstOrder,
+		stopAt: 3,
+		want: []string{
+			"D0", "E0-1", "D1", "E0-2", "D2", "F0", "E1-3", "D3",
+		},
+		wantFinal: 3,
+	},
+	{
+		name:   "depth first terminated",
+		order:  DepthFirstOrder,
+		stopAt: 2,
+		want: []string{
+			"D0", "E0-1", "D1", "E1-3", "D3", "E3-4", "D4", "F4", "F3", "F1", "E0-2", "D2",
+		},
+		wantFinal: 2,
+	},
+}
+
+func TestWalk(t *testing.T) {
+	// 0 -> 1 -> 3 -> 4
+	//  \        ^
+	//   -> 2 ---'
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {3, 4}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+
+	for _, test := range walkTests {
+		var got []string
+		v := VisitorFuncs{
+			ShouldVisitFunc: test.shouldVisit,
+			DiscoverFunc: func(n graph.Node) bool {
+				got = append(got, fmt.Sprintf("D%d", n.ID()))
+				return test.stopAt == 0 || n.ID() != test.stopAt
+			},
+			ExamineFunc: func(e graph.Edge) {
+				got = append(got, fmt.Sprintf("E%d-%d", e.From().ID(), e.To().ID()))
+			},
+			FinishFunc: func(n graph.Node) {
+				got = append(got, fmt.Sprintf("F%d", n.ID()))
+			},
+		}
+		final := Walk(g, simple.Node(0), v, test.order)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected events for %q:\ngot: %v\nwant:%v", test.name, got, test.want)
+		}
+		finalID := -1
+		if final != nil {
+			finalID = final.ID()
+		}
+		if finalID != test.wantFinal {
+			t.Errorf("unexpected final node for %q: got:%d want:%d", test.name, finalID, test.wantFinal)
+		}
+	}
+}
+
+func TestWalkInvalidOrder(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(0))
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		Walk(g, simple.Node(0), VisitorFuncs{}, Order(-1))
+		return
+	}()
+	if !panicked {
+		t.Error("expected panic for invalid order")
+	}
+}